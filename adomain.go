@@ -0,0 +1,233 @@
+package openrtb
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validation errors
+var (
+	ErrInvalidBidAdvDomain = errors.New("openrtb: bid adomain is not a valid domain")
+)
+
+// NormalizeDomain normalizes an advertiser domain, so block lists and
+// reports use consistent values. It strips the scheme, credentials, port,
+// path, a trailing dot and a leading "www.", lowercases the remainder and
+// converts internationalized labels to punycode, e.g.
+// "https://www.München.de/x" becomes "xn--mnchen-3ya.de".
+// It returns false if the result is not a plausible registrable domain,
+// i.e. a host name with at least two labels and a non-numeric TLD.
+//
+// No IDNA mapping or Unicode normalization is applied beyond lowercasing,
+// so differently composed spellings of the same name, e.g. NFC and NFD
+// forms, yield different results.
+func NormalizeDomain(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if _, rest, ok := strings.Cut(s, "://"); ok {
+		s = rest
+	} else {
+		s = strings.TrimPrefix(s, "//")
+	}
+	if i := strings.IndexAny(s, "/?#"); i > -1 {
+		s = s[:i]
+	}
+	if i := strings.LastIndexByte(s, '@'); i > -1 {
+		s = s[i+1:]
+	}
+	if i := strings.LastIndexByte(s, ':'); i > -1 {
+		s = s[:i]
+	}
+	s = strings.TrimSuffix(strings.ToLower(s), ".")
+	s = strings.TrimPrefix(s, "www.")
+
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return "", false
+	}
+	for i, label := range labels {
+		if !isASCII(label) {
+			label = punycode(label)
+		}
+		if !validDomainLabel(label) {
+			return "", false
+		}
+		labels[i] = label
+	}
+	if isDigits(labels[len(labels)-1]) {
+		return "", false
+	}
+
+	s = strings.Join(labels, ".")
+	if len(s) > 253 {
+		return "", false
+	}
+	return s, true
+}
+
+// MatchDomain returns true if host equals domain or is a sub-domain of it.
+// Both are normalized first, see NormalizeDomain, so schemes, ports, paths
+// and a leading "www." are ignored on either side and internationalized
+// names match their punycode form, e.g. a domain of "www.example.com"
+// matches the host "example.com".
+func MatchDomain(host, domain string) bool {
+	host, domain = matchableDomain(host), matchableDomain(domain)
+	if domain == "" {
+		return false
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// matchableDomain normalizes s, falling back to lowercasing it if it is not
+// a plausible domain.
+func matchableDomain(s string) string {
+	if domain, ok := NormalizeDomain(s); ok {
+		return domain
+	}
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), ".")
+	return strings.TrimPrefix(s, "www.")
+}
+
+// NormalizeAdvDomains normalizes the adomain entries of the bid in place,
+// see NormalizeDomain, removing duplicates. Implausible entries are removed
+// and reported as ErrInvalidBidAdvDomain.
+func (bid *Bid) NormalizeAdvDomains() error {
+	var err error
+	domains := bid.AdvDomain[:0]
+	for _, s := range bid.AdvDomain {
+		domain, ok := NormalizeDomain(s)
+		if !ok {
+			err = ErrInvalidBidAdvDomain
+			continue
+		}
+		if !containsString(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+	bid.AdvDomain = domains
+	return err
+}
+
+func validAdvDomains(domains []string) bool {
+	for _, s := range domains {
+		if _, ok := NormalizeDomain(s); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func validDomainLabel(s string) bool {
+	if len(s) == 0 || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Punycode parameters, see RFC 3492
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+// punycode encodes a label with the ACE prefix "xn--".
+func punycode(label string) string {
+	runes := []rune(label)
+	out := []byte("xn--")
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			out = append(out, byte(r))
+		}
+	}
+	basic := len(out) - 4
+	if basic > 0 {
+		out = append(out, '-')
+	}
+
+	n, delta, bias := rune(punyInitialN), 0, punyInitialBias
+	for h := basic; h < len(runes); {
+		m := utf8.MaxRune
+		for _, r := range runes {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+		delta += int(m-n) * (h + 1)
+		n = m
+
+		for _, r := range runes {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := k - bias
+				if t < punyTMin {
+					t = punyTMin
+				} else if t > punyTMax {
+					t = punyTMax
+				}
+				if q < t {
+					break
+				}
+				out = append(out, punyDigit(t+(q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out = append(out, punyDigit(q))
+			bias = punyAdapt(delta, h+1, h == basic)
+			delta = 0
+			h++
+		}
+		delta++
+		n++
+	}
+	return string(out)
+}
+
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func punyAdapt(delta, points int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / points
+
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
@@ -0,0 +1,177 @@
+/*
+Package adomain verifies declared advertiser domains (bid.adomain) post-auction
+by resolving click URLs to their final landing pages. Mismatches are fed into
+a ViolationSink, such as the included RepeatOffenders policy, which blocks
+seats or creatives after repeated violations.
+
+	offenders := adomain.NewRepeatOffenders(3, 10)
+	verifier := &adomain.Verifier{Resolver: crawler, Sink: offenders}
+
+	// after the auction
+	_, err := verifier.Verify(ctx, seat, bid, clickURL)
+
+	// during the next auctions
+	if offenders.IsBlocked(seat, bid.CreativeID) {
+		...
+	}
+*/
+package adomain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/bsm/openrtb"
+)
+
+// ErrNoResolver is returned when Verify is called without a Resolver.
+var ErrNoResolver = errors.New("adomain: no resolver")
+
+// Resolver resolves a click URL to the final landing page URL, e.g. by
+// following redirects or by crawling the page.
+type Resolver interface {
+	Resolve(ctx context.Context, clickURL string) (string, error)
+}
+
+// ResolverFunc is a function that implements Resolver.
+type ResolverFunc func(ctx context.Context, clickURL string) (string, error)
+
+// Resolve implements Resolver.
+func (f ResolverFunc) Resolve(ctx context.Context, clickURL string) (string, error) {
+	return f(ctx, clickURL)
+}
+
+// Violation describes a bid whose landing page does not match its declared
+// advertiser domains.
+type Violation struct {
+	Seat       string   `json:"seat,omitempty"`
+	BidID      string   `json:"bidid"`
+	CreativeID string   `json:"crid,omitempty"`
+	Declared   []string `json:"declared"` // Declared adomain
+	Actual     string   `json:"actual"`   // Host of the resolved landing page
+}
+
+// ViolationSink receives violations.
+type ViolationSink interface {
+	RecordViolation(v *Violation)
+}
+
+// Verifier verifies bids against their landing pages.
+type Verifier struct {
+	Resolver Resolver
+	Sink     ViolationSink // Optional
+}
+
+// Verify resolves clickURL and checks that the landing page host matches
+// one of the declared advertiser domains, either exactly or as a
+// sub-domain. It returns a violation if it doesn't and records it with the
+// sink.
+func (v *Verifier) Verify(ctx context.Context, seat string, bid *openrtb.Bid, clickURL string) (*Violation, error) {
+	if v.Resolver == nil {
+		return nil, ErrNoResolver
+	}
+
+	final, err := v.Resolver.Resolve(ctx, clickURL)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(final)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Hostname()
+	if domain, ok := openrtb.NormalizeDomain(host); ok {
+		host = domain
+	}
+	for _, d := range bid.AdvDomain {
+		if openrtb.MatchDomain(host, d) {
+			return nil, nil
+		}
+	}
+
+	violation := &Violation{
+		Seat:       seat,
+		BidID:      bid.ID,
+		CreativeID: bid.CreativeID,
+		Declared:   bid.AdvDomain,
+		Actual:     host,
+	}
+	if v.Sink != nil {
+		v.Sink.RecordViolation(violation)
+	}
+	return violation, nil
+}
+
+// RepeatOffenders is a ViolationSink which blocks creatives and seats once
+// they reach a number of violations. It is safe for concurrent use.
+type RepeatOffenders struct {
+	creativeLimit int
+	seatLimit     int
+	seats         map[string]int
+	creatives     map[string]int
+	mu            sync.RWMutex
+}
+
+// NewRepeatOffenders creates a new policy which blocks individual creatives
+// after creativeLimit violations and whole seats after seatLimit violations
+// across all their creatives. A limit of 0 disables blocking.
+func NewRepeatOffenders(creativeLimit, seatLimit int) *RepeatOffenders {
+	return &RepeatOffenders{
+		creativeLimit: creativeLimit,
+		seatLimit:     seatLimit,
+		seats:         make(map[string]int),
+		creatives:     make(map[string]int),
+	}
+}
+
+// RecordViolation implements ViolationSink.
+func (r *RepeatOffenders) RecordViolation(v *Violation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seats[v.Seat]++
+	if v.CreativeID != "" {
+		r.creatives[creativeKey(v.Seat, v.CreativeID)]++
+	}
+}
+
+// Violations returns the number of violations of a seat.
+func (r *RepeatOffenders) Violations(seat string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.seats[seat]
+}
+
+// IsBlocked returns true if the seat, or the creative of the seat, has
+// reached its limit. Pass a blank crid to check the seat only.
+func (r *RepeatOffenders) IsBlocked(seat, crid string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.seatLimit > 0 && r.seats[seat] >= r.seatLimit {
+		return true
+	}
+	return crid != "" && r.creativeLimit > 0 && r.creatives[creativeKey(seat, crid)] >= r.creativeLimit
+}
+
+// Reset clears all violations of a seat.
+func (r *RepeatOffenders) Reset(seat string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.seats, seat)
+	prefix := creativeKey(seat, "")
+	for key := range r.creatives {
+		if strings.HasPrefix(key, prefix) {
+			delete(r.creatives, key)
+		}
+	}
+}
+
+func creativeKey(seat, crid string) string {
+	return seat + "\x00" + crid
+}
@@ -0,0 +1,92 @@
+package adomain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Verifier", func() {
+	var subject *Verifier
+	var offenders *RepeatOffenders
+
+	BeforeEach(func() {
+		offenders = NewRepeatOffenders(2, 3)
+		subject = &Verifier{
+			Resolver: ResolverFunc(func(_ context.Context, clickURL string) (string, error) {
+				switch clickURL {
+				case "https://click.example/1":
+					return "https://www.Shop.Brand.com./landing?x=1", nil
+				case "https://click.example/2":
+					return "https://other.com/", nil
+				}
+				return "", errors.New("not found")
+			}),
+			Sink: offenders,
+		}
+	})
+
+	It("should verify matching domains", func() {
+		bid := &openrtb.Bid{ID: "1", AdvDomain: []string{"brand.com"}}
+		Expect(subject.Verify(context.Background(), "s1", bid, "https://click.example/1")).To(BeNil())
+		Expect(offenders.Violations("s1")).To(Equal(0))
+	})
+
+	It("should report violations", func() {
+		bid := &openrtb.Bid{ID: "1", CreativeID: "c1", AdvDomain: []string{"brand.com"}}
+		v, err := subject.Verify(context.Background(), "s1", bid, "https://click.example/2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(&Violation{Seat: "s1", BidID: "1", CreativeID: "c1", Declared: []string{"brand.com"}, Actual: "other.com"}))
+		Expect(offenders.Violations("s1")).To(Equal(1))
+	})
+
+	It("should fail on resolver errors", func() {
+		_, err := subject.Verify(context.Background(), "s1", &openrtb.Bid{ID: "1"}, "https://click.example/3")
+		Expect(err).To(MatchError("not found"))
+
+		_, err = (&Verifier{}).Verify(context.Background(), "s1", &openrtb.Bid{ID: "1"}, "")
+		Expect(err).To(Equal(ErrNoResolver))
+	})
+})
+
+var _ = Describe("RepeatOffenders", func() {
+	var subject *RepeatOffenders
+
+	BeforeEach(func() {
+		subject = NewRepeatOffenders(2, 3)
+	})
+
+	It("should block repeat offenders", func() {
+		subject.RecordViolation(&Violation{Seat: "s1", CreativeID: "c1"})
+		Expect(subject.IsBlocked("s1", "c1")).To(BeFalse())
+
+		subject.RecordViolation(&Violation{Seat: "s1", CreativeID: "c1"})
+		Expect(subject.IsBlocked("s1", "c1")).To(BeTrue())
+		Expect(subject.IsBlocked("s1", "c2")).To(BeFalse())
+		Expect(subject.IsBlocked("s1", "")).To(BeFalse())
+		Expect(subject.IsBlocked("s2", "c1")).To(BeFalse())
+
+		subject.RecordViolation(&Violation{Seat: "s1", CreativeID: "c3"})
+		Expect(subject.IsBlocked("s1", "c2")).To(BeTrue())
+		Expect(subject.Violations("s1")).To(Equal(3))
+
+		subject.Reset("s1")
+		Expect(subject.IsBlocked("s1", "c1")).To(BeFalse())
+		Expect(subject.Violations("s1")).To(Equal(0))
+	})
+
+	It("should support disabled limits", func() {
+		subject = NewRepeatOffenders(0, 0)
+		subject.RecordViolation(&Violation{Seat: "s1", CreativeID: "c1"})
+		Expect(subject.IsBlocked("s1", "c1")).To(BeFalse())
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/adomain")
+}
@@ -0,0 +1,64 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NormalizeDomain", func() {
+
+	normalize := func(s string) string {
+		domain, ok := NormalizeDomain(s)
+		if !ok {
+			return "!"
+		}
+		return domain
+	}
+
+	It("should normalize domains", func() {
+		Expect(normalize("example.com")).To(Equal("example.com"))
+		Expect(normalize(" Example.COM. ")).To(Equal("example.com"))
+		Expect(normalize("https://www.example.com/path?q=1#x")).To(Equal("example.com"))
+		Expect(normalize("//user:pass@shop.example.co.uk:8080")).To(Equal("shop.example.co.uk"))
+		Expect(normalize("www.München.de")).To(Equal("xn--mnchen-3ya.de"))
+		Expect(normalize("bücher.example")).To(Equal("xn--bcher-kva.example"))
+		Expect(normalize("例え.jp")).To(Equal("xn--r8jz45g.jp"))
+	})
+
+	It("should reject implausible domains", func() {
+		Expect(normalize("")).To(Equal("!"))
+		Expect(normalize("localhost")).To(Equal("!"))
+		Expect(normalize("127.0.0.1")).To(Equal("!"))
+		Expect(normalize("exa mple.com")).To(Equal("!"))
+		Expect(normalize("-example.com")).To(Equal("!"))
+		Expect(normalize("example..com")).To(Equal("!"))
+		Expect(normalize("example_ad.com")).To(Equal("!"))
+	})
+
+})
+
+var _ = Describe("MatchDomain", func() {
+	It("should match", func() {
+		Expect(MatchDomain("brand.com", "brand.com")).To(BeTrue())
+		Expect(MatchDomain("shop.brand.com", "Brand.com")).To(BeTrue())
+		Expect(MatchDomain("www.brand.com", "brand.com")).To(BeTrue())
+		Expect(MatchDomain("notbrand.com", "brand.com")).To(BeFalse())
+		Expect(MatchDomain("brand.com", "")).To(BeFalse())
+		Expect(MatchDomain("xn--mnchen-3ya.de", "https://www.München.de/")).To(BeTrue())
+		Expect(MatchDomain("brand.com", "www.brand.com")).To(BeTrue())
+	})
+})
+
+var _ = Describe("Bid", func() {
+
+	It("should normalize adomain", func() {
+		subject := &Bid{ID: "B", ImpID: "I", AdvDomain: []string{"https://www.Example.com/", "example.com", "localhost", "ad.example.org"}}
+		Expect(subject.Validate(RequireValidAdvDomains())).To(Equal(ErrInvalidBidAdvDomain))
+
+		Expect(subject.NormalizeAdvDomains()).To(Equal(ErrInvalidBidAdvDomain))
+		Expect(subject.AdvDomain).To(Equal([]string{"example.com", "ad.example.org"}))
+		Expect(subject.NormalizeAdvDomains()).To(Succeed())
+		Expect(subject.Validate(RequireValidAdvDomains())).To(Succeed())
+	})
+
+})
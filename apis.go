@@ -0,0 +1,178 @@
+package openrtb
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrInvalidBidAPI = errors.New("openrtb: bid requires api not supported by impression")
+)
+
+// mraid3Markers are MRAID methods and events introduced with MRAID 3.0.
+var mraid3Markers = []string{
+	"exposurechange", "audiovolumechange", "mraid.unload", "mraid.getcurrentapporientation", "mraid.getlocation",
+}
+
+// mraid2Markers are MRAID methods and events introduced with MRAID 2.0.
+var mraid2Markers = []string{
+	"sizechange", "mraid.resize", "mraid.setresizeproperties", "mraid.setorientationproperties", "mraid.storepicture",
+	"mraid.createcalendarevent", "mraid.playvideo", "mraid.supports", "mraid.getscreensize", "mraid.getmaxsize",
+	"mraid.getcurrentposition", "mraid.getdefaultposition",
+}
+
+// omidScripts are the file names of the OM SDK service and session scripts.
+var omidScripts = []string{"omweb-v1.js", "omid-session-client-v1.js"}
+
+// DetectAPIs returns the API frameworks used by the ad markup, see
+// APIFramework* constants. Detection is heuristic and based on well-known
+// script names, method names and VAST attributes. OMID is detected from VAST
+// verification resources with apiFramework="omid" and from the OM SDK
+// scripts.
+func DetectAPIs(adm string) []int {
+	adm = strings.ToLower(adm)
+
+	var apis []int
+	if strings.Contains(adm, "mraid.") {
+		if containsAny(adm, mraid3Markers) {
+			apis = append(apis, APIFrameworkMRAID3)
+		} else if containsAny(adm, mraid2Markers) {
+			apis = append(apis, APIFrameworkMRAID2)
+		} else {
+			apis = append(apis, APIFrameworkMRAID1)
+		}
+	}
+	if hasOMIDVerification(adm) || containsAny(adm, omidScripts) {
+		apis = append(apis, APIFrameworkOMID1)
+	}
+	if containsAny(adm, []string{`apiframework="vpaid"`, `apiframework='vpaid'`}) {
+		apis = append(apis, APIFrameworkVPAID2)
+	}
+	if containsAny(adm, []string{`apiframework="simid"`, `apiframework='simid'`}) {
+		apis = append(apis, APIFrameworkSIMID1)
+	}
+
+	sort.Ints(apis)
+	return apis
+}
+
+// DetectAPIs returns the API frameworks used by the bid markup.
+func (bid *Bid) DetectAPIs() []int {
+	return DetectAPIs(bid.AdMarkup)
+}
+
+// DeclaredAPIs returns the API frameworks declared by the bid via apis or,
+// if absent, api.
+func (bid *Bid) DeclaredAPIs() []int {
+	if len(bid.APIs) != 0 {
+		return bid.APIs
+	} else if bid.API != 0 {
+		return []int{bid.API}
+	}
+	return nil
+}
+
+// RequiredAPIs returns the API frameworks required by the bid, either as
+// declared via apis and api, or as detected from the markup.
+func (bid *Bid) RequiredAPIs() []int {
+	if apis := bid.DeclaredAPIs(); apis != nil {
+		return apis
+	}
+	return bid.DetectAPIs()
+}
+
+// PopulateAPIs sets bid.apis from the markup, unless apis or api are
+// already declared.
+func (bid *Bid) PopulateAPIs() {
+	if len(bid.APIs) == 0 && bid.API == 0 {
+		bid.APIs = bid.DetectAPIs()
+	}
+}
+
+// ValidateAPIs checks that all API frameworks declared by the bid are
+// supported by the impression. APIs which are not explicitly listed by the
+// impression are assumed not to be supported. APIs which are merely
+// detected from the markup are not enforced, use PopulateAPIs to opt in.
+func (bid *Bid) ValidateAPIs(imp *Impression) error {
+	supported := imp.SupportedAPIs(bid.MType)
+	for _, api := range bid.DeclaredAPIs() {
+		if !hasInt(supported, api) {
+			return ErrInvalidBidAPI
+		}
+	}
+	return nil
+}
+
+// SupportedAPIs returns the API frameworks supported by the impression for
+// a markup type, see MarkupType* constants. If mtype is 0, the APIs of all
+// offered media types are returned. Video and audio APIs which are unusable
+// with the server-side ad insertion mode of the impression are omitted.
+func (imp *Impression) SupportedAPIs(mtype int) []int {
+	var apis []int
+	if imp.Banner != nil && (mtype == 0 || mtype == MarkupTypeBanner) {
+		apis = append(apis, imp.Banner.Api...)
+	}
+	if imp.Video != nil && (mtype == 0 || mtype == MarkupTypeVideo) {
+		apis = append(apis, imp.ssaiAPIs(imp.Video.Api)...)
+	}
+	if imp.Audio != nil && (mtype == 0 || mtype == MarkupTypeAudio) {
+		apis = append(apis, imp.ssaiAPIs(imp.Audio.API)...)
+	}
+	if imp.Native != nil && (mtype == 0 || mtype == MarkupTypeNative) {
+		apis = append(apis, imp.Native.API...)
+	}
+	return apis
+}
+
+// hasOMIDVerification returns true if the lower-cased markup contains a VAST
+// AdVerifications element with an OMID JavaScriptResource.
+func hasOMIDVerification(adm string) bool {
+	for {
+		start := strings.Index(adm, "<adverifications")
+		if start < 0 {
+			return false
+		}
+		adm = adm[start:]
+
+		section := adm
+		if end := strings.Index(adm, "</adverifications>"); end >= 0 {
+			section = adm[:end]
+		}
+		for s := section; ; {
+			i := strings.Index(s, "<javascriptresource")
+			if i < 0 {
+				break
+			}
+			s = s[i:]
+			tag := s
+			if j := strings.IndexByte(s, '>'); j >= 0 {
+				tag = s[:j]
+			}
+			if containsAny(tag, []string{`apiframework="omid"`, `apiframework='omid'`}) {
+				return true
+			}
+			s = s[len("<javascriptresource"):]
+		}
+		adm = adm[len("<adverifications"):]
+	}
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DetectAPIs", func() {
+
+	It("should detect MRAID", func() {
+		Expect(DetectAPIs(`<script src="mraid.js"></script><script>mraid.addEventListener("ready", show)</script>`)).To(Equal([]int{APIFrameworkMRAID1}))
+		Expect(DetectAPIs(`<script>mraid.addEventListener("sizeChange", fn); mraid.resize()</script>`)).To(Equal([]int{APIFrameworkMRAID2}))
+		Expect(DetectAPIs(`<script>mraid.addEventListener("exposureChange", fn)</script>`)).To(Equal([]int{APIFrameworkMRAID3}))
+	})
+
+	It("should detect OMID", func() {
+		Expect(DetectAPIs(`<script src="https://cdn.example.com/omweb-v1.js"></script>`)).To(Equal([]int{APIFrameworkOMID1}))
+		Expect(DetectAPIs(`<VAST version="4.1"><Ad><InLine><AdVerifications><Verification vendor="example.com-omid"><JavaScriptResource apiFramework="omid" browserOptional="true"><![CDATA[https://verification.example.com/omid.js]]></JavaScriptResource></Verification></AdVerifications></InLine></Ad></VAST>`)).To(Equal([]int{APIFrameworkOMID1}))
+	})
+
+	It("should not detect OMID from unrelated markup", func() {
+		Expect(DetectAPIs(`<VAST version="4.1"><Ad><InLine><AdVerifications><Verification vendor="example"><JavaScriptResource apiFramework="other">https://example.com/v.js</JavaScriptResource></Verification></AdVerifications></InLine></Ad></VAST>`)).To(BeEmpty())
+		Expect(DetectAPIs(`<a href="https://example.com/omid/landing?ref=omid"><img src="nomidroll.png"></a>`)).To(BeEmpty())
+	})
+
+	It("should detect VAST API frameworks", func() {
+		Expect(DetectAPIs(`<MediaFile apiFramework="VPAID" type="application/javascript">https://example.com/vpaid.js</MediaFile>`)).To(Equal([]int{APIFrameworkVPAID2}))
+		Expect(DetectAPIs(`<InteractiveCreativeFile apiFramework="SIMID">https://example.com/simid.html</InteractiveCreativeFile>`)).To(Equal([]int{APIFrameworkSIMID1}))
+	})
+
+	It("should detect combinations", func() {
+		Expect(DetectAPIs(`<script src="mraid.js"></script><script src="omweb-v1.js"></script>`)).To(Equal([]int{APIFrameworkMRAID1, APIFrameworkOMID1}))
+		Expect(DetectAPIs(`<a href="https://example.com"><img src="ad.png"></a>`)).To(BeEmpty())
+	})
+
+})
+
+var _ = Describe("Bid APIs", func() {
+
+	It("should determine required APIs", func() {
+		Expect((&Bid{APIs: []int{7}, API: 3}).RequiredAPIs()).To(Equal([]int{7}))
+		Expect((&Bid{API: 3}).RequiredAPIs()).To(Equal([]int{3}))
+		Expect((&Bid{AdMarkup: `<script src="mraid.js">`}).RequiredAPIs()).To(Equal([]int{APIFrameworkMRAID1}))
+		Expect((&Bid{AdMarkup: `<script src="mraid.js">`}).DeclaredAPIs()).To(BeNil())
+	})
+
+	It("should populate APIs", func() {
+		bid := &Bid{AdMarkup: `<script src="mraid.js"></script><script src="omweb-v1.js"></script>`}
+		bid.PopulateAPIs()
+		Expect(bid.APIs).To(Equal([]int{APIFrameworkMRAID1, APIFrameworkOMID1}))
+
+		bid = &Bid{API: 5, AdMarkup: `<script src="mraid.js">`}
+		bid.PopulateAPIs()
+		Expect(bid.APIs).To(BeNil())
+	})
+
+	It("should validate against impressions", func() {
+		imp := &Impression{ID: "1", Banner: &Banner{W: 300, H: 250, Api: []int{APIFrameworkMRAID1, APIFrameworkMRAID2}}, Video: &Video{Api: []int{APIFrameworkVPAID2}}}
+
+		Expect((&Bid{AdMarkup: `<img src="ad.png">`}).ValidateAPIs(imp)).To(Succeed())
+		Expect((&Bid{AdMarkup: `<script src="mraid.js">`}).ValidateAPIs(imp)).To(Succeed())
+		Expect((&Bid{AdMarkup: `<script src="omweb-v1.js">`}).ValidateAPIs(imp)).To(Succeed())
+		Expect((&Bid{APIs: []int{APIFrameworkVPAID2}}).ValidateAPIs(imp)).To(Succeed())
+		Expect((&Bid{APIs: []int{APIFrameworkVPAID2}, MType: MarkupTypeBanner}).ValidateAPIs(imp)).To(Equal(ErrInvalidBidAPI))
+		Expect((&Bid{API: APIFrameworkMRAID1}).ValidateAPIs(&Impression{ID: "1", Banner: &Banner{}})).To(Equal(ErrInvalidBidAPI))
+
+		bid := &Bid{AdMarkup: `<script src="omweb-v1.js">`}
+		bid.PopulateAPIs()
+		Expect(bid.ValidateAPIs(imp)).To(Equal(ErrInvalidBidAPI))
+	})
+
+	It("should respect server-side ad insertion", func() {
+		imp := &Impression{ID: "1", Video: &Video{Api: []int{APIFrameworkVPAID2, APIFrameworkOMID1, APIFrameworkSIMID1}}}
+		Expect(imp.SupportedAPIs(MarkupTypeVideo)).To(Equal([]int{APIFrameworkVPAID2, APIFrameworkOMID1, APIFrameworkSIMID1}))
+		Expect(imp.IsServerSideStitched()).To(BeFalse())
+		Expect(imp.FiresClientTrackers()).To(BeTrue())
+
+		imp.SSAI = SSAIServerSideAssets
+		Expect(imp.SupportedAPIs(MarkupTypeVideo)).To(Equal([]int{APIFrameworkOMID1}))
+		Expect(imp.IsServerSideStitched()).To(BeTrue())
+		Expect(imp.FiresClientTrackers()).To(BeTrue())
+		Expect((&Bid{APIs: []int{APIFrameworkVPAID2}, MType: MarkupTypeVideo}).ValidateAPIs(imp)).To(Equal(ErrInvalidBidAPI))
+
+		imp.SSAI = SSAIServerSide
+		Expect(imp.SupportedAPIs(MarkupTypeVideo)).To(BeEmpty())
+		Expect(imp.FiresClientTrackers()).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,101 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+)
+
+// App store errors
+var (
+	ErrAppStoreNoBundle          = errors.New("openrtb: app bundle missing")
+	ErrAppStoreCatMismatch       = errors.New("openrtb: app categories do not match store metadata")
+	ErrAppStorePublisherMismatch = errors.New("openrtb: app publisher does not match store metadata")
+)
+
+// AppStoreInfo contains the metadata of an app, as listed in its store.
+type AppStoreInfo struct {
+	Name          string   // App name
+	Cat           []string // IAB content categories
+	ContentRating string   // Content rating, e.g. "12+"
+	PublisherName string   // Name of the app publisher
+	StoreURL      string   // App store URL
+}
+
+// AppStoreProvider looks up app store metadata by bundle.
+// Implementations should return nil, nil for unknown bundles.
+type AppStoreProvider interface {
+	LookupApp(ctx context.Context, bundle string) (*AppStoreInfo, error)
+}
+
+// EnrichFromStore fills missing name, store URL, categories, publisher name
+// and content rating from the store metadata. Declared values are never
+// overwritten.
+func (a *App) EnrichFromStore(ctx context.Context, p AppStoreProvider) error {
+	info, err := a.lookupStore(ctx, p)
+	if err != nil || info == nil {
+		return err
+	}
+
+	if a.Name == "" {
+		a.Name = info.Name
+	}
+	if a.StoreURL == "" {
+		a.StoreURL = info.StoreURL
+	}
+	if len(a.Cat) == 0 && len(info.Cat) != 0 {
+		a.Cat = append([]string(nil), info.Cat...)
+	}
+	if info.PublisherName != "" {
+		if a.Publisher == nil {
+			a.Publisher = new(Publisher)
+		}
+		if a.Publisher.Name == "" {
+			a.Publisher.Name = info.PublisherName
+		}
+	}
+	if info.ContentRating != "" {
+		if a.Content == nil {
+			a.Content = new(Content)
+		}
+		if a.Content.ContentRating == "" {
+			a.Content.ContentRating = info.ContentRating
+		}
+	}
+	return nil
+}
+
+// ValidateAgainstStore checks declared categories and publisher name against
+// the store metadata. Declared categories must overlap the store categories.
+// Unknown bundles pass validation.
+func (a *App) ValidateAgainstStore(ctx context.Context, p AppStoreProvider) error {
+	info, err := a.lookupStore(ctx, p)
+	if err != nil || info == nil {
+		return err
+	}
+
+	if len(a.Cat) != 0 && len(info.Cat) != 0 && !stringsOverlap(a.Cat, info.Cat) {
+		return ErrAppStoreCatMismatch
+	}
+	if a.Publisher != nil && a.Publisher.Name != "" && info.PublisherName != "" && a.Publisher.Name != info.PublisherName {
+		return ErrAppStorePublisherMismatch
+	}
+	return nil
+}
+
+func (a *App) lookupStore(ctx context.Context, p AppStoreProvider) (*AppStoreInfo, error) {
+	if a.Bundle == "" {
+		return nil, ErrAppStoreNoBundle
+	}
+	return p.LookupApp(ctx, a.Bundle)
+}
+
+func stringsOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
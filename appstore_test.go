@@ -0,0 +1,58 @@
+package openrtb
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockAppStore map[string]*AppStoreInfo
+
+func (m mockAppStore) LookupApp(_ context.Context, bundle string) (*AppStoreInfo, error) {
+	return m[bundle], nil
+}
+
+var _ = Describe("AppStoreProvider", func() {
+	var store = mockAppStore{
+		"com.foo.mygame": {
+			Name:          "My Game",
+			Cat:           []string{"IAB9-30"},
+			ContentRating: "12+",
+			PublisherName: "Foo Inc",
+			StoreURL:      "https://play.google.com/store/apps/details?id=com.foo.mygame",
+		},
+	}
+	var ctx = context.Background()
+
+	It("should enrich", func() {
+		subject := &App{Bundle: "com.foo.mygame", Inventory: Inventory{Name: "Game"}}
+		Expect(subject.EnrichFromStore(ctx, store)).To(Succeed())
+		Expect(subject).To(Equal(&App{
+			Inventory: Inventory{
+				Name:      "Game",
+				Cat:       []string{"IAB9-30"},
+				Publisher: &Publisher{Name: "Foo Inc"},
+				Content:   &Content{ContentRating: "12+"},
+			},
+			Bundle:   "com.foo.mygame",
+			StoreURL: "https://play.google.com/store/apps/details?id=com.foo.mygame",
+		}))
+
+		Expect((&App{}).EnrichFromStore(ctx, store)).To(Equal(ErrAppStoreNoBundle))
+		Expect((&App{Bundle: "com.unknown"}).EnrichFromStore(ctx, store)).To(Succeed())
+	})
+
+	It("should validate", func() {
+		subject := &App{Bundle: "com.foo.mygame", Inventory: Inventory{Cat: []string{"IAB1", "IAB9-30"}}}
+		Expect(subject.ValidateAgainstStore(ctx, store)).To(Succeed())
+
+		subject.Cat = []string{"IAB1"}
+		Expect(subject.ValidateAgainstStore(ctx, store)).To(Equal(ErrAppStoreCatMismatch))
+
+		subject.Cat = nil
+		subject.Publisher = &Publisher{Name: "Bar Ltd"}
+		Expect(subject.ValidateAgainstStore(ctx, store)).To(Equal(ErrAppStorePublisherMismatch))
+	})
+
+})
@@ -0,0 +1,472 @@
+/*
+Package auction selects the winning bids of a bid request.
+
+Bids are collected from all responses, converted into the auction currency,
+checked against the private marketplace and floors of their impressions and
+ranked per impression. Each impression is won by the highest ranked bid,
+which is charged according to the auction type of the request or, if the
+bid targets a deal, of the deal:
+
+  - 1: first price, the bid price is charged;
+  - 2: second price plus, the highest competing price or the floor,
+    whichever is higher, plus an increment, but never more than the bid;
+  - 3: fixed price, the deal floor is charged;
+  - 500+: exchange-specific, charged by the PricingFunc registered in
+    Options.Pricing or as second price plus if none is registered.
+
+Seatbids with group=1 are won or lost atomically: if any of their bids is
+rejected or outbid, all bids of the group lose and the affected impressions
+are re-auctioned without them. Winning groups are reported with their
+aggregate prices.
+
+Bid prices and floors are CPMs per impression. Impressions with a qty
+multiplier, e.g. DOOH screens seen by several people at once, are ranked and
+cleared in CPM, while Winner.Total and the aggregate prices of groups account
+for the number of impressions a single ad play represents.
+
+An auction trace, see openrtb.ContextWithTrace, records the eligibility of
+all bids, their currency conversions, floor checks and final ranks. When
+groups are re-auctioned, floor checks and ranks are recorded for each round.
+
+For example:
+
+	result := auction.Run(req, responses, &auction.Options{
+		Converter:    conv,
+		DealPriority: true,
+	})
+	for _, w := range result.Winners {
+		log.Printf("imp %s won by %s at %.2f %s", w.ImpID, w.Seat, w.ClearingPrice, result.Currency)
+	}
+*/
+package auction
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/bsm/openrtb"
+)
+
+// Auction types
+const (
+	FirstPrice  = openrtb.AuctionTypeFirstPrice
+	SecondPrice = openrtb.AuctionTypeSecondPricePlus
+	FixedPrice  = openrtb.AuctionTypeFixedPrice
+)
+
+// PricingFunc returns the clearing price of winner w of an exchange-specific
+// auction type. The ranked candidates include the winner, in order, and the
+// floor is in auction currency.
+type PricingFunc func(w *Winner, ranked []Candidate, floor float64) float64
+
+// DefaultIncrement is added to the second price, unless configured otherwise.
+const DefaultIncrement = 0.01
+
+// Errors
+var (
+	ErrNoConverter = errors.New("auction: currency conversion required but no converter given")
+	ErrGroupLost   = errors.New("auction: group bids must be won or lost together")
+)
+
+// Options configure an auction. A nil value uses the zero value defaults.
+type Options struct {
+	// Currency is the auction currency. Defaults to the first currency of
+	// the request or openrtb.DefaultCurrency.
+	Currency string
+	// Converter converts bids and floors into the auction currency. It is
+	// only required if currencies differ.
+	Converter openrtb.CurrencyConverter
+	// Increment is added to the second price. Defaults to DefaultIncrement.
+	Increment float64
+	// DealPriority ranks bids on deals above open market bids, regardless
+	// of their price.
+	DealPriority bool
+	// TieBreak optionally reports whether a should rank above b if both
+	// are ranked equally otherwise. By default, ties are won by the bid
+	// received first.
+	TieBreak func(a, b *Candidate) bool
+	// RefreshFloorFactor optionally scales the open market floors of
+	// impressions on refreshed ad slots, see openrtb.Refresh, e.g. 0.8 to
+	// discount refreshed inventory. Deal floors are never scaled.
+	RefreshFloorFactor float64
+	// Pricing optionally clears exchange-specific auction types, see
+	// openrtb.IsExchangeSpecificAuctionType, by type.
+	Pricing map[int]PricingFunc
+}
+
+// Candidate is a bid taking part in the auction.
+type Candidate struct {
+	Seat  string        // The seat of the bid
+	Bid   *openrtb.Bid  // The bid
+	Deal  *openrtb.Deal // The deal the bid targets, if any
+	Price float64       // The bid price, in auction currency
+
+	Group *openrtb.SeatBid // The seatbid, if its bids must be won as a group
+}
+
+// Winner is a winning bid.
+type Winner struct {
+	Candidate
+	ImpID         string  // The impression ID
+	AuctionType   int     // The applied auction type
+	ClearingPrice float64 // The price to charge, in auction currency
+	Multiplier    float64 // The impression multiplier, see openrtb.Qty
+}
+
+// Total returns the clearing price scaled by the impression multiplier.
+func (w *Winner) Total() float64 {
+	return w.ClearingPrice * w.Multiplier
+}
+
+// Loss is a bid which did not win.
+type Loss struct {
+	Candidate
+	ImpID string // The impression ID
+	Code  int    // Loss reason code, see openrtb.Loss* constants
+	Err   error  // The underlying error, if any
+}
+
+// Group is a group of bids which was won together.
+type Group struct {
+	Seat          string   // The seat of the group
+	ImpIDs        []string // The impression IDs
+	Price         float64  // The aggregate bid price, scaled by impression multipliers, in auction currency
+	ClearingPrice float64  // The aggregate price to charge, scaled by impression multipliers, in auction currency
+}
+
+// Result is the outcome of an auction.
+type Result struct {
+	Currency string   // Auction currency
+	Winners  []Winner // Winning bids, in the order of the request impressions
+	Losses   []Loss   // Losing bids
+	Groups   []Group  // Winning groups, see Candidate.Group
+}
+
+// Winner returns the winner of an impression or nil if there is none.
+func (r *Result) Winner(impID string) *Winner {
+	for i := range r.Winners {
+		if r.Winners[i].ImpID == impID {
+			return &r.Winners[i]
+		}
+	}
+	return nil
+}
+
+// Run runs the auction for req, using the bids of all responses.
+func Run(req *openrtb.BidRequest, responses []*openrtb.BidResponse, opts *Options) *Result {
+	return RunContext(context.Background(), req, responses, opts)
+}
+
+// RunContext runs the auction like Run and records its decisions in the
+// trace attached to ctx, if any.
+func RunContext(ctx context.Context, req *openrtb.BidRequest, responses []*openrtb.BidResponse, opts *Options) *Result {
+	if opts == nil {
+		opts = new(Options)
+	}
+
+	cur := opts.Currency
+	if cur == "" && len(req.Cur) != 0 {
+		cur = req.Cur[0]
+	}
+	cur = openrtb.NormalizeCurrency(cur)
+
+	a := &auction{req: req, opts: opts, result: &Result{Currency: cur}, trace: openrtb.TraceFromContext(ctx)}
+	a.collect(responses)
+	a.run()
+	return a.result
+}
+
+type auction struct {
+	req    *openrtb.BidRequest
+	opts   *Options
+	result *Result
+	trace  *openrtb.Trace
+
+	candidates map[string][]Candidate // by imp ID
+	groups     []*openrtb.SeatBid     // seatbids with group=1, in order
+	failed     map[*openrtb.SeatBid]bool
+}
+
+func (a *auction) collect(responses []*openrtb.BidResponse) {
+	a.candidates = make(map[string][]Candidate, len(a.req.Imp))
+	a.failed = make(map[*openrtb.SeatBid]bool)
+	for _, res := range responses {
+		if res == nil {
+			continue
+		}
+
+		for i := range res.SeatBid {
+			sb := &res.SeatBid[i]
+			if sb.IsGroup() {
+				a.groups = append(a.groups, sb)
+			}
+
+			for j := range sb.Bid {
+				bid := &sb.Bid[j]
+				c := Candidate{Seat: sb.Seat, Bid: bid}
+				if sb.IsGroup() {
+					c.Group = sb
+				}
+
+				imp := a.req.ImpByID(bid.ImpID)
+				if imp == nil {
+					a.lose(c, bid.ImpID, openrtb.LossInvalidBidResponse, openrtb.ErrInvalidBidImpID)
+					continue
+				}
+				if rej := imp.CheckDeal(bid, sb.Seat); rej != nil {
+					a.lose(c, imp.ID, rej.Code, rej)
+					continue
+				}
+				if imp.Pmp != nil && bid.DealID != "" {
+					c.Deal = imp.Pmp.DealByID(bid.DealID)
+				}
+
+				price, err := a.convert(bid.Price, res.Currency)
+				if err != nil {
+					a.lose(c, imp.ID, openrtb.LossInvalidBidResponse, err)
+					continue
+				}
+				if price != bid.Price {
+					a.trace.Adjustment(sb.Seat, bid, bid.Price, price, "currency")
+				}
+				c.Price = price
+
+				if c.Price <= 0 {
+					a.lose(c, imp.ID, openrtb.LossMissingPrice, nil)
+					continue
+				}
+				a.trace.Eligibility(sb.Seat, bid, true, "")
+				a.candidates[imp.ID] = append(a.candidates[imp.ID], c)
+			}
+		}
+	}
+}
+
+// run runs the auctions of all impressions. Groups which fail to win all
+// their bids are excluded and the auctions are repeated without them, until
+// all remaining groups win in full.
+func (a *auction) run() {
+	for _, g := range a.groups {
+		if a.failed[g] {
+			a.exclude(g, nil)
+		}
+	}
+
+	for {
+		var winners []Winner
+		var losses []Loss
+		for i := range a.req.Imp {
+			w, ll := a.runImp(&a.req.Imp[i])
+			if w != nil {
+				winners = append(winners, *w)
+			}
+			losses = append(losses, ll...)
+		}
+
+		excluded := false
+		for _, g := range a.groups {
+			if !a.failed[g] && !wonAll(g, winners) {
+				a.failed[g] = true
+				a.exclude(g, losses)
+				excluded = true
+			}
+		}
+		if excluded {
+			continue
+		}
+
+		a.result.Winners = append(a.result.Winners, winners...)
+		a.result.Losses = append(a.result.Losses, losses...)
+		a.result.Groups = groupsOf(a.groups, a.failed, winners)
+		return
+	}
+}
+
+// exclude removes the remaining candidates of group g from the auction. They
+// lose with the reason given in losses, if any, or ErrGroupLost otherwise.
+func (a *auction) exclude(g *openrtb.SeatBid, losses []Loss) {
+	for i := range a.req.Imp {
+		impID := a.req.Imp[i].ID
+		kept := a.candidates[impID][:0]
+		for _, c := range a.candidates[impID] {
+			if c.Group != g {
+				kept = append(kept, c)
+				continue
+			}
+
+			loss := Loss{Candidate: c, ImpID: impID, Code: openrtb.LossLostToHigherBid, Err: ErrGroupLost}
+			for _, l := range losses {
+				if l.Bid == c.Bid {
+					loss = l
+					break
+				}
+			}
+			a.result.Losses = append(a.result.Losses, loss)
+		}
+		a.candidates[impID] = kept
+	}
+}
+
+func (a *auction) runImp(imp *openrtb.Impression) (*Winner, []Loss) {
+	var losses []Loss
+	lose := func(c Candidate, code int, err error) {
+		losses = append(losses, Loss{Candidate: c, ImpID: imp.ID, Code: code, Err: err})
+	}
+
+	var ranked []Candidate
+	for _, c := range a.candidates[imp.ID] {
+		floor, err := a.floor(imp, c.Deal)
+		if err != nil {
+			a.trace.Record(openrtb.TraceEvent{Stage: openrtb.TraceStageFloor, ImpID: imp.ID, Seat: c.Seat, BidID: c.Bid.ID, Reason: err.Error(), Price: c.Price})
+			lose(c, openrtb.LossInternalError, err)
+			continue
+		}
+		a.trace.Record(openrtb.TraceEvent{Stage: openrtb.TraceStageFloor, ImpID: imp.ID, Seat: c.Seat, BidID: c.Bid.ID, OK: c.Price >= floor, Price: c.Price, Value: floor})
+		if c.Price < floor {
+			code := openrtb.LossBelowAuctionFloor
+			if c.Deal != nil {
+				code = openrtb.LossBelowDealFloor
+			}
+			lose(c, code, nil)
+			continue
+		}
+		ranked = append(ranked, c)
+	}
+	if len(ranked) == 0 {
+		return nil, losses
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool { return a.less(&ranked[i], &ranked[j]) })
+	w := Winner{Candidate: ranked[0], ImpID: imp.ID, AuctionType: a.auctionType(ranked[0].Deal), Multiplier: imp.Multiplier()}
+
+	floor, _ := a.floor(imp, w.Deal)
+	pricing := a.opts.Pricing[w.AuctionType]
+	switch {
+	case pricing != nil && openrtb.IsExchangeSpecificAuctionType(w.AuctionType):
+		w.ClearingPrice = pricing(&w, ranked, floor)
+	case w.AuctionType == FirstPrice:
+		w.ClearingPrice = w.Price
+	case w.AuctionType == FixedPrice:
+		w.ClearingPrice = floor
+	default:
+		second := floor
+		if len(ranked) > 1 && ranked[1].Price > second {
+			second = ranked[1].Price
+		}
+		w.ClearingPrice = second + a.increment()
+		if w.ClearingPrice > w.Price {
+			w.ClearingPrice = w.Price
+		}
+	}
+
+	for i, c := range ranked {
+		clearing := 0.0
+		if i == 0 {
+			clearing = w.ClearingPrice
+		}
+		a.trace.Record(openrtb.TraceEvent{Stage: openrtb.TraceStageRanking, ImpID: imp.ID, Seat: c.Seat, BidID: c.Bid.ID, OK: i == 0, Price: c.Price, Value: clearing, Rank: i + 1})
+	}
+	for _, c := range ranked[1:] {
+		code := openrtb.LossLostToHigherBid
+		if w.Deal != nil && c.Deal == nil {
+			code = openrtb.LossLostToPMPDeal
+		}
+		lose(c, code, nil)
+	}
+	return &w, losses
+}
+
+// less reports whether x ranks above y.
+func (a *auction) less(x, y *Candidate) bool {
+	if a.opts.DealPriority && (x.Deal != nil) != (y.Deal != nil) {
+		return x.Deal != nil
+	}
+	if x.Price != y.Price {
+		return x.Price > y.Price
+	}
+	if a.opts.TieBreak != nil {
+		return a.opts.TieBreak(x, y)
+	}
+	return false
+}
+
+func (a *auction) auctionType(deal *openrtb.Deal) int {
+	return a.req.EffectiveAuctionType(deal)
+}
+
+func (a *auction) increment() float64 {
+	if a.opts.Increment > 0 {
+		return a.opts.Increment
+	}
+	return DefaultIncrement
+}
+
+func (a *auction) floor(imp *openrtb.Impression, deal *openrtb.Deal) (float64, error) {
+	floor, err := a.req.Floor(imp, deal, a.opts.Converter)
+	if err != nil {
+		return 0, err
+	}
+	if deal == nil && a.opts.RefreshFloorFactor > 0 && imp.IsRefresh() {
+		floor.Price *= a.opts.RefreshFloorFactor
+	}
+	return a.convert(floor.Price, floor.Currency)
+}
+
+func (a *auction) convert(amount float64, from string) (float64, error) {
+	from = openrtb.NormalizeCurrency(from)
+	if from == a.result.Currency || amount == 0 {
+		return amount, nil
+	}
+	if a.opts.Converter == nil {
+		return 0, ErrNoConverter
+	}
+	return a.opts.Converter.Convert(amount, from, a.result.Currency)
+}
+
+// lose rejects candidate c before it takes part in the auction.
+func (a *auction) lose(c Candidate, impID string, code int, err error) {
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	} else if code == openrtb.LossMissingPrice {
+		reason = "missing price"
+	}
+	a.trace.Eligibility(c.Seat, c.Bid, false, reason)
+
+	if c.Group != nil {
+		a.failed[c.Group] = true
+	}
+	a.result.Losses = append(a.result.Losses, Loss{Candidate: c, ImpID: impID, Code: code, Err: err})
+}
+
+// wonAll returns true if all bids of group g are among the winners.
+func wonAll(g *openrtb.SeatBid, winners []Winner) bool {
+	n := 0
+	for _, w := range winners {
+		if w.Group == g {
+			n++
+		}
+	}
+	return n == len(g.Bid)
+}
+
+// groupsOf summarises the winning groups.
+func groupsOf(groups []*openrtb.SeatBid, failed map[*openrtb.SeatBid]bool, winners []Winner) []Group {
+	var res []Group
+	for _, g := range groups {
+		if failed[g] {
+			continue
+		}
+
+		grp := Group{Seat: g.Seat, ImpIDs: g.ImpIDs()}
+		for _, w := range winners {
+			if w.Group == g {
+				grp.Price += w.Price * w.Multiplier
+				grp.ClearingPrice += w.Total()
+			}
+		}
+		res = append(res, grp)
+	}
+	return res
+}
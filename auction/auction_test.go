@@ -0,0 +1,303 @@
+package auction
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/currency"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Run", func() {
+	var req *openrtb.BidRequest
+
+	response := func(cur, seat string, bids ...openrtb.Bid) *openrtb.BidResponse {
+		return &openrtb.BidResponse{ID: "R", Currency: cur, SeatBid: []openrtb.SeatBid{{Seat: seat, Bid: bids}}}
+	}
+
+	BeforeEach(func() {
+		req = &openrtb.BidRequest{ID: "R", AuctionType: SecondPrice, Imp: []openrtb.Impression{
+			{ID: "1", Banner: &openrtb.Banner{}, BidFloor: 1},
+			{ID: "2", Banner: &openrtb.Banner{}, Pmp: &openrtb.Pmp{Deals: []openrtb.Deal{
+				{ID: "D1", BidFloor: 2, WSeat: []string{"a"}},
+				{ID: "D2", BidFloor: 3, AuctionType: FixedPrice},
+			}}},
+			{ID: "3", Video: &openrtb.Video{}},
+		}}
+	})
+
+	It("should select second price winners", func() {
+		res := Run(req, []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 2}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 1.5}, openrtb.Bid{ID: "b2", ImpID: "1", Price: 0.5}),
+			nil,
+		}, nil)
+		Expect(res.Currency).To(Equal("USD"))
+		Expect(res.Winners).To(HaveLen(1))
+
+		w := res.Winner("1")
+		Expect(w).NotTo(BeNil())
+		Expect(w.Bid.ID).To(Equal("a1"))
+		Expect(w.Seat).To(Equal("a"))
+		Expect(w.AuctionType).To(Equal(SecondPrice))
+		Expect(w.ClearingPrice).To(BeNumerically("~", 1.51, 1e-9))
+		Expect(res.Winner("2")).To(BeNil())
+
+		Expect(res.Losses).To(HaveLen(2))
+		Expect(res.Losses[0].Bid.ID).To(Equal("b2"))
+		Expect(res.Losses[0].Code).To(Equal(openrtb.LossBelowAuctionFloor))
+		Expect(res.Losses[1].Bid.ID).To(Equal("b1"))
+		Expect(res.Losses[1].Code).To(Equal(openrtb.LossLostToHigherBid))
+	})
+
+	It("should record a trace", func() {
+		trace := openrtb.NewTrace("R")
+		ctx := openrtb.ContextWithTrace(context.Background(), trace)
+		res := RunContext(ctx, req, []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 2}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 1.5}, openrtb.Bid{ID: "b2", ImpID: "1", Price: 0.5}, openrtb.Bid{ID: "b3", ImpID: "9", Price: 1}),
+		}, nil)
+		Expect(res.Winner("1").Bid.ID).To(Equal("a1"))
+
+		events := trace.Snapshot()
+		Expect(events).To(HaveLen(9))
+		Expect(events[:4]).To(Equal([]openrtb.TraceEvent{
+			{Stage: openrtb.TraceStageEligibility, ImpID: "1", Seat: "a", BidID: "a1", OK: true, Price: 2},
+			{Stage: openrtb.TraceStageEligibility, ImpID: "1", Seat: "b", BidID: "b1", OK: true, Price: 1.5},
+			{Stage: openrtb.TraceStageEligibility, ImpID: "1", Seat: "b", BidID: "b2", OK: true, Price: 0.5},
+			{Stage: openrtb.TraceStageEligibility, ImpID: "9", Seat: "b", BidID: "b3", Reason: openrtb.ErrInvalidBidImpID.Error(), Price: 1},
+		}))
+		Expect(events[4:7]).To(Equal([]openrtb.TraceEvent{
+			{Stage: openrtb.TraceStageFloor, ImpID: "1", Seat: "a", BidID: "a1", OK: true, Price: 2, Value: 1},
+			{Stage: openrtb.TraceStageFloor, ImpID: "1", Seat: "b", BidID: "b1", OK: true, Price: 1.5, Value: 1},
+			{Stage: openrtb.TraceStageFloor, ImpID: "1", Seat: "b", BidID: "b2", Price: 0.5, Value: 1},
+		}))
+		Expect(events[7].Stage).To(Equal(openrtb.TraceStageRanking))
+		Expect(events[7].BidID).To(Equal("a1"))
+		Expect(events[7].Rank).To(Equal(1))
+		Expect(events[7].Value).To(BeNumerically("~", 1.51, 1e-9))
+		Expect(events[8]).To(Equal(openrtb.TraceEvent{Stage: openrtb.TraceStageRanking, ImpID: "1", Seat: "b", BidID: "b1", Price: 1.5, Rank: 2}))
+	})
+
+	It("should fall back on floors and cap at the bid price", func() {
+		res := Run(req, []*openrtb.BidResponse{response("", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 1.005})}, &Options{Increment: 0.5})
+		Expect(res.Winner("1").ClearingPrice).To(Equal(1.005))
+
+		res = Run(req, []*openrtb.BidResponse{response("", "a", openrtb.Bid{ID: "a1", ImpID: "3", Price: 4})}, nil)
+		Expect(res.Winner("3").ClearingPrice).To(Equal(0.01))
+	})
+
+	It("should select first price winners", func() {
+		req.AuctionType = FirstPrice
+		res := Run(req, []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 2}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 1.5}),
+		}, nil)
+		Expect(res.Winner("1").AuctionType).To(Equal(FirstPrice))
+		Expect(res.Winner("1").ClearingPrice).To(Equal(2.0))
+	})
+
+	It("should honour deals", func() {
+		responses := []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "2", Price: 2.5, DealID: "D1"}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "2", Price: 4}, openrtb.Bid{ID: "b2", ImpID: "2", Price: 3.5, DealID: "D1"}),
+		}
+
+		res := Run(req, responses, nil)
+		Expect(res.Winner("2").Bid.ID).To(Equal("b1"))
+		Expect(res.Winner("2").ClearingPrice).To(Equal(2.51))
+		Expect(res.Losses).To(HaveLen(2))
+		Expect(res.Losses[0].Bid.ID).To(Equal("b2"))
+		Expect(res.Losses[0].Code).To(Equal(openrtb.LossSeatBlocked))
+		Expect(res.Losses[0].Err).To(HaveOccurred())
+
+		res = Run(req, responses, &Options{DealPriority: true})
+		Expect(res.Winner("2").Bid.ID).To(Equal("a1"))
+		Expect(res.Winner("2").Deal.ID).To(Equal("D1"))
+		Expect(res.Winner("2").ClearingPrice).To(Equal(2.5))
+		Expect(res.Losses[1].Bid.ID).To(Equal("b1"))
+		Expect(res.Losses[1].Code).To(Equal(openrtb.LossLostToPMPDeal))
+	})
+
+	It("should apply deal auction types", func() {
+		res := Run(req, []*openrtb.BidResponse{
+			response("USD", "c", openrtb.Bid{ID: "c1", ImpID: "2", Price: 5, DealID: "D2"}),
+			response("USD", "d", openrtb.Bid{ID: "d1", ImpID: "2", Price: 2.9, DealID: "D2"}),
+		}, nil)
+		Expect(res.Winner("2").AuctionType).To(Equal(FixedPrice))
+		Expect(res.Winner("2").ClearingPrice).To(Equal(3.0))
+		Expect(res.Losses).To(HaveLen(1))
+		Expect(res.Losses[0].Code).To(Equal(openrtb.LossBelowDealFloor))
+	})
+
+	It("should break ties", func() {
+		responses := []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 2}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 2}),
+		}
+		Expect(Run(req, responses, nil).Winner("1").Bid.ID).To(Equal("a1"))
+		Expect(Run(req, responses, nil).Winner("1").ClearingPrice).To(Equal(2.0))
+
+		res := Run(req, responses, &Options{TieBreak: func(a, b *Candidate) bool { return a.Seat == "b" }})
+		Expect(res.Winner("1").Bid.ID).To(Equal("b1"))
+	})
+
+	It("should convert currencies", func() {
+		conv := currency.NewConverter(&currency.Rates{Base: "EUR", Rates: map[string]float64{"EUR": 1, "USD": 2}})
+		responses := []*openrtb.BidResponse{
+			response("EUR", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 1}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 1.5}),
+			response("GBP", "c", openrtb.Bid{ID: "c1", ImpID: "1", Price: 9}),
+		}
+
+		trace := openrtb.NewTrace("R")
+		res := RunContext(openrtb.ContextWithTrace(context.Background(), trace), req, responses, &Options{Currency: "eur", Converter: conv})
+		Expect(res.Currency).To(Equal("EUR"))
+		Expect(trace.Snapshot()[1]).To(Equal(openrtb.TraceEvent{Stage: openrtb.TraceStageAdjustment, ImpID: "1", Seat: "b", BidID: "b1", OK: true, Reason: "currency", Price: 1.5, Value: res.Losses[1].Price}))
+		Expect(res.Winner("1").Bid.ID).To(Equal("a1"))
+		Expect(res.Winner("1").Price).To(Equal(1.0))
+		Expect(res.Winner("1").ClearingPrice).To(Equal(0.76))
+		Expect(res.Losses).To(HaveLen(2))
+		Expect(res.Losses[0].Code).To(Equal(openrtb.LossInvalidBidResponse))
+		Expect(res.Losses[0].Err).To(MatchError(currency.ErrUnknownCurrency))
+
+		res = Run(req, responses, &Options{Currency: "EUR"})
+		Expect(res.Winners).To(BeEmpty())
+		Expect(res.Losses[0].Err).To(Equal(ErrNoConverter))
+	})
+
+	It("should reject invalid bids", func() {
+		res := Run(req, []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "X", Price: 2}, openrtb.Bid{ID: "a2", ImpID: "3"}),
+		}, nil)
+		Expect(res.Winners).To(BeEmpty())
+		Expect(res.Losses).To(HaveLen(2))
+		Expect(res.Losses[0].Code).To(Equal(openrtb.LossInvalidBidResponse))
+		Expect(res.Losses[0].ImpID).To(Equal("X"))
+		Expect(res.Losses[1].Code).To(Equal(openrtb.LossMissingPrice))
+	})
+
+	It("should award groups atomically", func() {
+		group := func(seat string, bids ...openrtb.Bid) *openrtb.BidResponse {
+			res := response("USD", seat, bids...)
+			res.SeatBid[0].Group = 1
+			return res
+		}
+
+		res := Run(req, []*openrtb.BidResponse{
+			group("a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 2}, openrtb.Bid{ID: "a3", ImpID: "3", Price: 3}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 1.5}, openrtb.Bid{ID: "b3", ImpID: "3", Price: 1}),
+		}, nil)
+		Expect(res.Winner("1").Bid.ID).To(Equal("a1"))
+		Expect(res.Winner("3").Bid.ID).To(Equal("a3"))
+		Expect(res.Groups).To(Equal([]Group{{Seat: "a", ImpIDs: []string{"1", "3"}, Price: 5, ClearingPrice: 2.52}}))
+
+		res = Run(req, []*openrtb.BidResponse{
+			group("a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 2}, openrtb.Bid{ID: "a3", ImpID: "3", Price: 3}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 1.5}, openrtb.Bid{ID: "b3", ImpID: "3", Price: 4}),
+		}, nil)
+		Expect(res.Winner("1").Bid.ID).To(Equal("b1"))
+		Expect(res.Winner("1").ClearingPrice).To(Equal(1.01))
+		Expect(res.Winner("3").Bid.ID).To(Equal("b3"))
+		Expect(res.Winner("3").ClearingPrice).To(Equal(0.01))
+		Expect(res.Groups).To(BeEmpty())
+		Expect(res.Losses).To(HaveLen(2))
+		Expect(res.Losses[0].Bid.ID).To(Equal("a1"))
+		Expect(res.Losses[0].Code).To(Equal(openrtb.LossLostToHigherBid))
+		Expect(res.Losses[0].Err).To(Equal(ErrGroupLost))
+		Expect(res.Losses[1].Bid.ID).To(Equal("a3"))
+		Expect(res.Losses[1].Code).To(Equal(openrtb.LossLostToHigherBid))
+		Expect(res.Losses[1].Err).To(BeNil())
+	})
+
+	It("should account for impression multipliers", func() {
+		req.Imp[0].Qty = &openrtb.Qty{Multiplier: 2.5, SourceType: openrtb.QtySourceTypePublisher}
+		res := Run(req, []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 2}, openrtb.Bid{ID: "a3", ImpID: "3", Price: 3}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 1.5}),
+		}, nil)
+		w := res.Winner("1")
+		Expect(w.Bid.ID).To(Equal("a1"))
+		Expect(w.ClearingPrice).To(BeNumerically("~", 1.51, 1e-9))
+		Expect(w.Multiplier).To(Equal(2.5))
+		Expect(w.Total()).To(BeNumerically("~", 3.775, 1e-9))
+		Expect(res.Winner("3").Total()).To(Equal(0.01))
+
+		res = Run(req, []*openrtb.BidResponse{
+			{ID: "R", Currency: "USD", SeatBid: []openrtb.SeatBid{{Seat: "a", Group: 1, Bid: []openrtb.Bid{
+				{ID: "a1", ImpID: "1", Price: 2},
+				{ID: "a3", ImpID: "3", Price: 3},
+			}}}},
+		}, nil)
+		Expect(res.Groups).To(HaveLen(1))
+		Expect(res.Groups[0].Price).To(BeNumerically("~", 8, 1e-9))
+		Expect(res.Groups[0].ClearingPrice).To(BeNumerically("~", 2.535, 1e-9))
+	})
+
+	It("should scale floors of refreshed impressions", func() {
+		req.Imp[0].Refresh = &openrtb.Refresh{Count: 1}
+		res := Run(req, []*openrtb.BidResponse{response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 0.6})}, &Options{RefreshFloorFactor: 0.5})
+		Expect(res.Winner("1").ClearingPrice).To(BeNumerically("~", 0.51, 1e-9))
+
+		res = Run(req, []*openrtb.BidResponse{response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 0.6})}, nil)
+		Expect(res.Winner("1")).To(BeNil())
+
+		req.Imp[1].Refresh = &openrtb.Refresh{Count: 1}
+		res = Run(req, []*openrtb.BidResponse{response("USD", "a", openrtb.Bid{ID: "a2", ImpID: "2", DealID: "D1", Price: 1.5})}, &Options{RefreshFloorFactor: 0.5})
+		Expect(res.Winner("2")).To(BeNil())
+	})
+
+	It("should apply the request auction type to decoded deals", func() {
+		var req *openrtb.BidRequest
+		Expect(json.Unmarshal([]byte(`{"id":"R","at":1,"imp":[{"id":"1","banner":{},"bidfloor":1,"pmp":{"deals":[{"id":"D1","bidfloor":1}]}}]}`), &req)).To(Succeed())
+
+		res := Run(req, []*openrtb.BidResponse{response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", DealID: "D1", Price: 5})}, nil)
+		Expect(res.Winner("1").AuctionType).To(Equal(FirstPrice))
+		Expect(res.Winner("1").ClearingPrice).To(Equal(5.0))
+	})
+
+	It("should apply exchange-specific pricing", func() {
+		req.AuctionType = 501
+		bids := []*openrtb.BidResponse{
+			response("USD", "a", openrtb.Bid{ID: "a1", ImpID: "1", Price: 4}),
+			response("USD", "b", openrtb.Bid{ID: "b1", ImpID: "1", Price: 2}),
+		}
+
+		res := Run(req, bids, nil)
+		Expect(res.Winner("1").AuctionType).To(Equal(501))
+		Expect(res.Winner("1").ClearingPrice).To(BeNumerically("~", 2.01, 1e-9))
+
+		res = Run(req, bids, &Options{Pricing: map[int]PricingFunc{
+			501: func(w *Winner, ranked []Candidate, floor float64) float64 {
+				Expect(ranked).To(HaveLen(2))
+				Expect(floor).To(Equal(1.0))
+				return (w.Price + ranked[1].Price) / 2
+			},
+		}})
+		Expect(res.Winner("1").ClearingPrice).To(Equal(3.0))
+	})
+
+	It("should reject groups with invalid bids", func() {
+		res := Run(req, []*openrtb.BidResponse{
+			{ID: "R", SeatBid: []openrtb.SeatBid{{Seat: "a", Group: 1, Bid: []openrtb.Bid{
+				{ID: "a1", ImpID: "1", Price: 2},
+				{ID: "a2", ImpID: "X", Price: 2},
+			}}}},
+		}, nil)
+		Expect(res.Winners).To(BeEmpty())
+		Expect(res.Groups).To(BeEmpty())
+		Expect(res.Losses).To(HaveLen(2))
+		Expect(res.Losses[0].Code).To(Equal(openrtb.LossInvalidBidResponse))
+		Expect(res.Losses[1].Bid.ID).To(Equal("a1"))
+		Expect(res.Losses[1].Err).To(Equal(ErrGroupLost))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/auction")
+}
@@ -0,0 +1,51 @@
+package openrtb
+
+// Auction types of at in bid requests and deals
+const (
+	AuctionTypeFirstPrice       = 1   // The bid price is charged
+	AuctionTypeSecondPricePlus  = 2   // The second highest price plus an increment is charged
+	AuctionTypeFixedPrice       = 3   // The deal floor is the agreed upon price, deals only
+	AuctionTypeExchangeSpecific = 500 // Lowest exchange-specific auction type
+)
+
+// DefaultAuctionType applies if at is not set.
+const DefaultAuctionType = AuctionTypeSecondPricePlus
+
+// IsExchangeSpecificAuctionType returns true if at is an exchange-specific
+// auction type.
+func IsExchangeSpecificAuctionType(at int) bool {
+	return at >= AuctionTypeExchangeSpecific
+}
+
+// EffectiveAuctionType returns the auction type which applies to bids on
+// deal, which may be nil for open market bids. Deals may override the
+// auction type of the request, which defaults to DefaultAuctionType.
+func (req *BidRequest) EffectiveAuctionType(deal *Deal) int {
+	if deal != nil && deal.AuctionType != 0 {
+		return deal.AuctionType
+	}
+	if req.AuctionType != 0 {
+		return req.AuctionType
+	}
+	return DefaultAuctionType
+}
+
+// ImpAuctionType returns the auction type which applies to bids on the
+// impression, for the given deal ID or the open market if empty.
+func (req *BidRequest) ImpAuctionType(imp *Impression, dealID string) int {
+	if dealID != "" && imp.Pmp != nil {
+		return req.EffectiveAuctionType(imp.Pmp.DealByID(dealID))
+	}
+	return req.EffectiveAuctionType(nil)
+}
+
+// validRequestAuctionType returns true for the auction types of requests,
+// fixed prices are reserved for deals.
+func validRequestAuctionType(at int) bool {
+	return (at >= 0 && at <= AuctionTypeSecondPricePlus) || IsExchangeSpecificAuctionType(at)
+}
+
+// validDealAuctionType returns true for the auction types of deals.
+func validDealAuctionType(at int) bool {
+	return (at >= 0 && at <= AuctionTypeFixedPrice) || IsExchangeSpecificAuctionType(at)
+}
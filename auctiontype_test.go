@@ -0,0 +1,62 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuctionType", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{ID: "R", Imp: []Impression{{ID: "1", Banner: &Banner{}, Pmp: &Pmp{Deals: []Deal{
+			{ID: "D1"},
+			{ID: "D2", AuctionType: AuctionTypeFixedPrice},
+		}}}}}
+	})
+
+	It("should detect exchange-specific types", func() {
+		Expect(IsExchangeSpecificAuctionType(AuctionTypeFixedPrice)).To(BeFalse())
+		Expect(IsExchangeSpecificAuctionType(500)).To(BeTrue())
+		Expect(IsExchangeSpecificAuctionType(501)).To(BeTrue())
+	})
+
+	It("should resolve effective auction types", func() {
+		imp := &subject.Imp[0]
+		Expect(subject.EffectiveAuctionType(nil)).To(Equal(AuctionTypeSecondPricePlus))
+		Expect(subject.ImpAuctionType(imp, "")).To(Equal(AuctionTypeSecondPricePlus))
+		Expect(subject.ImpAuctionType(imp, "D2")).To(Equal(AuctionTypeFixedPrice))
+
+		subject.AuctionType = AuctionTypeFirstPrice
+		Expect(subject.ImpAuctionType(imp, "D1")).To(Equal(AuctionTypeFirstPrice))
+		Expect(subject.ImpAuctionType(imp, "D9")).To(Equal(AuctionTypeFirstPrice))
+		Expect(subject.EffectiveAuctionType(&imp.Pmp.Deals[1])).To(Equal(AuctionTypeFixedPrice))
+	})
+
+	It("should inherit the request auction type for decoded deals", func() {
+		var req *BidRequest
+		Expect(json.Unmarshal([]byte(`{"id":"R","at":1,"imp":[{"id":"1","banner":{},"pmp":{"deals":[{"id":"D1"},{"id":"D2","at":3}]}}]}`), &req)).To(Succeed())
+		Expect(req.ImpAuctionType(&req.Imp[0], "D1")).To(Equal(AuctionTypeFirstPrice))
+		Expect(req.ImpAuctionType(&req.Imp[0], "D2")).To(Equal(AuctionTypeFixedPrice))
+	})
+
+	It("should validate", func() {
+		Expect(subject.Validate()).To(Succeed())
+
+		subject.AuctionType = 501
+		Expect(subject.Validate()).To(Succeed())
+		subject.AuctionType = AuctionTypeFixedPrice
+		Expect(subject.Validate()).To(MatchError(ErrInvalidReqAuction))
+
+		subject.AuctionType = AuctionTypeFirstPrice
+		subject.Imp[0].Pmp.Deals[0].AuctionType = 7
+		Expect(subject.Validate()).To(MatchError(ErrInvalidImpDealAuction))
+
+		var verr *ValidationError
+		Expect(errors.As(subject.Validate(), &verr)).To(BeTrue())
+		Expect(verr.Path).To(Equal("imp[0].pmp.deals[0].at"))
+	})
+})
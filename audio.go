@@ -7,7 +7,10 @@ import (
 
 // Validation errors
 var (
-	ErrInvalidAudioNoMimes = errors.New("openrtb: audio has no mimes")
+	ErrInvalidAudioNoMimes     = errors.New("openrtb: audio has no mimes")
+	ErrInvalidAudioDuration    = errors.New("openrtb: audio min-duration exceeds max-duration")
+	ErrInvalidAudioRqdDurs     = errors.New("openrtb: audio rqddurs cannot be combined with min/max-duration")
+	ErrInvalidAudioPodDuration = errors.New("openrtb: audio duration exceeds pod duration")
 )
 
 // The "audio" object must be included directly in the impression object
@@ -15,6 +18,7 @@ type Audio struct {
 	Mimes         []string  `json:"mimes"`                 // Content MIME types supported.
 	MinDuration   int       `json:"minduration,omitempty"` // Minimum video ad duration in seconds
 	MaxDuration   int       `json:"maxduration,omitempty"` // Maximum video ad duration in seconds
+	RqdDurs       []int     `json:"rqddurs,omitempty"`     // Precise acceptable durations for audio creatives in seconds, mutually exclusive with min/max-duration
 	Protocols     []int     `json:"protocols,omitempty"`   // Video bid response protocols
 	StartDelay    int       `json:"startdelay,omitempty"`  // Indicates the start delay in seconds
 	Sequence      int       `json:"sequence,omitempty"`    // Default: 1
@@ -26,10 +30,15 @@ type Audio struct {
 	CompanionAd   []Banner  `json:"companionad,omitempty"`
 	API           []int     `json:"api,omitempty"`
 	CompanionType []int     `json:"companiontype,omitempty"`
-	MaxSequence   int       `json:"maxseq,omitempty"`   // The maximumnumber of ads that canbe played in an ad pod.
-	Feed          int       `json:"feed,omitempty"`     // Type of audio feed.
-	Stitched      int       `json:"stitched,omitempty"` // Indicates if the ad is stitched with audio content or delivered independently
-	NVol          int       `json:"nvol,omitempty"`     // Volume normalization mode.
+	MaxSequence   int       `json:"maxseq,omitempty"`       // The maximumnumber of ads that canbe played in an ad pod.
+	Feed          int       `json:"feed,omitempty"`         // Type of audio feed.
+	Stitched      int       `json:"stitched,omitempty"`     // Indicates if the ad is stitched with audio content or delivered independently
+	NVol          int       `json:"nvol,omitempty"`         // Volume normalization mode.
+	PodDuration   int       `json:"poddur,omitempty"`       // Total amount of time in seconds that advertisers may fill for a dynamic audio ad pod.
+	PodID         string    `json:"podid,omitempty"`        // Unique identifier indicating that an impression opportunity belongs to an audio ad pod.
+	PodSequence   int       `json:"podseq,omitempty"`       // The sequence (position) of the audio ad pod within a content stream.
+	SlotInPod     int       `json:"slotinpod,omitempty"`    // Guidance on the position of the individual ad impression opportunity within the pod.
+	MinCPMPerSec  float64   `json:"mincpmpersec,omitempty"` // Minimum CPM per second, a price floor for dynamic pods.
 	Ext           Extension `json:"ext,omitempty"`
 }
 
@@ -39,6 +48,23 @@ type jsonAudio Audio
 func (a *Audio) Validate() error {
 	if len(a.Mimes) == 0 {
 		return ErrInvalidAudioNoMimes
+	} else if !validMimes(a.Mimes) {
+		return ErrInvalidAudioMime
+	} else if a.MaxDuration != 0 && a.MinDuration > a.MaxDuration {
+		return ErrInvalidAudioDuration
+	} else if len(a.RqdDurs) != 0 && (a.MinDuration != 0 || a.MaxDuration != 0) {
+		return ErrInvalidAudioRqdDurs
+	}
+
+	if a.PodDuration != 0 {
+		if a.MaxDuration > a.PodDuration {
+			return ErrInvalidAudioPodDuration
+		}
+		for _, d := range a.RqdDurs {
+			if d > a.PodDuration {
+				return ErrInvalidAudioPodDuration
+			}
+		}
 	}
 	return nil
 }
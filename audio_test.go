@@ -53,6 +53,14 @@ var _ = Describe("Audio", func() {
 			},
 			CompanionType: []int{1, 2},
 		}).Validate()).To(Equal(ErrInvalidAudioNoMimes))
+
+		Expect((&Audio{Mimes: []string{"audio/"}}).Validate()).To(Equal(ErrInvalidAudioMime))
+		Expect((&Audio{Mimes: []string{"audio/mp4"}, MinDuration: 30, MaxDuration: 15}).Validate()).To(Equal(ErrInvalidAudioDuration))
+		Expect((&Audio{Mimes: []string{"audio/mp4"}, MinDuration: 5, RqdDurs: []int{15, 30}}).Validate()).To(Equal(ErrInvalidAudioRqdDurs))
+		Expect((&Audio{Mimes: []string{"audio/mp4"}, MaxDuration: 60, PodDuration: 30}).Validate()).To(Equal(ErrInvalidAudioPodDuration))
+		Expect((&Audio{Mimes: []string{"audio/mp4"}, RqdDurs: []int{15, 60}, PodDuration: 30}).Validate()).To(Equal(ErrInvalidAudioPodDuration))
+		Expect((&Audio{Mimes: []string{"audio/mp4"}, RqdDurs: []int{15, 30}, PodDuration: 60}).Validate()).To(Succeed())
+		Expect(subject.Validate()).To(Succeed())
 	})
 
 })
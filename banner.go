@@ -7,20 +7,55 @@ package openrtb
 // VAST response to dictate placement of the companion creatives when multiple companion ad
 // opportunities of the same size are available on a page.
 type Banner struct {
-	W        int       `json:"w,omitempty"`        // Width
-	H        int       `json:"h,omitempty"`        // Height
-	Format   []Format  `json:"format,omitempty"`   //Array of format objects representing the banner sizes permitted.
-	WMax     int       `json:"wmax,omitempty"`     // Width maximum DEPRECATED
-	HMax     int       `json:"hmax,omitempty"`     // Height maximum DEPRECATED
-	WMin     int       `json:"wmin,omitempty"`     // Width minimum DEPRECATED
-	HMin     int       `json:"hmin,omitempty"`     // Height minimum DEPRECATED
-	ID       string    `json:"id,omitempty"`       // A unique identifier
-	BType    []int     `json:"btype,omitempty"`    // Blocked creative types
-	BAttr    []int     `json:"battr,omitempty"`    // Blocked creative attributes
-	Pos      int       `json:"pos,omitempty"`      // Ad Position
-	Mimes    []string  `json:"mimes,omitempty"`    // Whitelist of content MIME types supported
-	TopFrame int       `json:"topframe,omitempty"` // Default: 0 ("1": Delivered in top frame, "0": Elsewhere)
-	ExpDir   []int     `json:"expdir,omitempty"`   // Specify properties for an expandable ad
-	Api      []int     `json:"api,omitempty"`      // List of supported API frameworks
+	W        int       `json:"w,omitempty"`                            // Width
+	H        int       `json:"h,omitempty"`                            // Height
+	Format   []Format  `json:"format,omitempty"`                       //Array of format objects representing the banner sizes permitted.
+	WMax     int       `json:"wmax,omitempty" deprecated:"2.5,format"` // Width maximum DEPRECATED
+	HMax     int       `json:"hmax,omitempty" deprecated:"2.5,format"` // Height maximum DEPRECATED
+	WMin     int       `json:"wmin,omitempty" deprecated:"2.5,format"` // Width minimum DEPRECATED
+	HMin     int       `json:"hmin,omitempty" deprecated:"2.5,format"` // Height minimum DEPRECATED
+	ID       string    `json:"id,omitempty"`                           // A unique identifier
+	BType    []int     `json:"btype,omitempty"`                        // Blocked creative types
+	BAttr    []int     `json:"battr,omitempty"`                        // Blocked creative attributes
+	Pos      int       `json:"pos,omitempty"`                          // Ad Position
+	Mimes    []string  `json:"mimes,omitempty"`                        // Whitelist of content MIME types supported
+	TopFrame int       `json:"topframe,omitempty"`                     // Default: 0 ("1": Delivered in top frame, "0": Elsewhere)
+	ExpDir   []int     `json:"expdir,omitempty"`                       // Specify properties for an expandable ad
+	Api      []int     `json:"api,omitempty"`                          // List of supported API frameworks
 	Ext      Extension `json:"ext,omitempty"`
 }
+
+// Validates the object
+func (b *Banner) Validate() error {
+	if !validMimes(b.Mimes) {
+		return ErrInvalidBannerMime
+	}
+	return nil
+}
+
+// AcceptsSize returns true if an ad of the given size may be served, e.g. to
+// validate the W/H of a bid. Sizes are checked against the Format array if
+// present, otherwise against W/H or the deprecated min/max ranges. Banners
+// without any size constraints accept all sizes.
+func (b *Banner) AcceptsSize(w, h int) bool {
+	if len(b.Format) != 0 {
+		for i := range b.Format {
+			if b.Format[i].Matches(w, h) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if b.W != 0 || b.H != 0 {
+		if b.W == w && b.H == h {
+			return true
+		}
+	}
+	if b.WMax != 0 || b.HMax != 0 || b.WMin != 0 || b.HMin != 0 {
+		return w >= b.WMin && h >= b.HMin &&
+			(b.WMax == 0 || w <= b.WMax) &&
+			(b.HMax == 0 || h <= b.HMax)
+	}
+	return b.W == 0 && b.H == 0
+}
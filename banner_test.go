@@ -24,4 +24,19 @@ var _ = Describe("Banner", func() {
 		}))
 	})
 
+	It("should accept sizes", func() {
+		Expect(subject.AcceptsSize(728, 90)).To(BeTrue())
+		Expect(subject.AcceptsSize(300, 250)).To(BeFalse())
+		Expect((&Banner{}).AcceptsSize(300, 250)).To(BeTrue())
+		Expect((&Banner{WMin: 300, WMax: 320, HMin: 50}).AcceptsSize(310, 50)).To(BeTrue())
+		Expect((&Banner{WMin: 300, WMax: 320, HMin: 50}).AcceptsSize(330, 50)).To(BeFalse())
+
+		multi := &Banner{W: 728, H: 90, Format: []Format{{W: 300, H: 250}, {WRatio: 16, HRatio: 9, WMin: 320}}}
+		Expect(multi.AcceptsSize(728, 90)).To(BeFalse())
+		Expect(multi.AcceptsSize(300, 250)).To(BeTrue())
+		Expect(multi.AcceptsSize(640, 360)).To(BeTrue())
+		Expect(multi.AcceptsSize(160, 90)).To(BeFalse())
+		Expect(multi.AcceptsSize(640, 480)).To(BeFalse())
+	})
+
 })
@@ -0,0 +1,228 @@
+/*
+Package batch validates large numbers of OpenRTB payloads concurrently, e.g.
+to scan a day of logs for spec drift during offline QA, and aggregates the
+results into a report of violation counts by rule.
+
+	f, _ := os.Open("requests-2026-10-14.jsonl")
+	defer f.Close()
+
+	report, err := batch.ValidateLines(ctx, f, "requests-2026-10-14.jsonl", nil)
+	if err != nil {
+		return err
+	}
+	for _, v := range report.Violations() {
+		fmt.Println(v.Count, v.Rule, v.Examples)
+	}
+*/
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/bsm/openrtb"
+)
+
+// RuleDecode is the rule reported for payloads that cannot be decoded.
+const RuleDecode = "decode"
+
+// Payload is a single encoded payload.
+type Payload struct {
+	Ref  string // Reference to the payload source, e.g. "file.jsonl:42"
+	Data []byte
+}
+
+// Options configure validation.
+type Options struct {
+	// Concurrency is the number of worker goroutines.
+	// Default: runtime.NumCPU()
+	Concurrency int
+
+	// MaxExamples is the maximum number of example payload references
+	// stored per rule. Default: 10
+	MaxExamples int
+
+	// Responses validates bid responses instead of requests.
+	Responses bool
+}
+
+func (o *Options) norm() *Options {
+	var oo Options
+	if o != nil {
+		oo = *o
+	}
+	if oo.Concurrency < 1 {
+		oo.Concurrency = runtime.NumCPU()
+	}
+	if oo.MaxExamples < 1 {
+		oo.MaxExamples = 10
+	}
+	return &oo
+}
+
+// Violation aggregates all failures of a single rule.
+type Violation struct {
+	Rule     string   `json:"rule"`     // The validation error message, without the path, or RuleDecode
+	Count    int      `json:"count"`    // Number of payloads violating the rule
+	Examples []string `json:"examples"` // Example payload references
+}
+
+// Report is an aggregate validation report.
+type Report struct {
+	Total   int `json:"total"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+
+	rules       map[string]*Violation
+	maxExamples int
+	mu          sync.Mutex
+}
+
+// Violations returns all violations, ordered by count (descending) and rule.
+func (r *Report) Violations() []*Violation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	vs := make([]*Violation, 0, len(r.rules))
+	for _, v := range r.rules {
+		vs = append(vs, v)
+	}
+	sort.Slice(vs, func(i, j int) bool {
+		if vs[i].Count != vs[j].Count {
+			return vs[i].Count > vs[j].Count
+		}
+		return vs[i].Rule < vs[j].Rule
+	})
+	return vs
+}
+
+// MarshalJSON implements json.Marshaler
+func (r *Report) MarshalJSON() ([]byte, error) {
+	type jsonReport Report
+	return json.Marshal(struct {
+		*jsonReport
+		Violations []*Violation `json:"violations"`
+	}{
+		jsonReport: (*jsonReport)(r),
+		Violations: r.Violations(),
+	})
+}
+
+func (r *Report) record(ref, rule string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Total++
+	if rule == "" {
+		r.Valid++
+		return
+	}
+
+	r.Invalid++
+	v, ok := r.rules[rule]
+	if !ok {
+		v = &Violation{Rule: rule}
+		r.rules[rule] = v
+	}
+	v.Count++
+	if len(v.Examples) < r.maxExamples {
+		v.Examples = append(v.Examples, ref)
+	}
+}
+
+// Validate validates all payloads received from src until it is closed or
+// ctx is cancelled.
+func Validate(ctx context.Context, src <-chan Payload, opt *Options) *Report {
+	opt = opt.norm()
+	report := &Report{rules: make(map[string]*Violation), maxExamples: opt.MaxExamples}
+
+	var wg sync.WaitGroup
+	for i := 0; i < opt.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case p, ok := <-src:
+					if !ok {
+						return
+					}
+					report.record(p.Ref, check(p.Data, opt.Responses))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// ValidateLines validates newline-delimited JSON payloads read from r. Blank
+// lines are skipped. Payloads are referenced as "<name>:<line>".
+func ValidateLines(ctx context.Context, r io.Reader, name string, opt *Options) (*Report, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	src := make(chan Payload, 128)
+	done := make(chan *Report, 1)
+	go func() { done <- Validate(ctx, src, opt) }()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var err error
+	for line := 1; scanner.Scan(); line++ {
+		data := scanner.Bytes()
+		if len(data) == 0 {
+			continue
+		}
+
+		p := Payload{Ref: name + ":" + strconv.Itoa(line), Data: append([]byte(nil), data...)}
+		select {
+		case src <- p:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err != nil {
+			break
+		}
+	}
+	close(src)
+
+	report := <-done
+	if err == nil {
+		err = scanner.Err()
+	}
+	return report, err
+}
+
+func check(data []byte, response bool) string {
+	var v interface{ Validate() error }
+	if response {
+		v = new(openrtb.BidResponse)
+	} else {
+		v = new(openrtb.BidRequest)
+	}
+
+	if len(data) == 0 || data[0] != '{' {
+		return RuleDecode
+	} else if err := json.Unmarshal(data, v); err != nil {
+		return RuleDecode
+	} else if err := v.Validate(); err != nil {
+		var verr *openrtb.ValidationError
+		if errors.As(err, &verr) {
+			return verr.Err.Error()
+		}
+		return err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	It("should validate payloads concurrently", func() {
+		src := make(chan Payload, 10)
+		src <- Payload{Ref: "a", Data: []byte(`{"id":"1","imp":[{"id":"1","banner":{}}]}`)}
+		src <- Payload{Ref: "b", Data: []byte(`{"imp":[]}`)}
+		src <- Payload{Ref: "c", Data: []byte(`{"id":"1"}`)}
+		src <- Payload{Ref: "d", Data: []byte(`{"id":"1","imp":[{"id":"1"}]}`)}
+		src <- Payload{Ref: "e", Data: []byte(`{"id":"1","imp":[{"id":"1"}]}`)}
+		src <- Payload{Ref: "f", Data: []byte(`{"id":`)}
+		src <- Payload{Ref: "g", Data: []byte(`null`)}
+		close(src)
+
+		report := Validate(context.Background(), src, &Options{Concurrency: 3, MaxExamples: 1})
+		Expect(report.Total).To(Equal(7))
+		Expect(report.Valid).To(Equal(1))
+		Expect(report.Invalid).To(Equal(6))
+
+		vs := report.Violations()
+		Expect(vs).To(HaveLen(4))
+		Expect(vs[0].Rule).To(Equal("decode"))
+		Expect(vs[0].Count).To(Equal(2))
+		Expect(vs[0].Examples).To(HaveLen(1))
+		Expect(vs[1].Rule).To(Equal("openrtb: impression has no assets"))
+		Expect(vs[1].Count).To(Equal(2))
+		Expect(vs[2]).To(Equal(&Violation{Rule: "openrtb: request ID missing", Count: 1, Examples: []string{"b"}}))
+		Expect(vs[3]).To(Equal(&Violation{Rule: "openrtb: request has no impressions", Count: 1, Examples: []string{"c"}}))
+	})
+
+	It("should validate responses", func() {
+		src := make(chan Payload, 2)
+		src <- Payload{Ref: "a", Data: []byte(`{"id":"1","seatbid":[{"bid":[{"id":"1","impid":"1","price":1}]}]}`)}
+		src <- Payload{Ref: "b", Data: []byte(`{"id":"1","seatbid":[]}`)}
+		close(src)
+
+		report := Validate(context.Background(), src, &Options{Responses: true})
+		Expect(report.Valid).To(Equal(1))
+		Expect(report.Violations()).To(Equal([]*Violation{
+			{Rule: "openrtb: response missing seatbids", Count: 1, Examples: []string{"b"}},
+		}))
+	})
+
+	It("should validate lines", func() {
+		r := strings.NewReader("{\"id\":\"1\",\"imp\":[{\"id\":\"1\",\"banner\":{}}]}\n\n{\"id\":\"1\"}\n{}\n")
+		report, err := ValidateLines(context.Background(), r, "log.jsonl", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Total).To(Equal(3))
+		Expect(report.Violations()).To(Equal([]*Violation{
+			{Rule: "openrtb: request ID missing", Count: 1, Examples: []string{"log.jsonl:4"}},
+			{Rule: "openrtb: request has no impressions", Count: 1, Examples: []string{"log.jsonl:3"}},
+		}))
+
+		data, err := json.Marshal(report)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"total": 3, "valid": 1, "invalid": 2,
+			"violations": [
+				{"rule": "openrtb: request ID missing", "count": 1, "examples": ["log.jsonl:4"]},
+				{"rule": "openrtb: request has no impressions", "count": 1, "examples": ["log.jsonl:3"]}
+			]
+		}`))
+	})
+
+	It("should stop on cancellation", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		r := strings.NewReader(strings.Repeat("{}\n", 1000))
+		_, err := ValidateLines(ctx, r, "log.jsonl", &Options{Concurrency: 1})
+		Expect(err).To(Equal(context.Canceled))
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/batch")
+}
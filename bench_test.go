@@ -1,6 +1,7 @@
 package openrtb
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
@@ -13,6 +14,7 @@ func BenchmarkBidRequest_Unmarshal(b *testing.B) {
 		b.Fatal(err.Error())
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		var req *BidRequest
@@ -22,6 +24,22 @@ func BenchmarkBidRequest_Unmarshal(b *testing.B) {
 	}
 }
 
+func BenchmarkBidRequest_UnmarshalInto(b *testing.B) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "breq.video.json"))
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	req := new(BidRequest)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := UnmarshalInto(data, req); err != nil {
+			b.Fatal(err.Error())
+		}
+	}
+}
+
 func BenchmarkBidRequest_Marshal(b *testing.B) {
 	data, err := ioutil.ReadFile(filepath.Join("testdata", "breq.video.json"))
 	if err != nil {
@@ -40,3 +58,21 @@ func BenchmarkBidRequest_Marshal(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkBidRequest_DecodeInterned(b *testing.B) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "breq.video.json"))
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	opts := &DecodeOptions{Lenient: true, Interner: NewStringInterner(10000)}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := UnmarshalBidRequestContext(ctx, data, opts); err != nil {
+			b.Fatal(err.Error())
+		}
+	}
+}
@@ -6,12 +6,21 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // Validation errors
 var (
 	ErrInvalidBidNoID    = errors.New("openrtb: bid is missing ID")
 	ErrInvalidBidNoImpID = errors.New("openrtb: bid is missing impression ID")
+	ErrInvalidBidImpID   = errors.New("openrtb: bid references unknown impression")
+	ErrInvalidBidMType   = errors.New("openrtb: bid mtype not offered by impression")
+	ErrInvalidBidMarkup  = errors.New("openrtb: bid markup does not match mtype") // e.g. no VAST for video, no JSON for native
+	ErrInvalidBidPrice   = errors.New("openrtb: bid price must be positive")
+	ErrInvalidBidFloor   = errors.New("openrtb: bid price is below floor")
+	ErrInvalidBidNoAdm   = errors.New("openrtb: bid has neither markup nor win notice URL")
+	ErrInvalidBidNoSize  = errors.New("openrtb: banner bid is missing width or height")
+	ErrInvalidBidDealID  = errors.New("openrtb: bid deal ID contains invalid characters")
 )
 
 type MultiString string
@@ -52,35 +61,169 @@ func (s MultiString) String() string {
 // Substitution macros may allow a bidder to use a static notice URL for all of its bids.
 type Bid struct {
 	ID             string      `json:"id"`
-	ImpID          string      `json:"impid"`                    // Required string ID of the impression object to which this bid applies.
-	Price          float64     `json:"price"`                    // Bid price in CPM. Suggests using integer math for accounting to avoid rounding errors.
-	AdID           string      `json:"adid,omitempty"`           // References the ad to be served if the bid wins.
-	NURL           string      `json:"nurl,omitempty"`           // Win notice URL.
-	AdMarkup       string      `json:"adm,omitempty"`            // Actual ad markup. XHTML if a response to a banner object, or VAST XML if a response to a video object.
-	AdvDomain      []string    `json:"adomain,omitempty"`        // Advertiser’s primary or top-level domain for advertiser checking; or multiple if imp rotating.
-	Bundle         string      `json:"bundle,omitempty"`         // A platform-specific application identifier intended to be unique to the app and independent of the exchange.
-	IURL           string      `json:"iurl,omitempty"`           // Sample image URL.
-	CampaignID     MultiString `json:"cid,omitempty"`            // Campaign ID that appears with the Ad markup.
-	CreativeID     string      `json:"crid,omitempty"`           // Creative ID for reporting content issues or defects. This could also be used as a reference to a creative ID that is posted with an exchange.
-	Cat            []string    `json:"cat,omitempty"`            // IAB content categories of the creative. Refer to List 5.1
-	Attr           []int       `json:"attr,omitempty"`           // Array of creative attributes.
-	API            int         `json:"api,omitempty"`            // API required by the markup if applicable
-	Protocol       int         `json:"protocol,omitempty"`       // Video response protocol of the markup if applicable
-	QAGMediaRating int         `json:"qagmediarating,omitempty"` // Creative media rating per IQG guidelines.
-	DealID         string      `json:"dealid,omitempty"`         // DealID extension of private marketplace deals
-	H              int         `json:"h,omitempty"`              // Height of the ad in pixels.
-	W              int         `json:"w,omitempty"`              // Width of the ad in pixels.
-	Exp            int         `json:"exp,omitempty"`            // Advisory as to the number of seconds the bidder is willing to wait between the auction and the actual impression.
+	ImpID          string      `json:"impid"`                               // Required string ID of the impression object to which this bid applies.
+	Price          float64     `json:"price"`                               // Bid price in CPM. Suggests using integer math for accounting to avoid rounding errors.
+	AdID           string      `json:"adid,omitempty"`                      // References the ad to be served if the bid wins.
+	NURL           string      `json:"nurl,omitempty"`                      // Win notice URL.
+	AdMarkup       string      `json:"adm,omitempty"`                       // Actual ad markup. XHTML if a response to a banner object, or VAST XML if a response to a video object.
+	AdvDomain      []string    `json:"adomain,omitempty"`                   // Advertiser’s primary or top-level domain for advertiser checking; or multiple if imp rotating.
+	Bundle         string      `json:"bundle,omitempty"`                    // A platform-specific application identifier intended to be unique to the app and independent of the exchange.
+	IURL           string      `json:"iurl,omitempty"`                      // Sample image URL.
+	CampaignID     MultiString `json:"cid,omitempty"`                       // Campaign ID that appears with the Ad markup.
+	CreativeID     string      `json:"crid,omitempty"`                      // Creative ID for reporting content issues or defects. This could also be used as a reference to a creative ID that is posted with an exchange.
+	CatTax         int         `json:"cattax,omitempty"`                    // The taxonomy in use for cat, Default: 1
+	Cat            []string    `json:"cat,omitempty"`                       // IAB content categories of the creative. Refer to List 5.1
+	Attr           []int       `json:"attr,omitempty"`                      // Array of creative attributes.
+	API            int         `json:"api,omitempty" deprecated:"2.6,apis"` // API required by the markup if applicable
+	APIs           []int       `json:"apis,omitempty"`                      // List of APIs required by the markup if applicable
+	Protocol       int         `json:"protocol,omitempty"`                  // Video response protocol of the markup if applicable
+	QAGMediaRating int         `json:"qagmediarating,omitempty"`            // Creative media rating per IQG guidelines.
+	Language       string      `json:"language,omitempty"`                  // Language of the creative using ISO-639-1-alpha-2.
+	LangB          string      `json:"langb,omitempty"`                     // Language of the creative using IETF BCP 47.
+	DealID         string      `json:"dealid,omitempty"`                    // DealID extension of private marketplace deals
+	H              int         `json:"h,omitempty"`                         // Height of the ad in pixels.
+	W              int         `json:"w,omitempty"`                         // Width of the ad in pixels.
+	Exp            int         `json:"exp,omitempty"`                       // Advisory as to the number of seconds the bidder is willing to wait between the auction and the actual impression.
+	MType          int         `json:"mtype,omitempty"`                     // Type of the creative markup so that it can properly be associated with the right sub-object of the BidRequest.Imp.
 	Ext            Extension   `json:"ext,omitempty"`
 }
 
-// Validate required attributes
-func (bid *Bid) Validate() error {
+// Validate required attributes. By default, only the ID and impression ID
+// are checked, additional rules can be enabled via opts.
+func (bid *Bid) Validate(opts ...ValidateOption) error {
 	if bid.ID == "" {
 		return ErrInvalidBidNoID
 	} else if bid.ImpID == "" {
 		return ErrInvalidBidNoImpID
 	}
 
+	var rules bidRules
+	for _, opt := range opts {
+		opt(&rules)
+	}
+
+	if rules.price && bid.Price <= 0 {
+		return ErrInvalidBidPrice
+	} else if rules.floor > 0 && bid.Price < rules.floor {
+		return ErrInvalidBidFloor
+	} else if rules.markup && bid.AdMarkup == "" && bid.NURL == "" {
+		return ErrInvalidBidNoAdm
+	} else if rules.size && bid.MType == MarkupTypeBanner && (bid.W == 0 || bid.H == 0) {
+		return ErrInvalidBidNoSize
+	} else if rules.dealID && !validDealID(bid.DealID) {
+		return ErrInvalidBidDealID
+	} else if rules.advDomain && !validAdvDomains(bid.AdvDomain) {
+		return ErrInvalidBidAdvDomain
+	}
+
+	return nil
+}
+
+// ValidateOption enables an additional bid validation rule.
+type ValidateOption func(*bidRules)
+
+type bidRules struct {
+	price, markup, size, dealID, advDomain bool
+	floor                                  float64
+}
+
+// RequirePrice requires bids to have a positive price.
+func RequirePrice() ValidateOption {
+	return func(r *bidRules) { r.price = true }
+}
+
+// RequireFloor requires bids to be priced at or above floor. Floors <= 0
+// are ignored.
+func RequireFloor(floor float64) ValidateOption {
+	return func(r *bidRules) { r.floor = floor }
+}
+
+// RequireMarkup requires bids to have either markup or a win notice URL.
+func RequireMarkup() ValidateOption {
+	return func(r *bidRules) { r.markup = true }
+}
+
+// RequireBannerSize requires bids with banner mtype to specify their width
+// and height.
+func RequireBannerSize() ValidateOption {
+	return func(r *bidRules) { r.size = true }
+}
+
+// RequireValidDealID restricts deal IDs to ASCII letters, digits and the
+// characters '-', '_', '.' and ':'.
+func RequireValidDealID() ValidateOption {
+	return func(r *bidRules) { r.dealID = true }
+}
+
+// RequireValidAdvDomains requires adomain entries to be plausible
+// registrable domains, see NormalizeDomain.
+func RequireValidAdvDomains() ValidateOption {
+	return func(r *bidRules) { r.advDomain = true }
+}
+
+func validDealID(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// MediaType returns the media type of the markup, see MediaType* constants.
+// It returns an empty string if mtype is not set.
+func (bid *Bid) MediaType() string {
+	switch bid.MType {
+	case MarkupTypeBanner:
+		return MediaTypeBanner
+	case MarkupTypeVideo:
+		return MediaTypeVideo
+	case MarkupTypeAudio:
+		return MediaTypeAudio
+	case MarkupTypeNative:
+		return MediaTypeNative
+	}
+	return ""
+}
+
+// ValidateForImp cross-checks the bid against the impression it applies to.
+// Bids with an mtype must correspond to a media type offered by imp and
+// their markup, if present, must plausibly match: VAST for video and audio,
+// a JSON object for native.
+func (bid *Bid) ValidateForImp(imp *Impression) error {
+	if bid.MType == 0 {
+		return nil
+	}
+
+	mediaType := bid.MediaType()
+	offered := false
+	for _, typ := range imp.MediaTypes() {
+		if typ == mediaType {
+			offered = true
+			break
+		}
+	}
+	if !offered {
+		return ErrInvalidBidMType
+	}
+
+	adm := strings.TrimSpace(bid.AdMarkup)
+	if adm == "" {
+		return nil
+	}
+
+	switch bid.MType {
+	case MarkupTypeVideo, MarkupTypeAudio:
+		if !strings.Contains(adm, "<VAST") {
+			return ErrInvalidBidMarkup
+		}
+	case MarkupTypeNative:
+		if adm[0] != '{' || !json.Valid([]byte(adm)) {
+			return ErrInvalidBidMarkup
+		}
+	}
 	return nil
 }
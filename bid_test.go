@@ -36,4 +36,52 @@ var _ = Describe("Bid", func() {
 		Expect(subject.Validate()).NotTo(HaveOccurred())
 	})
 
+	It("should validate with options", func() {
+		bid := &Bid{ID: "BIDID", ImpID: "IMPID"}
+		Expect(bid.Validate()).To(Succeed())
+		Expect(bid.Validate(RequirePrice())).To(Equal(ErrInvalidBidPrice))
+		Expect(bid.Validate(RequireMarkup())).To(Equal(ErrInvalidBidNoAdm))
+
+		bid.Price = 1.5
+		Expect(bid.Validate(RequirePrice(), RequireFloor(1.5))).To(Succeed())
+		Expect(bid.Validate(RequireFloor(1.6))).To(Equal(ErrInvalidBidFloor))
+		Expect(bid.Validate(RequireFloor(0))).To(Succeed())
+
+		bid.NURL = "http://example.com/win"
+		Expect(bid.Validate(RequireMarkup())).To(Succeed())
+
+		bid.MType = MarkupTypeBanner
+		Expect(bid.Validate(RequireBannerSize())).To(Equal(ErrInvalidBidNoSize))
+		bid.W, bid.H = 300, 250
+		Expect(bid.Validate(RequireBannerSize())).To(Succeed())
+		Expect((&Bid{ID: "BIDID", ImpID: "IMPID", MType: MarkupTypeVideo}).Validate(RequireBannerSize())).To(Succeed())
+
+		Expect(subject.Validate(RequireValidDealID())).To(Succeed())
+		bid.DealID = "deal 1"
+		Expect(bid.Validate(RequireValidDealID())).To(Equal(ErrInvalidBidDealID))
+		bid.DealID = "pub-1.deal_2:x"
+		Expect(bid.Validate(RequireValidDealID())).To(Succeed())
+	})
+
+	It("should have accessors", func() {
+		Expect(subject.MediaType()).To(BeEmpty())
+		Expect((&Bid{MType: MarkupTypeNative}).MediaType()).To(Equal(MediaTypeNative))
+	})
+
+	It("should validate against impressions", func() {
+		banner := &Impression{ID: "1", Banner: &Banner{}}
+		video := &Impression{ID: "1", Video: &Video{}}
+		native := &Impression{ID: "1", Native: &Native{}}
+
+		Expect(subject.ValidateForImp(video)).To(Succeed())
+		Expect((&Bid{MType: MarkupTypeBanner, AdMarkup: "<html/>"}).ValidateForImp(banner)).To(Succeed())
+		Expect((&Bid{MType: MarkupTypeBanner}).ValidateForImp(video)).To(Equal(ErrInvalidBidMType))
+		Expect((&Bid{MType: MarkupTypeVideo, AdMarkup: " <VAST version=\"3.0\"></VAST>"}).ValidateForImp(video)).To(Succeed())
+		Expect((&Bid{MType: MarkupTypeVideo, AdMarkup: "<html/>"}).ValidateForImp(video)).To(Equal(ErrInvalidBidMarkup))
+		Expect((&Bid{MType: MarkupTypeVideo}).ValidateForImp(video)).To(Succeed())
+		Expect((&Bid{MType: MarkupTypeNative, AdMarkup: `{"native":{"assets":[]}}`}).ValidateForImp(native)).To(Succeed())
+		Expect((&Bid{MType: MarkupTypeNative, AdMarkup: "<html/>"}).ValidateForImp(native)).To(Equal(ErrInvalidBidMarkup))
+		Expect((&Bid{MType: MarkupTypeAudio}).ValidateForImp(native)).To(Equal(ErrInvalidBidMType))
+	})
+
 })
@@ -0,0 +1,126 @@
+/*
+Package bidderparams validates adapter-specific bidder params, passed as
+imp.ext.{bidder} (or Prebid-style as imp.ext.prebid.bidder.{bidder}),
+against registered JSON schema definitions.
+*/
+package bidderparams
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/jsonschema"
+)
+
+// Registry holds bidder param schemas by bidder name.
+type Registry struct {
+	schemas map[string]*jsonschema.Schema
+	mu      sync.RWMutex
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{schemas: make(map[string]*jsonschema.Schema)}
+}
+
+// Register parses and registers the JSON schema for a bidder.
+func (r *Registry) Register(bidder string, schema []byte) error {
+	s, err := jsonschema.Parse(schema)
+	if err != nil {
+		return err
+	}
+	r.RegisterSchema(bidder, s)
+	return nil
+}
+
+// RegisterSchema registers a schema for a bidder.
+func (r *Registry) RegisterSchema(bidder string, s *jsonschema.Schema) {
+	r.mu.Lock()
+	r.schemas[bidder] = s
+	r.mu.Unlock()
+}
+
+// Schema returns the schema registered for a bidder, or nil.
+func (r *Registry) Schema(bidder string) *jsonschema.Schema {
+	r.mu.RLock()
+	s := r.schemas[bidder]
+	r.mu.RUnlock()
+	return s
+}
+
+// Decode decodes a bid request and validates its bidder params.
+func (r *Registry) Decode(data []byte) (*openrtb.BidRequest, error) {
+	var req *openrtb.BidRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	if err := r.Validate(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// Validate validates the params of all registered bidders found in the
+// impression extensions. Params of unregistered bidders are ignored. It
+// returns jsonschema.Errors with paths like "imp[0].ext.appnexus.placementId".
+func (r *Registry) Validate(req *openrtb.BidRequest) error {
+	var errs jsonschema.Errors
+	for i, imp := range req.Imp {
+		path := "imp[" + strconv.Itoa(i) + "].ext"
+		if err := r.validateExt(imp.Ext, path, &errs); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+func (r *Registry) validateExt(ext openrtb.Extension, path string, errs *jsonschema.Errors) error {
+	if len(ext) == 0 {
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(ext, &obj); err != nil {
+		*errs = append(*errs, &jsonschema.Error{Path: path, Message: "invalid JSON: " + err.Error()})
+		return nil
+	}
+
+	if raw, ok := obj["prebid"]; ok {
+		var prebid struct {
+			Bidder openrtb.Extension `json:"bidder"`
+		}
+		if err := json.Unmarshal(raw, &prebid); err == nil {
+			if err := r.validateExt(prebid.Bidder, path+".prebid.bidder", errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	bidders := make([]string, 0, len(obj))
+	for bidder := range obj {
+		bidders = append(bidders, bidder)
+	}
+	sort.Strings(bidders)
+
+	for _, bidder := range bidders {
+		s := r.Schema(bidder)
+		if s == nil {
+			continue
+		}
+		if err := s.ValidateJSON(obj[bidder], path+"."+bidder); err != nil {
+			verrs, ok := err.(jsonschema.Errors)
+			if !ok {
+				return err
+			}
+			*errs = append(*errs, verrs...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package bidderparams
+
+import (
+	"testing"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/jsonschema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var subject *Registry
+
+	BeforeEach(func() {
+		subject = NewRegistry()
+		Expect(subject.Register("appnexus", []byte(`{
+			"type": "object",
+			"properties": {"placementId": {"type": "integer", "minimum": 1}},
+			"required": ["placementId"]
+		}`))).To(Succeed())
+		Expect(subject.Register("rubicon", []byte(`{
+			"type": "object",
+			"properties": {"zoneId": {"type": "string"}},
+			"required": ["zoneId"]
+		}`))).To(Succeed())
+	})
+
+	It("should register schemas", func() {
+		Expect(subject.Schema("appnexus")).NotTo(BeNil())
+		Expect(subject.Schema("unknown")).To(BeNil())
+		Expect(subject.Register("bad", []byte(`{"pattern":"("}`))).NotTo(Succeed())
+	})
+
+	It("should validate bidder params", func() {
+		req := &openrtb.BidRequest{ID: "r", Imp: []openrtb.Impression{
+			{ID: "1", Ext: openrtb.Extension(`{"appnexus":{"placementId":12},"other":{"x":1}}`)},
+			{ID: "2", Ext: openrtb.Extension(`{"prebid":{"bidder":{"rubicon":{"zoneId":"z"}}}}`)},
+			{ID: "3"},
+		}}
+		Expect(subject.Validate(req)).To(Succeed())
+	})
+
+	It("should report path-qualified errors", func() {
+		req := &openrtb.BidRequest{ID: "r", Imp: []openrtb.Impression{
+			{ID: "1", Ext: openrtb.Extension(`{"rubicon":{"zoneId":1},"appnexus":{"placementId":0}}`)},
+			{ID: "2", Ext: openrtb.Extension(`{"prebid":{"bidder":{"appnexus":{}}}}`)},
+		}}
+		err := subject.Validate(req)
+		Expect(err).To(BeAssignableToTypeOf(jsonschema.Errors{}))
+		Expect(err).To(MatchError("imp[0].ext.appnexus.placementId: must be >= 1; " +
+			"imp[0].ext.rubicon.zoneId: expected string, got integer; " +
+			"imp[1].ext.prebid.bidder.appnexus.placementId: is required"))
+	})
+
+	It("should decode and validate", func() {
+		req, err := subject.Decode([]byte(`{"id":"r","imp":[{"id":"1","ext":{"appnexus":{"placementId":3}}}]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("r"))
+
+		_, err = subject.Decode([]byte(`{"id":"r","imp":[{"id":"1","ext":{"appnexus":{"placementId":"3"}}}]}`))
+		Expect(err).To(MatchError("imp[0].ext.appnexus.placementId: expected integer, got string"))
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/bidderparams")
+}
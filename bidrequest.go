@@ -4,9 +4,14 @@ import "errors"
 
 // Validation errors
 var (
-	ErrInvalidReqNoID     = errors.New("openrtb: request ID missing")
-	ErrInvalidReqNoImps   = errors.New("openrtb: request has no impressions")
-	ErrInvalidReqMultiInv = errors.New("openrtb: request has multiple inventory sources") // has site and app
+	ErrInvalidReqNoID      = errors.New("openrtb: request ID missing")
+	ErrInvalidReqNoImps    = errors.New("openrtb: request has no impressions")
+	ErrInvalidReqMultiInv  = errors.New("openrtb: request has multiple inventory sources") // more than one of site, app and dooh
+	ErrInvalidReqCur       = errors.New("openrtb: request has invalid currency")           // not an ISO-4217 code
+	ErrInvalidReqWLang     = errors.New("openrtb: request has invalid wlang")              // not an ISO-639-1 code
+	ErrInvalidReqWLangB    = errors.New("openrtb: request has invalid wlangb")             // not a BCP-47 language tag
+	ErrInvalidReqMultiLang = errors.New("openrtb: request has both wlang and wlangb")
+	ErrInvalidReqAuction   = errors.New("openrtb: request has invalid at") // not first or second price, nor exchange-specific
 )
 
 // The top-level bid request object contains a globally unique bid request or auction ID.  This "id"
@@ -17,38 +22,141 @@ type BidRequest struct {
 	Imp         []Impression `json:"imp,omitempty"`
 	Site        *Site        `json:"site,omitempty"`
 	App         *App         `json:"app,omitempty"`
+	DOOH        *DOOH        `json:"dooh,omitempty"`
 	Device      *Device      `json:"device,omitempty"`
 	User        *User        `json:"user,omitempty"`
 	Test        int          `json:"test,omitempty"`    // Indicator of test mode in which auctions are not billable, where 0 = live mode, 1 = test mode
 	AuctionType int          `json:"at"`                // Auction type, where 1 = First Price, 2 = Second Price Plus. Exchange-specific auction types can be defined using values greater than 500.
 	TMax        int          `json:"tmax,omitempty"`    // Maximum amount of time in milliseconds to submit a bid
 	WSeat       []string     `json:"wseat,omitempty"`   // Array of buyer seats allowed to bid on this auction
+	BSeat       []string     `json:"bseat,omitempty"`   // Block list of buyer seats restricted from bidding on this auction. Only one of wseat and bseat should be present.
 	AllImps     int          `json:"allimps,omitempty"` // Flag to indicate whether exchange can verify that all impressions offered represent all of the impressions available in context, Default: 0
 	Cur         []string     `json:"cur,omitempty"`     // Array of allowed currencies
+	WLang       []string     `json:"wlang,omitempty"`   // Allowed list of languages for creatives using ISO-639-1-alpha-2. Only one of wlang or wlangb should be present.
+	WLangB      []string     `json:"wlangb,omitempty"`  // Allowed list of languages for creatives using IETF BCP 47. Only one of wlang or wlangb should be present.
+	CatTax      int          `json:"cattax,omitempty"`  // The taxonomy in use for bcat, Default: 1
 	Bcat        []string     `json:"bcat,omitempty"`    // Blocked Advertiser Categories.
 	BAdv        []string     `json:"badv,omitempty"`    // Array of strings of blocked toplevel domains of advertisers
 	BApp        []string     `json:"bapp,omitempty"`    // Block list of applications by their platform-specific exchange-independent application identifiers. On Android, these should be bundle or package names (e.g., com.foo.mygame).  On iOS, these are numeric IDs.
+	Source      *Source      `json:"source,omitempty"`
 	Regs        *Regulations `json:"regs,omitempty"`
 	Ext         Extension    `json:"ext,omitempty"`
 
-	Pmp *Pmp `json:"pmp,omitempty"` // DEPRECATED: kept for backwards compatibility
+	Pmp *Pmp `json:"pmp,omitempty" deprecated:"2.2,imp.pmp"` // DEPRECATED: kept for backwards compatibility
 }
 
-// Validates the request
+func (req *BidRequest) inventoryCount() int {
+	n := 0
+	if req.Site != nil {
+		n++
+	}
+	if req.App != nil {
+		n++
+	}
+	if req.DOOH != nil {
+		n++
+	}
+	return n
+}
+
+// Validates the request. Errors are returned as *ValidationError.
 func (req *BidRequest) Validate() error {
-	if req.ID == "" {
-		return ErrInvalidReqNoID
-	} else if len(req.Imp) == 0 {
-		return ErrInvalidReqNoImps
-	} else if req.Site != nil && req.App != nil {
-		return ErrInvalidReqMultiInv
+	v := new(validator)
+	req.validate(v)
+	return v.err()
+}
+
+// ValidateAll validates the request and returns all violations as
+// ValidationErrors, rather than just the first.
+func (req *BidRequest) ValidateAll() error {
+	v := &validator{all: true}
+	req.validate(v)
+	return v.err()
+}
+
+func (req *BidRequest) validate(v *validator) bool {
+	if req.ID == "" && !v.add("", ErrInvalidReqNoID) {
+		return false
+	}
+	if len(req.Imp) == 0 && !v.add("", ErrInvalidReqNoImps) {
+		return false
+	}
+	if req.inventoryCount() > 1 && !v.add("", ErrInvalidReqMultiInv) {
+		return false
+	}
+	if !validRequestAuctionType(req.AuctionType) && !v.add("", ErrInvalidReqAuction) {
+		return false
+	}
+
+	for i, cur := range req.Cur {
+		if (cur == "" || !validCurrency(cur)) && !v.addAt(indexPath("cur", i), ErrInvalidReqCur) {
+			return false
+		}
+	}
+	if len(req.WLang) != 0 && len(req.WLangB) != 0 && !v.add("", ErrInvalidReqMultiLang) {
+		return false
+	}
+	for i, lang := range req.WLang {
+		if !IsValidLanguage(lang) && !v.addAt(indexPath("wlang", i), ErrInvalidReqWLang) {
+			return false
+		}
+	}
+	for i, tag := range req.WLangB {
+		if !IsValidLanguageTag(tag) && !v.addAt(indexPath("wlangb", i), ErrInvalidReqWLangB) {
+			return false
+		}
 	}
 
-	for _, imp := range req.Imp {
-		if err := (&imp).Validate(); err != nil {
-			return err
+	if req.Device != nil {
+		if name := req.Device.invalidFlag(); name != "" && !v.addAt("device."+name, ErrInvalidFlag) {
+			return false
 		}
 	}
+	if req.Device != nil && req.Device.Geo != nil && !v.add("device.geo", req.Device.Geo.Validate()) {
+		return false
+	}
+	if req.User != nil && req.User.Geo != nil && !v.add("user.geo", req.User.Geo.Validate()) {
+		return false
+	}
+
+	for i := range req.Imp {
+		if !req.Imp[i].validate(v, indexPath("imp", i)) {
+			return false
+		}
+	}
+
+	return true
+}
 
+// ImpByID returns the impression with the given ID, or nil if not found
+func (req *BidRequest) ImpByID(id string) *Impression {
+	for i := range req.Imp {
+		if req.Imp[i].ID == id {
+			return &req.Imp[i]
+		}
+	}
 	return nil
 }
+
+// IsTest returns true if the request is test traffic, which is not
+// billable.
+func (req *BidRequest) IsTest() bool {
+	return req.Test == 1
+}
+
+// PublisherID returns the ID of the site, app or DOOH publisher, if any
+func (req *BidRequest) PublisherID() string {
+	var pub *Publisher
+	if req.Site != nil {
+		pub = req.Site.Publisher
+	} else if req.App != nil {
+		pub = req.App.Publisher
+	} else if req.DOOH != nil {
+		pub = req.DOOH.Publisher
+	}
+
+	if pub == nil {
+		return ""
+	}
+	return pub.ID
+}
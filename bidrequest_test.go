@@ -64,15 +64,53 @@ var _ = Describe("BidRequest", func() {
 	})
 
 	It("should validate", func() {
-		Expect((&BidRequest{}).Validate()).To(Equal(ErrInvalidReqNoID))
-		Expect((&BidRequest{ID: "A"}).Validate()).To(Equal(ErrInvalidReqNoImps))
-		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}, Site: &Site{}, App: &App{}}).Validate()).To(Equal(ErrInvalidReqMultiInv))
-		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}}).Validate()).To(Equal(ErrInvalidImpNoAssets))
+		Expect((&BidRequest{}).Validate()).To(MatchError(ErrInvalidReqNoID))
+		Expect((&BidRequest{ID: "A"}).Validate()).To(MatchError(ErrInvalidReqNoImps))
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}, Site: &Site{}, App: &App{}}).Validate()).To(MatchError(ErrInvalidReqMultiInv))
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}, App: &App{}, DOOH: &DOOH{}}).Validate()).To(MatchError(ErrInvalidReqMultiInv))
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}, Site: &Site{}, DOOH: &DOOH{}}).Validate()).To(MatchError(ErrInvalidReqMultiInv))
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}}).Validate()).To(MatchError(ErrInvalidImpNoAssets))
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}, Cur: []string{"USD", "XYZ"}}).Validate()).To(MatchError(ErrInvalidReqCur))
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}, Cur: []string{""}}).Validate()).To(MatchError(ErrInvalidReqCur))
 
 		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}}).Validate()).NotTo(HaveOccurred())
 		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}, Site: &Site{}}).Validate()).NotTo(HaveOccurred())
 		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}, App: &App{}}).Validate()).NotTo(HaveOccurred())
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}, DOOH: &DOOH{}}).Validate()).NotTo(HaveOccurred())
+		Expect((&BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}, Cur: []string{"eur", "USD"}}).Validate()).NotTo(HaveOccurred())
 		Expect(subject.Validate()).NotTo(HaveOccurred())
 	})
 
+	It("should validate geo locations", func() {
+		req := &BidRequest{
+			ID:     "A",
+			Imp:    []Impression{{ID: "1", Banner: &Banner{}}},
+			Device: &Device{Geo: &Geo{Lat: 91}},
+			User:   &User{Geo: &Geo{Country: "XX"}},
+		}
+		Expect(req.Validate()).To(MatchError(ErrInvalidGeoLat))
+
+		err := req.ValidateAll()
+		Expect(err).To(BeAssignableToTypeOf(ValidationErrors{}))
+		errs := err.(ValidationErrors)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Path).To(Equal("device.geo.lat"))
+		Expect(errs[0].Code).To(Equal("geo_invalid_lat"))
+		Expect(errs[1].Path).To(Equal("user.geo.country"))
+		Expect(errs[1].Code).To(Equal("geo_invalid_country"))
+	})
+
+	It("should have accessors", func() {
+		Expect(subject.ImpByID("1")).To(BeIdenticalTo(&subject.Imp[0]))
+		Expect(subject.ImpByID("2")).To(BeNil())
+		Expect(subject.PublisherID()).To(Equal("pub12345"))
+		Expect((&BidRequest{DOOH: &DOOH{Publisher: &Publisher{ID: "P"}}}).PublisherID()).To(Equal("P"))
+		Expect((&BidRequest{}).PublisherID()).To(BeEmpty())
+	})
+
+	It("should detect test traffic", func() {
+		Expect((&BidRequest{}).IsTest()).To(BeFalse())
+		Expect((&BidRequest{Test: 1}).IsTest()).To(BeTrue())
+	})
+
 })
@@ -6,6 +6,7 @@ import "errors"
 var (
 	ErrInvalidRespNoID       = errors.New("openrtb: response missing ID")
 	ErrInvalidRespNoSeatBids = errors.New("openrtb: response missing seatbids")
+	ErrInvalidRespCur        = errors.New("openrtb: response has invalid currency") // not an ISO-4217 code
 )
 
 // ID and at least one "seatbid” object is required, which contains a bid on at least one impression.
@@ -22,19 +23,90 @@ type BidResponse struct {
 	Ext        Extension `json:"ext,omitempty"`        // Custom specifications in JSon
 }
 
-// Validate required attributes
+// Validate required attributes. Errors are returned as *ValidationError.
 func (res *BidResponse) Validate() error {
-	if res.ID == "" {
-		return ErrInvalidRespNoID
-	} else if len(res.SeatBid) == 0 {
-		return ErrInvalidRespNoSeatBids
+	v := new(validator)
+	res.validate(v)
+	return v.err()
+}
+
+// ValidateAll validates the response and returns all violations as
+// ValidationErrors, rather than just the first.
+func (res *BidResponse) ValidateAll() error {
+	v := &validator{all: true}
+	res.validate(v)
+	return v.err()
+}
+
+func (res *BidResponse) validate(v *validator) bool {
+	if res.ID == "" && !v.add("", ErrInvalidRespNoID) {
+		return false
+	}
+	if len(res.SeatBid) == 0 && !v.add("", ErrInvalidRespNoSeatBids) {
+		return false
+	}
+	if !validCurrency(res.Currency) && !v.add("", ErrInvalidRespCur) {
+		return false
+	}
+
+	for i := range res.SeatBid {
+		if !res.SeatBid[i].validate(v, indexPath("seatbid", i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateForRequest validates the response and cross-checks all seats
+// against the wseat/bseat lists and all bids against the impressions of the
+// original request.
+func (res *BidResponse) ValidateForRequest(req *BidRequest) error {
+	v := new(validator)
+	res.validateForRequest(v, req)
+	return v.err()
+}
+
+// ValidateAllForRequest is like ValidateForRequest, but returns all
+// violations as ValidationErrors, rather than just the first.
+func (res *BidResponse) ValidateAllForRequest(req *BidRequest) error {
+	v := &validator{all: true}
+	res.validateForRequest(v, req)
+	return v.err()
+}
+
+func (res *BidResponse) validateForRequest(v *validator, req *BidRequest) bool {
+	if !res.validate(v) {
+		return false
 	}
 
-	for _, sb := range res.SeatBid {
-		if err := sb.Validate(); err != nil {
-			return err
+	for i, sb := range res.SeatBid {
+		if req.CheckSeat(sb.Seat) != nil {
+			if !v.add(indexPath("seatbid", i), ErrInvalidSeatBidSeat) {
+				return false
+			}
+			continue
+		}
+
+		for j := range sb.Bid {
+			bid := &sb.Bid[j]
+			path := indexPath(indexPath("seatbid", i)+".bid", j)
+			if bid.ImpID == "" {
+				continue // reported by Validate
+			}
+
+			imp := req.ImpByID(bid.ImpID)
+			if imp == nil {
+				if !v.add(path, ErrInvalidBidImpID) {
+					return false
+				}
+				continue
+			}
+			if !v.add(path, bid.ValidateForImp(imp)) {
+				return false
+			}
 		}
 	}
 
-	return nil
+	return true
 }
@@ -49,9 +49,31 @@ var _ = Describe("BidResponse", func() {
 	})
 
 	It("should validate", func() {
-		Expect((&BidResponse{}).Validate()).To(Equal(ErrInvalidRespNoID))
-		Expect((&BidResponse{ID: "RESPID"}).Validate()).To(Equal(ErrInvalidRespNoSeatBids))
+		Expect((&BidResponse{}).Validate()).To(MatchError(ErrInvalidRespNoID))
+		Expect((&BidResponse{ID: "RESPID"}).Validate()).To(MatchError(ErrInvalidRespNoSeatBids))
+		Expect((&BidResponse{ID: "RESPID", SeatBid: []SeatBid{{Bid: []Bid{{ID: "A", ImpID: "1"}}}}, Currency: "BTC"}).Validate()).To(MatchError(ErrInvalidRespCur))
 		Expect(subject.Validate()).NotTo(HaveOccurred())
 	})
 
+	It("should validate against requests", func() {
+		req := &BidRequest{ID: "REQID", Imp: []Impression{
+			{ID: "1", Banner: &Banner{}},
+			{ID: "2", Video: &Video{}},
+		}}
+		res := &BidResponse{ID: "RESPID", SeatBid: []SeatBid{{Bid: []Bid{
+			{ID: "A", ImpID: "1", MType: MarkupTypeBanner, AdMarkup: "<div/>"},
+			{ID: "B", ImpID: "2", MType: MarkupTypeVideo, AdMarkup: `<?xml version="1.0"?><VAST version="4.0"/>`},
+			{ID: "C", ImpID: "2"},
+		}}}}
+		Expect(res.ValidateForRequest(req)).To(Succeed())
+
+		res.SeatBid[0].Bid[2].ImpID = "3"
+		Expect(res.ValidateForRequest(req)).To(MatchError(ErrInvalidBidImpID))
+
+		res.SeatBid[0].Bid[2] = Bid{ID: "C", ImpID: "2", MType: MarkupTypeNative}
+		Expect(res.ValidateForRequest(req)).To(MatchError(ErrInvalidBidMType))
+
+		Expect((&BidResponse{}).ValidateForRequest(req)).To(MatchError(ErrInvalidRespNoID))
+	})
+
 })
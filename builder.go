@@ -0,0 +1,234 @@
+package openrtb
+
+import "strconv"
+
+// RequestBuilder assembles bid requests, filling required IDs and sane
+// defaults, e.g. for tests and synthetic traffic.
+type RequestBuilder struct {
+	req BidRequest
+}
+
+// NewBidRequest starts a new request with a random ID and a second price
+// auction type.
+func NewBidRequest() *RequestBuilder {
+	return &RequestBuilder{req: BidRequest{
+		ID:          NewTransactionID(),
+		AuctionType: DefaultAuctionType,
+	}}
+}
+
+// WithID sets the request ID.
+func (b *RequestBuilder) WithID(id string) *RequestBuilder {
+	b.req.ID = id
+	return b
+}
+
+// WithImp appends impressions. Impressions without IDs are assigned
+// sequential IDs on Build.
+func (b *RequestBuilder) WithImp(imps ...Impression) *RequestBuilder {
+	b.req.Imp = append(b.req.Imp, imps...)
+	return b
+}
+
+// WithBanner appends a banner impression of the given size.
+func (b *RequestBuilder) WithBanner(w, h int) *RequestBuilder {
+	return b.WithImp(Impression{Banner: &Banner{W: w, H: h}})
+}
+
+// WithSite sets the site, replacing any app or DOOH.
+func (b *RequestBuilder) WithSite(site *Site) *RequestBuilder {
+	b.req.Site, b.req.App, b.req.DOOH = site, nil, nil
+	return b
+}
+
+// WithApp sets the app, replacing any site or DOOH.
+func (b *RequestBuilder) WithApp(app *App) *RequestBuilder {
+	b.req.Site, b.req.App, b.req.DOOH = nil, app, nil
+	return b
+}
+
+// WithDOOH sets the DOOH inventory, replacing any site or app.
+func (b *RequestBuilder) WithDOOH(dooh *DOOH) *RequestBuilder {
+	b.req.Site, b.req.App, b.req.DOOH = nil, nil, dooh
+	return b
+}
+
+// WithDevice sets the device.
+func (b *RequestBuilder) WithDevice(device *Device) *RequestBuilder {
+	b.req.Device = device
+	return b
+}
+
+// WithUser sets the user.
+func (b *RequestBuilder) WithUser(user *User) *RequestBuilder {
+	b.req.User = user
+	return b
+}
+
+// WithRegs sets the regulations.
+func (b *RequestBuilder) WithRegs(regs *Regulations) *RequestBuilder {
+	b.req.Regs = regs
+	return b
+}
+
+// WithSource sets the source. A transaction ID is generated on Build if
+// missing.
+func (b *RequestBuilder) WithSource(src *Source) *RequestBuilder {
+	b.req.Source = src
+	return b
+}
+
+// WithTMax sets the maximum time to bid, in milliseconds.
+func (b *RequestBuilder) WithTMax(ms int) *RequestBuilder {
+	b.req.TMax = ms
+	return b
+}
+
+// WithAuctionType sets the auction type.
+func (b *RequestBuilder) WithAuctionType(at int) *RequestBuilder {
+	b.req.AuctionType = at
+	return b
+}
+
+// WithCurrency sets the allowed currencies.
+func (b *RequestBuilder) WithCurrency(cur ...string) *RequestBuilder {
+	b.req.Cur = cur
+	return b
+}
+
+// WithTest marks the request as a test.
+func (b *RequestBuilder) WithTest() *RequestBuilder {
+	b.req.Test = 1
+	return b
+}
+
+// WithExt sets the request extension.
+func (b *RequestBuilder) WithExt(ext Extension) *RequestBuilder {
+	b.req.Ext = ext
+	return b
+}
+
+// Build fills missing impression and transaction IDs, validates and returns
+// the request. The builder must not be reused afterwards.
+func (b *RequestBuilder) Build() (*BidRequest, error) {
+	req := &b.req
+	for i := range req.Imp {
+		if req.Imp[i].ID == "" {
+			req.Imp[i].ID = strconv.Itoa(i + 1)
+		}
+	}
+	req.EnsureTransactionID()
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// MustBuild is like Build but panics on validation errors.
+func (b *RequestBuilder) MustBuild() *BidRequest {
+	req, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
+
+// ResponseBuilder assembles bid responses.
+type ResponseBuilder struct {
+	req *BidRequest
+	res BidResponse
+}
+
+// NewBidResponse starts a new response to req. The response ID reflects the
+// request ID and the currency defaults to the first one allowed by the request.
+// If req is nil, the response must be given an ID using WithID.
+func NewBidResponse(req *BidRequest) *ResponseBuilder {
+	b := &ResponseBuilder{req: req}
+	if req != nil {
+		b.res.ID = req.ID
+		if len(req.Cur) != 0 {
+			b.res.Currency = NormalizeCurrency(req.Cur[0])
+		}
+	}
+	return b
+}
+
+// WithID sets the response ID.
+func (b *ResponseBuilder) WithID(id string) *ResponseBuilder {
+	b.res.ID = id
+	return b
+}
+
+// WithBidID sets the response tracking ID.
+func (b *ResponseBuilder) WithBidID(id string) *ResponseBuilder {
+	b.res.BidID = id
+	return b
+}
+
+// WithCurrency sets the bid currency.
+func (b *ResponseBuilder) WithCurrency(cur string) *ResponseBuilder {
+	b.res.Currency = cur
+	return b
+}
+
+// WithBid appends bids on behalf of a seat. Bids without IDs are assigned
+// sequential IDs on Build. If the request has exactly one impression, bids
+// without an impression ID are assigned to it.
+func (b *ResponseBuilder) WithBid(seat string, bids ...Bid) *ResponseBuilder {
+	for i := range b.res.SeatBid {
+		if sb := &b.res.SeatBid[i]; sb.Seat == seat {
+			sb.Bid = append(sb.Bid, bids...)
+			return b
+		}
+	}
+	b.res.SeatBid = append(b.res.SeatBid, SeatBid{Seat: seat, Bid: bids})
+	return b
+}
+
+// WithExt sets the response extension.
+func (b *ResponseBuilder) WithExt(ext Extension) *ResponseBuilder {
+	b.res.Ext = ext
+	return b
+}
+
+// Build fills missing bid IDs, validates and returns the response. If the
+// builder was started with a request, bids are cross-checked against its
+// impressions. The builder must not be reused afterwards.
+func (b *ResponseBuilder) Build() (*BidResponse, error) {
+	res := &b.res
+
+	n := 0
+	for i := range res.SeatBid {
+		for j := range res.SeatBid[i].Bid {
+			n++
+			bid := &res.SeatBid[i].Bid[j]
+			if bid.ID == "" {
+				bid.ID = strconv.Itoa(n)
+			}
+			if bid.ImpID == "" && b.req != nil && len(b.req.Imp) == 1 {
+				bid.ImpID = b.req.Imp[0].ID
+			}
+		}
+	}
+
+	var err error
+	if b.req != nil {
+		err = res.ValidateForRequest(b.req)
+	} else {
+		err = res.Validate()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MustBuild is like Build but panics on validation errors.
+func (b *ResponseBuilder) MustBuild() *BidResponse {
+	res, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,91 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RequestBuilder", func() {
+
+	It("should build requests", func() {
+		req, err := NewBidRequest().
+			WithBanner(300, 250).
+			WithImp(Impression{ID: "x", Audio: &Audio{Mimes: []string{"audio/mp4"}}}).
+			WithSite(&Site{Inventory: Inventory{ID: "site"}}).
+			WithTMax(120).
+			WithCurrency("EUR").
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(HaveLen(36))
+		Expect(req.AuctionType).To(Equal(2))
+		Expect(req.TMax).To(Equal(120))
+		Expect(req.Cur).To(Equal([]string{"EUR"}))
+		Expect(req.Imp).To(HaveLen(2))
+		Expect(req.Imp[0].ID).To(Equal("1"))
+		Expect(req.Imp[0].Banner).To(Equal(&Banner{W: 300, H: 250}))
+		Expect(req.Imp[1].ID).To(Equal("x"))
+		Expect(req.Site.ID).To(Equal("site"))
+		Expect(req.TransactionID()).To(HaveLen(36))
+	})
+
+	It("should keep a single inventory source", func() {
+		req := NewBidRequest().WithBanner(1, 1).WithSite(&Site{}).WithApp(&App{}).MustBuild()
+		Expect(req.Site).To(BeNil())
+		Expect(req.App).NotTo(BeNil())
+	})
+
+	It("should validate", func() {
+		_, err := NewBidRequest().Build()
+		Expect(err).To(MatchError(ErrInvalidReqNoImps))
+		_, err = NewBidRequest().WithBanner(1, 1).WithCurrency("EURO").Build()
+		Expect(err).To(MatchError(ErrInvalidReqCur))
+		Expect(func() { NewBidRequest().WithID("").MustBuild() }).To(Panic())
+	})
+
+})
+
+var _ = Describe("ResponseBuilder", func() {
+	var req *BidRequest
+
+	BeforeEach(func() {
+		req = NewBidRequest().WithID("REQ").WithBanner(300, 250).WithCurrency("eur").MustBuild()
+	})
+
+	It("should build responses", func() {
+		res, err := NewBidResponse(req).
+			WithBid("a", Bid{Price: 1.2}, Bid{Price: 1.1}).
+			WithBid("b", Bid{ID: "x", ImpID: "1", Price: 0.9}).
+			WithBid("a", Bid{Price: 1.0}).
+			WithBidID("BID").
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(&BidResponse{
+			ID:       "REQ",
+			BidID:    "BID",
+			Currency: "EUR",
+			SeatBid: []SeatBid{
+				{Seat: "a", Bid: []Bid{
+					{ID: "1", ImpID: "1", Price: 1.2},
+					{ID: "2", ImpID: "1", Price: 1.1},
+					{ID: "3", ImpID: "1", Price: 1.0},
+				}},
+				{Seat: "b", Bid: []Bid{
+					{ID: "x", ImpID: "1", Price: 0.9},
+				}},
+			},
+		}))
+	})
+
+	It("should validate", func() {
+		_, err := NewBidResponse(req).Build()
+		Expect(err).To(MatchError(ErrInvalidRespNoSeatBids))
+		_, err = NewBidResponse(req).WithBid("a", Bid{ImpID: "2"}).Build()
+		Expect(err).To(MatchError(ErrInvalidBidImpID))
+		_, err = NewBidResponse(nil).WithBid("a", Bid{}).Build()
+		Expect(err).To(MatchError(ErrInvalidRespNoID))
+		_, err = NewBidResponse(nil).WithID("R").WithBid("a", Bid{}).Build()
+		Expect(err).To(MatchError(ErrInvalidBidNoImpID))
+		Expect(NewBidResponse(nil).WithID("R").WithBid("a", Bid{ImpID: "1"}).MustBuild().SeatBid).To(HaveLen(1))
+	})
+
+})
@@ -0,0 +1,173 @@
+package openrtb
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// App bundle platforms
+const (
+	BundlePlatformIOS     = "ios"
+	BundlePlatformAndroid = "android"
+)
+
+// Validation errors
+var (
+	ErrInvalidAppBundle         = errors.New("openrtb: app bundle is neither an iOS app ID nor an Android package name")
+	ErrInvalidAppStoreURL       = errors.New("openrtb: app storeurl is not an App Store or Play Store URL")
+	ErrAppStoreURLMismatch      = errors.New("openrtb: app storeurl does not match bundle")
+	ErrInvalidBidBundle         = errors.New("openrtb: bid bundle is neither an iOS app ID nor an Android package name")
+	ErrInvalidBidBundlePlatform = errors.New("openrtb: bid bundle platform does not match app")
+)
+
+// NormalizeBundle trims whitespace and strips the "id" prefix of iOS app
+// IDs, e.g. " id628677149" becomes "628677149".
+func NormalizeBundle(bundle string) string {
+	bundle = strings.TrimSpace(bundle)
+	if len(bundle) > 2 && (bundle[:2] == "id" || bundle[:2] == "ID") && isDigits(bundle[2:]) {
+		return bundle[2:]
+	}
+	return bundle
+}
+
+// BundlePlatform returns the platform of a bundle: BundlePlatformIOS for
+// numeric App Store IDs, BundlePlatformAndroid for Java-style package
+// names, e.g. "com.example.app", or an empty string otherwise.
+func BundlePlatform(bundle string) string {
+	bundle = NormalizeBundle(bundle)
+	if isDigits(bundle) {
+		return BundlePlatformIOS
+	}
+	if isPackageName(bundle) {
+		return BundlePlatformAndroid
+	}
+	return ""
+}
+
+// ParseStoreURL extracts the platform and bundle from an App Store URL,
+// e.g. "https://apps.apple.com/us/app/example/id628677149", or a Play Store
+// URL, e.g. "https://play.google.com/store/apps/details?id=com.example.app".
+// It returns empty strings for other URLs.
+func ParseStoreURL(storeURL string) (platform, bundle string) {
+	u, err := url.Parse(strings.TrimSpace(storeURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", ""
+	}
+
+	switch strings.ToLower(u.Host) {
+	case "apps.apple.com", "itunes.apple.com":
+		segments := strings.Split(u.Path, "/")
+		last := segments[len(segments)-1]
+		if id := strings.TrimPrefix(last, "id"); id != last && isDigits(id) {
+			return BundlePlatformIOS, id
+		}
+	case "play.google.com":
+		if strings.TrimSuffix(u.Path, "/") == "/store/apps/details" {
+			if id := u.Query().Get("id"); isPackageName(id) {
+				return BundlePlatformAndroid, id
+			}
+		}
+	}
+	return "", ""
+}
+
+// Normalize normalizes the bundle of the app, see NormalizeBundle.
+func (a *App) Normalize() {
+	a.Bundle = NormalizeBundle(a.Bundle)
+}
+
+// Platform returns the platform of the app, derived from its bundle or,
+// failing that, its store URL.
+func (a *App) Platform() string {
+	if platform := BundlePlatform(a.Bundle); platform != "" {
+		return platform
+	}
+	platform, _ := ParseStoreURL(a.StoreURL)
+	return platform
+}
+
+// ValidateBundle checks that the bundle of the app is an iOS app ID or an
+// Android package name and that the store URL, if present, is a valid App
+// Store or Play Store URL that references the same app.
+func (a *App) ValidateBundle() error {
+	if a.Bundle == "" {
+		return ErrAppStoreNoBundle
+	}
+
+	bundle := NormalizeBundle(a.Bundle)
+	platform := BundlePlatform(bundle)
+	if platform == "" {
+		return ErrInvalidAppBundle
+	}
+	if a.StoreURL == "" {
+		return nil
+	}
+
+	storePlatform, storeBundle := ParseStoreURL(a.StoreURL)
+	if storePlatform == "" {
+		return ErrInvalidAppStoreURL
+	}
+	if storePlatform != platform || storeBundle != bundle {
+		return ErrAppStoreURLMismatch
+	}
+	return nil
+}
+
+// ValidateBidBundle cross-checks the bundle of the advertised app of a bid
+// against the app of the request. Bids without a bundle pass. The bundle
+// must be an iOS app ID or an Android package name and, if the platform of
+// the request app is known, it must target the same platform.
+func (req *BidRequest) ValidateBidBundle(bid *Bid) error {
+	if bid.Bundle == "" {
+		return nil
+	}
+
+	platform := BundlePlatform(bid.Bundle)
+	if platform == "" {
+		return ErrInvalidBidBundle
+	}
+	if req.App != nil {
+		if appPlatform := req.App.Platform(); appPlatform != "" && appPlatform != platform {
+			return ErrInvalidBidBundlePlatform
+		}
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isPackageName returns true for Java-style package names, i.e. at least
+// two dot-separated segments of letters, digits and underscores, each
+// starting with a letter.
+func isPackageName(s string) bool {
+	segments := strings.Split(s, ".")
+	if len(segments) < 2 {
+		return false
+	}
+	for _, seg := range segments {
+		if seg == "" || !isLetter(seg[0]) {
+			return false
+		}
+		for i := 1; i < len(seg); i++ {
+			if c := seg[i]; !isLetter(c) && (c < '0' || c > '9') && c != '_' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
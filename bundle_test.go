@@ -0,0 +1,118 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NormalizeBundle", func() {
+
+	It("should normalize", func() {
+		Expect(NormalizeBundle(" id628677149 ")).To(Equal("628677149"))
+		Expect(NormalizeBundle("628677149")).To(Equal("628677149"))
+		Expect(NormalizeBundle("com.example.app")).To(Equal("com.example.app"))
+		Expect(NormalizeBundle("identity.app")).To(Equal("identity.app"))
+	})
+
+	It("should detect platforms", func() {
+		Expect(BundlePlatform("628677149")).To(Equal(BundlePlatformIOS))
+		Expect(BundlePlatform("id628677149")).To(Equal(BundlePlatformIOS))
+		Expect(BundlePlatform("com.example.app_2")).To(Equal(BundlePlatformAndroid))
+		Expect(BundlePlatform("example")).To(BeEmpty())
+		Expect(BundlePlatform("com..app")).To(BeEmpty())
+		Expect(BundlePlatform("com.1example")).To(BeEmpty())
+		Expect(BundlePlatform("")).To(BeEmpty())
+	})
+
+})
+
+var _ = Describe("ParseStoreURL", func() {
+
+	It("should parse", func() {
+		platform, bundle := ParseStoreURL("https://itunes.apple.com/id628677149")
+		Expect(platform).To(Equal(BundlePlatformIOS))
+		Expect(bundle).To(Equal("628677149"))
+
+		platform, bundle = ParseStoreURL("https://apps.apple.com/us/app/yahoo-weather/id628677149")
+		Expect(platform).To(Equal(BundlePlatformIOS))
+		Expect(bundle).To(Equal("628677149"))
+
+		platform, bundle = ParseStoreURL("https://play.google.com/store/apps/details?id=com.example.app&hl=en")
+		Expect(platform).To(Equal(BundlePlatformAndroid))
+		Expect(bundle).To(Equal("com.example.app"))
+	})
+
+	It("should reject other URLs", func() {
+		for _, u := range []string{
+			"",
+			"ftp://apps.apple.com/id628677149",
+			"https://apps.apple.com/us/app/yahoo-weather",
+			"https://play.google.com/store/apps/details",
+			"https://play.google.com/store/apps/developer?id=com.example",
+			"https://example.com/id628677149",
+		} {
+			platform, bundle := ParseStoreURL(u)
+			Expect(platform).To(BeEmpty(), "for %q", u)
+			Expect(bundle).To(BeEmpty(), "for %q", u)
+		}
+	})
+
+})
+
+var _ = Describe("App", func() {
+	var subject *App
+
+	BeforeEach(func() {
+		err := fixture("app", &subject)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should validate bundles", func() {
+		Expect(subject.ValidateBundle()).To(Succeed())
+		Expect(subject.Platform()).To(Equal(BundlePlatformIOS))
+
+		subject.StoreURL = "https://apps.apple.com/us/app/other/id1"
+		Expect(subject.ValidateBundle()).To(Equal(ErrAppStoreURLMismatch))
+		subject.StoreURL = "https://example.com/app"
+		Expect(subject.ValidateBundle()).To(Equal(ErrInvalidAppStoreURL))
+
+		subject.Bundle, subject.StoreURL = "com.example.app", "https://play.google.com/store/apps/details?id=com.example.app"
+		Expect(subject.ValidateBundle()).To(Succeed())
+		subject.StoreURL = ""
+		Expect(subject.ValidateBundle()).To(Succeed())
+
+		subject.Bundle = "not a bundle"
+		Expect(subject.ValidateBundle()).To(Equal(ErrInvalidAppBundle))
+		subject.Bundle = ""
+		Expect(subject.ValidateBundle()).To(Equal(ErrAppStoreNoBundle))
+	})
+
+	It("should normalize", func() {
+		subject.Bundle = "id628677149"
+		subject.Normalize()
+		Expect(subject.Bundle).To(Equal("628677149"))
+	})
+
+	It("should derive platforms from store URLs", func() {
+		subject.Bundle = ""
+		Expect(subject.Platform()).To(Equal(BundlePlatformIOS))
+		subject.StoreURL = ""
+		Expect(subject.Platform()).To(BeEmpty())
+	})
+
+})
+
+var _ = Describe("BidRequest", func() {
+
+	It("should validate bid bundles", func() {
+		req := &BidRequest{App: &App{Bundle: "628677149"}}
+		Expect(req.ValidateBidBundle(&Bid{})).To(Succeed())
+		Expect(req.ValidateBidBundle(&Bid{Bundle: "id284882215"})).To(Succeed())
+		Expect(req.ValidateBidBundle(&Bid{Bundle: "com.example.game"})).To(Equal(ErrInvalidBidBundlePlatform))
+		Expect(req.ValidateBidBundle(&Bid{Bundle: "game"})).To(Equal(ErrInvalidBidBundle))
+
+		req = &BidRequest{Site: &Site{}}
+		Expect(req.ValidateBidBundle(&Bid{Bundle: "com.example.game"})).To(Succeed())
+	})
+
+})
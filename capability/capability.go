@@ -0,0 +1,202 @@
+/*
+Package capability provides optional HTTP handlers which expose the supported
+OpenRTB versions, media types and the current health of a bidder in a
+standard JSON shape, so exchanges can auto-discover capabilities.
+
+	mon := capability.NewMonitor()
+	mux.Handle("/openrtb/capabilities", capability.Handler(capability.Capabilities{
+		Versions:   []string{"2.5", "2.6"},
+		MediaTypes: []string{openrtb.MediaTypeBanner, openrtb.MediaTypeVideo},
+	}))
+	mux.Handle("/openrtb/health", mon.Handler())
+
+The health handler supports long-polling: clients pass the last seen
+version and a maximum wait duration, e.g. "?version=3&wait=30s", and the
+request blocks until the health status changes or the wait expires.
+*/
+package capability
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// Health statuses
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// MaxWait is the maximum long-poll duration accepted by the health handler.
+var MaxWait = time.Minute
+
+// Capabilities describes what a bidder supports.
+type Capabilities struct {
+	Versions   []string          `json:"versions"`             // Supported OpenRTB versions, e.g. "2.5"
+	MediaTypes []string          `json:"mediatypes"`           // Supported media types, see openrtb.MediaType* constants
+	Currencies []string          `json:"currencies,omitempty"` // Supported bid currencies
+	Ext        openrtb.Extension `json:"ext,omitempty"`
+}
+
+// Handler serves the capabilities as JSON.
+func Handler(caps Capabilities) http.Handler {
+	data, err := json.Marshal(caps)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+// Health is a health snapshot.
+type Health struct {
+	Version          uint64  `json:"version"`            // Incremented on every status change
+	Status           string  `json:"status"`             // See Status* constants
+	QueueDepth       int64   `json:"queuedepth"`         // Number of requests currently in flight
+	DecodeLatencyP99 float64 `json:"decodelatencyp99ms"` // 99th percentile of recent decode latencies, in milliseconds
+}
+
+// Monitor tracks the health of a bidder. The zero value is not usable, use
+// NewMonitor to create a monitor.
+type Monitor struct {
+	mu      sync.Mutex
+	version uint64
+	status  string
+	queue   int64
+	samples []time.Duration
+	next    int
+	changed chan struct{}
+}
+
+// numSamples is the number of recent decode latencies used for percentiles.
+const numSamples = 1024
+
+// NewMonitor creates a new monitor with StatusOK.
+func NewMonitor() *Monitor {
+	return &Monitor{
+		status:  StatusOK,
+		samples: make([]time.Duration, 0, numSamples),
+		changed: make(chan struct{}),
+	}
+}
+
+// SetStatus updates the status and notifies long-polling clients.
+func (m *Monitor) SetStatus(status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.status == status {
+		return
+	}
+	m.status = status
+	m.version++
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// Enqueue increments the queue depth, call Dequeue when done.
+func (m *Monitor) Enqueue() {
+	m.mu.Lock()
+	m.queue++
+	m.mu.Unlock()
+}
+
+// Dequeue decrements the queue depth.
+func (m *Monitor) Dequeue() {
+	m.mu.Lock()
+	m.queue--
+	m.mu.Unlock()
+}
+
+// ObserveDecode records the latency of a single request decode.
+func (m *Monitor) ObserveDecode(d time.Duration) {
+	m.mu.Lock()
+	if len(m.samples) < numSamples {
+		m.samples = append(m.samples, d)
+	} else {
+		m.samples[m.next] = d
+	}
+	m.next = (m.next + 1) % numSamples
+	m.mu.Unlock()
+}
+
+// Health returns the current health snapshot.
+func (m *Monitor) Health() Health {
+	health, _ := m.snapshot()
+	return health
+}
+
+func (m *Monitor) snapshot() (Health, <-chan struct{}) {
+	m.mu.Lock()
+	health := Health{
+		Version:    m.version,
+		Status:     m.status,
+		QueueDepth: m.queue,
+	}
+	samples := append([]time.Duration(nil), m.samples...)
+	changed := m.changed
+	m.mu.Unlock()
+
+	if len(samples) != 0 {
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		p99 := samples[(len(samples)*99-1)/100]
+		health.DecodeLatencyP99 = float64(p99) / float64(time.Millisecond)
+	}
+	return health, changed
+}
+
+// Handler serves the current health as JSON. Responses with a status other
+// than StatusOK are served with 503 Service Unavailable.
+func (m *Monitor) Handler() http.Handler {
+	return http.HandlerFunc(m.serveHTTP)
+}
+
+func (m *Monitor) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	health, changed := m.snapshot()
+
+	if s := r.URL.Query().Get("wait"); s != "" {
+		wait, err := time.ParseDuration(s)
+		if err != nil {
+			http.Error(w, "invalid wait duration", http.StatusBadRequest)
+			return
+		}
+		if wait > MaxWait {
+			wait = MaxWait
+		}
+
+		var since uint64
+		if s := r.URL.Query().Get("version"); s != "" {
+			if since, err = strconv.ParseUint(s, 10, 64); err != nil {
+				http.Error(w, "invalid version", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if health.Version == since {
+			timer := time.NewTimer(wait)
+			select {
+			case <-changed:
+			case <-timer.C:
+			case <-r.Context().Done():
+			}
+			timer.Stop()
+			health, _ = m.snapshot()
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if health.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(health)
+}
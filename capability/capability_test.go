@@ -0,0 +1,101 @@
+package capability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Handler", func() {
+	It("should serve capabilities", func() {
+		w := httptest.NewRecorder()
+		Handler(Capabilities{
+			Versions:   []string{"2.5", "2.6"},
+			MediaTypes: []string{openrtb.MediaTypeBanner, openrtb.MediaTypeVideo},
+		}).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(w.Body.String()).To(MatchJSON(`{"versions":["2.5","2.6"],"mediatypes":["banner","video"]}`))
+	})
+})
+
+var _ = Describe("Monitor", func() {
+	var subject *Monitor
+
+	BeforeEach(func() {
+		subject = NewMonitor()
+	})
+
+	It("should track health", func() {
+		Expect(subject.Health()).To(Equal(Health{Status: StatusOK}))
+
+		subject.Enqueue()
+		subject.Enqueue()
+		subject.Dequeue()
+		for i := 1; i <= 100; i++ {
+			subject.ObserveDecode(time.Duration(i) * time.Millisecond)
+		}
+		subject.SetStatus(StatusDegraded)
+		subject.SetStatus(StatusDegraded)
+		Expect(subject.Health()).To(Equal(Health{Version: 1, Status: StatusDegraded, QueueDepth: 1, DecodeLatencyP99: 99}))
+	})
+
+	It("should limit latency samples", func() {
+		for i := 0; i < numSamples; i++ {
+			subject.ObserveDecode(time.Second)
+		}
+		for i := 0; i < numSamples; i++ {
+			subject.ObserveDecode(time.Millisecond)
+		}
+		Expect(subject.Health().DecodeLatencyP99).To(Equal(1.0))
+	})
+
+	It("should serve health", func() {
+		w := httptest.NewRecorder()
+		subject.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(MatchJSON(`{"version":0,"status":"ok","queuedepth":0,"decodelatencyp99ms":0}`))
+
+		subject.SetStatus(StatusDown)
+		w = httptest.NewRecorder()
+		subject.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+
+		w = httptest.NewRecorder()
+		subject.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/?wait=bad", nil))
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should support long-polling", func() {
+		w := httptest.NewRecorder()
+		subject.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/?wait=10ms&version=0", nil))
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(10 * time.Millisecond)
+			subject.SetStatus(StatusDegraded)
+		}()
+
+		start := time.Now()
+		w = httptest.NewRecorder()
+		subject.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/?wait=5s&version=0", nil))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(w.Body.String()).To(MatchJSON(`{"version":1,"status":"degraded","queuedepth":0,"decodelatencyp99ms":0}`))
+
+		w = httptest.NewRecorder()
+		subject.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/?wait=5s&version=0", nil))
+		Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/capability")
+}
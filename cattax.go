@@ -0,0 +1,37 @@
+package openrtb
+
+// EffectiveCatTax returns the taxonomy in use for a list of categories
+// declared with cattax. A cattax of 0 defaults to CatTaxIABContent10.
+func EffectiveCatTax(cattax int) int {
+	if cattax == 0 {
+		return CatTaxIABContent10
+	}
+	return cattax
+}
+
+// SameCatTax returns true if two lists of categories, declared with
+// cattax a and b, use the same taxonomy and can be compared.
+func SameCatTax(a, b int) bool {
+	return EffectiveCatTax(a) == EffectiveCatTax(b)
+}
+
+// EffectiveCatTax returns the taxonomy in use for bcat.
+func (req *BidRequest) EffectiveCatTax() int {
+	return EffectiveCatTax(req.CatTax)
+}
+
+// EffectiveCatTax returns the taxonomy in use for cat, sectioncat and
+// pagecat.
+func (a *Inventory) EffectiveCatTax() int {
+	return EffectiveCatTax(a.CatTax)
+}
+
+// EffectiveCatTax returns the taxonomy in use for cat.
+func (c *Content) EffectiveCatTax() int {
+	return EffectiveCatTax(c.CatTax)
+}
+
+// EffectiveCatTax returns the taxonomy in use for cat.
+func (bid *Bid) EffectiveCatTax() int {
+	return EffectiveCatTax(bid.CatTax)
+}
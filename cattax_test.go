@@ -0,0 +1,27 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EffectiveCatTax", func() {
+
+	It("should default to IAB content 1.0", func() {
+		Expect(EffectiveCatTax(0)).To(Equal(CatTaxIABContent10))
+		Expect(EffectiveCatTax(CatTaxIABContent30)).To(Equal(CatTaxIABContent30))
+
+		Expect((&BidRequest{}).EffectiveCatTax()).To(Equal(CatTaxIABContent10))
+		Expect((&Site{Inventory: Inventory{CatTax: CatTaxIABContent22}}).EffectiveCatTax()).To(Equal(CatTaxIABContent22))
+		Expect((&App{}).EffectiveCatTax()).To(Equal(CatTaxIABContent10))
+		Expect((&Content{CatTax: CatTaxIABContent30}).EffectiveCatTax()).To(Equal(CatTaxIABContent30))
+		Expect((&Bid{}).EffectiveCatTax()).To(Equal(CatTaxIABContent10))
+	})
+
+	It("should compare taxonomies", func() {
+		Expect(SameCatTax(0, CatTaxIABContent10)).To(BeTrue())
+		Expect(SameCatTax(CatTaxIABContent30, CatTaxIABContent30)).To(BeTrue())
+		Expect(SameCatTax(0, CatTaxIABContent30)).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,66 @@
+package openrtb
+
+// CookieDeprecation returns the Chrome cookie deprecation label from
+// device.ext.cdep, as assigned to browsers participating in the Privacy
+// Sandbox testing. It returns an empty string if absent.
+func (d *Device) CookieDeprecation() (string, error) {
+	var label string
+	if _, err := d.Ext.getKey("cdep", &label); err != nil {
+		return "", err
+	}
+	return label, nil
+}
+
+// SetCookieDeprecation stores label as device.ext.cdep. An empty label
+// removes the key.
+func (d *Device) SetCookieDeprecation(label string) error {
+	var ext Extension
+	var err error
+	if label == "" {
+		ext, err = d.Ext.deleteKey("cdep")
+	} else {
+		ext, err = d.Ext.setKey("cdep", label)
+	}
+	if err != nil {
+		return err
+	}
+	d.Ext = ext
+	return nil
+}
+
+// CookieDeprecationPartners is the set of partners which requested to
+// receive the cookie deprecation label.
+type CookieDeprecationPartners map[string]bool
+
+// Propagate applies the cookie deprecation label of the original request src
+// to the partner request dst. The label is copied if the partner requested
+// it and removed otherwise. The device of dst is copied before it is modified,
+// so dst may be a shallow copy of src.
+func (p CookieDeprecationPartners) Propagate(partner string, dst, src *BidRequest) error {
+	if dst.Device == nil {
+		return nil
+	}
+
+	var label string
+	if p[partner] && src.Device != nil {
+		var err error
+		if label, err = src.Device.CookieDeprecation(); err != nil {
+			return err
+		}
+	}
+
+	current, err := dst.Device.CookieDeprecation()
+	if err != nil {
+		return err
+	}
+	if current == label {
+		return nil
+	}
+
+	device := *dst.Device
+	if err := device.SetCookieDeprecation(label); err != nil {
+		return err
+	}
+	dst.Device = &device
+	return nil
+}
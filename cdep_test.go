@@ -0,0 +1,56 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CookieDeprecation", func() {
+
+	It("should decode device.ext.cdep", func() {
+		device := &Device{UA: "Mozilla", Ext: Extension(`{"cdep":"label_only_1"}`)}
+		Expect(device.CookieDeprecation()).To(Equal("label_only_1"))
+		Expect((&Device{}).CookieDeprecation()).To(BeEmpty())
+
+		_, err := (&Device{Ext: Extension(`{"cdep":1}`)}).CookieDeprecation()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should encode device.ext.cdep", func() {
+		device := &Device{Ext: Extension(`{"other":1}`)}
+		Expect(device.SetCookieDeprecation("treatment_1.1")).To(Succeed())
+		Expect(string(device.Ext)).To(Equal(`{"cdep":"treatment_1.1","other":1}`))
+
+		Expect(device.SetCookieDeprecation("")).To(Succeed())
+		Expect(string(device.Ext)).To(Equal(`{"other":1}`))
+
+		device = &Device{Ext: Extension(`{"cdep":"label_only_1"}`)}
+		Expect(device.SetCookieDeprecation("")).To(Succeed())
+		Expect(device.Ext).To(BeNil())
+	})
+
+	It("should propagate to partners", func() {
+		partners := CookieDeprecationPartners{"a": true}
+		src := &BidRequest{ID: "1", Device: &Device{UA: "Mozilla", Ext: Extension(`{"cdep":"label_only_1"}`)}}
+
+		dst := *src
+		Expect(partners.Propagate("a", &dst, src)).To(Succeed())
+		Expect(dst.Device).To(BeIdenticalTo(src.Device))
+
+		dst = *src
+		Expect(partners.Propagate("b", &dst, src)).To(Succeed())
+		Expect(dst.Device).NotTo(BeIdenticalTo(src.Device))
+		Expect(dst.Device.UA).To(Equal("Mozilla"))
+		Expect(dst.Device.Ext).To(BeNil())
+		Expect(src.Device.CookieDeprecation()).To(Equal("label_only_1"))
+
+		dst = BidRequest{ID: "1", Device: &Device{UA: "Mozilla"}}
+		Expect(partners.Propagate("a", &dst, src)).To(Succeed())
+		Expect(dst.Device.CookieDeprecation()).To(Equal("label_only_1"))
+
+		dst = BidRequest{ID: "1"}
+		Expect(partners.Propagate("a", &dst, src)).To(Succeed())
+		Expect(dst.Device).To(BeNil())
+	})
+
+})
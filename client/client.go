@@ -0,0 +1,370 @@
+/*
+Package client provides an HTTP client for sending bid requests to bidders.
+
+Requests are POSTed as JSON with the x-openrtb-version header, over pooled
+HTTP/2 connections where the endpoint supports it. Responses with a 204 No
+Content status are no-bids.
+
+	c, err := client.NewBidderClient("https://bidder.example.com/openrtb", &client.Options{Gzip: true})
+	if err != nil {
+		return err
+	}
+
+	res, err := c.Bid(ctx, req)
+	switch {
+	case errors.Is(err, client.ErrTimeout):
+		// bidder did not respond in time
+	case errors.Is(err, client.ErrMalformedResponse):
+		// bidder responded with an invalid payload
+	case err != nil:
+		// transport failure or unexpected status, see *client.StatusError
+	case res == nil:
+		// no-bid
+	}
+
+Clients satisfy the function signature of fanout.PartnerFunc and can be
+combined with the fan-out to query multiple bidders concurrently.
+*/
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// HeaderVersion is the header which carries the OpenRTB version.
+const HeaderVersion = openrtb.HeaderVersion
+
+// DefaultVersion is the OpenRTB version sent by default.
+const DefaultVersion = "2.6"
+
+// Errors
+var (
+	ErrTimeout           = errors.New("client: request timed out")
+	ErrMalformedResponse = errors.New("client: malformed response")
+)
+
+// StatusError is returned when a bidder responds with an unexpected HTTP
+// status, i.e. anything other than 200 or 204.
+type StatusError struct {
+	Code int // HTTP status code
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return "client: unexpected status " + strconv.Itoa(e.Code)
+}
+
+// Options configure the client. A nil value uses the zero value defaults.
+type Options struct {
+	// Version is sent in the x-openrtb-version header, default: DefaultVersion.
+	// Requests to bidders on older versions are downgraded accordingly, see
+	// openrtb.EncodeOptions.
+	Version string
+	// Header contains additional headers to send with every request.
+	Header http.Header
+	// Gzip compresses request bodies. Gzipped responses are always accepted.
+	Gzip bool
+	// MaxIdleConnsPerHost limits the number of pooled connections,
+	// default: 32.
+	MaxIdleConnsPerHost int
+	// HTTPClient overrides the default HTTP client. When set,
+	// MaxIdleConnsPerHost is ignored.
+	HTTPClient *http.Client
+	// Decode controls the decoding of responses. Unless Lenient is set,
+	// responses are validated against the request.
+	Decode *openrtb.DecodeOptions
+	// RequestHook is applied to a copy of each request before it is sent,
+	// see openrtb.ChainRequestHooks to combine multiple hooks. Only the
+	// request, its impressions and its top-level objects, i.e. site, app,
+	// dooh, device, user, source and regs, are copied. Hooks must replace
+	// other nested objects and slices rather than modify them in place, as
+	// they are shared with the original request.
+	RequestHook openrtb.RequestHook
+	// ResponseHook is applied to each decoded response before it is
+	// validated and returned.
+	ResponseHook openrtb.ResponseHook
+	// Test marks all requests as test traffic, i.e. sends them with test=1,
+	// e.g. for integration testing against production bidders.
+	Test bool
+	// Metrics optionally records the latency of each call, labelled by
+	// outcome. Unless Decode.Metrics is set, it also receives the decoder
+	// metrics of responses.
+	Metrics openrtb.Metrics
+}
+
+// BidderClient sends bid requests to a single bidder endpoint. It is safe
+// for concurrent use.
+type BidderClient struct {
+	endpoint   string
+	client     *http.Client
+	opts       Options
+	encodeOpts openrtb.EncodeOptions
+}
+
+// NewBidderClient creates a client for the given endpoint URL. It returns
+// openrtb.ErrInvalidVersion if opts.Version cannot be parsed.
+func NewBidderClient(endpoint string, opts *Options) (*BidderClient, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Version == "" {
+		o.Version = DefaultVersion
+	}
+	if o.MaxIdleConnsPerHost == 0 {
+		o.MaxIdleConnsPerHost = 32
+	}
+
+	version, err := openrtb.ParseVersion(o.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	client := o.HTTPClient
+	if client == nil {
+		client = &http.Client{Transport: newTransport(o.MaxIdleConnsPerHost)}
+	}
+	c := &BidderClient{endpoint: endpoint, client: client, opts: o}
+	c.encodeOpts.Version = version
+	return c, nil
+}
+
+func newTransport(maxIdle int) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        maxIdle * 4,
+		MaxIdleConnsPerHost: maxIdle,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		DisableCompression:  true, // handled by the client
+	}
+}
+
+// Bid sends req to the bidder and returns its response. It returns a nil
+// response for no-bids. Timeouts are reported as ErrTimeout, invalid
+// payloads as ErrMalformedResponse and unexpected statuses as *StatusError.
+// Unless decoding is lenient, seatbids of seats which are not permitted by
+// req are dropped, see openrtb.BidResponse.FilterSeats. Dropped bids and
+// those of invalid responses are recorded as ineligible in the trace attached
+// to ctx, see openrtb.ContextWithTrace.
+func (c *BidderClient) Bid(ctx context.Context, req *openrtb.BidRequest) (*openrtb.BidResponse, error) {
+	if c.opts.Metrics == nil {
+		return c.bid(ctx, req)
+	}
+
+	start := time.Now()
+	res, err := c.bid(ctx, req)
+
+	outcome := openrtb.MetricOutcomeBid
+	switch {
+	case errors.Is(err, ErrTimeout):
+		outcome = openrtb.MetricOutcomeTimeout
+	case err != nil:
+		outcome = openrtb.MetricOutcomeError
+	case res == nil || len(res.SeatBid) == 0:
+		outcome = openrtb.MetricOutcomeNoBid
+	}
+	c.opts.Metrics.Observe(openrtb.MetricResponseLatency, map[string]string{openrtb.MetricLabelOutcome: outcome}, time.Since(start).Seconds())
+	return res, err
+}
+
+func (c *BidderClient) bid(ctx context.Context, req *openrtb.BidRequest) (*openrtb.BidResponse, error) {
+	if c.opts.RequestHook != nil {
+		var err error
+		if req, err = c.applyRequestHook(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.opts.Test && !req.IsTest() {
+		dup := *req
+		dup.Test = 1
+		req = &dup
+	}
+
+	body, err := c.encode(req)
+	if err != nil {
+		return nil, err
+	}
+
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range c.opts.Header {
+		hreq.Header[name] = values
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hreq.Header.Set("Accept-Encoding", "gzip")
+	hreq.Header.Set(HeaderVersion, c.opts.Version)
+	if c.opts.Gzip {
+		hreq.Header.Set("Content-Encoding", "gzip")
+	}
+
+	hres, err := c.client.Do(hreq)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, hres.Body)
+		_ = hres.Body.Close()
+	}()
+
+	switch hres.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return nil, nil
+	default:
+		return nil, &StatusError{Code: hres.StatusCode}
+	}
+
+	var r io.Reader = hres.Body
+	if hres.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(hres.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedResponse, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	return c.decode(ctx, r, req)
+}
+
+// applyRequestHook applies the request hook to a copy of req, so the
+// original can be shared by concurrent calls.
+func (c *BidderClient) applyRequestHook(ctx context.Context, req *openrtb.BidRequest) (*openrtb.BidRequest, error) {
+	dup := copyRequest(req)
+	if err := c.opts.RequestHook(ctx, dup); err != nil {
+		return nil, err
+	}
+	return dup, nil
+}
+
+// copyRequest copies req, its impressions and its top-level objects.
+func copyRequest(req *openrtb.BidRequest) *openrtb.BidRequest {
+	dup := *req
+	if req.Imp != nil {
+		dup.Imp = append([]openrtb.Impression(nil), req.Imp...)
+	}
+	if req.Site != nil {
+		site := *req.Site
+		dup.Site = &site
+	}
+	if req.App != nil {
+		app := *req.App
+		dup.App = &app
+	}
+	if req.DOOH != nil {
+		dooh := *req.DOOH
+		dup.DOOH = &dooh
+	}
+	if req.Device != nil {
+		device := *req.Device
+		dup.Device = &device
+	}
+	if req.User != nil {
+		user := *req.User
+		dup.User = &user
+	}
+	if req.Source != nil {
+		source := *req.Source
+		dup.Source = &source
+	}
+	if req.Regs != nil {
+		regs := *req.Regs
+		dup.Regs = &regs
+	}
+	return &dup
+}
+
+func (c *BidderClient) encode(req *openrtb.BidRequest) (io.Reader, error) {
+	buf := new(bytes.Buffer)
+	if !c.opts.Gzip {
+		if err := openrtb.EncodeBidRequest(buf, req, &c.encodeOpts); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+
+	zw := gzip.NewWriter(buf)
+	if err := openrtb.EncodeBidRequest(zw, req, &c.encodeOpts); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (c *BidderClient) decode(ctx context.Context, r io.Reader, req *openrtb.BidRequest) (*openrtb.BidResponse, error) {
+	var opts openrtb.DecodeOptions
+	if c.opts.Decode != nil {
+		opts = *c.opts.Decode
+	}
+	lenient := opts.Lenient
+	opts.Lenient = true
+	if opts.Metrics == nil {
+		opts.Metrics = c.opts.Metrics
+	}
+
+	res, err := openrtb.DecodeBidResponseContext(ctx, r, &opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, wrapErr(err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrMalformedResponse, err)
+	}
+	if c.opts.ResponseHook != nil {
+		if err := c.opts.ResponseHook(ctx, res); err != nil {
+			return nil, err
+		}
+	}
+	if !lenient {
+		trace := openrtb.TraceFromContext(ctx)
+		for _, rej := range res.FilterSeats(req) {
+			traceIneligible(trace, &rej.SeatBid, rej.Rejection.Error())
+		}
+		if len(res.SeatBid) == 0 {
+			return nil, nil
+		}
+		if err := res.ValidateForRequest(req); err != nil {
+			for i := range res.SeatBid {
+				traceIneligible(trace, &res.SeatBid[i], err.Error())
+			}
+			return nil, fmt.Errorf("%w: %w", ErrMalformedResponse, err)
+		}
+	}
+	return res, nil
+}
+
+// traceIneligible records all bids of sb as ineligible.
+func traceIneligible(t *openrtb.Trace, sb *openrtb.SeatBid, reason string) {
+	for i := range sb.Bid {
+		t.Eligibility(sb.Seat, &sb.Bid[i], false, reason)
+	}
+}
+
+// wrapErr wraps transport errors caused by timeouts in ErrTimeout.
+func wrapErr(err error) error {
+	var nerr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &nerr) && nerr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
@@ -0,0 +1,290 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BidderClient", func() {
+	var server *httptest.Server
+	var handler http.HandlerFunc
+	var req *openrtb.BidRequest
+	var ctx = context.Background()
+
+	respond := func(w http.ResponseWriter, body string) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}
+
+	BeforeEach(func() {
+		req = &openrtb.BidRequest{ID: "R", Imp: []openrtb.Impression{{ID: "I", Banner: &openrtb.Banner{}}}}
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			respond(w, `{"id":"R","seatbid":[{"bid":[{"id":"B","impid":"I","price":1.5}]}]}`)
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { handler(w, r) }))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send requests", func() {
+		var received *openrtb.BidRequest
+		var header http.Header
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+			respond(w, `{"id":"R","seatbid":[{"bid":[{"id":"B","impid":"I","price":1.5}]}]}`)
+		}
+
+		res, err := newBidderClient(server.URL, &Options{Header: http.Header{"X-Custom": {"1"}}}).Bid(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid[0].Bid[0].Price).To(Equal(1.5))
+		Expect(received).To(Equal(req))
+		Expect(header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(header.Get("X-Openrtb-Version")).To(Equal("2.6"))
+		Expect(header.Get("X-Custom")).To(Equal("1"))
+	})
+
+	It("should downgrade requests", func() {
+		var body []byte
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Header.Get("X-Openrtb-Version")).To(Equal("2.5"))
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		req.Regs = &openrtb.Regulations{GDPR: 1}
+		Expect(newBidderClient(server.URL, &Options{Version: "2.5"}).Bid(ctx, req)).To(BeNil())
+		Expect(body).To(MatchJSON(`{"id":"R","imp":[{"id":"I","banner":{}}],"at":0,"regs":{"ext":{"gdpr":1}}}`))
+
+		_, err := NewBidderClient(server.URL, &Options{Version: "latest"})
+		Expect(err).To(Equal(openrtb.ErrInvalidVersion))
+	})
+
+	It("should mark test traffic", func() {
+		var received *openrtb.BidRequest
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		Expect(newBidderClient(server.URL, &Options{Test: true}).Bid(ctx, req)).To(BeNil())
+		Expect(received.Test).To(Equal(1))
+		Expect(req.Test).To(BeZero())
+
+		req.Test = 1
+		Expect(newBidderClient(server.URL, nil).Bid(ctx, req)).To(BeNil())
+		Expect(received.Test).To(Equal(1))
+	})
+
+	It("should handle no-bids", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+		Expect(newBidderClient(server.URL, nil).Bid(ctx, req)).To(BeNil())
+	})
+
+	It("should compress", func() {
+		var encoding string
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+			zr, err := gzip.NewReader(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(io.ReadAll(zr)).To(ContainSubstring(`"id":"R"`))
+
+			Expect(r.Header.Get("Accept-Encoding")).To(Equal("gzip"))
+			w.Header().Set("Content-Encoding", "gzip")
+			zw := gzip.NewWriter(w)
+			_, _ = io.WriteString(zw, `{"id":"R","seatbid":[{"bid":[{"id":"B","impid":"I","price":2}]}]}`)
+			Expect(zw.Close()).To(Succeed())
+		}
+
+		res, err := newBidderClient(server.URL, &Options{Gzip: true, Version: "2.5"}).Bid(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid[0].Bid[0].Price).To(Equal(2.0))
+		Expect(encoding).To(Equal("gzip"))
+	})
+
+	It("should apply hooks", func() {
+		var received *openrtb.BidRequest
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+			respond(w, `{"id":"R","seatbid":[{"bid":[{"id":"B","impid":"I","price":1.5}]}]}`)
+		}
+
+		subject := newBidderClient(server.URL, &Options{
+			RequestHook: openrtb.ChainRequestHooks(func(_ context.Context, req *openrtb.BidRequest) error {
+				req.Device = nil
+				req.Imp[0].BidFloor = 9
+				req.Site.Page = "https://example.com/"
+				return nil
+			}),
+			ResponseHook: func(_ context.Context, res *openrtb.BidResponse) error {
+				res.Currency = "EUR"
+				return nil
+			},
+		})
+
+		req.Device = &openrtb.Device{IP: "123.145.167.189"}
+		req.Site = &openrtb.Site{}
+		res, err := subject.Bid(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Currency).To(Equal("EUR"))
+		Expect(received.Device).To(BeNil())
+		Expect(received.Imp[0].BidFloor).To(Equal(9.0))
+		Expect(received.Site.Page).To(Equal("https://example.com/"))
+		Expect(req.Device).NotTo(BeNil())
+		Expect(req.Imp[0].BidFloor).To(BeZero())
+		Expect(req.Site.Page).To(BeEmpty())
+	})
+
+	It("should abort on hook errors", func() {
+		_, err := newBidderClient(server.URL, &Options{
+			RequestHook: func(context.Context, *openrtb.BidRequest) error { return errors.New("request boom") },
+		}).Bid(ctx, req)
+		Expect(err).To(MatchError("request boom"))
+
+		_, err = newBidderClient(server.URL, &Options{
+			ResponseHook: func(context.Context, *openrtb.BidResponse) error { return errors.New("response boom") },
+		}).Bid(ctx, req)
+		Expect(err).To(MatchError("response boom"))
+	})
+
+	It("should record metrics", func() {
+		metrics := new(mockMetrics)
+		subject := newBidderClient(server.URL, &Options{Metrics: metrics})
+		_, err := subject.Bid(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+		_, err = subject.Bid(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(metrics.names).To(Equal([]string{
+			"openrtb_decode_bytes type=response",
+			"openrtb_response_latency_seconds outcome=bid",
+			"openrtb_response_latency_seconds outcome=nobid",
+		}))
+	})
+
+	It("should report timeouts", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+
+		cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := newBidderClient(server.URL, nil).Bid(cctx, req)
+		Expect(err).To(MatchError(ErrTimeout))
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should report malformed responses", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			respond(w, `{"id":`)
+		}
+		_, err := newBidderClient(server.URL, nil).Bid(ctx, req)
+		Expect(err).To(MatchError(ErrMalformedResponse))
+
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			respond(w, `{"id":"R","seatbid":[{"bid":[{"id":"B","impid":"X","price":1}]}]}`)
+		}
+		trace := openrtb.NewTrace("R")
+		_, err = newBidderClient(server.URL, nil).Bid(openrtb.ContextWithTrace(ctx, trace), req)
+		Expect(err).To(MatchError(ErrMalformedResponse))
+		Expect(err).To(MatchError(openrtb.ErrInvalidBidImpID))
+		Expect(trace.Snapshot()).To(HaveLen(1))
+		Expect(trace.Snapshot()[0].BidID).To(Equal("B"))
+		Expect(trace.Snapshot()[0].OK).To(BeFalse())
+
+		res, err := newBidderClient(server.URL, &Options{Decode: &openrtb.DecodeOptions{Lenient: true}}).Bid(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid[0].Bid[0].ImpID).To(Equal("X"))
+	})
+
+	It("should drop seats which are not permitted", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			respond(w, `{"id":"R","seatbid":[{"seat":"a","bid":[{"id":"B1","impid":"I","price":1}]},{"seat":"b","bid":[{"id":"B2","impid":"I","price":2}]}]}`)
+		}
+
+		req.BSeat = []string{"b"}
+		trace := openrtb.NewTrace("R")
+		res, err := newBidderClient(server.URL, nil).Bid(openrtb.ContextWithTrace(ctx, trace), req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid).To(HaveLen(1))
+		Expect(res.SeatBid[0].Seat).To(Equal("a"))
+		Expect(trace.Snapshot()).To(Equal([]openrtb.TraceEvent{
+			{Stage: openrtb.TraceStageEligibility, ImpID: "I", Seat: "b", BidID: "B2", Reason: req.CheckSeat("b").Error(), Price: 2},
+		}))
+
+		req.BSeat = []string{"a", "b"}
+		Expect(newBidderClient(server.URL, nil).Bid(ctx, req)).To(BeNil())
+	})
+
+	It("should report unexpected statuses", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+		_, err := newBidderClient(server.URL, nil).Bid(ctx, req)
+		Expect(err).To(MatchError("client: unexpected status 502"))
+
+		var serr *StatusError
+		Expect(errors.As(err, &serr)).To(BeTrue())
+		Expect(serr.Code).To(Equal(http.StatusBadGateway))
+	})
+
+})
+
+type mockMetrics struct {
+	names []string
+	mu    sync.Mutex
+}
+
+func (m *mockMetrics) Count(name string, labels map[string]string, _ float64) {
+	m.record(name, labels)
+}
+
+func (m *mockMetrics) Observe(name string, labels map[string]string, _ float64) {
+	m.record(name, labels)
+}
+
+func (m *mockMetrics) record(name string, labels map[string]string) {
+	for k, v := range labels {
+		name += " " + k + "=" + v
+	}
+
+	m.mu.Lock()
+	m.names = append(m.names, name)
+	m.mu.Unlock()
+}
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/client")
+}
+
+func newBidderClient(endpoint string, opts *Options) *BidderClient {
+	c, err := NewBidderClient(endpoint, opts)
+	Expect(err).NotTo(HaveOccurred())
+	return c
+}
@@ -0,0 +1,177 @@
+package openrtb
+
+import (
+	"net/http"
+	"strings"
+)
+
+// User-Agent Client Hints headers
+const (
+	HeaderSecCHUA                = "Sec-CH-UA"
+	HeaderSecCHUAFullVersionList = "Sec-CH-UA-Full-Version-List"
+	HeaderSecCHUAPlatform        = "Sec-CH-UA-Platform"
+	HeaderSecCHUAPlatformVersion = "Sec-CH-UA-Platform-Version"
+	HeaderSecCHUAMobile          = "Sec-CH-UA-Mobile"
+	HeaderSecCHUAArch            = "Sec-CH-UA-Arch"
+	HeaderSecCHUABitness         = "Sec-CH-UA-Bitness"
+	HeaderSecCHUAModel           = "Sec-CH-UA-Model"
+)
+
+// UserAgentFromClientHints builds a structured user agent from the
+// Sec-CH-UA* headers of h. Browsers are taken from the full version list,
+// falling back to the low-entropy brand list. The source is set to
+// UASourceHighEntropy if any high-entropy hints are present. It returns nil
+// if h contains neither valid brands nor a platform.
+func UserAgentFromClientHints(h http.Header) *UserAgent {
+	brands := h.Get(HeaderSecCHUAFullVersionList)
+	highEntropy := brands != ""
+	if brands == "" {
+		brands = h.Get(HeaderSecCHUA)
+	}
+
+	ua := &UserAgent{Source: UASourceLowEntropy}
+	for _, item := range splitSFList(brands) {
+		if bv, ok := parseSFBrand(item); ok {
+			ua.Browsers = append(ua.Browsers, bv)
+		}
+	}
+	platform := parseSFString(h.Get(HeaderSecCHUAPlatform))
+	if len(ua.Browsers) == 0 && platform == "" {
+		return nil
+	}
+	if platform != "" {
+		ua.Platform = &BrandVersion{Brand: platform}
+		if version := parseSFString(h.Get(HeaderSecCHUAPlatformVersion)); version != "" {
+			ua.Platform.Version = strings.Split(version, ".")
+			highEntropy = true
+		}
+	}
+	switch strings.TrimSpace(h.Get(HeaderSecCHUAMobile)) {
+	case "?1":
+		mobile := 1
+		ua.Mobile = &mobile
+	case "?0":
+		mobile := 0
+		ua.Mobile = &mobile
+	}
+	if ua.Architecture = parseSFString(h.Get(HeaderSecCHUAArch)); ua.Architecture != "" {
+		highEntropy = true
+	}
+	if ua.Bitness = parseSFString(h.Get(HeaderSecCHUABitness)); ua.Bitness != "" {
+		highEntropy = true
+	}
+	if ua.Model = parseSFString(h.Get(HeaderSecCHUAModel)); ua.Model != "" {
+		highEntropy = true
+	}
+	if highEntropy {
+		ua.Source = UASourceHighEntropy
+	}
+	return ua
+}
+
+// ApplyClientHints sets the structured user agent of the device from the
+// Sec-CH-UA* headers of h, see UserAgentFromClientHints, and fills missing
+// os, osv and model. Devices which already have a structured user agent are
+// left untouched. It returns true if the device was updated.
+func (d *Device) ApplyClientHints(h http.Header) bool {
+	if d.SUA != nil {
+		return false
+	}
+
+	ua := UserAgentFromClientHints(h)
+	if ua == nil {
+		return false
+	}
+
+	d.SUA = ua
+	if ua.Platform != nil {
+		if d.OS == "" {
+			d.OS = ua.Platform.Brand
+		}
+		if d.OSVer == "" {
+			d.OSVer = strings.Join(ua.Platform.Version, ".")
+		}
+	}
+	if d.Model == "" {
+		d.Model = ua.Model
+	}
+	return true
+}
+
+// splitSFList splits a structured header list at commas outside of quoted
+// strings.
+func splitSFList(s string) []string {
+	var items []string
+
+	start, quoted, escaped := 0, false, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		items = append(items, s[start:])
+	}
+	return items
+}
+
+// parseSFBrand parses a brand list item, e.g. `"Chromium";v="110.0.5481.77"`.
+func parseSFBrand(item string) (BrandVersion, bool) {
+	item = strings.TrimSpace(item)
+	brand, rest, ok := cutSFString(item)
+	if !ok || brand == "" {
+		return BrandVersion{}, false
+	}
+
+	bv := BrandVersion{Brand: brand}
+	for _, param := range strings.Split(rest, ";") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok && name == "v" {
+			if version := parseSFString(value); version != "" {
+				bv.Version = strings.Split(version, ".")
+			}
+		}
+	}
+	return bv, true
+}
+
+// parseSFString parses a structured header string, e.g. `"Windows"`. It
+// returns an empty string if s is not a string.
+func parseSFString(s string) string {
+	str, rest, ok := cutSFString(strings.TrimSpace(s))
+	if !ok || strings.TrimSpace(rest) != "" {
+		return ""
+	}
+	return str
+}
+
+// cutSFString parses the quoted string at the beginning of s and returns
+// its unescaped value and the remainder of s.
+func cutSFString(s string) (string, string, bool) {
+	if len(s) < 2 || s[0] != '"' {
+		return "", "", false
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i++; i == len(s) {
+				return "", "", false
+			}
+			b.WriteByte(s[i])
+		case '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
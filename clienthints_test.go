@@ -0,0 +1,89 @@
+package openrtb
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserAgentFromClientHints", func() {
+	var header http.Header
+
+	BeforeEach(func() {
+		header = http.Header{}
+		header.Set(HeaderSecCHUA, `"Chromium";v="110", "Not A(Brand";v="24", "Google Chrome";v="110"`)
+		header.Set(HeaderSecCHUAPlatform, `"Windows"`)
+		header.Set(HeaderSecCHUAMobile, `?0`)
+	})
+
+	It("should parse low-entropy hints", func() {
+		mobile := 0
+		Expect(UserAgentFromClientHints(header)).To(Equal(&UserAgent{
+			Browsers: []BrandVersion{
+				{Brand: "Chromium", Version: []string{"110"}},
+				{Brand: "Not A(Brand", Version: []string{"24"}},
+				{Brand: "Google Chrome", Version: []string{"110"}},
+			},
+			Platform: &BrandVersion{Brand: "Windows"},
+			Mobile:   &mobile,
+			Source:   UASourceLowEntropy,
+		}))
+	})
+
+	It("should parse high-entropy hints", func() {
+		header.Set(HeaderSecCHUAFullVersionList, `"Chromium";v="110.0.5481.77", "Not \"A\\Brand";v="24.0.0.0"`)
+		header.Set(HeaderSecCHUAPlatformVersion, `"15.0.0"`)
+		header.Set(HeaderSecCHUAMobile, `?1`)
+		header.Set(HeaderSecCHUAArch, `"arm"`)
+		header.Set(HeaderSecCHUABitness, `"64"`)
+		header.Set(HeaderSecCHUAModel, `"Pixel 7"`)
+
+		mobile := 1
+		Expect(UserAgentFromClientHints(header)).To(Equal(&UserAgent{
+			Browsers: []BrandVersion{
+				{Brand: "Chromium", Version: []string{"110", "0", "5481", "77"}},
+				{Brand: `Not "A\Brand`, Version: []string{"24", "0", "0", "0"}},
+			},
+			Platform:     &BrandVersion{Brand: "Windows", Version: []string{"15", "0", "0"}},
+			Mobile:       &mobile,
+			Architecture: "arm",
+			Bitness:      "64",
+			Model:        "Pixel 7",
+			Source:       UASourceHighEntropy,
+		}))
+	})
+
+	It("should skip malformed hints", func() {
+		header.Set(HeaderSecCHUA, `Chromium;v=110, "Edge";v="109`)
+		header.Set(HeaderSecCHUAPlatform, `Windows`)
+		Expect(UserAgentFromClientHints(header).Browsers).To(Equal([]BrandVersion{{Brand: "Edge"}}))
+
+		header.Set(HeaderSecCHUA, `Chromium;v=110`)
+		Expect(UserAgentFromClientHints(header)).To(BeNil())
+		Expect(UserAgentFromClientHints(http.Header{})).To(BeNil())
+	})
+
+})
+
+var _ = Describe("Device", func() {
+
+	It("should apply client hints", func() {
+		header := http.Header{}
+		header.Set(HeaderSecCHUA, `"Chromium";v="110"`)
+		header.Set(HeaderSecCHUAPlatform, `"Android"`)
+		header.Set(HeaderSecCHUAPlatformVersion, `"13.0.0"`)
+		header.Set(HeaderSecCHUAModel, `"Pixel 7"`)
+
+		subject := &Device{OS: "android"}
+		Expect(subject.ApplyClientHints(header)).To(BeTrue())
+		Expect(subject.SUA.Platform).To(Equal(&BrandVersion{Brand: "Android", Version: []string{"13", "0", "0"}}))
+		Expect(subject.OS).To(Equal("android"))
+		Expect(subject.OSVer).To(Equal("13.0.0"))
+		Expect(subject.Model).To(Equal("Pixel 7"))
+
+		Expect(subject.ApplyClientHints(header)).To(BeFalse())
+		Expect((&Device{}).ApplyClientHints(http.Header{})).To(BeFalse())
+	})
+
+})
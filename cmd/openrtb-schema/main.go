@@ -0,0 +1,88 @@
+// Command openrtb-schema writes JSON schema documents for bid requests and
+// responses. By default, it prints the request schema of the latest spec
+// version; with -out, it writes bidrequest-<version>.json and
+// bidresponse-<version>.json for all supported versions.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bsm/openrtb"
+)
+
+var objects = map[string]interface{}{
+	"bidrequest":  &openrtb.BidRequest{},
+	"bidresponse": &openrtb.BidResponse{},
+}
+
+func main() {
+	version := flag.String("version", openrtb.LatestVersion.String(), "OpenRTB spec version")
+	object := flag.String("object", "bidrequest", "object to describe, bidrequest or bidresponse")
+	out := flag.String("out", "", "output directory, writes all objects and versions")
+	flag.Parse()
+
+	var err error
+	if *out != "" {
+		err = writeAll(*out)
+	} else {
+		err = write(os.Stdout, *object, *version)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openrtb-schema:", err)
+		os.Exit(1)
+	}
+}
+
+func writeAll(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, version := range openrtb.SupportedVersions {
+		for name := range objects {
+			if err := writeFile(filepath.Join(dir, name+"-"+version.String()+".json"), name, version.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeFile(fname, object, version string) error {
+	f, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := write(f, object, version); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func write(w io.Writer, object, version string) error {
+	v, ok := objects[object]
+	if !ok {
+		return fmt.Errorf("unknown object %q", object)
+	}
+
+	ver, err := openrtb.ParseVersion(version)
+	if err != nil {
+		return err
+	}
+
+	s, err := openrtb.JSONSchema(v, ver)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
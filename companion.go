@@ -0,0 +1,53 @@
+package openrtb
+
+// MatchCompanion returns the companion slot a returned companion ad is
+// served in. Companions which reference a slot by its banner id, i.e. the
+// VAST adSlotID, are matched by id only; others are matched to the first
+// slot which accepts their size, see Banner.AcceptsSize. It returns nil if
+// no slot matches.
+func MatchCompanion(slots []Banner, adSlotID string, w, h int) *Banner {
+	for i := range slots {
+		if slot := &slots[i]; adSlotID != "" && slot.ID == adSlotID {
+			if slot.AcceptsSize(w, h) {
+				return slot
+			}
+			return nil
+		}
+	}
+	if adSlotID != "" {
+		return nil
+	}
+
+	for i := range slots {
+		if slot := &slots[i]; slot.AcceptsSize(w, h) {
+			return slot
+		}
+	}
+	return nil
+}
+
+// AcceptsCompanionType returns true if companions of the given type, see
+// VASTCompanion* constants, may be served. An empty list accepts all types.
+func AcceptsCompanionType(types []int, typ int) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// CompanionSlot returns the companion slot of the video matching a returned
+// companion ad, see MatchCompanion.
+func (v *Video) CompanionSlot(adSlotID string, w, h int) *Banner {
+	return MatchCompanion(v.CompanionAd, adSlotID, w, h)
+}
+
+// CompanionSlot returns the companion slot of the audio matching a returned
+// companion ad, see MatchCompanion.
+func (a *Audio) CompanionSlot(adSlotID string, w, h int) *Banner {
+	return MatchCompanion(a.CompanionAd, adSlotID, w, h)
+}
@@ -0,0 +1,47 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MatchCompanion", func() {
+	var slots []Banner
+
+	BeforeEach(func() {
+		slots = []Banner{
+			{ID: "1", W: 300, H: 250},
+			{ID: "2", W: 728, H: 90},
+			{ID: "3", W: 300, H: 250},
+		}
+	})
+
+	It("should match by slot id", func() {
+		Expect(MatchCompanion(slots, "3", 300, 250)).To(BeIdenticalTo(&slots[2]))
+		Expect(MatchCompanion(slots, "3", 728, 90)).To(BeNil())
+		Expect(MatchCompanion(slots, "4", 300, 250)).To(BeNil())
+	})
+
+	It("should match by size", func() {
+		Expect(MatchCompanion(slots, "", 300, 250)).To(BeIdenticalTo(&slots[0]))
+		Expect(MatchCompanion(slots, "", 728, 90)).To(BeIdenticalTo(&slots[1]))
+		Expect(MatchCompanion(slots, "", 160, 600)).To(BeNil())
+		Expect(MatchCompanion(nil, "", 300, 250)).To(BeNil())
+	})
+
+	It("should match on video and audio", func() {
+		Expect((&Video{CompanionAd: slots}).CompanionSlot("2", 728, 90)).To(Equal(&slots[1]))
+		Expect((&Audio{CompanionAd: slots}).CompanionSlot("", 728, 90)).To(Equal(&slots[1]))
+	})
+
+})
+
+var _ = Describe("AcceptsCompanionType", func() {
+
+	It("should check types", func() {
+		Expect(AcceptsCompanionType(nil, VASTCompanionHTML)).To(BeTrue())
+		Expect(AcceptsCompanionType([]int{VASTCompanionStatic, VASTCompanionHTML}, VASTCompanionHTML)).To(BeTrue())
+		Expect(AcceptsCompanionType([]int{VASTCompanionStatic}, VASTCompanionIFrame)).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,111 @@
+package openrtb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rejection describes why a bid violates the block-lists of a request.
+type Rejection struct {
+	Code   int    // Loss reason code, see Loss* constants
+	Field  string // The request field containing the block-list, e.g. "badv"
+	Value  string // The offending bid value
+	Reason string // Human readable reason
+}
+
+// Error implements the error interface
+func (r *Rejection) Error() string {
+	return "openrtb: bid rejected: " + r.Reason + " (" + r.Field + ": " + r.Value + ")"
+}
+
+// CheckBlockLists checks a bid against the block-lists of the request:
+//
+//   - badv: blocks advertiser domains, including all their sub-domains, see
+//     MatchDomain;
+//   - bapp: blocks app bundles;
+//   - bcat: blocks categories, including all their sub-categories (i.e.
+//     "IAB25" blocks "IAB25-3" but not "IAB2"). Categories are only compared
+//     if both request and bid use the same taxonomy.
+//
+// It returns nil if the bid is compliant.
+func (req *BidRequest) CheckBlockLists(bid *Bid) *Rejection {
+	for _, domain := range bid.AdvDomain {
+		for _, blocked := range req.BAdv {
+			if MatchDomain(domain, blocked) {
+				return &Rejection{Code: LossCreativeAdvertiserExclusion, Field: "badv", Value: domain, Reason: "blocked advertiser domain " + blocked}
+			}
+		}
+	}
+
+	if bid.Bundle != "" {
+		for _, blocked := range req.BApp {
+			if bid.Bundle == blocked {
+				return &Rejection{Code: LossCreativeAppExclusion, Field: "bapp", Value: bid.Bundle, Reason: "blocked app " + blocked}
+			}
+		}
+	}
+
+	if SameCatTax(req.CatTax, bid.CatTax) {
+		for _, cat := range bid.Cat {
+			for _, blocked := range req.Bcat {
+				if matchCategory(cat, blocked) {
+					return &Rejection{Code: LossCreativeCategoryExclusion, Field: "bcat", Value: cat, Reason: "blocked category " + blocked}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckAttributes checks the creative attributes of a bid against the
+// blocked attributes (battr) of the impression's banner, video, audio and
+// native objects. If the bid declares an mtype, only the battr of the
+// matching object applies. It returns nil if the bid is compliant.
+func (imp *Impression) CheckAttributes(bid *Bid) *Rejection {
+	if len(bid.Attr) == 0 {
+		return nil
+	}
+
+	for _, bl := range imp.blockedAttrs(bid.MType) {
+		for _, attr := range bid.Attr {
+			for _, blocked := range bl.attrs {
+				if attr == blocked {
+					value := strconv.Itoa(attr)
+					return &Rejection{Code: LossCreativeAttributeExclusion, Field: bl.field, Value: value, Reason: "blocked creative attribute " + value}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type blockedAttrs struct {
+	field string
+	attrs []int
+}
+
+func (imp *Impression) blockedAttrs(mtype int) []blockedAttrs {
+	var res []blockedAttrs
+	if imp.Banner != nil && (mtype == 0 || mtype == MarkupTypeBanner) {
+		res = append(res, blockedAttrs{field: "imp.banner.battr", attrs: imp.Banner.BAttr})
+	}
+	if imp.Video != nil && (mtype == 0 || mtype == MarkupTypeVideo) {
+		res = append(res, blockedAttrs{field: "imp.video.battr", attrs: imp.Video.BAttr})
+	}
+	if imp.Audio != nil && (mtype == 0 || mtype == MarkupTypeAudio) {
+		res = append(res, blockedAttrs{field: "imp.audio.battr", attrs: imp.Audio.BAttr})
+	}
+	if imp.Native != nil && (mtype == 0 || mtype == MarkupTypeNative) {
+		res = append(res, blockedAttrs{field: "imp.native.battr", attrs: imp.Native.BAttr})
+	}
+	return res
+}
+
+// matchCategory returns true if cat equals blocked or is a sub-category of it.
+func matchCategory(cat, blocked string) bool {
+	if blocked == "" {
+		return false
+	}
+	return cat == blocked || strings.HasPrefix(cat, blocked+"-")
+}
@@ -0,0 +1,97 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			BAdv: []string{"blocked.com", "Other.org."},
+			BApp: []string{"com.blocked.app"},
+			Bcat: []string{"IAB25", "IAB7-39"},
+		}
+	})
+
+	It("should accept compliant bids", func() {
+		Expect(subject.CheckBlockLists(&Bid{ID: "1"})).To(BeNil())
+		Expect(subject.CheckBlockLists(&Bid{
+			ID:        "1",
+			AdvDomain: []string{"notblocked.com", "blocked.com.evil"},
+			Bundle:    "com.blocked.app.lite",
+			Cat:       []string{"IAB2", "IAB7", "IAB7-3", "IAB7-390"},
+		})).To(BeNil())
+	})
+
+	It("should reject blocked advertiser domains", func() {
+		Expect(subject.CheckBlockLists(&Bid{AdvDomain: []string{"ads.blocked.com"}})).To(Equal(&Rejection{
+			Code:   LossCreativeAdvertiserExclusion,
+			Field:  "badv",
+			Value:  "ads.blocked.com",
+			Reason: "blocked advertiser domain blocked.com",
+		}))
+		Expect(subject.CheckBlockLists(&Bid{AdvDomain: []string{"OTHER.org"}})).NotTo(BeNil())
+	})
+
+	It("should reject blocked apps", func() {
+		rej := subject.CheckBlockLists(&Bid{Bundle: "com.blocked.app"})
+		Expect(rej).NotTo(BeNil())
+		Expect(rej.Code).To(Equal(LossCreativeAppExclusion))
+		Expect(rej.Error()).To(Equal("openrtb: bid rejected: blocked app com.blocked.app (bapp: com.blocked.app)"))
+	})
+
+	It("should reject blocked categories", func() {
+		rej := subject.CheckBlockLists(&Bid{Cat: []string{"IAB1", "IAB25-3"}})
+		Expect(rej).To(Equal(&Rejection{
+			Code:   LossCreativeCategoryExclusion,
+			Field:  "bcat",
+			Value:  "IAB25-3",
+			Reason: "blocked category IAB25",
+		}))
+		Expect(subject.CheckBlockLists(&Bid{Cat: []string{"IAB7-39"}, CatTax: CatTaxIABContent10})).NotTo(BeNil())
+		Expect(subject.CheckBlockLists(&Bid{Cat: []string{"IAB7-39"}, CatTax: CatTaxIABContent30})).To(BeNil())
+	})
+
+})
+
+var _ = Describe("Impression", func() {
+	var subject *Impression
+
+	BeforeEach(func() {
+		subject = &Impression{
+			ID:     "1",
+			Banner: &Banner{BAttr: []int{1, 3}},
+			Video:  &Video{BAttr: []int{16}},
+		}
+	})
+
+	It("should accept compliant bids", func() {
+		Expect(subject.CheckAttributes(&Bid{})).To(BeNil())
+		Expect(subject.CheckAttributes(&Bid{Attr: []int{2, 4}})).To(BeNil())
+		Expect(subject.CheckAttributes(&Bid{Attr: []int{16}, MType: MarkupTypeBanner})).To(BeNil())
+		Expect(subject.CheckAttributes(&Bid{Attr: []int{3}, MType: MarkupTypeVideo})).To(BeNil())
+	})
+
+	It("should reject blocked attributes", func() {
+		Expect(subject.CheckAttributes(&Bid{Attr: []int{2, 3}})).To(Equal(&Rejection{
+			Code:   LossCreativeAttributeExclusion,
+			Field:  "imp.banner.battr",
+			Value:  "3",
+			Reason: "blocked creative attribute 3",
+		}))
+		Expect(subject.CheckAttributes(&Bid{Attr: []int{16}})).To(Equal(&Rejection{
+			Code:   LossCreativeAttributeExclusion,
+			Field:  "imp.video.battr",
+			Value:  "16",
+			Reason: "blocked creative attribute 16",
+		}))
+		Expect(subject.CheckAttributes(&Bid{Attr: []int{16}, MType: MarkupTypeVideo})).NotTo(BeNil())
+
+		subject = &Impression{ID: "1", Native: &Native{BAttr: []int{8}}}
+		Expect(subject.CheckAttributes(&Bid{Attr: []int{8}})).NotTo(BeNil())
+	})
+
+})
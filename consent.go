@@ -0,0 +1,60 @@
+package openrtb
+
+import "errors"
+
+// Consent errors
+var (
+	ErrNoConsentDecoder = errors.New("openrtb: no consent decoder registered")
+	ErrNoConsent        = errors.New("openrtb: request has no consent string")
+)
+
+// Consent is a decoded TCF v2 consent string.
+type Consent interface {
+	// PurposeAllowed reports whether the user consented to the given purpose.
+	PurposeAllowed(purpose int) bool
+	// VendorAllowed reports whether the user consented to the given vendor.
+	VendorAllowed(vendorID int) bool
+}
+
+// ConsentDecoder decodes TCF v2 consent strings. This package does not
+// implement the TCF format itself, a decoder must be registered via
+// RegisterConsentDecoder.
+type ConsentDecoder interface {
+	DecodeConsent(consent string) (Consent, error)
+}
+
+var consentDecoder ConsentDecoder
+
+// RegisterConsentDecoder registers the decoder used by the consent helpers.
+// It is not safe for concurrent use and should be called on init.
+// Pass nil to unregister.
+func RegisterConsentDecoder(d ConsentDecoder) {
+	consentDecoder = d
+}
+
+// Consent decodes the request's user consent string using the registered decoder.
+func (req *BidRequest) Consent() (Consent, error) {
+	if consentDecoder == nil {
+		return nil, ErrNoConsentDecoder
+	}
+	if req.User == nil || req.User.Consent == "" {
+		return nil, ErrNoConsent
+	}
+	return consentDecoder.DecodeConsent(req.User.Consent)
+}
+
+// HasConsentForPurpose returns true if the vendor may process data for the
+// given purpose. Requests that are not subject to GDPR always return true.
+// Requests that are subject to GDPR return false unless a decoder is registered
+// and the consent string grants both the purpose and the vendor.
+func (req *BidRequest) HasConsentForPurpose(vendorID, purpose int) bool {
+	if !req.IsGDPRInScope() {
+		return true
+	}
+
+	consent, err := req.Consent()
+	if err != nil {
+		return false
+	}
+	return consent.PurposeAllowed(purpose) && consent.VendorAllowed(vendorID)
+}
@@ -0,0 +1,82 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockConsent struct{ purposes, vendors []int }
+
+func (c *mockConsent) PurposeAllowed(p int) bool { return containsInt(c.purposes, p) }
+func (c *mockConsent) VendorAllowed(v int) bool  { return containsInt(c.vendors, v) }
+
+type mockConsentDecoder struct{}
+
+func (mockConsentDecoder) DecodeConsent(s string) (Consent, error) {
+	if s != "VALID" {
+		return nil, errors.New("bad consent")
+	}
+	return &mockConsent{purposes: []int{1, 2}, vendors: []int{32}}, nil
+}
+
+func containsInt(vv []int, n int) bool {
+	for _, v := range vv {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+var _ = Describe("Consent", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID:   "A",
+			Regs: &Regulations{GDPR: 1},
+			User: &User{Consent: "VALID"},
+		}
+	})
+
+	AfterEach(func() {
+		RegisterConsentDecoder(nil)
+	})
+
+	It("should parse gdpr and consent", func() {
+		var req *BidRequest
+		err := json.Unmarshal([]byte(`{"id":"A","regs":{"gdpr":1},"user":{"consent":"VALID"}}`), &req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Regs).To(Equal(&Regulations{GDPR: 1}))
+		Expect(req.User).To(Equal(&User{Consent: "VALID"}))
+	})
+
+	It("should require a decoder", func() {
+		_, err := subject.Consent()
+		Expect(err).To(Equal(ErrNoConsentDecoder))
+		Expect(subject.HasConsentForPurpose(32, 1)).To(BeFalse())
+	})
+
+	It("should check consent", func() {
+		RegisterConsentDecoder(mockConsentDecoder{})
+		Expect(subject.HasConsentForPurpose(32, 1)).To(BeTrue())
+		Expect(subject.HasConsentForPurpose(32, 3)).To(BeFalse())
+		Expect(subject.HasConsentForPurpose(33, 1)).To(BeFalse())
+
+		subject.User.Consent = "INVALID"
+		Expect(subject.HasConsentForPurpose(32, 1)).To(BeFalse())
+
+		subject.User = nil
+		_, err := subject.Consent()
+		Expect(err).To(Equal(ErrNoConsent))
+	})
+
+	It("should allow requests outside of GDPR scope", func() {
+		subject.Regs = nil
+		Expect(subject.HasConsentForPurpose(32, 3)).To(BeTrue())
+	})
+
+})
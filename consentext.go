@@ -0,0 +1,74 @@
+package openrtb
+
+// UserExt is the typed form of the commonly used user.ext fields.
+type UserExt struct {
+	Consent string `json:"consent,omitempty"` // TCF consent string, as passed before OpenRTB 2.6
+}
+
+// RegsExt is the typed form of the commonly used regs.ext fields.
+type RegsExt struct {
+	GDPR      *int   `json:"gdpr,omitempty"`       // GDPR flag, as passed before OpenRTB 2.6
+	USPrivacy string `json:"us_privacy,omitempty"` // U.S. Privacy String, as passed before OpenRTB 2.6
+	DSA       *DSA   `json:"dsa,omitempty"`        // Digital Services Act requirements
+}
+
+// DecodeExt decodes the typed fields of user.ext. Other keys are ignored.
+func (u *User) DecodeExt() (*UserExt, error) {
+	x := new(UserExt)
+	if _, err := u.Ext.getKey("consent", &x.Consent); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// EncodeExt stores the typed fields in user.ext, preserving other keys.
+// Empty fields are removed.
+func (u *User) EncodeExt(x *UserExt) error {
+	ext, err := u.Ext.setOrDeleteKey("consent", x.Consent, x.Consent == "")
+	if err != nil {
+		return err
+	}
+	u.Ext = ext
+	return nil
+}
+
+// DecodeExt decodes the typed fields of regs.ext. Other keys are ignored.
+func (r *Regulations) DecodeExt() (*RegsExt, error) {
+	x := new(RegsExt)
+	if _, err := r.Ext.getKey("gdpr", &x.GDPR); err != nil {
+		return nil, err
+	}
+	if _, err := r.Ext.getKey("us_privacy", &x.USPrivacy); err != nil {
+		return nil, err
+	}
+	if _, err := r.Ext.getKey("dsa", &x.DSA); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// EncodeExt stores the typed fields in regs.ext, preserving other keys.
+// Empty fields are removed.
+func (r *Regulations) EncodeExt(x *RegsExt) error {
+	ext, err := r.Ext.setOrDeleteKey("gdpr", x.GDPR, x.GDPR == nil)
+	if err == nil {
+		ext, err = ext.setOrDeleteKey("us_privacy", x.USPrivacy, x.USPrivacy == "")
+	}
+	if err == nil {
+		ext, err = ext.setOrDeleteKey("dsa", x.DSA, x.DSA == nil)
+	}
+	if err != nil {
+		return err
+	}
+	r.Ext = ext
+	return nil
+}
+
+// DSA decodes regs.ext.dsa. It returns nil if absent.
+func (r *Regulations) DSA() (*DSA, error) {
+	var dsa *DSA
+	if _, err := r.Ext.getKey("dsa", &dsa); err != nil {
+		return nil, err
+	}
+	return dsa, nil
+}
@@ -0,0 +1,52 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("User", func() {
+
+	It("should decode and encode ext", func() {
+		subject := &User{Ext: Extension(`{"consent":"C","eids":[]}`)}
+		Expect(subject.DecodeExt()).To(Equal(&UserExt{Consent: "C"}))
+
+		Expect(subject.EncodeExt(&UserExt{Consent: "D"})).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"consent":"D","eids":[]}`))
+		Expect(subject.EncodeExt(&UserExt{})).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"eids":[]}`))
+
+		subject.Ext = Extension(`bad`)
+		_, err := subject.DecodeExt()
+		Expect(err).To(HaveOccurred())
+	})
+
+})
+
+var _ = Describe("Regulations", func() {
+
+	It("should decode and encode ext", func() {
+		subject := &Regulations{Ext: Extension(`{"gdpr":1,"us_privacy":"1YNN","dsa":{"dsarequired":2,"pubrender":1,"transparency":[{"domain":"ssp.com","dsaparams":[1]}]},"x":1}`)}
+
+		gdpr := 1
+		x := &RegsExt{
+			GDPR:      &gdpr,
+			USPrivacy: "1YNN",
+			DSA: &DSA{
+				Required:     DSARequired,
+				PubRender:    DSAPubRenderCould,
+				Transparency: []DSATransparency{{Domain: "ssp.com", Params: []int{1}}},
+			},
+		}
+		Expect(subject.DecodeExt()).To(Equal(x))
+		Expect(subject.DSA()).To(Equal(x.DSA))
+
+		x.USPrivacy, x.DSA = "", &DSA{Required: DSARequiredOnlinePlatform}
+		Expect(subject.EncodeExt(x)).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"gdpr":1,"dsa":{"dsarequired":3},"x":1}`))
+		Expect(subject.EncodeExt(&RegsExt{})).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"x":1}`))
+		Expect((&Regulations{}).DSA()).To(BeNil())
+	})
+
+})
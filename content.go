@@ -1,5 +1,7 @@
 package openrtb
 
+import "strings"
+
 // This object describes the content in which the impression will appear, which may be syndicated or nonsyndicated
 // content. This object may be useful when syndicated content contains impressions and does
 // not necessarily match the publisher's general content. The exchange might or might not have
@@ -13,10 +15,11 @@ type Content struct {
 	Season             string    `json:"season,omitempty"`             // Content season.
 	Artist             string    `json:"artist,omitempty"`             // Artist credited with the content.
 	Genre              string    `json:"genre,omitempty"`              // Genre that best describes the content
-	Album              string    `json:"album,omiyempty"`              // Album to which the content belongs; typically for audio.
+	Album              string    `json:"album,omitempty"`              // Album to which the content belongs; typically for audio.
 	ISRC               string    `json:"isrc,omitempty"`               // International Standard Recording Code conforming to ISO - 3901.
 	Producer           *Producer `json:"producer,omitempty"`           // The producer.
 	URL                string    `json:"url,omitempty"`                // URL of the content, for buy-side contextualization or review.
+	CatTax             int       `json:"cattax,omitempty"`             // The taxonomy in use for cat, Default: 1
 	Cat                []string  `json:"cat,omitempty"`                // Array of IAB content categories that describe the content.
 	ProdQuality        int       `json:"prodq,omitempty"`              // Production quality per IAB's classification.
 	VideoQuality       int       `json:"videoquality,omitempty"`       // Video quality per IAB's classification.
@@ -25,11 +28,49 @@ type Content struct {
 	UserRating         string    `json:"userrating,omitempty"`         // User rating of the content (e.g., number of stars, likes, etc.).
 	QAGMediaRating     int       `json:"qagmediarating,omitempty"`     // Media rating per QAG guidelines.
 	Keywords           string    `json:"keywords,omitempty"`           // Comma separated list of keywords describing the content.
+	KwArray            []string  `json:"kwarray,omitempty"`            // Array of keywords describing the content. Only one of keywords or kwarray should be present.
 	LiveStream         int       `json:"livestream,omitempty"`         // 0 = not live, 1 = content is live (e.g., stream, live blog).
 	SourceRelationship int       `json:"sourcerelationship,omitempty"` // 0 = indirect, 1 = direct.
 	Len                int       `json:"len,omitempty"`                // Length of content in seconds; appropriate for video or audio.
 	Language           string    `json:"language,omitempty"`           // Content language using ISO-639-1-alpha-2.
+	LangB              string    `json:"langb,omitempty"`              // Content language using IETF BCP 47. Only one of language or langb should be present.
 	Embeddable         int       `json:"embeddable,omitempty"`         // Indicator of whether or not the content is embeddable (e.g., an embeddable video player), where 0 = no, 1 = yes.
 	Data               []Data    `json:"data,omitempty"`               // Additional content data.
+	Network            *Network  `json:"network,omitempty"`            // Network the content is on, e.g. a TV network.
+	Channel            *Channel  `json:"channel,omitempty"`            // Channel the content is on, e.g. a local channel.
 	Ext                Extension `json:"ext,omitempty"`
 }
+
+// KeywordList returns the keywords of the content, from kwarray or, if
+// absent, from the comma separated keywords.
+func (c *Content) KeywordList() []string {
+	if len(c.KwArray) != 0 {
+		return c.KwArray
+	}
+
+	var list []string
+	for _, kw := range strings.Split(c.Keywords, ",") {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			list = append(list, kw)
+		}
+	}
+	return list
+}
+
+// Network describes the entity which distributes content, typically the
+// parent of a number of channels, e.g. a TV network such as "ABC".
+type Network struct {
+	ID     string    `json:"id,omitempty"`     // A unique identifier assigned by the publisher.
+	Name   string    `json:"name,omitempty"`   // Network the content is on (e.g., a TV network like "ABC").
+	Domain string    `json:"domain,omitempty"` // The primary domain of the network (e.g. "abc.com" in the case of the network ABC).
+	Ext    Extension `json:"ext,omitempty"`
+}
+
+// Channel describes the channel content is on, which may be a local channel
+// of a network, e.g. "WABC-TV".
+type Channel struct {
+	ID     string    `json:"id,omitempty"`     // A unique identifier assigned by the publisher.
+	Name   string    `json:"name,omitempty"`   // Channel the content is on (e.g., a local channel like "WABC-TV").
+	Domain string    `json:"domain,omitempty"` // The primary domain of the channel (e.g. "abc7ny.com" in the case of the local channel WABC-TV).
+	Ext    Extension `json:"ext,omitempty"`
+}
@@ -22,7 +22,17 @@ var _ = Describe("Content", func() {
 				Name:   "yahoo",
 				Domain: "www.yahoo.com",
 			},
+			KwArray:    []string{"news", "live"},
+			LiveStream: 1,
+			Network:    &Network{ID: "N1", Name: "ABC", Domain: "abc.com"},
+			Channel:    &Channel{ID: "C1", Name: "WABC-TV", Domain: "abc7ny.com"},
 		}))
 	})
 
+	It("should list keywords", func() {
+		Expect(subject.KeywordList()).To(Equal([]string{"news", "live"}))
+		Expect((&Content{Keywords: "a, b,,c "}).KeywordList()).To(Equal([]string{"a", "b", "c"}))
+		Expect((&Content{}).KeywordList()).To(BeEmpty())
+	})
+
 })
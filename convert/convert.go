@@ -0,0 +1,107 @@
+/*
+Package convert adapts the objects of this package to and from those of
+other Go OpenRTB libraries, e.g. github.com/prebid/openrtb or earlier major
+versions of github.com/bsm/openrtb, so services can migrate handler by
+handler.
+
+Conversion is performed via the JSON wire format, which all libraries share,
+so no dependency on the other libraries is required:
+
+	var legacy openrtb2.BidRequest
+	...
+	req, err := convert.ToBidRequest(&legacy, nil)
+	...
+	var out openrtb2.BidResponse
+	err = convert.FromBidResponse(res, &out, convert.PrebidOptions)
+
+Extensions are carried over unchanged and Options.Version relocates fields
+which older OpenRTB versions carry in ext, e.g. regs.gdpr. All other fields
+which the target does not declare are dropped; they are not retained in ext.
+*/
+package convert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/bsm/openrtb"
+)
+
+// ErrNilTarget is returned when converting into a nil value.
+var ErrNilTarget = errors.New("convert: target must be a non-nil pointer")
+
+// Options configure conversions. A nil value uses the zero value defaults.
+type Options struct {
+	// Version downgrades objects converted into other libraries, see
+	// openrtb.EncodeOptions. The zero value retains all fields.
+	Version openrtb.Version
+	// Validate validates objects converted from other libraries.
+	Validate bool
+}
+
+// Options of the supported libraries
+var (
+	// PrebidOptions target github.com/prebid/openrtb, which implements 2.6.
+	PrebidOptions = &Options{Version: openrtb.Version26}
+	// BSMv3Options target github.com/bsm/openrtb/v3, the upstream major
+	// version this package descends from, which implements 2.5.
+	BSMv3Options = &Options{Version: openrtb.Version25}
+)
+
+// ToBidRequest converts a bid request of another library, e.g. a
+// *openrtb2.BidRequest.
+func ToBidRequest(src interface{}, opts *Options) (*openrtb.BidRequest, error) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return nil, err
+	}
+	return openrtb.UnmarshalBidRequestContext(context.Background(), data, opts.decodeOptions())
+}
+
+// FromBidRequest converts req into dst, a pointer to the bid request of
+// another library.
+func FromBidRequest(req *openrtb.BidRequest, dst interface{}, opts *Options) error {
+	if dst == nil {
+		return ErrNilTarget
+	}
+	data, err := openrtb.MarshalBidRequest(req, opts.encodeOptions())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// ToBidResponse converts a bid response of another library, e.g. a
+// *openrtb2.BidResponse.
+func ToBidResponse(src interface{}, opts *Options) (*openrtb.BidResponse, error) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return nil, err
+	}
+	return openrtb.UnmarshalBidResponseContext(context.Background(), data, opts.decodeOptions())
+}
+
+// FromBidResponse converts res into dst, a pointer to the bid response of
+// another library.
+func FromBidResponse(res *openrtb.BidResponse, dst interface{}, opts *Options) error {
+	if dst == nil {
+		return ErrNilTarget
+	}
+	data, err := openrtb.MarshalBidResponse(res, opts.encodeOptions())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func (o *Options) decodeOptions() *openrtb.DecodeOptions {
+	return &openrtb.DecodeOptions{Lenient: o == nil || !o.Validate}
+}
+
+func (o *Options) encodeOptions() *openrtb.EncodeOptions {
+	if o == nil {
+		return nil
+	}
+	return &openrtb.EncodeOptions{Version: o.Version}
+}
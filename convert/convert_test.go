@@ -0,0 +1,127 @@
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// legacyBidRequest mimics the bid request of another library.
+type legacyBidRequest struct {
+	ID  string `json:"id"`
+	Imp []struct {
+		ID    string `json:"id"`
+		Video *struct {
+			Placement int64 `json:"placement,omitempty"`
+			Plcmt     int64 `json:"plcmt,omitempty"`
+		} `json:"video,omitempty"`
+	} `json:"imp"`
+	Regs *struct {
+		GDPR *int8           `json:"gdpr,omitempty"`
+		Ext  json.RawMessage `json:"ext,omitempty"`
+	} `json:"regs,omitempty"`
+	Ext json.RawMessage `json:"ext,omitempty"`
+}
+
+// legacyBidResponse mimics the bid response of another library.
+type legacyBidResponse struct {
+	ID      string `json:"id"`
+	SeatBid []struct {
+		Seat string `json:"seat,omitempty"`
+		Bid  []struct {
+			ID    string  `json:"id"`
+			ImpID string  `json:"impid"`
+			Price float64 `json:"price"`
+		} `json:"bid"`
+	} `json:"seatbid,omitempty"`
+	Cur string `json:"cur,omitempty"`
+}
+
+var _ = Describe("BidRequest", func() {
+	var subject *openrtb.BidRequest
+
+	BeforeEach(func() {
+		subject = &openrtb.BidRequest{
+			ID:   "R",
+			Imp:  []openrtb.Impression{{ID: "I", Video: &openrtb.Video{Plcmt: openrtb.VideoPlcmtInstream}}},
+			Regs: &openrtb.Regulations{GDPR: 1},
+			Ext:  openrtb.Extension(`{"custom":true}`),
+		}
+	})
+
+	It("should convert from other libraries", func() {
+		var legacy legacyBidRequest
+		Expect(FromBidRequest(subject, &legacy, PrebidOptions)).To(Succeed())
+		Expect(legacy.ID).To(Equal("R"))
+		Expect(legacy.Imp[0].Video.Plcmt).To(Equal(int64(1)))
+		Expect(*legacy.Regs.GDPR).To(Equal(int8(1)))
+		Expect([]byte(legacy.Ext)).To(MatchJSON(`{"custom":true}`))
+
+		legacy = legacyBidRequest{}
+		Expect(FromBidRequest(subject, &legacy, BSMv3Options)).To(Succeed())
+		Expect(legacy.Imp[0].Video.Placement).To(Equal(int64(1)))
+		Expect(legacy.Regs.GDPR).To(BeNil())
+		Expect([]byte(legacy.Regs.Ext)).To(MatchJSON(`{"gdpr":1}`))
+
+		Expect(FromBidRequest(subject, nil, nil)).To(MatchError(ErrNilTarget))
+	})
+
+	It("should convert to other libraries", func() {
+		var legacy legacyBidRequest
+		Expect(FromBidRequest(subject, &legacy, nil)).To(Succeed())
+
+		req, err := ToBidRequest(&legacy, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("R"))
+		Expect(req.Imp[0].Video.Plcmt).To(Equal(openrtb.VideoPlcmtInstream))
+		Expect(req.Regs.GDPR).To(Equal(1))
+
+		legacy.ID = ""
+		_, err = ToBidRequest(&legacy, &Options{Validate: true})
+		Expect(err).To(MatchError(openrtb.ErrInvalidReqNoID))
+	})
+
+	It("should drop fields unknown to the target", func() {
+		subject.TMax = 120
+
+		var legacy legacyBidRequest
+		Expect(FromBidRequest(subject, &legacy, nil)).To(Succeed())
+		Expect([]byte(legacy.Ext)).To(MatchJSON(`{"custom":true}`))
+
+		req, err := ToBidRequest(&legacy, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.TMax).To(BeZero())
+		Expect([]byte(req.Ext)).To(MatchJSON(`{"custom":true}`))
+	})
+
+})
+
+var _ = Describe("BidResponse", func() {
+
+	It("should convert", func() {
+		subject := &openrtb.BidResponse{
+			ID:       "R",
+			Currency: "EUR",
+			SeatBid:  []openrtb.SeatBid{{Seat: "S", Bid: []openrtb.Bid{{ID: "B", ImpID: "I", Price: 1.5}}}},
+		}
+
+		var legacy legacyBidResponse
+		Expect(FromBidResponse(subject, &legacy, PrebidOptions)).To(Succeed())
+		Expect(legacy.Cur).To(Equal("EUR"))
+		Expect(legacy.SeatBid[0].Bid[0].Price).To(Equal(1.5))
+
+		res, err := ToBidResponse(&legacy, &Options{Validate: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid[0].Seat).To(Equal("S"))
+		Expect(res.SeatBid[0].Bid[0].ID).To(Equal("B"))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/convert")
+}
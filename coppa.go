@@ -0,0 +1,71 @@
+package openrtb
+
+// ScrubPolicy controls Scrub.
+type ScrubPolicy struct {
+	// Force scrubs requests even if regs.coppa is not set, e.g. for
+	// child-directed inventory known to the caller.
+	Force bool
+}
+
+// Scrub removes the personal data prohibited by COPPA from requests with
+// regs.coppa=1, or from all requests if policy.Force is set, in place: user
+// IDs, eids, yob and gender, the IFA and hashed device IDs, and precise geo,
+// i.e. lat, lon and zip of device and user geo. A nil policy scrubs COPPA
+// requests only. It returns the paths of all removed fields, for audit logs.
+func Scrub(req *BidRequest, policy *ScrubPolicy) []string {
+	force := policy != nil && policy.Force
+	if !force && (req.Regs == nil || req.Regs.Coppa != 1) {
+		return nil
+	}
+
+	var removed []string
+	if d := req.Device; d != nil {
+		removed = scrubString(removed, "device.ifa", &d.IFA)
+		removed = scrubString(removed, "device.didsha1", &d.IDSHA1)
+		removed = scrubString(removed, "device.didmd5", &d.IDMD5)
+		removed = scrubString(removed, "device.dpidsha1", &d.PIDSHA1)
+		removed = scrubString(removed, "device.dpidmd5", &d.PIDMD5)
+		removed = scrubString(removed, "device.macsha1", &d.MacSHA1)
+		removed = scrubString(removed, "device.macmd5", &d.MacMD5)
+		if d.Geo != nil {
+			removed = scrubGeo(removed, "device.geo", d.Geo)
+		}
+	}
+	if u := req.User; u != nil {
+		removed = scrubString(removed, "user.id", &u.ID)
+		removed = scrubString(removed, "user.buyerid", &u.BuyerID)
+		removed = scrubString(removed, "user.buyeruid", &u.BuyerUID)
+		removed = scrubString(removed, "user.gender", &u.Gender)
+		if u.YOB != 0 {
+			u.YOB = 0
+			removed = append(removed, "user.yob")
+		}
+		if u.Geo != nil {
+			removed = scrubGeo(removed, "user.geo", u.Geo)
+		}
+		if ext, err := u.Ext.deleteKey("eids"); err != nil {
+			u.Ext = nil // drop malformed extensions entirely
+			removed = append(removed, "user.ext")
+		} else if len(ext) != len(u.Ext) {
+			u.Ext = ext
+			removed = append(removed, "user.ext.eids")
+		}
+	}
+	return removed
+}
+
+func scrubString(removed []string, path string, s *string) []string {
+	if *s == "" {
+		return removed
+	}
+	*s = ""
+	return append(removed, path)
+}
+
+func scrubGeo(removed []string, path string, g *Geo) []string {
+	if g.Lat != 0 || g.Lon != 0 {
+		g.Lat, g.Lon = 0, 0
+		removed = append(removed, path+".lat", path+".lon")
+	}
+	return scrubString(removed, path+".zip", &g.Zip)
+}
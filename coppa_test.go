@@ -0,0 +1,70 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scrub", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID: "R",
+			Device: &Device{
+				IFA:    "AEBE52E7-03EE-455A-B3C4-E57283966239",
+				IDSHA1: "SHA1",
+				IP:     "123.145.167.189",
+				Geo:    &Geo{Lat: 51.5, Lon: -0.12, Country: "GBR", Zip: "SW1A"},
+			},
+			User: &User{
+				ID:       "U",
+				BuyerUID: "B",
+				YOB:      2012,
+				Gender:   "F",
+				Keywords: "k",
+				Geo:      &Geo{Country: "GBR"},
+				Ext:      Extension(`{"eids":[{"source":"x.com"}],"consent":"C"}`),
+			},
+			Regs: &Regulations{Coppa: 1},
+		}
+	})
+
+	It("should scrub COPPA requests", func() {
+		Expect(Scrub(subject, nil)).To(Equal([]string{
+			"device.ifa",
+			"device.didsha1",
+			"device.geo.lat",
+			"device.geo.lon",
+			"device.geo.zip",
+			"user.id",
+			"user.buyeruid",
+			"user.gender",
+			"user.yob",
+			"user.ext.eids",
+		}))
+		Expect(subject.Device).To(Equal(&Device{IP: "123.145.167.189", Geo: &Geo{Country: "GBR"}}))
+		Expect(subject.User.ID).To(BeEmpty())
+		Expect(subject.User.YOB).To(BeZero())
+		Expect(subject.User.Keywords).To(Equal("k"))
+		Expect(string(subject.User.Ext)).To(MatchJSON(`{"consent":"C"}`))
+
+		Expect(Scrub(subject, nil)).To(BeEmpty())
+	})
+
+	It("should skip other requests unless forced", func() {
+		subject.Regs = nil
+		Expect(Scrub(subject, nil)).To(BeNil())
+		Expect(subject.User.ID).To(Equal("U"))
+
+		Expect(Scrub(subject, &ScrubPolicy{Force: true})).To(ContainElement("user.id"))
+		Expect(subject.User.ID).To(BeEmpty())
+	})
+
+	It("should drop malformed user extensions", func() {
+		subject.User.Ext = Extension(`bad`)
+		Expect(Scrub(subject, nil)).To(ContainElement("user.ext"))
+		Expect(subject.User.Ext).To(BeNil())
+	})
+
+})
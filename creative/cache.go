@@ -0,0 +1,186 @@
+/*
+Package creative implements a bounded, per-creative cache of sanitized ad
+markup with pre-compiled macro templates. It backs template-based response
+writing, where the markup of a known creative is reused across auctions, and
+creative audit flows, which need to invalidate creatives explicitly, e.g.
+after a policy review.
+*/
+package creative
+
+import (
+	"container/list"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/bsm/openrtb"
+)
+
+// ErrNoCreativeID is returned when storing bids without a crid.
+var ErrNoCreativeID = errors.New("creative: bid is missing creative ID")
+
+// Sanitizer sanitizes ad markup before it is stored.
+type Sanitizer func(adm string) (string, error)
+
+// DefaultSanitizer trims surrounding white-space and removes control
+// characters, except for tabs and line breaks.
+func DefaultSanitizer(adm string) (string, error) {
+	adm = strings.TrimSpace(adm)
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, adm), nil
+}
+
+// Entry is a cached creative.
+type Entry struct {
+	CRID      string                 // Creative ID
+	Markup    string                 // Sanitized ad markup
+	Template  *openrtb.MacroTemplate // Pre-compiled markup template
+	AdvDomain []string               // Advertiser domains, for audits
+	Cat       []string               // Creative categories, for audits
+	Attr      []int                  // Creative attributes, for audits
+	StoredAt  time.Time
+}
+
+// Render expands the auction macros of the cached markup.
+func (e *Entry) Render(v *openrtb.MacroValues) string {
+	return e.Template.Expand(v)
+}
+
+// RenderEscaped expands the auction macros of the cached markup, escaping
+// the values according to esc.
+func (e *Entry) RenderEscaped(v *openrtb.MacroValues, esc openrtb.MacroEscaping) string {
+	return e.Template.ExpandEscaped(v, esc)
+}
+
+// Cache is an LRU-bounded creative cache, keyed by crid. It is safe for
+// concurrent use.
+type Cache struct {
+	capacity int
+	sanitize Sanitizer
+
+	ll    *list.List
+	items map[string]*list.Element
+	mu    sync.Mutex
+}
+
+// NewCache creates a cache holding up to capacity creatives. A nil sanitizer
+// defaults to DefaultSanitizer.
+func NewCache(capacity int, sanitize Sanitizer) *Cache {
+	if sanitize == nil {
+		sanitize = DefaultSanitizer
+	}
+	return &Cache{
+		capacity: capacity,
+		sanitize: sanitize,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+// Store sanitizes and caches the markup of a bid, replacing any existing
+// entry for the same crid. The least recently used entries are evicted when
+// the cache is full.
+func (c *Cache) Store(bid *openrtb.Bid) (*Entry, error) {
+	if bid.CreativeID == "" {
+		return nil, ErrNoCreativeID
+	}
+
+	markup, err := c.sanitize(bid.AdMarkup)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		CRID:      bid.CreativeID,
+		Markup:    markup,
+		Template:  openrtb.CompileMacros(markup),
+		AdvDomain: bid.AdvDomain,
+		Cat:       bid.Cat,
+		Attr:      bid.Attr,
+		StoredAt:  time.Now(),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[entry.CRID]; ok {
+		el.Value = entry
+		c.ll.MoveToFront(el)
+		return entry, nil
+	}
+
+	c.items[entry.CRID] = c.ll.PushFront(entry)
+	for c.capacity > 0 && c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+	}
+	return entry, nil
+}
+
+// Get returns a cached creative and marks it as recently used.
+func (c *Cache) Get(crid string) (*Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[crid]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*Entry), true
+}
+
+// Invalidate removes a creative. It returns true if it was cached.
+func (c *Cache) Invalidate(crid string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[crid]
+	if ok {
+		c.removeElement(el)
+	}
+	return ok
+}
+
+// InvalidateFunc removes all creatives matching fn, e.g. all creatives of
+// an advertiser domain, and returns the number of removed entries.
+func (c *Cache) InvalidateFunc(fn func(*Entry) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for el := c.ll.Front(); el != nil; {
+		next := el.Next()
+		if fn(el.Value.(*Entry)) {
+			c.removeElement(el)
+			n++
+		}
+		el = next
+	}
+	return n
+}
+
+// Purge removes all creatives.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	c.ll.Init()
+	c.items = make(map[string]*list.Element, c.capacity)
+	c.mu.Unlock()
+}
+
+// Len returns the number of cached creatives.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *Cache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*Entry).CRID)
+}
@@ -0,0 +1,98 @@
+package creative
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var subject *Cache
+
+	BeforeEach(func() {
+		subject = NewCache(2, nil)
+	})
+
+	It("should store and render creatives", func() {
+		entry, err := subject.Store(&openrtb.Bid{
+			CreativeID: "c1",
+			AdMarkup:   " \x00<img src=\"https://ads.com/i?p=${AUCTION_PRICE}\">\n",
+			AdvDomain:  []string{"ads.com"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entry.Markup).To(Equal(`<img src="https://ads.com/i?p=${AUCTION_PRICE}">`))
+		Expect(entry.StoredAt).NotTo(BeZero())
+
+		cached, ok := subject.Get("c1")
+		Expect(ok).To(BeTrue())
+		Expect(cached).To(BeIdenticalTo(entry))
+		Expect(cached.Render(&openrtb.MacroValues{Price: 1.5})).To(Equal(`<img src="https://ads.com/i?p=1.5">`))
+		Expect(cached.RenderEscaped(&openrtb.MacroValues{Price: 1.5}, openrtb.MacroEscapeHTML)).To(Equal(`<img src="https://ads.com/i?p=1.5">`))
+
+		_, err = subject.Store(&openrtb.Bid{AdMarkup: "x"})
+		Expect(err).To(Equal(ErrNoCreativeID))
+	})
+
+	It("should use custom sanitizers", func() {
+		failure := errors.New("unsafe markup")
+		subject = NewCache(2, func(adm string) (string, error) {
+			if adm == "<script/>" {
+				return "", failure
+			}
+			return adm, nil
+		})
+		_, err := subject.Store(&openrtb.Bid{CreativeID: "c1", AdMarkup: "<script/>"})
+		Expect(err).To(Equal(failure))
+		Expect(subject.Len()).To(Equal(0))
+	})
+
+	It("should evict least recently used creatives", func() {
+		for _, crid := range []string{"c1", "c2"} {
+			_, err := subject.Store(&openrtb.Bid{CreativeID: crid})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, ok := subject.Get("c1")
+		Expect(ok).To(BeTrue())
+
+		_, err := subject.Store(&openrtb.Bid{CreativeID: "c3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subject.Len()).To(Equal(2))
+
+		_, ok = subject.Get("c2")
+		Expect(ok).To(BeFalse())
+		_, ok = subject.Get("c1")
+		Expect(ok).To(BeTrue())
+
+		entry, err := subject.Store(&openrtb.Bid{CreativeID: "c3", AdMarkup: "v2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subject.Len()).To(Equal(2))
+		cached, _ := subject.Get("c3")
+		Expect(cached).To(BeIdenticalTo(entry))
+	})
+
+	It("should invalidate", func() {
+		_, _ = subject.Store(&openrtb.Bid{CreativeID: "c1", AdvDomain: []string{"bad.com"}})
+		_, _ = subject.Store(&openrtb.Bid{CreativeID: "c2", AdvDomain: []string{"good.com"}})
+
+		Expect(subject.Invalidate("c3")).To(BeFalse())
+		Expect(subject.InvalidateFunc(func(e *Entry) bool {
+			return len(e.AdvDomain) != 0 && e.AdvDomain[0] == "bad.com"
+		})).To(Equal(1))
+		Expect(subject.Len()).To(Equal(1))
+
+		Expect(subject.Invalidate("c2")).To(BeTrue())
+		Expect(subject.Len()).To(Equal(0))
+
+		_, _ = subject.Store(&openrtb.Bid{CreativeID: "c1"})
+		subject.Purge()
+		Expect(subject.Len()).To(Equal(0))
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/creative")
+}
@@ -0,0 +1,159 @@
+/*
+Package currency converts prices between ISO-4217 currencies, e.g. to enforce
+floors and rank bids when responses arrive in different currencies.
+
+	rates, err := currency.LoadRates(file)
+	if err != nil {
+		return err
+	}
+	conv := currency.NewConverter(rates)
+
+	// use as an openrtb.CurrencyConverter
+	floor, err := req.Floor(imp, nil, conv)
+
+	// drop bids below their imp or deal floors
+	violations, err := currency.EnforceFloors(req, res, rates)
+
+It also formats prices for display, e.g. in reports:
+
+	currency.LookupLocale("de-DE").FormatPrice(res, bid) // "1,25 €"
+*/
+package currency
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/bsm/openrtb"
+)
+
+// Errors
+var (
+	ErrUnknownCurrency = errors.New("currency: unknown currency")
+	ErrInvalidRates    = errors.New("currency: invalid rates")
+)
+
+// RateProvider provides exchange rates.
+type RateProvider interface {
+	// Rate returns the exchange rate from one currency to another, such
+	// that amount*rate converts an amount in from into to.
+	Rate(from, to string) (float64, error)
+}
+
+// Rates is a snapshot of exchange rates, relative to a base currency. It
+// implements RateProvider and computes cross rates via the base.
+type Rates struct {
+	Base  string             `json:"base"`           // Base currency, e.g. "EUR"
+	Date  string             `json:"date,omitempty"` // Reference date, e.g. "2026-10-14"
+	Rates map[string]float64 `json:"rates"`          // Units of currency per unit of base currency
+}
+
+// LoadRates decodes ECB-style rate JSON, e.g.:
+//
+//	{"base":"EUR","date":"2026-10-14","rates":{"USD":1.0834,"GBP":0.8541}}
+func LoadRates(r io.Reader) (*Rates, error) {
+	var rates Rates
+	if err := json.NewDecoder(r).Decode(&rates); err != nil {
+		return nil, err
+	}
+	if err := rates.norm(); err != nil {
+		return nil, err
+	}
+	return &rates, nil
+}
+
+func (r *Rates) norm() error {
+	r.Base = strings.ToUpper(r.Base)
+	if r.Base == "" {
+		return ErrInvalidRates
+	}
+
+	rates := make(map[string]float64, len(r.Rates)+1)
+	for cur, rate := range r.Rates {
+		if rate <= 0 {
+			return ErrInvalidRates
+		}
+		rates[strings.ToUpper(cur)] = rate
+	}
+	rates[r.Base] = 1
+	r.Rates = rates
+	return nil
+}
+
+// Rate implements RateProvider.
+func (r *Rates) Rate(from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+
+	src, ok := r.Rates[from]
+	if !ok {
+		return 0, ErrUnknownCurrency
+	}
+	dst, ok := r.Rates[to]
+	if !ok {
+		return 0, ErrUnknownCurrency
+	}
+	return dst / src, nil
+}
+
+// Converter converts amounts using a RateProvider. It implements the
+// openrtb.CurrencyConverter interface.
+type Converter struct {
+	provider RateProvider
+}
+
+// NewConverter creates a new converter.
+func NewConverter(p RateProvider) *Converter {
+	return &Converter{provider: p}
+}
+
+// Convert converts amount from one currency to another. Blank currencies
+// default to openrtb.DefaultCurrency.
+func (c *Converter) Convert(amount float64, from, to string) (float64, error) {
+	from, to = openrtb.NormalizeCurrency(from), openrtb.NormalizeCurrency(to)
+	if from == to || amount == 0 {
+		return amount, nil
+	}
+
+	rate, err := c.provider.Rate(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// ConvertBidPrice converts the price of a bid in place.
+func (c *Converter) ConvertBidPrice(bid *openrtb.Bid, from, to string) error {
+	price, err := c.Convert(bid.Price, from, to)
+	if err != nil {
+		return err
+	}
+	bid.Price = price
+	return nil
+}
+
+// ConvertResponse converts all bid prices of a response into the target
+// currency and updates the response currency accordingly.
+func (c *Converter) ConvertResponse(res *openrtb.BidResponse, to string) error {
+	from, to := openrtb.NormalizeCurrency(res.Currency), openrtb.NormalizeCurrency(to)
+	if from == to {
+		res.Currency = to
+		return nil
+	}
+
+	rate, err := c.provider.Rate(from, to)
+	if err != nil {
+		return err
+	}
+	for i := range res.SeatBid {
+		for j := range res.SeatBid[i].Bid {
+			res.SeatBid[i].Bid[j].Price *= rate
+		}
+	}
+	res.Currency = to
+	return nil
+}
@@ -0,0 +1,98 @@
+package currency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rates", func() {
+	var subject *Rates
+
+	BeforeEach(func() {
+		var err error
+		subject, err = LoadRates(strings.NewReader(`{"base":"eur","date":"2026-10-14","rates":{"USD":1.25,"gbp":0.8}}`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should load", func() {
+		Expect(subject.Base).To(Equal("EUR"))
+		Expect(subject.Date).To(Equal("2026-10-14"))
+		Expect(subject.Rates).To(Equal(map[string]float64{"EUR": 1, "USD": 1.25, "GBP": 0.8}))
+
+		_, err := LoadRates(strings.NewReader(`{"rates":{"USD":1.25}}`))
+		Expect(err).To(Equal(ErrInvalidRates))
+		_, err = LoadRates(strings.NewReader(`{"base":"EUR","rates":{"USD":0}}`))
+		Expect(err).To(Equal(ErrInvalidRates))
+		_, err = LoadRates(strings.NewReader(`[]`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should provide rates", func() {
+		Expect(subject.Rate("EUR", "USD")).To(Equal(1.25))
+		Expect(subject.Rate("usd", "eur")).To(Equal(0.8))
+		Expect(subject.Rate("USD", "GBP")).To(Equal(0.64))
+		Expect(subject.Rate("JPY", "JPY")).To(Equal(1.0))
+
+		_, err := subject.Rate("USD", "JPY")
+		Expect(err).To(Equal(ErrUnknownCurrency))
+		_, err = subject.Rate("JPY", "USD")
+		Expect(err).To(Equal(ErrUnknownCurrency))
+	})
+})
+
+var _ = Describe("Converter", func() {
+	var subject *Converter
+
+	BeforeEach(func() {
+		subject = NewConverter(&Rates{Base: "EUR", Rates: map[string]float64{"EUR": 1, "USD": 1.25}})
+	})
+
+	It("should convert", func() {
+		var _ openrtb.CurrencyConverter = subject
+
+		Expect(subject.Convert(2, "EUR", "USD")).To(Equal(2.5))
+		Expect(subject.Convert(2.5, "", "eur")).To(Equal(2.0))
+		Expect(subject.Convert(0, "JPY", "USD")).To(Equal(0.0))
+
+		_, err := subject.Convert(1, "JPY", "USD")
+		Expect(err).To(Equal(ErrUnknownCurrency))
+	})
+
+	It("should convert bid prices", func() {
+		bid := &openrtb.Bid{ID: "1", ImpID: "1", Price: 4}
+		Expect(subject.ConvertBidPrice(bid, "EUR", "USD")).To(Succeed())
+		Expect(bid.Price).To(Equal(5.0))
+		Expect(subject.ConvertBidPrice(bid, "JPY", "USD")).To(Equal(ErrUnknownCurrency))
+		Expect(bid.Price).To(Equal(5.0))
+	})
+
+	It("should convert responses", func() {
+		res := &openrtb.BidResponse{ID: "1", Currency: "eur", SeatBid: []openrtb.SeatBid{
+			{Bid: []openrtb.Bid{{ID: "1", Price: 2}, {ID: "2", Price: 4}}},
+		}}
+		Expect(subject.ConvertResponse(res, "USD")).To(Succeed())
+		Expect(res.Currency).To(Equal("USD"))
+		Expect(res.SeatBid[0].Bid[0].Price).To(Equal(2.5))
+		Expect(res.SeatBid[0].Bid[1].Price).To(Equal(5.0))
+
+		Expect(subject.ConvertResponse(res, "")).To(Succeed())
+		Expect(res.SeatBid[0].Bid[1].Price).To(Equal(5.0))
+		Expect(subject.ConvertResponse(res, "JPY")).To(Equal(ErrUnknownCurrency))
+	})
+
+	It("should be usable for floors", func() {
+		req := &openrtb.BidRequest{Cur: []string{"USD"}}
+		floor, err := req.Floor(&openrtb.Impression{BidFloor: 2, BidFloorCurrency: "EUR"}, nil, subject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(floor).To(Equal(openrtb.Floor{Price: 2.5, Currency: "USD"}))
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/currency")
+}
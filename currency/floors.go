@@ -0,0 +1,112 @@
+package currency
+
+import (
+	"strconv"
+
+	"github.com/bsm/openrtb"
+)
+
+// FloorViolation is a bid priced below the floor of its impression or deal.
+type FloorViolation struct {
+	Seat  string        // The seat of the bid
+	Bid   openrtb.Bid   // The bid
+	Floor openrtb.Floor // The applicable floor, in response currency
+	Deal  bool          // True if the floor of the targeted deal applied
+}
+
+// Rejection returns the violation as a rejection, with a loss reason code
+// suitable for loss notices.
+func (v *FloorViolation) Rejection() *openrtb.Rejection {
+	rej := &openrtb.Rejection{
+		Code:   openrtb.LossBelowAuctionFloor,
+		Field:  "imp.bidfloor",
+		Value:  strconv.FormatFloat(v.Bid.Price, 'f', -1, 64),
+		Reason: "bid below floor " + strconv.FormatFloat(v.Floor.Price, 'f', -1, 64) + " " + v.Floor.Currency,
+	}
+	if v.Deal {
+		rej.Code = openrtb.LossBelowDealFloor
+		rej.Field = "imp.pmp.deals.bidfloor"
+	}
+	return rej
+}
+
+// CheckFloors compares the price of each bid of res against the floor of
+// the impression it applies to or, if the bid targets a deal with a floor,
+// against the deal floor, see openrtb.BidRequest.Floor. Floors are converted
+// into the response currency using rates. It returns all bids priced below their
+// floor. Bids referencing unknown impressions are ignored.
+func CheckFloors(req *openrtb.BidRequest, res *openrtb.BidResponse, rates RateProvider) ([]FloorViolation, error) {
+	var violations []FloorViolation
+	if err := checkFloors(req, res, rates, func(v FloorViolation, _ *openrtb.Bid) {
+		violations = append(violations, v)
+	}); err != nil {
+		return nil, err
+	}
+	return violations, nil
+}
+
+// EnforceFloors is like CheckFloors, but also removes all bids priced below
+// their floor from res, as well as seatbids left without any bids.
+func EnforceFloors(req *openrtb.BidRequest, res *openrtb.BidResponse, rates RateProvider) ([]FloorViolation, error) {
+	var violations []FloorViolation
+	below := make(map[*openrtb.Bid]bool)
+	if err := checkFloors(req, res, rates, func(v FloorViolation, bid *openrtb.Bid) {
+		violations = append(violations, v)
+		below[bid] = true
+	}); err != nil {
+		return nil, err
+	}
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	seatbids := res.SeatBid[:0]
+	for _, sb := range res.SeatBid {
+		bids := sb.Bid[:0]
+		for j := range sb.Bid {
+			if !below[&sb.Bid[j]] {
+				bids = append(bids, sb.Bid[j])
+			}
+		}
+		if len(bids) != 0 {
+			sb.Bid = bids
+			seatbids = append(seatbids, sb)
+		}
+	}
+	res.SeatBid = seatbids
+	return violations, nil
+}
+
+func checkFloors(req *openrtb.BidRequest, res *openrtb.BidResponse, rates RateProvider, fn func(FloorViolation, *openrtb.Bid)) error {
+	conv := NewConverter(rates)
+	cur := openrtb.NormalizeCurrency(res.Currency)
+
+	for i := range res.SeatBid {
+		sb := &res.SeatBid[i]
+		for j := range sb.Bid {
+			bid := &sb.Bid[j]
+
+			imp := req.ImpByID(bid.ImpID)
+			if imp == nil {
+				continue
+			}
+
+			var deal *openrtb.Deal
+			if imp.Pmp != nil && bid.DealID != "" {
+				deal = imp.Pmp.DealByID(bid.DealID)
+			}
+
+			floor, err := req.Floor(imp, deal, conv)
+			if err == nil {
+				floor, err = floor.Convert(cur, conv)
+			}
+			if err != nil {
+				return err
+			}
+			if bid.Price < floor.Price {
+				fn(FloorViolation{Seat: sb.Seat, Bid: *bid, Floor: floor, Deal: deal != nil && deal.BidFloor != 0}, bid)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package currency
+
+import (
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnforceFloors", func() {
+	var req *openrtb.BidRequest
+	var res *openrtb.BidResponse
+	var rates *Rates
+
+	BeforeEach(func() {
+		rates = &Rates{Base: "EUR", Rates: map[string]float64{"EUR": 1, "USD": 2}}
+		req = &openrtb.BidRequest{ID: "R", Imp: []openrtb.Impression{
+			{ID: "1", BidFloor: 1, BidFloorCurrency: "EUR"},
+			{ID: "2", BidFloor: 1, Pmp: &openrtb.Pmp{Deals: []openrtb.Deal{{ID: "D1", BidFloor: 3}}}},
+		}}
+		res = &openrtb.BidResponse{ID: "R", Currency: "USD", SeatBid: []openrtb.SeatBid{
+			{Seat: "a", Bid: []openrtb.Bid{{ID: "a1", ImpID: "1", Price: 2}, {ID: "a2", ImpID: "1", Price: 1.5}}},
+			{Seat: "b", Bid: []openrtb.Bid{{ID: "b1", ImpID: "2", Price: 2, DealID: "D1"}}},
+			{Seat: "c", Bid: []openrtb.Bid{{ID: "c1", ImpID: "2", Price: 2}, {ID: "c2", ImpID: "X", Price: 0}}},
+		}}
+	})
+
+	It("should check floors", func() {
+		violations, err := CheckFloors(req, res, rates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(Equal([]FloorViolation{
+			{Seat: "a", Bid: res.SeatBid[0].Bid[1], Floor: openrtb.Floor{Price: 2, Currency: "USD"}},
+			{Seat: "b", Bid: res.SeatBid[1].Bid[0], Floor: openrtb.Floor{Price: 3, Currency: "USD"}, Deal: true},
+		}))
+		Expect(res.SeatBid).To(HaveLen(3))
+	})
+
+	It("should enforce floors", func() {
+		violations, err := EnforceFloors(req, res, rates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(HaveLen(2))
+		Expect(res.SeatBid).To(HaveLen(2))
+		Expect(res.SeatBid[0].Bid).To(Equal([]openrtb.Bid{{ID: "a1", ImpID: "1", Price: 2}}))
+		Expect(res.SeatBid[1].Seat).To(Equal("c"))
+		Expect(res.SeatBid[1].Bid).To(HaveLen(2))
+
+		violations, err = EnforceFloors(req, res, rates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(BeEmpty())
+		Expect(res.SeatBid).To(HaveLen(2))
+	})
+
+	It("should convert rejections", func() {
+		violations, err := CheckFloors(req, res, rates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations[0].Rejection()).To(Equal(&openrtb.Rejection{
+			Code:   openrtb.LossBelowAuctionFloor,
+			Field:  "imp.bidfloor",
+			Value:  "1.5",
+			Reason: "bid below floor 2 USD",
+		}))
+		Expect(violations[1].Rejection().Code).To(Equal(openrtb.LossBelowDealFloor))
+	})
+
+	It("should report imp floors for deals without a floor", func() {
+		req.Imp[1].Pmp.Deals = append(req.Imp[1].Pmp.Deals, openrtb.Deal{ID: "D2"})
+		res.SeatBid = []openrtb.SeatBid{{Seat: "b", Bid: []openrtb.Bid{{ID: "b2", ImpID: "2", Price: 0.5, DealID: "D2"}}}}
+
+		violations, err := CheckFloors(req, res, rates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Deal).To(BeFalse())
+		Expect(violations[0].Rejection().Code).To(Equal(openrtb.LossBelowAuctionFloor))
+		Expect(violations[0].Rejection().Field).To(Equal("imp.bidfloor"))
+	})
+
+	It("should fail on unknown currencies", func() {
+		res.Currency = "JPY"
+		_, err := EnforceFloors(req, res, rates)
+		Expect(err).To(Equal(ErrUnknownCurrency))
+		Expect(res.SeatBid).To(HaveLen(3))
+	})
+
+})
@@ -0,0 +1,142 @@
+package currency
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bsm/openrtb"
+)
+
+// Locale describes how monetary amounts are formatted for display.
+type Locale struct {
+	Decimal     string // Decimal separator
+	Group       string // Digit group separator
+	SymbolAfter bool   // Place the currency symbol after the amount
+	Space       bool   // Separate the currency symbol and the amount by a space
+}
+
+// Common locales
+var (
+	LocaleEnglish    = Locale{Decimal: ".", Group: ","}
+	LocaleGerman     = Locale{Decimal: ",", Group: ".", SymbolAfter: true, Space: true}
+	LocaleFrench     = Locale{Decimal: ",", Group: " ", SymbolAfter: true, Space: true}
+	LocaleDutch      = Locale{Decimal: ",", Group: ".", Space: true}
+	LocaleItalian    = Locale{Decimal: ",", Group: ".", SymbolAfter: true, Space: true}
+	LocalePortuguese = Locale{Decimal: ",", Group: " ", SymbolAfter: true, Space: true}
+	LocaleSpanish    = Locale{Decimal: ",", Group: ".", SymbolAfter: true, Space: true}
+)
+
+// Locales maps language tags to locales, see LookupLocale.
+var Locales = map[string]Locale{
+	"en":    LocaleEnglish,
+	"de":    LocaleGerman,
+	"es":    LocaleSpanish,
+	"fr":    LocaleFrench,
+	"it":    LocaleItalian,
+	"ja":    LocaleEnglish,
+	"nl":    LocaleDutch,
+	"pt":    LocalePortuguese,
+	"pt-br": {Decimal: ",", Group: ".", Space: true},
+	"ru":    {Decimal: ",", Group: " ", SymbolAfter: true, Space: true},
+	"zh":    LocaleEnglish,
+}
+
+// LookupLocale returns the locale for a language tag, e.g. "de-AT", falling
+// back to the language only and finally to LocaleEnglish.
+func LookupLocale(tag string) Locale {
+	tag = strings.ToLower(strings.Replace(strings.TrimSpace(tag), "_", "-", -1))
+	if loc, ok := Locales[tag]; ok {
+		return loc
+	}
+	if i := strings.IndexByte(tag, '-'); i > -1 {
+		if loc, ok := Locales[tag[:i]]; ok {
+			return loc
+		}
+	}
+	return LocaleEnglish
+}
+
+// Format formats amount in currency cur, e.g. "$1,234.50" or "1.234,50 €".
+// Amounts are rounded to the minor units of the currency.
+func (l Locale) Format(amount float64, cur string) string {
+	cur = openrtb.NormalizeCurrency(cur)
+
+	num := strconv.FormatFloat(math.Abs(amount), 'f', MinorUnits(cur), 64)
+	neg := amount < 0 && strings.Trim(num, "0.") != ""
+
+	whole, frac := num, ""
+	if i := strings.IndexByte(num, '.'); i > -1 {
+		whole, frac = num[:i], num[i+1:]
+	}
+
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(c)
+	}
+	if frac != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(frac)
+	}
+	num = b.String()
+
+	sym, sep := Symbol(cur), ""
+	if l.Space || sym == cur {
+		sep = " "
+	}
+	if l.SymbolAfter {
+		num = num + sep + sym
+	} else {
+		num = sym + sep + num
+	}
+	if neg {
+		num = "-" + num
+	}
+	return num
+}
+
+// FormatPrice formats the price of a bid in the currency of the response.
+func (l Locale) FormatPrice(res *openrtb.BidResponse, bid *openrtb.Bid) string {
+	return l.Format(bid.Price, res.Currency)
+}
+
+// FormatFloor formats the floor of an impression in its floor currency.
+func (l Locale) FormatFloor(imp *openrtb.Impression) string {
+	return l.Format(imp.BidFloor, imp.BidFloorCurrency)
+}
+
+// MinorUnits returns the number of decimal digits of the minor unit of
+// currency cur, e.g. 2 for USD and 0 for JPY.
+func MinorUnits(cur string) int {
+	if n, ok := minorUnits[openrtb.NormalizeCurrency(cur)]; ok {
+		return n
+	}
+	return 2
+}
+
+// Symbol returns the display symbol of currency cur, e.g. "$" for USD.
+// It returns the normalized code if no distinct symbol is known.
+func Symbol(cur string) string {
+	cur = openrtb.NormalizeCurrency(cur)
+	if sym, ok := symbols[cur]; ok {
+		return sym
+	}
+	return cur
+}
+
+// minorUnits lists the currencies with minor units other than 2.
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0, "RWF": 0,
+	"UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+var symbols = map[string]string{
+	"AUD": "A$", "BRL": "R$", "CAD": "CA$", "CNY": "CN¥", "EUR": "€", "GBP": "£", "HKD": "HK$", "ILS": "₪",
+	"INR": "₹", "JPY": "¥", "KRW": "₩", "MXN": "MX$", "NGN": "₦", "NZD": "NZ$", "PHP": "₱", "PLN": "zł",
+	"RUB": "₽", "THB": "฿", "TRY": "₺", "TWD": "NT$", "UAH": "₴", "USD": "$", "VND": "₫",
+}
@@ -0,0 +1,65 @@
+package currency
+
+import (
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Locale", func() {
+
+	It("should format amounts", func() {
+		Expect(LocaleEnglish.Format(1234.5, "USD")).To(Equal("$1,234.50"))
+		Expect(LocaleEnglish.Format(0.126, "")).To(Equal("$0.13"))
+		Expect(LocaleEnglish.Format(1234567, "jpy")).To(Equal("¥1,234,567"))
+		Expect(LocaleEnglish.Format(1.5, "KWD")).To(Equal("KWD 1.500"))
+		Expect(LocaleEnglish.Format(-12, "GBP")).To(Equal("-£12.00"))
+		Expect(LocaleEnglish.Format(-0.001, "GBP")).To(Equal("£0.00"))
+
+		Expect(LocaleGerman.Format(1234.5, "EUR")).To(Equal("1.234,50 €"))
+		Expect(LocaleFrench.Format(1234567.891, "EUR")).To(Equal("1 234 567,89 €"))
+		Expect(LocaleDutch.Format(99.9, "EUR")).To(Equal("€ 99,90"))
+	})
+
+	It("should format prices and floors", func() {
+		res := &openrtb.BidResponse{ID: "1", Currency: "EUR"}
+		bid := &openrtb.Bid{ID: "1", ImpID: "1", Price: 2.5}
+		Expect(LocaleGerman.FormatPrice(res, bid)).To(Equal("2,50 €"))
+
+		imp := &openrtb.Impression{ID: "1", BidFloor: 0.75}
+		Expect(LocaleEnglish.FormatFloor(imp)).To(Equal("$0.75"))
+	})
+
+	It("should lookup locales", func() {
+		Expect(LookupLocale("de-AT")).To(Equal(LocaleGerman))
+		Expect(LookupLocale("fr_CA")).To(Equal(LocaleFrench))
+		Expect(LookupLocale("NL")).To(Equal(LocaleDutch))
+		Expect(LookupLocale("xx")).To(Equal(LocaleEnglish))
+		Expect(LookupLocale("")).To(Equal(LocaleEnglish))
+
+		for tag, exp := range map[string]string{
+			"es":    "1.234,50 €",
+			"es-MX": "1.234,50 €",
+			"it":    "1.234,50 €",
+			"pt":    "1 234,50 €",
+			"pt-PT": "1 234,50 €",
+			"pt-BR": "€ 1.234,50",
+			"nl":    "€ 1.234,50",
+		} {
+			Expect(LookupLocale(tag).Format(1234.5, "EUR")).To(Equal(exp), "for %s", tag)
+		}
+		Expect(LookupLocale("es")).To(Equal(LocaleSpanish))
+		Expect(LookupLocale("it_IT")).To(Equal(LocaleItalian))
+		Expect(LookupLocale("pt")).To(Equal(LocalePortuguese))
+	})
+
+	It("should provide minor units and symbols", func() {
+		Expect(MinorUnits("USD")).To(Equal(2))
+		Expect(MinorUnits("jpy")).To(Equal(0))
+		Expect(MinorUnits("BHD")).To(Equal(3))
+		Expect(Symbol("eur")).To(Equal("€"))
+		Expect(Symbol("CHF")).To(Equal("CHF"))
+		Expect(Symbol("")).To(Equal("$"))
+	})
+
+})
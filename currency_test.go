@@ -0,0 +1,24 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Currency", func() {
+
+	It("should validate codes", func() {
+		Expect(IsValidCurrency("USD")).To(BeTrue())
+		Expect(IsValidCurrency("EUR")).To(BeTrue())
+		Expect(IsValidCurrency("usd")).To(BeFalse())
+		Expect(IsValidCurrency("")).To(BeFalse())
+		Expect(IsValidCurrency("XYZ")).To(BeFalse())
+	})
+
+	It("should normalize codes", func() {
+		Expect(NormalizeCurrency("")).To(Equal("USD"))
+		Expect(NormalizeCurrency(" eur ")).To(Equal("EUR"))
+		Expect(NormalizeCurrency("GBP")).To(Equal("GBP"))
+	})
+
+})
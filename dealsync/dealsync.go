@@ -0,0 +1,273 @@
+/*
+Package dealsync implements a deal-sync feed format, used to ingest
+programmatic deal terms from buyers or deal management platforms, and a
+catalog which populates the Pmp objects of outbound bid requests with
+matching, in-flight deals.
+
+	feed, err := dealsync.Parse(data)
+	if err != nil {
+		return err
+	}
+	catalog := dealsync.NewCatalog(feed)
+	catalog.Apply(req, time.Now())
+*/
+package dealsync
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// Validation errors
+var (
+	ErrInvalidDealNoID      = errors.New("dealsync: deal ID missing")
+	ErrInvalidDealDuplicate = errors.New("dealsync: duplicate deal ID")
+	ErrInvalidDealFlight    = errors.New("dealsync: deal flight ends before it starts")
+	ErrInvalidDealFloor     = errors.New("dealsync: deal floor is negative")
+)
+
+// Feed is a deal-sync feed.
+type Feed struct {
+	Version string            `json:"ver,omitempty"`     // Version of the feed format
+	Updated time.Time         `json:"updated,omitempty"` // Time of the last update
+	Deals   []Deal            `json:"deals"`
+	Ext     openrtb.Extension `json:"ext,omitempty"`
+}
+
+// Deal describes the terms of a single deal.
+type Deal struct {
+	ID               string            `json:"id"`                    // Unique deal ID
+	Name             string            `json:"name,omitempty"`        // Human readable name
+	BidFloor         float64           `json:"bidfloor,omitempty"`    // Floor or fixed price in CPM
+	BidFloorCurrency string            `json:"bidfloorcur,omitempty"` // Currency of bid floor
+	AuctionType      int               `json:"at,omitempty"`          // 1 = First Price, 2 = Second Price Plus, 3 = Fixed Price
+	WSeat            []string          `json:"wseat,omitempty"`       // Buyer seats allowed to bid on this deal
+	WAdvDomain       []string          `json:"wadomain,omitempty"`    // Advertiser domains allowed to bid on this deal
+	Private          int               `json:"private_auction,omitempty"`
+	Flight           Flight            `json:"flight"`
+	Targeting        Targeting         `json:"targeting"`
+	Ext              openrtb.Extension `json:"ext,omitempty"`
+}
+
+// Validate validates the deal terms.
+func (d *Deal) Validate() error {
+	if d.ID == "" {
+		return ErrInvalidDealNoID
+	} else if d.BidFloor < 0 {
+		return ErrInvalidDealFloor
+	} else if !d.Flight.Start.IsZero() && !d.Flight.End.IsZero() && d.Flight.End.Before(d.Flight.Start) {
+		return ErrInvalidDealFlight
+	} else if !d.Targeting.Rewarded.Valid() {
+		return openrtb.ErrInvalidFlag
+	}
+	return nil
+}
+
+// Deal converts the terms to an OpenRTB deal.
+func (d *Deal) Deal() openrtb.Deal {
+	return openrtb.Deal{
+		ID:               d.ID,
+		BidFloor:         d.BidFloor,
+		BidFloorCurrency: d.BidFloorCurrency,
+		WSeat:            d.WSeat,
+		WAdvDomain:       d.WAdvDomain,
+		AuctionType:      d.AuctionType,
+		Ext:              d.Ext,
+	}
+}
+
+// Flight is the time window in which a deal is active. Zero values are
+// unbounded.
+type Flight struct {
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+// Active returns true if the flight is active at t.
+func (f Flight) Active(t time.Time) bool {
+	if !f.Start.IsZero() && t.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && !t.Before(f.End) {
+		return false
+	}
+	return true
+}
+
+// Targeting hints restrict the requests and impressions a deal applies to.
+// Empty hints match everything.
+type Targeting struct {
+	Publishers  []string         `json:"publishers,omitempty"`  // Publisher IDs
+	Domains     []string         `json:"domains,omitempty"`     // Site domains
+	Bundles     []string         `json:"bundles,omitempty"`     // App bundles
+	TagIDs      []string         `json:"tagids,omitempty"`      // Impression tag IDs
+	Countries   []string         `json:"countries,omitempty"`   // ISO-3166-1-alpha-3 device geo countries
+	DeviceTypes []int            `json:"devicetypes,omitempty"` // Device types
+	MediaTypes  []string         `json:"mediatypes,omitempty"`  // One of "banner", "video", "audio", "native"
+	Sizes       []openrtb.Format `json:"sizes,omitempty"`       // Banner sizes
+	Rewarded    openrtb.Flag     `json:"rewarded,omitempty"`    // 1 = rewarded impressions only, 0 = non-rewarded only
+}
+
+// Matches returns true if the targeting hints match imp within req.
+func (t *Targeting) Matches(req *openrtb.BidRequest, imp *openrtb.Impression) bool {
+	if len(t.Publishers) != 0 && !containsString(t.Publishers, req.PublisherID()) {
+		return false
+	}
+	if len(t.Domains) != 0 && (req.Site == nil || !containsString(t.Domains, req.Site.Domain)) {
+		return false
+	}
+	if len(t.Bundles) != 0 && (req.App == nil || !containsString(t.Bundles, req.App.Bundle)) {
+		return false
+	}
+	if len(t.TagIDs) != 0 && !containsString(t.TagIDs, imp.TagID) {
+		return false
+	}
+	if len(t.Countries) != 0 && (req.Device == nil || req.Device.Geo == nil || !containsString(t.Countries, req.Device.Geo.Country)) {
+		return false
+	}
+	if len(t.DeviceTypes) != 0 && (req.Device == nil || !containsInt(t.DeviceTypes, req.Device.DeviceType)) {
+		return false
+	}
+	if len(t.MediaTypes) != 0 && !t.matchesMediaType(imp) {
+		return false
+	}
+	if len(t.Sizes) != 0 && !t.matchesSize(imp) {
+		return false
+	}
+	if t.Rewarded.IsSet() && t.Rewarded.IsTrue() != imp.IsRewarded() {
+		return false
+	}
+	return true
+}
+
+func (t *Targeting) matchesMediaType(imp *openrtb.Impression) bool {
+	for _, typ := range imp.MediaTypes() {
+		if containsString(t.MediaTypes, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Targeting) matchesSize(imp *openrtb.Impression) bool {
+	if imp.Banner == nil {
+		return false
+	}
+	for _, f := range t.Sizes {
+		if imp.Banner.AcceptsSize(f.W, f.H) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses and validates a deal-sync feed.
+func Parse(data []byte) (*Feed, error) {
+	var feed Feed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+	if err := feed.Validate(); err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// Validate validates all deals of the feed.
+func (f *Feed) Validate() error {
+	seen := make(map[string]struct{}, len(f.Deals))
+	for i := range f.Deals {
+		deal := &f.Deals[i]
+		if err := deal.Validate(); err != nil {
+			return err
+		}
+		if _, ok := seen[deal.ID]; ok {
+			return ErrInvalidDealDuplicate
+		}
+		seen[deal.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Catalog is a read-only set of synced deals.
+type Catalog struct {
+	deals []Deal
+}
+
+// NewCatalog creates a catalog from a feed.
+func NewCatalog(feed *Feed) *Catalog {
+	return &Catalog{deals: append([]Deal(nil), feed.Deals...)}
+}
+
+// Len returns the number of deals in the catalog.
+func (c *Catalog) Len() int { return len(c.deals) }
+
+// Match returns all deals active at t that target imp within req.
+func (c *Catalog) Match(req *openrtb.BidRequest, imp *openrtb.Impression, t time.Time) []*Deal {
+	var matches []*Deal
+	for i := range c.deals {
+		deal := &c.deals[i]
+		if deal.Flight.Active(t) && deal.Targeting.Matches(req, imp) {
+			matches = append(matches, deal)
+		}
+	}
+	return matches
+}
+
+// Apply populates the Pmp objects of all impressions of req with the
+// matching deals. Deals already present on an impression are preserved and
+// not duplicated. Impressions are marked as private auctions if any of the
+// applied deals requires it.
+func (c *Catalog) Apply(req *openrtb.BidRequest, t time.Time) {
+	for i := range req.Imp {
+		imp := &req.Imp[i]
+
+		matches := c.Match(req, imp, t)
+		if len(matches) == 0 {
+			continue
+		}
+
+		if imp.Pmp == nil {
+			imp.Pmp = new(openrtb.Pmp)
+		}
+		for _, deal := range matches {
+			if hasDeal(imp.Pmp, deal.ID) {
+				continue
+			}
+			imp.Pmp.Deals = append(imp.Pmp.Deals, deal.Deal())
+			if deal.Private == 1 {
+				imp.Pmp.Private = 1
+			}
+		}
+	}
+}
+
+func hasDeal(pmp *openrtb.Pmp, id string) bool {
+	for _, d := range pmp.Deals {
+		if d.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(ns []int, n int) bool {
+	for _, x := range ns {
+		if x == n {
+			return true
+		}
+	}
+	return false
+}
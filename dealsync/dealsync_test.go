@@ -0,0 +1,123 @@
+package dealsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feed", func() {
+	It("should parse", func() {
+		feed, err := Parse([]byte(`{
+			"ver": "1.0",
+			"deals": [{
+				"id": "deal-1",
+				"bidfloor": 2.5,
+				"bidfloorcur": "EUR",
+				"at": 3,
+				"wseat": ["seat-1"],
+				"flight": {"start": "2026-01-01T00:00:00Z", "end": "2026-02-01T00:00:00Z"},
+				"targeting": {"domains": ["example.com"], "mediatypes": ["banner"], "sizes": [{"w": 300, "h": 250}]}
+			}]
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(feed.Version).To(Equal("1.0"))
+		Expect(feed.Deals).To(HaveLen(1))
+		Expect(feed.Deals[0].Flight.Start).To(Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(feed.Deals[0].Targeting.Sizes).To(Equal([]openrtb.Format{{W: 300, H: 250}}))
+		Expect(feed.Deals[0].Deal()).To(Equal(openrtb.Deal{
+			ID:               "deal-1",
+			BidFloor:         2.5,
+			BidFloorCurrency: "EUR",
+			AuctionType:      3,
+			WSeat:            []string{"seat-1"},
+		}))
+	})
+
+	It("should validate", func() {
+		_, err := Parse([]byte(`{"deals":[{"id":""}]}`))
+		Expect(err).To(Equal(ErrInvalidDealNoID))
+		_, err = Parse([]byte(`{"deals":[{"id":"a"},{"id":"a"}]}`))
+		Expect(err).To(Equal(ErrInvalidDealDuplicate))
+		_, err = Parse([]byte(`{"deals":[{"id":"a","bidfloor":-1}]}`))
+		Expect(err).To(Equal(ErrInvalidDealFloor))
+		_, err = Parse([]byte(`{"deals":[{"id":"a","flight":{"start":"2026-02-01T00:00:00Z","end":"2026-01-01T00:00:00Z"}}]}`))
+		Expect(err).To(Equal(ErrInvalidDealFlight))
+		_, err = Parse([]byte(`{"deals":[{"id":"a","targeting":{"rewarded":2}}]}`))
+		Expect(err).To(Equal(openrtb.ErrInvalidFlag))
+	})
+})
+
+var _ = Describe("Flight", func() {
+	It("should check activity", func() {
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := start.Add(24 * time.Hour)
+
+		Expect(Flight{}.Active(start)).To(BeTrue())
+		Expect(Flight{Start: start, End: end}.Active(start)).To(BeTrue())
+		Expect(Flight{Start: start, End: end}.Active(start.Add(-time.Second))).To(BeFalse())
+		Expect(Flight{Start: start, End: end}.Active(end)).To(BeFalse())
+		Expect(Flight{End: end}.Active(start.Add(-time.Hour))).To(BeTrue())
+	})
+})
+
+var _ = Describe("Catalog", func() {
+	var subject *Catalog
+	var req *openrtb.BidRequest
+	var now = time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		subject = NewCatalog(&Feed{Deals: []Deal{
+			{ID: "all", BidFloor: 1},
+			{ID: "banner", Private: 1, Targeting: Targeting{Domains: []string{"example.com"}, Sizes: []openrtb.Format{{W: 300, H: 250}}}},
+			{ID: "video", Targeting: Targeting{MediaTypes: []string{"video"}, Countries: []string{"USA"}}},
+			{ID: "expired", Flight: Flight{End: now.Add(-time.Hour)}},
+			{ID: "app", Targeting: Targeting{Bundles: []string{"com.example"}}},
+			{ID: "rewarded", Targeting: Targeting{Rewarded: openrtb.FlagTrue}},
+		}})
+		req = &openrtb.BidRequest{
+			ID:     "r",
+			Site:   &openrtb.Site{Inventory: openrtb.Inventory{Domain: "example.com"}},
+			Device: &openrtb.Device{Geo: &openrtb.Geo{Country: "USA"}},
+			Imp: []openrtb.Impression{
+				{ID: "1", Banner: &openrtb.Banner{W: 300, H: 250}},
+				{ID: "2", Video: &openrtb.Video{}, Rwdd: 1, Pmp: &openrtb.Pmp{Deals: []openrtb.Deal{{ID: "all", BidFloor: 5}}}},
+			},
+		}
+	})
+
+	It("should match deals", func() {
+		Expect(subject.Len()).To(Equal(6))
+
+		var ids []string
+		for _, d := range subject.Match(req, &req.Imp[0], now) {
+			ids = append(ids, d.ID)
+		}
+		Expect(ids).To(Equal([]string{"all", "banner"}))
+
+		ids = nil
+		for _, d := range subject.Match(req, &req.Imp[0], now.Add(-72*time.Hour*365)) {
+			ids = append(ids, d.ID)
+		}
+		Expect(ids).To(Equal([]string{"all", "banner", "expired"}))
+	})
+
+	It("should apply deals to requests", func() {
+		subject.Apply(req, now)
+		Expect(req.Imp[0].Pmp).To(Equal(&openrtb.Pmp{
+			Private: 1,
+			Deals:   []openrtb.Deal{{ID: "all", BidFloor: 1}, {ID: "banner"}},
+		}))
+		Expect(req.Imp[1].Pmp).To(Equal(&openrtb.Pmp{
+			Deals: []openrtb.Deal{{ID: "all", BidFloor: 5}, {ID: "video"}, {ID: "rewarded"}},
+		}))
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/dealsync")
+}
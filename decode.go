@@ -0,0 +1,421 @@
+package openrtb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Decode errors
+var (
+	ErrDecodeTooLarge        = errors.New("openrtb: payload exceeds maximum size")
+	ErrDecodeTooManyImps     = errors.New("openrtb: request exceeds maximum number of impressions")
+	ErrDecodeTooManyBids     = errors.New("openrtb: response exceeds maximum number of bids")
+	ErrDecodeTooManySeatBids = errors.New("openrtb: response exceeds maximum number of seatbids")
+	ErrDecodeExtTooLarge     = errors.New("openrtb: ext exceeds maximum size")
+	ErrDecodeTooDeep         = errors.New("openrtb: payload exceeds maximum nesting depth")
+	ErrDecodeTrailing        = errors.New("openrtb: payload has trailing data")
+)
+
+// DecodeOptions control the decoding of bid requests and responses.
+// A nil value uses the zero value defaults.
+type DecodeOptions struct {
+	// Strict rejects payloads with unknown fields.
+	Strict bool
+	// Lenient skips validation of the decoded object and accepts invalid
+	// flag values, e.g. dnt=2, which are left unset.
+	Lenient bool
+	// MaxSize limits the size of the payload in bytes, 0 = unlimited.
+	MaxSize int64
+	// MaxImps limits the number of impressions in a request, 0 = unlimited.
+	MaxImps int
+	// MaxBids limits the total number of bids in a response, 0 = unlimited.
+	MaxBids int
+	// MaxSeatBids limits the number of seatbids in a response, 0 = unlimited.
+	MaxSeatBids int
+	// MaxExtSize limits the size of each ext object in bytes, 0 = unlimited.
+	MaxExtSize int
+	// MaxDepth limits the nesting depth of JSON objects and arrays,
+	// 0 = unlimited.
+	//
+	// All Max* limits are enforced while the payload is read, before it is
+	// decoded.
+	MaxDepth int
+	// DeviceEnricher optionally fills missing device attributes of decoded
+	// requests from their user agent, see Device.EnrichFromUA.
+	DeviceEnricher DeviceEnricher
+	// Metrics optionally records payload sizes, decode failures and
+	// impression counts, see Metric* constants.
+	Metrics Metrics
+	// Interner optionally interns low-cardinality values of decoded
+	// objects, e.g. currencies, countries, mimes, categories and seats, so
+	// repeated values share memory, see NewStringInterner.
+	Interner Interner
+	// RequestHook is optionally applied to decoded requests, after the
+	// device was enriched and before the request is validated.
+	RequestHook RequestHook
+
+	header http.Header // Inbound HTTP headers, see ReadBidRequest
+}
+
+// decodeChunkSize is the amount of data read between context checks.
+const decodeChunkSize = 32 * 1024
+
+// UnmarshalBidRequestContext decodes and validates a bid request. Decoding
+// stops early when ctx is cancelled.
+func UnmarshalBidRequestContext(ctx context.Context, data []byte, opts *DecodeOptions) (*BidRequest, error) {
+	return DecodeBidRequestContext(ctx, bytes.NewReader(data), opts)
+}
+
+// DecodeBidRequestContext decodes and validates a bid request from r.
+// Decoding stops early when ctx is cancelled.
+func DecodeBidRequestContext(ctx context.Context, r io.Reader, opts *DecodeOptions) (*BidRequest, error) {
+	if opts == nil {
+		opts = new(DecodeOptions)
+	}
+
+	var size int64
+	req, err := opts.decodeBidRequest(ctx, r, &size)
+	if m := opts.Metrics; m != nil {
+		observeDecode(m, MetricTypeRequest, size, err)
+		if req != nil {
+			m.Observe(MetricRequestImps, nil, float64(len(req.Imp)))
+		}
+	}
+	return req, err
+}
+
+func (o *DecodeOptions) decodeBidRequest(ctx context.Context, r io.Reader, size *int64) (*BidRequest, error) {
+	var req *BidRequest
+	if err := o.decode(ctx, r, &req, size, &payloadScanner{maxImps: o.MaxImps}); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		req = new(BidRequest)
+	}
+
+	if o.Lenient && req.Device != nil {
+		req.Device.resetInvalidFlags()
+	}
+	if o.Interner != nil {
+		internStrings(reflect.ValueOf(req), o.Interner)
+	}
+	if o.header != nil && req.Device != nil {
+		req.Device.ApplyClientHints(o.header)
+	}
+	if o.DeviceEnricher != nil && req.Device != nil {
+		if err := req.Device.EnrichFromUA(ctx, o.DeviceEnricher); err != nil {
+			return nil, err
+		}
+	}
+	if o.RequestHook != nil {
+		if err := o.RequestHook(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	if !o.Lenient {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// UnmarshalBidResponseContext decodes and validates a bid response. Decoding
+// stops early when ctx is cancelled.
+func UnmarshalBidResponseContext(ctx context.Context, data []byte, opts *DecodeOptions) (*BidResponse, error) {
+	return DecodeBidResponseContext(ctx, bytes.NewReader(data), opts)
+}
+
+// DecodeBidResponseContext decodes and validates a bid response from r.
+// Decoding stops early when ctx is cancelled.
+func DecodeBidResponseContext(ctx context.Context, r io.Reader, opts *DecodeOptions) (*BidResponse, error) {
+	if opts == nil {
+		opts = new(DecodeOptions)
+	}
+
+	var size int64
+	res, err := opts.decodeBidResponse(ctx, r, &size)
+	if m := opts.Metrics; m != nil {
+		observeDecode(m, MetricTypeResponse, size, err)
+	}
+	return res, err
+}
+
+func (o *DecodeOptions) decodeBidResponse(ctx context.Context, r io.Reader, size *int64) (*BidResponse, error) {
+	var res *BidResponse
+	if err := o.decode(ctx, r, &res, size, &payloadScanner{maxSeatBids: o.MaxSeatBids, maxBids: o.MaxBids}); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		res = new(BidResponse)
+	}
+
+	if o.Interner != nil {
+		internStrings(reflect.ValueOf(res), o.Interner)
+	}
+	if !o.Lenient {
+		if err := res.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// decode decodes the JSON payload of r into v and stores the number of bytes
+// read in size. The payload limits of s are enforced while reading.
+func (o *DecodeOptions) decode(ctx context.Context, r io.Reader, v interface{}, size *int64, s *payloadScanner) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.maxDepth, s.maxExt = o.MaxDepth, o.MaxExtSize
+	cr := &contextReader{ctx: ctx, r: r, limit: o.MaxSize}
+	if s.active() {
+		cr.scan = s
+	}
+	defer func() { *size = cr.read }()
+	dec := json.NewDecoder(cr)
+	if o.Strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		if cr.err != nil {
+			return cr.err
+		}
+		return err
+	}
+	if cr.err != nil {
+		return cr.err
+	}
+	if dec.More() {
+		if cr.err != nil {
+			return cr.err
+		}
+		return ErrDecodeTrailing
+	}
+	return ctx.Err()
+}
+
+// contextReader reads in chunks, checking for cancellation and the size
+// limit in between. It also scans the JSON data read for payload limits.
+type contextReader struct {
+	ctx   context.Context
+	r     io.Reader
+	limit int64 // 0 = unlimited
+	read  int64
+	err   error
+	scan  *payloadScanner // optional
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	if err := c.ctx.Err(); err != nil {
+		c.err = err
+		return 0, err
+	}
+
+	if len(p) > decodeChunkSize {
+		p = p[:decodeChunkSize]
+	}
+	n, err := c.r.Read(p)
+	c.read += int64(n)
+	if c.limit > 0 && c.read > c.limit {
+		c.err = ErrDecodeTooLarge
+		return n, c.err
+	}
+	if c.scan != nil {
+		if serr := c.scan.scan(p[:n]); serr != nil {
+			c.err = serr
+			return 0, c.err
+		}
+	}
+	return n, err
+}
+
+// payloadScanner tracks the structure of JSON data across chunks and
+// enforces the nesting depth, the number of impressions, seatbids and bids
+// as well as the size of ext values before the data is decoded. Keys are
+// matched like encoding/json does, i.e. unescaped and case-insensitively.
+type payloadScanner struct {
+	maxDepth, maxImps, maxSeatBids, maxBids, maxExt int // 0 = unlimited
+
+	stack  []scanFrame
+	bids   int
+	pos    int64
+	expect bool // a value is expected next
+	key    string
+
+	inString, escaped, isKey bool
+	keyBuf                   []byte
+
+	extStart int64 // start of the current ext value or -1
+	extDepth int   // stack size of the current ext container
+	extStr   bool  // the current ext value is a string
+}
+
+type scanFrame struct {
+	array bool
+	key   string // the key of the container in its parent object
+	n     int    // number of array elements
+}
+
+// maxScanKey is the maximum raw length of keys which are matched.
+const maxScanKey = 64
+
+func (s *payloadScanner) active() bool {
+	return s.maxDepth > 0 || s.maxImps > 0 || s.maxSeatBids > 0 || s.maxBids > 0 || s.maxExt > 0
+}
+
+func (s *payloadScanner) scan(p []byte) error {
+	if s.pos == 0 {
+		s.expect, s.extStart = true, -1
+	}
+
+	for _, b := range p {
+		pos := s.pos
+		s.pos++
+		if s.maxExt > 0 && s.extStart >= 0 && pos-s.extStart+1 > int64(s.maxExt) {
+			return ErrDecodeExtTooLarge
+		}
+
+		if s.inString {
+			switch {
+			case s.escaped:
+				s.escaped = false
+			case b == '\\':
+				s.escaped = true
+			case b == '"':
+				s.inString = false
+			}
+			if s.isKey {
+				if !s.inString {
+					s.key = decodeScanKey(s.keyBuf)
+				} else if len(s.keyBuf) < maxScanKey {
+					s.keyBuf = append(s.keyBuf, b)
+				}
+			} else if s.extStr && !s.inString {
+				s.extStr, s.extStart = false, -1
+			}
+			continue
+		}
+
+		switch b {
+		case ' ', '\t', '\r', '\n':
+		case '"':
+			s.inString, s.isKey = true, false
+			if top := s.top(); top != nil && !top.array && !s.expect {
+				s.isKey, s.keyBuf = true, s.keyBuf[:0]
+				continue
+			}
+			key, err := s.value()
+			if err != nil {
+				return err
+			}
+			if isScanKey(key, "ext") && s.extStart < 0 {
+				s.extStart, s.extStr = pos, true
+			}
+		case '{', '[':
+			key, err := s.value()
+			if err != nil {
+				return err
+			}
+			s.stack = append(s.stack, scanFrame{array: b == '[', key: key})
+			if s.maxDepth > 0 && len(s.stack) > s.maxDepth {
+				return ErrDecodeTooDeep
+			}
+			if isScanKey(key, "ext") && s.extStart < 0 {
+				s.extStart, s.extDepth = pos, len(s.stack)
+			}
+			s.expect = b == '['
+		case '}', ']':
+			if s.extStart >= 0 && !s.extStr && len(s.stack) == s.extDepth {
+				s.extStart = -1
+			}
+			if len(s.stack) != 0 {
+				s.stack = s.stack[:len(s.stack)-1]
+			}
+			s.expect = false
+		case ',':
+			if top := s.top(); top != nil && top.array {
+				s.expect = true
+			}
+		case ':':
+			s.expect = true
+		default:
+			if _, err := s.value(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *payloadScanner) top() *scanFrame {
+	if len(s.stack) == 0 {
+		return nil
+	}
+	return &s.stack[len(s.stack)-1]
+}
+
+// value registers the start of a value, if one is expected, and returns
+// its key if it is the value of an object member.
+func (s *payloadScanner) value() (string, error) {
+	if !s.expect {
+		return "", nil
+	}
+	s.expect = false
+
+	top := s.top()
+	if top == nil {
+		return "", nil
+	}
+	if !top.array {
+		key := s.key
+		s.key = ""
+		return key, nil
+	}
+
+	top.n++
+	switch {
+	case len(s.stack) == 2 && isScanKey(top.key, "imp"):
+		if s.maxImps > 0 && top.n > s.maxImps {
+			return "", ErrDecodeTooManyImps
+		}
+	case len(s.stack) == 2 && isScanKey(top.key, "seatbid"):
+		if s.maxSeatBids > 0 && top.n > s.maxSeatBids {
+			return "", ErrDecodeTooManySeatBids
+		}
+	case len(s.stack) == 4 && isScanKey(top.key, "bid") && isScanKey(s.stack[1].key, "seatbid"):
+		if s.bids++; s.maxBids > 0 && s.bids > s.maxBids {
+			return "", ErrDecodeTooManyBids
+		}
+	}
+	return "", nil
+}
+
+// decodeScanKey returns the unescaped key of raw key bytes.
+func decodeScanKey(raw []byte) string {
+	if bytes.IndexByte(raw, '\\') < 0 {
+		return string(raw)
+	}
+
+	var key string
+	if err := json.Unmarshal(append(append([]byte{'"'}, raw...), '"'), &key); err != nil {
+		return ""
+	}
+	return key
+}
+
+func isScanKey(key, name string) bool {
+	return len(key) != 0 && strings.EqualFold(key, name)
+}
+
+var extensionType = reflect.TypeOf(Extension(nil))
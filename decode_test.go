@@ -0,0 +1,199 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnmarshalBidRequestContext", func() {
+	var data []byte
+	var ctx = context.Background()
+
+	BeforeEach(func() {
+		var err error
+		data, err = ioutil.ReadFile("testdata/breq.banner.json")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should decode and validate", func() {
+		req, err := UnmarshalBidRequestContext(ctx, data, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("1234534625254"))
+
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"imp":[]}`), nil)
+		Expect(err).To(MatchError(ErrInvalidReqNoID))
+		req, err = UnmarshalBidRequestContext(ctx, []byte(`{"imp":[]}`), &DecodeOptions{Lenient: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req).To(Equal(&BidRequest{Imp: []Impression{}}))
+	})
+
+	It("should apply limits", func() {
+		_, err := UnmarshalBidRequestContext(ctx, data, &DecodeOptions{MaxSize: 100})
+		Expect(err).To(Equal(ErrDecodeTooLarge))
+		_, err = UnmarshalBidRequestContext(ctx, data, &DecodeOptions{MaxSize: int64(len(data))})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","imp":[{"id":"1"},{"id":"2"}]}`), &DecodeOptions{MaxImps: 1, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeTooManyImps))
+
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","imp":[{"id":"1","banner":{"ext":{"a":"long"}}}]}`), &DecodeOptions{MaxExtSize: 10, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeExtTooLarge))
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","imp":[{"id":"1","banner":{"ext":{"a":1}}}]}`), &DecodeOptions{MaxExtSize: 10, Lenient: true})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should apply limits before decoding", func() {
+		head := `{"id":"1","imp":[` + strings.Repeat(`{"id":"1"},`, 10)
+		r := io.MultiReader(strings.NewReader(head), &failReader{})
+		_, err := DecodeBidRequestContext(ctx, r, &DecodeOptions{MaxImps: 5, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeTooManyImps))
+
+		head = `{"id":"1","ext":{"a":"` + strings.Repeat("x", 100)
+		r = io.MultiReader(strings.NewReader(head), &failReader{})
+		_, err = DecodeBidRequestContext(ctx, r, &DecodeOptions{MaxExtSize: 50, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeExtTooLarge))
+	})
+
+	It("should match keys like encoding/json", func() {
+		for _, key := range []string{`IMP`, `\u0069mp`} {
+			_, err := UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","`+key+`":[{"id":"1"},{"id":"2"}]}`), &DecodeOptions{MaxImps: 1, Lenient: true})
+			Expect(err).To(Equal(ErrDecodeTooManyImps), key)
+		}
+
+		_, err := UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","site":{"imp":[1,2]},"imp":[{"id":"1"}]}`), &DecodeOptions{MaxImps: 1, Lenient: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","Ext":{"a":"long"}}`), &DecodeOptions{MaxExtSize: 10, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeExtTooLarge))
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","ext":"\"longer\""}`), &DecodeOptions{MaxExtSize: 10, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeExtTooLarge))
+	})
+
+	It("should limit nesting depth", func() {
+		_, err := UnmarshalBidRequestContext(ctx, data, &DecodeOptions{MaxDepth: 5})
+		Expect(err).NotTo(HaveOccurred())
+
+		deep := []byte(`{"id":"1","ext":` + strings.Repeat("[", 100) + strings.Repeat("]", 100) + `}`)
+		_, err = UnmarshalBidRequestContext(ctx, deep, &DecodeOptions{MaxDepth: 32, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeTooDeep))
+
+		quoted := []byte(`{"id":"1","ext":{"s":"` + strings.Repeat("[{", 50) + `\"]"}}`)
+		_, err = UnmarshalBidRequestContext(ctx, quoted, &DecodeOptions{MaxDepth: 2, Lenient: true})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should apply request hooks", func() {
+		hook := func(_ context.Context, req *BidRequest) error {
+			req.ID = "hooked"
+			return nil
+		}
+		req, err := UnmarshalBidRequestContext(ctx, data, &DecodeOptions{RequestHook: hook})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("hooked"))
+
+		hook = func(context.Context, *BidRequest) error { return errors.New("boom") }
+		_, err = UnmarshalBidRequestContext(ctx, data, &DecodeOptions{RequestHook: hook})
+		Expect(err).To(MatchError("boom"))
+	})
+
+	It("should support strict mode", func() {
+		_, err := UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","foo":1}`), &DecodeOptions{Lenient: true})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","foo":1}`), &DecodeOptions{Lenient: true, Strict: true})
+		Expect(err).To(MatchError(`json: unknown field "foo"`))
+	})
+
+	It("should enrich devices", func() {
+		req, err := UnmarshalBidRequestContext(ctx, data, &DecodeOptions{DeviceEnricher: mockUAParser{}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Device.Make).To(Equal("Apple"))
+		Expect(req.Device.DeviceType).To(Equal(DeviceTypePC))
+		Expect(req.Device.OS).To(Equal("OS X"))
+
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1","device":{"ua":"error"}}`), &DecodeOptions{Lenient: true, DeviceEnricher: mockUAParser{}})
+		Expect(err).To(MatchError("parse failed"))
+	})
+
+	It("should reject trailing data", func() {
+		_, err := UnmarshalBidRequestContext(ctx, []byte(`{"id":"1"} {}`), &DecodeOptions{Lenient: true})
+		Expect(err).To(Equal(ErrDecodeTrailing))
+		_, err = UnmarshalBidRequestContext(ctx, []byte(`{"id":"1"}  `), &DecodeOptions{Lenient: true})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should honour cancellation", func() {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := UnmarshalBidRequestContext(cctx, data, nil)
+		Expect(err).To(Equal(context.Canceled))
+
+		cctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+
+		large := `{"id":"1","imp":[{"id":"1","banner":{}}],"ext":"` + strings.Repeat("x", 4*decodeChunkSize) + `"}`
+		r := &cancelReader{r: strings.NewReader(large), cancel: cancel}
+		_, err = DecodeBidRequestContext(cctx, r, nil)
+		Expect(err).To(Equal(context.Canceled))
+		Expect(r.reads).To(BeNumerically("<", 4))
+	})
+
+})
+
+var _ = Describe("UnmarshalBidResponseContext", func() {
+	var data []byte
+	var ctx = context.Background()
+
+	BeforeEach(func() {
+		var err error
+		data, err = ioutil.ReadFile("testdata/bres.single.json")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should decode and validate", func() {
+		res, err := UnmarshalBidResponseContext(ctx, data, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid).To(HaveLen(1))
+
+		_, err = UnmarshalBidResponseContext(ctx, []byte(`{"id":"1"}`), nil)
+		Expect(err).To(MatchError(ErrInvalidRespNoSeatBids))
+	})
+
+	It("should apply limits", func() {
+		_, err := UnmarshalBidResponseContext(ctx, data, &DecodeOptions{MaxBids: 1})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = UnmarshalBidResponseContext(ctx, []byte(`{"id":"1","seatbid":[{"bid":[{"id":"1"}]},{"bid":[{"id":"2"}]}]}`), &DecodeOptions{MaxBids: 1, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeTooManyBids))
+
+		_, err = UnmarshalBidResponseContext(ctx, []byte(`{"id":"1","seatbid":[{"bid":[{"id":"1"}]},{"bid":[{"id":"2"}]}]}`), &DecodeOptions{MaxSeatBids: 1, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeTooManySeatBids))
+		_, err = UnmarshalBidResponseContext(ctx, []byte(`{"id":"1","seatbid":[{"bid":[{"id":"1","ext":{"a":"long"}}]}]}`), &DecodeOptions{MaxExtSize: 10, Lenient: true})
+		Expect(err).To(Equal(ErrDecodeExtTooLarge))
+	})
+
+})
+
+// cancelReader cancels the context after the first read.
+type cancelReader struct {
+	r      *strings.Reader
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (c *cancelReader) Read(p []byte) (int, error) {
+	c.reads++
+	c.cancel()
+	return c.r.Read(p)
+}
+
+// failReader fails all reads.
+type failReader struct{}
+
+func (*failReader) Read(p []byte) (int, error) {
+	return 0, errors.New("unexpected read")
+}
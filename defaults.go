@@ -0,0 +1,147 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+var supplyChainNodesType = reflect.TypeOf([]SupplyChainNode(nil))
+
+// ApplyDefaults fills the unset fields of req from a template, in place.
+// Zero values are considered unset. The template is copied and never
+// shared with req. The following rules apply:
+//
+//   - scalars are set only if zero;
+//   - objects are merged recursively, missing objects are copied;
+//   - arrays are copied only if empty, they are never merged;
+//   - ext objects are merged by top-level key, keys present in req win;
+//   - source.schain.nodes is the exception to the rule on arrays: nodes of
+//     the template are appended, unless a node with the same asi and sid is
+//     already present.
+func ApplyDefaults(req, defaults *BidRequest) error {
+	if defaults == nil {
+		return nil
+	}
+	return mergeDefaults(reflect.ValueOf(req).Elem(), reflect.ValueOf(defaults).Elem())
+}
+
+func mergeDefaults(dst, src reflect.Value) error {
+	switch dst.Type() {
+	case extensionType:
+		ext, err := mergeExt(dst.Interface().(Extension), src.Interface().(Extension))
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(ext))
+		return nil
+	case supplyChainNodesType:
+		dst.Set(reflect.ValueOf(mergeSupplyChainNodes(dst.Interface().([]SupplyChainNode), src.Interface().([]SupplyChainNode))))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			if dst.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := mergeDefaults(dst.Field(i), src.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		switch {
+		case src.IsNil():
+		case dst.IsNil():
+			dst.Set(deepCopy(src))
+		case dst.Elem().Kind() == reflect.Struct:
+			return mergeDefaults(dst.Elem(), src.Elem())
+		}
+	case reflect.Slice:
+		if dst.Len() == 0 && src.Len() != 0 {
+			dst.Set(deepCopy(src))
+		}
+	default:
+		if dst.IsZero() {
+			dst.Set(src)
+		}
+	}
+	return nil
+}
+
+// mergeExt adds the top-level keys of src missing in dst.
+func mergeExt(dst, src Extension) (Extension, error) {
+	if len(src) == 0 {
+		return dst, nil
+	}
+	if len(dst) == 0 {
+		return append(Extension(nil), src...), nil
+	}
+
+	var x, y map[string]json.RawMessage
+	if err := json.Unmarshal(dst, &x); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(src, &y); err != nil {
+		return nil, err
+	}
+
+	n := len(x)
+	for key, val := range y {
+		if _, ok := x[key]; !ok {
+			x[key] = val
+		}
+	}
+	if len(x) == n {
+		return dst, nil
+	}
+	return json.Marshal(x)
+}
+
+func mergeSupplyChainNodes(dst, src []SupplyChainNode) []SupplyChainNode {
+	for _, node := range src {
+		found := false
+		for _, existing := range dst {
+			if existing.ASI == node.ASI && existing.SID == node.SID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, deepCopy(reflect.ValueOf(node)).Interface().(SupplyChainNode))
+		}
+	}
+	return dst
+}
+
+// deepCopy returns a deep copy of v.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		dup := reflect.New(v.Type().Elem())
+		dup.Elem().Set(deepCopy(v.Elem()))
+		return dup
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		dup := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			dup.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return dup
+	case reflect.Struct:
+		dup := reflect.New(v.Type()).Elem()
+		dup.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				dup.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return dup
+	}
+	return v
+}
@@ -0,0 +1,75 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApplyDefaults", func() {
+	var subject, defaults *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID:   "R",
+			Imp:  []Impression{{ID: "1"}},
+			Site: &Site{Inventory: Inventory{ID: "S"}},
+			Bcat: []string{"IAB25"},
+			Source: &Source{SChain: &SupplyChain{Complete: 1, Ver: "1.0", Nodes: []SupplyChainNode{
+				{ASI: "exchange.com", SID: "1", HP: 1},
+			}}},
+			Ext: Extension(`{"a":1}`),
+		}
+		defaults = &BidRequest{
+			TMax: 120,
+			Cur:  []string{"USD"},
+			Bcat: []string{"IAB7", "IAB8"},
+			Site: &Site{Inventory: Inventory{ID: "X", Publisher: &Publisher{ID: "P"}}},
+			Source: &Source{SChain: &SupplyChain{Nodes: []SupplyChainNode{
+				{ASI: "exchange.com", SID: "1", HP: 1},
+				{ASI: "ssp.com", SID: "2", HP: 1},
+			}}},
+			Regs: &Regulations{Coppa: 1},
+			Ext:  Extension(`{"a":2,"b":3}`),
+		}
+	})
+
+	It("should fill unset fields", func() {
+		Expect(ApplyDefaults(subject, defaults)).To(Succeed())
+		Expect(subject.ID).To(Equal("R"))
+		Expect(subject.TMax).To(Equal(120))
+		Expect(subject.Cur).To(Equal([]string{"USD"}))
+		Expect(subject.Bcat).To(Equal([]string{"IAB25"}))
+		Expect(subject.Site.ID).To(Equal("S"))
+		Expect(subject.Site.Publisher).To(Equal(&Publisher{ID: "P"}))
+		Expect(subject.Regs).To(Equal(&Regulations{Coppa: 1}))
+		Expect(subject.Source.SChain.Nodes).To(Equal([]SupplyChainNode{
+			{ASI: "exchange.com", SID: "1", HP: 1},
+			{ASI: "ssp.com", SID: "2", HP: 1},
+		}))
+		Expect(string(subject.Ext)).To(MatchJSON(`{"a":1,"b":3}`))
+	})
+
+	It("should not share the template", func() {
+		Expect(ApplyDefaults(subject, defaults)).To(Succeed())
+
+		subject.Cur[0] = "EUR"
+		subject.Site.Publisher.ID = "Q"
+		subject.Regs.Coppa = 0
+		Expect(defaults.Cur).To(Equal([]string{"USD"}))
+		Expect(defaults.Site.Publisher.ID).To(Equal("P"))
+		Expect(defaults.Regs.Coppa).To(Equal(1))
+
+		other := &BidRequest{ID: "O"}
+		Expect(ApplyDefaults(other, defaults)).To(Succeed())
+		Expect(other.Source.SChain.Nodes).To(HaveLen(2))
+		Expect(other.Source.SChain).NotTo(BeIdenticalTo(defaults.Source.SChain))
+	})
+
+	It("should handle nil and invalid templates", func() {
+		Expect(ApplyDefaults(subject, nil)).To(Succeed())
+
+		subject.Ext = Extension(`bad`)
+		Expect(ApplyDefaults(subject, defaults)).NotTo(Succeed())
+	})
+
+})
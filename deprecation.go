@@ -0,0 +1,148 @@
+package openrtb
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Deprecation describes a deprecated field. Fields are marked deprecated
+// using a struct tag of the form `deprecated:"<since>[,<replacement>]"`.
+type Deprecation struct {
+	Path        string // JSON path of the field, e.g. "imp[0].banner.wmax"
+	Since       string // Spec version that deprecated the field
+	Replacement string // Replacement field, if any
+}
+
+// String returns a human readable lint warning
+func (d Deprecation) String() string {
+	s := d.Path + " is deprecated since OpenRTB " + d.Since
+	if d.Replacement != "" {
+		s += ", use " + d.Replacement + " instead"
+	}
+	return s
+}
+
+// DeprecatedFields lists all deprecated fields reachable from v, which
+// must be a struct or a pointer to a struct. Array elements are represented
+// as "[]" in paths.
+func DeprecatedFields(v interface{}) []Deprecation {
+	var res []Deprecation
+	walkDeprecatedType(reflect.TypeOf(v), "", make(map[reflect.Type]bool), &res)
+	return res
+}
+
+// LintDeprecated reports all deprecated fields that are populated in v.
+func LintDeprecated(v interface{}) []Deprecation {
+	var res []Deprecation
+	walkDeprecatedValue(reflect.ValueOf(v), "", &res)
+	return res
+}
+
+func walkDeprecatedType(t reflect.Type, prefix string, seen map[reflect.Type]bool, res *[]Deprecation) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		if t.Kind() == reflect.Slice {
+			if t.Elem().Kind() == reflect.Uint8 {
+				return
+			}
+			prefix += "[]"
+		}
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		path, ok := deprecationFieldPath(field, prefix)
+		if !ok {
+			continue
+		}
+		if d, ok := parseDeprecation(field, path); ok {
+			*res = append(*res, d)
+		}
+		walkDeprecatedType(field.Type, path, seen, res)
+	}
+}
+
+func walkDeprecatedValue(v reflect.Value, prefix string, res *[]Deprecation) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			walkDeprecatedValue(v.Index(i), prefix+"["+strconv.Itoa(i)+"]", res)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			path, ok := deprecationFieldPath(field, prefix)
+			if !ok {
+				continue
+			}
+
+			fv := v.Field(i)
+			if d, ok := parseDeprecation(field, path); ok && !isZeroValue(fv) {
+				*res = append(*res, d)
+			}
+			walkDeprecatedValue(fv, path, res)
+		}
+	}
+}
+
+// deprecationFieldPath returns the JSON path of a field. Embedded structs are
+// flattened, exactly like encoding/json does.
+func deprecationFieldPath(field reflect.StructField, prefix string) (string, bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false
+	}
+
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if field.Anonymous && name == "" {
+		return prefix, true
+	}
+	if name == "" {
+		name = field.Name
+	}
+	if prefix == "" {
+		return name, true
+	}
+	return prefix + "." + name, true
+}
+
+func parseDeprecation(field reflect.StructField, path string) (Deprecation, bool) {
+	tag, ok := field.Tag.Lookup("deprecated")
+	if !ok {
+		return Deprecation{}, false
+	}
+
+	parts := strings.SplitN(tag, ",", 2)
+	d := Deprecation{Path: path, Since: parts[0]}
+	if len(parts) > 1 {
+		d.Replacement = parts[1]
+	}
+	return d, true
+}
+
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
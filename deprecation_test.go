@@ -0,0 +1,41 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deprecation", func() {
+
+	It("should list deprecated fields", func() {
+		fields := DeprecatedFields(&BidRequest{})
+		Expect(fields).To(ContainElement(Deprecation{Path: "imp[].banner.wmax", Since: "2.5", Replacement: "format"}))
+		Expect(fields).To(ContainElement(Deprecation{Path: "imp[].video.placement", Since: "2.6", Replacement: "plcmt"}))
+		Expect(fields).To(ContainElement(Deprecation{Path: "device.didsha1", Since: "2.6"}))
+		Expect(fields).To(ContainElement(Deprecation{Path: "user.geo.regionFIPS104", Since: "2.6"}))
+		Expect(fields).To(ContainElement(Deprecation{Path: "pmp", Since: "2.2", Replacement: "imp.pmp"}))
+	})
+
+	It("should lint populated fields", func() {
+		req := &BidRequest{
+			ID: "A",
+			Imp: []Impression{
+				{ID: "1", Banner: &Banner{W: 300, H: 250}},
+				{ID: "2", Banner: &Banner{WMax: 300}, Video: &Video{Placement: VideoPlacementInStream}},
+			},
+			Device: &Device{IDSHA1: "abc", Geo: &Geo{Country: "USA"}},
+		}
+		Expect(LintDeprecated(req)).To(Equal([]Deprecation{
+			{Path: "imp[1].banner.wmax", Since: "2.5", Replacement: "format"},
+			{Path: "imp[1].video.placement", Since: "2.6", Replacement: "plcmt"},
+			{Path: "device.didsha1", Since: "2.6"},
+		}))
+		Expect(LintDeprecated(&BidRequest{ID: "A"})).To(BeEmpty())
+	})
+
+	It("should format warnings", func() {
+		Expect(Deprecation{Path: "device.didsha1", Since: "2.6"}.String()).To(Equal("device.didsha1 is deprecated since OpenRTB 2.6"))
+		Expect(Deprecation{Path: "pmp", Since: "2.2", Replacement: "imp.pmp"}.String()).To(Equal("pmp is deprecated since OpenRTB 2.2, use imp.pmp instead"))
+	})
+
+})
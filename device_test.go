@@ -1,6 +1,9 @@
 package openrtb
 
 import (
+	"context"
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -15,9 +18,20 @@ var _ = Describe("Device", func() {
 
 	It("should parse correctly", func() {
 		Expect(subject).To(Equal(&Device{
-			DNT: 0,
+			DNT: FlagFalse,
 			UA:  "Mozilla/5.0 (iPhone; CPU iPhone OS 6_1 like Mac OS X) AppleWebKit/534.46 (KHTML, like Gecko) Version/5.1 Mobile/9A334 Safari/7534.48.3",
 			IP:  "123.145.167.189",
+			SUA: &UserAgent{
+				Browsers: []BrandVersion{
+					{Brand: "Chromium", Version: []string{"91", "0", "4472", "124"}},
+					{Brand: "Google Chrome", Version: []string{"91", "0", "4472", "124"}},
+				},
+				Platform:     &BrandVersion{Brand: "macOS", Version: []string{"11", "4"}},
+				Mobile:       iptr(0),
+				Architecture: "x86",
+				Bitness:      "64",
+				Source:       UASourceHighEntropy,
+			},
 			Geo: &Geo{
 				Lat:     35.012345,
 				Lon:     -115.12345,
@@ -35,9 +49,58 @@ var _ = Describe("Device", func() {
 			Model:      "iPhone",
 			OS:         "iOS",
 			OSVer:      "6.1",
-			JS:         1,
+			JS:         FlagTrue,
 			ConnType:   3,
 			DeviceType: 1,
 		}))
 	})
 })
+
+var _ = Describe("Flag", func() {
+
+	It("should distinguish unset values", func() {
+		var d *Device
+		Expect(json.Unmarshal([]byte(`{"dnt":0,"lmt":1}`), &d)).To(Succeed())
+		Expect(d.DNT).To(Equal(FlagFalse))
+		Expect(d.DNT.IsSet()).To(BeTrue())
+		Expect(d.DNT.IsFalse()).To(BeTrue())
+		Expect(d.LMT.IsTrue()).To(BeTrue())
+		Expect(d.JS.IsSet()).To(BeFalse())
+		Expect(d.JS.Int(1)).To(Equal(1))
+		Expect(d.DNT.Int(1)).To(Equal(0))
+
+		Expect(json.Marshal(d)).To(MatchJSON(`{"dnt":0,"lmt":1}`))
+	})
+
+	It("should parse booleans and null", func() {
+		var f Flag
+		Expect(json.Unmarshal([]byte(`true`), &f)).To(Succeed())
+		Expect(f).To(Equal(FlagTrue))
+		Expect(json.Unmarshal([]byte(`null`), &f)).To(Succeed())
+		Expect(f).To(Equal(FlagUnset))
+		Expect(json.Unmarshal([]byte(`2`), &f)).To(Succeed())
+		Expect(f.IsSet()).To(BeFalse())
+		Expect(f.Valid()).To(BeFalse())
+		Expect(json.Marshal(f)).To(Equal([]byte(`null`)))
+	})
+
+	It("should be lenient if requested", func() {
+		data := []byte(`{"id":"1","imp":[{"id":"1","banner":{}}],"device":{"dnt":2,"lmt":1}}`)
+		_, err := UnmarshalBidRequestContext(context.Background(), data, nil)
+		Expect(err).To(MatchError(ErrInvalidFlag))
+		Expect(err).To(MatchError(`openrtb: invalid flag value (device.dnt)`))
+
+		req, err := UnmarshalBidRequestContext(context.Background(), data, &DecodeOptions{Lenient: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Device.DNT).To(Equal(FlagUnset))
+		Expect(req.Device.LMT).To(Equal(FlagTrue))
+	})
+
+	It("should have helpers", func() {
+		Expect(NewFlag(true)).To(Equal(FlagTrue))
+		Expect(NewFlag(false)).To(Equal(FlagFalse))
+		Expect(FlagFalse.String()).To(Equal("0"))
+		Expect(FlagUnset.String()).To(Equal("unset"))
+	})
+
+})
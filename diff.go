@@ -0,0 +1,154 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Change kinds
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// Change describes a difference between two objects.
+type Change struct {
+	Path string      // JSON path of the field, e.g. "imp[0].banner.w" or "user.ext.eids[1]"
+	Kind string      // See Change* constants
+	From interface{} // Previous JSON value, nil when added
+	To   interface{} // New JSON value, nil when removed
+}
+
+// String returns a human readable description of the change.
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return c.Path + " added: " + jsonString(c.To)
+	case ChangeRemoved:
+		return c.Path + " removed: " + jsonString(c.From)
+	}
+	return c.Path + " modified: " + jsonString(c.From) + " -> " + jsonString(c.To)
+}
+
+// Diff compares the JSON encodings of two bid requests, including their
+// ext payloads, and returns the changes from a to b, ordered by path. Arrays
+// are compared element by element.
+func Diff(a, b *BidRequest) ([]Change, error) {
+	return diffValues(a, b)
+}
+
+// DiffResponses is like Diff, but compares two bid responses.
+func DiffResponses(a, b *BidResponse) ([]Change, error) {
+	return diffValues(a, b)
+}
+
+// DiffJSON is like Diff, but compares two JSON documents.
+func DiffJSON(a, b []byte) ([]Change, error) {
+	x, err := decodeGenericJSON(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeGenericJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	diffGeneric("", x, y, &changes)
+	return changes, nil
+}
+
+func diffValues(a, b interface{}) ([]Change, error) {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	return DiffJSON(x, y)
+}
+
+func decodeGenericJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, ErrDecodeTrailing
+	}
+	return v, nil
+}
+
+func diffGeneric(path string, a, b interface{}, changes *[]Change) {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		if y, ok := b.(map[string]interface{}); ok {
+			diffObjects(path, x, y, changes)
+			return
+		}
+	case []interface{}:
+		if y, ok := b.([]interface{}); ok {
+			diffArrays(path, x, y, changes)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, Change{Path: path, Kind: ChangeModified, From: a, To: b})
+	}
+}
+
+func diffObjects(path string, a, b map[string]interface{}, changes *[]Change) {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		x, inA := a[key]
+		y, inB := b[key]
+		switch child := joinPath(path, key); {
+		case !inA:
+			*changes = append(*changes, Change{Path: child, Kind: ChangeAdded, To: y})
+		case !inB:
+			*changes = append(*changes, Change{Path: child, Kind: ChangeRemoved, From: x})
+		default:
+			diffGeneric(child, x, y, changes)
+		}
+	}
+}
+
+func diffArrays(path string, a, b []interface{}, changes *[]Change) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch child := indexPath(path, i); {
+		case i >= len(a):
+			*changes = append(*changes, Change{Path: child, Kind: ChangeAdded, To: b[i]})
+		case i >= len(b):
+			*changes = append(*changes, Change{Path: child, Kind: ChangeRemoved, From: a[i]})
+		default:
+			diffGeneric(child, a[i], b[i], changes)
+		}
+	}
+}
+
+func jsonString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "?"
+	}
+	return string(data)
+}
@@ -0,0 +1,89 @@
+package openrtb
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var a, b *BidRequest
+
+	BeforeEach(func() {
+		a = &BidRequest{
+			ID:   "R",
+			Imp:  []Impression{{ID: "1", Banner: &Banner{W: 300, H: 250}, BidFloor: 1}},
+			User: &User{ID: "U", Ext: Extension(`{"eids":[{"source":"a.com"}],"x":1}`)},
+			Cur:  []string{"USD"},
+		}
+		b = &BidRequest{
+			ID:     "R",
+			Imp:    []Impression{{ID: "1", Banner: &Banner{W: 300, H: 250}, BidFloor: 1.5}, {ID: "2"}},
+			User:   &User{Ext: Extension(`{"eids":[{"source":"b.com"}],"x":1}`)},
+			Device: &Device{IP: "1.2.3.0"},
+		}
+	})
+
+	It("should report changes", func() {
+		changes, err := Diff(a, b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal([]Change{
+			{Path: "cur", Kind: ChangeRemoved, From: []interface{}{"USD"}},
+			{Path: "device", Kind: ChangeAdded, To: map[string]interface{}{"ip": "1.2.3.0"}},
+			{Path: "imp[0].bidfloor", Kind: ChangeModified, From: json.Number("1"), To: json.Number("1.5")},
+			{Path: "imp[1]", Kind: ChangeAdded, To: map[string]interface{}{"id": "2"}},
+			{Path: "user.ext.eids[0].source", Kind: ChangeModified, From: "a.com", To: "b.com"},
+			{Path: "user.id", Kind: ChangeRemoved, From: "U"},
+		}))
+	})
+
+	It("should report no changes for equal requests", func() {
+		Expect(Diff(a, a)).To(BeEmpty())
+	})
+
+	It("should format changes", func() {
+		changes, err := Diff(a, b)
+		Expect(err).NotTo(HaveOccurred())
+
+		var strs []string
+		for _, c := range changes {
+			strs = append(strs, c.String())
+		}
+		Expect(strs).To(Equal([]string{
+			`cur removed: ["USD"]`,
+			`device added: {"ip":"1.2.3.0"}`,
+			`imp[0].bidfloor modified: 1 -> 1.5`,
+			`imp[1] added: {"id":"2"}`,
+			`user.ext.eids[0].source modified: "a.com" -> "b.com"`,
+			`user.id removed: "U"`,
+		}))
+	})
+
+})
+
+var _ = Describe("DiffResponses", func() {
+
+	It("should report changes", func() {
+		a := &BidResponse{ID: "R", SeatBid: []SeatBid{{Bid: []Bid{{ID: "B", ImpID: "1", Price: 1}}}}}
+		b := &BidResponse{ID: "R", SeatBid: []SeatBid{{Bid: []Bid{{ID: "B", ImpID: "1", Price: 2}}}}}
+		Expect(DiffResponses(a, b)).To(Equal([]Change{
+			{Path: "seatbid[0].bid[0].price", Kind: ChangeModified, From: json.Number("1"), To: json.Number("2")},
+		}))
+	})
+
+})
+
+var _ = Describe("DiffJSON", func() {
+
+	It("should compare documents", func() {
+		Expect(DiffJSON([]byte(`{"a":[1,2],"b":{"c":true}}`), []byte(`{"a":[1],"b":"x"}`))).To(Equal([]Change{
+			{Path: "a[1]", Kind: ChangeRemoved, From: json.Number("2")},
+			{Path: "b", Kind: ChangeModified, From: map[string]interface{}{"c": true}, To: "x"},
+		}))
+
+		_, err := DiffJSON([]byte(`{}`), []byte(`{`))
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,67 @@
+package openrtb
+
+// DSA required values of regs.ext.dsa.dsarequired
+const (
+	DSANotRequired            = 0 // Not required
+	DSASupported              = 1 // Supported, bid responses with or without DSA object will be accepted
+	DSARequired               = 2 // Required, bid responses without DSA object will not be accepted
+	DSARequiredOnlinePlatform = 3 // Required, the publisher is an online platform
+)
+
+// DSA publisher render values of regs.ext.dsa.pubrender
+const (
+	DSAPubRenderNo    = 0 // Publisher can't render
+	DSAPubRenderCould = 1 // Publisher could render depending on adrender
+	DSAPubRenderWill  = 2 // Publisher will render
+)
+
+// DSA data to publisher values of regs.ext.dsa.datatopub
+const (
+	DSADataToPubNo       = 0 // Do not send transparency data
+	DSADataToPubOptional = 1 // Optional to send transparency data
+	DSADataToPubRequired = 2 // Send transparency data
+)
+
+// DSA is the regs.ext.dsa object, signalling the requirements of the EU
+// Digital Services Act.
+type DSA struct {
+	Required     int               `json:"dsarequired,omitempty"`  // See DSA* required constants
+	PubRender    int               `json:"pubrender,omitempty"`    // See DSAPubRender* constants
+	DataToPub    int               `json:"datatopub,omitempty"`    // See DSADataToPub* constants
+	Transparency []DSATransparency `json:"transparency,omitempty"` // Entities which applied user parameters
+}
+
+// DSAResponse is the bid.ext.dsa object, containing the DSA transparency
+// information of a bid.
+type DSAResponse struct {
+	Behalf       string            `json:"behalf,omitempty"`       // Advertiser on whose behalf the ad is displayed
+	Paid         string            `json:"paid,omitempty"`         // Advertiser or agency who paid for the ad
+	Transparency []DSATransparency `json:"transparency,omitempty"` // Entities which applied user parameters
+	AdRender     *int              `json:"adrender,omitempty"`     // 1 if the buyer will render the DSA transparency information
+}
+
+// DSATransparency identifies an entity which applied user parameters to
+// target an ad.
+type DSATransparency struct {
+	Domain string `json:"domain,omitempty"`    // Domain of the entity
+	Params []int  `json:"dsaparams,omitempty"` // User parameters used, 1 = profiling, 2 = basic advertising, 3 = precise geo
+}
+
+// DSA decodes bid.ext.dsa. It returns nil if absent.
+func (bid *Bid) DSA() (*DSAResponse, error) {
+	var dsa *DSAResponse
+	if _, err := bid.Ext.getKey("dsa", &dsa); err != nil {
+		return nil, err
+	}
+	return dsa, nil
+}
+
+// SetDSA stores dsa as bid.ext.dsa. A nil value removes it.
+func (bid *Bid) SetDSA(dsa *DSAResponse) error {
+	ext, err := bid.Ext.setOrDeleteKey("dsa", dsa, dsa == nil)
+	if err != nil {
+		return err
+	}
+	bid.Ext = ext
+	return nil
+}
@@ -0,0 +1,33 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bid", func() {
+
+	It("should read and write DSA transparency", func() {
+		subject := &Bid{ID: "B", Ext: Extension(`{"x":1}`)}
+		Expect(subject.DSA()).To(BeNil())
+
+		adrender := 1
+		dsa := &DSAResponse{
+			Behalf:       "Advertiser",
+			Paid:         "Agency",
+			Transparency: []DSATransparency{{Domain: "dsp.com", Params: []int{1, 2}}},
+			AdRender:     &adrender,
+		}
+		Expect(subject.SetDSA(dsa)).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"x":1,"dsa":{"behalf":"Advertiser","paid":"Agency","transparency":[{"domain":"dsp.com","dsaparams":[1,2]}],"adrender":1}}`))
+		Expect(subject.DSA()).To(Equal(dsa))
+
+		Expect(subject.SetDSA(nil)).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"x":1}`))
+
+		subject.Ext = Extension(`bad`)
+		_, err := subject.DSA()
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,127 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// EncodeOptions control the encoding of bid requests and responses.
+// A nil value uses the zero value defaults.
+type EncodeOptions struct {
+	// Canonical produces deterministic output, suitable for hashing: object
+	// keys are sorted, including those inside of Extension payloads, and
+	// numbers are formatted consistently.
+	Canonical bool
+	// Version downgrades the output for partners on older spec versions:
+	// fields introduced after Version are omitted or, if they were
+	// previously passed as extensions, moved to ext. Some fields are
+	// remapped onto their predecessors, e.g. video.plcmt onto
+	// video.placement. The zero value and LatestVersion retain all fields.
+	Version Version
+}
+
+// MarshalBidRequest encodes a bid request.
+func MarshalBidRequest(req *BidRequest, opts *EncodeOptions) ([]byte, error) {
+	return opts.marshal(req)
+}
+
+// EncodeBidRequest encodes a bid request to w.
+func EncodeBidRequest(w io.Writer, req *BidRequest, opts *EncodeOptions) error {
+	data, err := opts.marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// MarshalBidResponse encodes a bid response.
+func MarshalBidResponse(res *BidResponse, opts *EncodeOptions) ([]byte, error) {
+	return opts.marshal(res)
+}
+
+// EncodeBidResponse encodes a bid response to w.
+func EncodeBidResponse(w io.Writer, res *BidResponse, opts *EncodeOptions) error {
+	data, err := opts.marshal(res)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (o *EncodeOptions) marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return data, nil
+	}
+	if !o.Version.IsZero() && o.Version.Compare(LatestVersion) < 0 {
+		if data, err = downgrade(data, reflect.TypeOf(v), o.Version); err != nil {
+			return nil, err
+		}
+	}
+	if o.Canonical {
+		return Canonicalize(data)
+	}
+	return data, nil
+}
+
+// Canonicalize re-encodes JSON data deterministically. Object keys are
+// sorted, whitespace is removed and non-integer numbers are normalised to
+// their shortest float64 representation. Integer literals are retained
+// as-is, to preserve precision.
+func Canonicalize(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, ErrDecodeTrailing
+	}
+
+	v, err := canonicalValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func canonicalValue(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for key, val := range x {
+			c, err := canonicalValue(val)
+			if err != nil {
+				return nil, err
+			}
+			x[key] = c
+		}
+	case []interface{}:
+		for i, val := range x {
+			c, err := canonicalValue(val)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = c
+		}
+	case json.Number:
+		if !strings.ContainsAny(string(x), ".eE") {
+			return x, nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,79 @@
+package openrtb
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MarshalBidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID:  "A",
+			Imp: []Impression{{ID: "1", Banner: &Banner{}, BidFloor: 1.50, Ext: Extension(`{"z":1.50, "a":{"y":true,"b":1e2}}`)}},
+			Ext: Extension(`{"b":"x","a":[3,2.0]}`),
+		}
+	})
+
+	It("should encode", func() {
+		data, err := MarshalBidRequest(subject, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"ext":{"b":"x","a":[3,2.0]}`))
+	})
+
+	It("should encode canonically", func() {
+		data, err := MarshalBidRequest(subject, &EncodeOptions{Canonical: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"at":0,"ext":{"a":[3,2],"b":"x"},"id":"A","imp":[{"banner":{},"bidfloor":1.5,"ext":{"a":{"b":100,"y":true},"z":1.5},"id":"1"}]}`))
+
+		subject.Ext = Extension(`{ "a": [3, 2], "b": "x" }`)
+		other, err := MarshalBidRequest(subject, &EncodeOptions{Canonical: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).To(Equal(data))
+
+		var buf bytes.Buffer
+		Expect(EncodeBidRequest(&buf, subject, &EncodeOptions{Canonical: true})).To(Succeed())
+		Expect(buf.Bytes()).To(Equal(data))
+	})
+
+	It("should round-trip fixtures", func() {
+		raw, err := ioutil.ReadFile("testdata/breq.video.json")
+		Expect(err).NotTo(HaveOccurred())
+		req, err := UnmarshalBidRequestContext(context.Background(), raw, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := MarshalBidRequest(req, &EncodeOptions{Canonical: true})
+		Expect(err).NotTo(HaveOccurred())
+		again, err := Canonicalize(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(Equal(data))
+	})
+
+})
+
+var _ = Describe("MarshalBidResponse", func() {
+
+	It("should encode canonically", func() {
+		res := &BidResponse{ID: "A", SeatBid: []SeatBid{{Bid: []Bid{{ID: "1", ImpID: "1", Price: 0.1, Ext: Extension(`{"y":1,"x":2}`)}}}}}
+		var buf bytes.Buffer
+		Expect(EncodeBidResponse(&buf, res, &EncodeOptions{Canonical: true})).To(Succeed())
+		Expect(buf.String()).To(Equal(`{"id":"A","seatbid":[{"bid":[{"ext":{"x":2,"y":1},"id":"1","impid":"1","price":0.1}]}]}`))
+	})
+
+})
+
+var _ = Describe("Canonicalize", func() {
+
+	It("should normalise", func() {
+		Expect(Canonicalize([]byte(`{"b":[1.0, 12345678901234567890, -0.50e1], "a":null}`))).
+			To(Equal([]byte(`{"a":null,"b":[1,12345678901234567890,-5]}`)))
+		Expect(Canonicalize([]byte(`{} {}`))).Error().To(Equal(ErrDecodeTrailing))
+		Expect(Canonicalize([]byte(`{`))).Error().To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,182 @@
+/*
+Package enrich runs time-boxed enrichment stages (geo, UA, app-store,
+brand-safety, etc.) against incoming bid requests.
+
+Each stage is given a time budget. Before a stage is started, the pipeline
+checks the time remaining until the request's tmax (minus a reserve kept for
+the auction itself) and skips the stage if its budget would exceed it. Stages
+run with a context bound to their budget and are expected to honour it.
+
+	p := enrich.NewPipeline(20 * time.Millisecond).
+		Add(enrich.AppStoreStage(store), 10*time.Millisecond).
+		Add(enrich.GeoStage(geoDB), 5*time.Millisecond)
+
+	result := p.Run(ctx, req, receivedAt)
+*/
+package enrich
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// Common stage names
+const (
+	StageGeo         = "geo"
+	StageUA          = "ua"
+	StageAppStore    = "appstore"
+	StageBrandSafety = "brandsafety"
+)
+
+// Skip reasons
+const (
+	SkipNoBudget = "insufficient budget"
+	SkipExpired  = "deadline exceeded"
+)
+
+// Stage is a single enrichment stage.
+type Stage interface {
+	// Name returns the stage name, see Stage* constants.
+	Name() string
+	// Enrich enriches req. Implementations must return once ctx is done.
+	Enrich(ctx context.Context, req *openrtb.BidRequest) error
+}
+
+type stageFunc struct {
+	name string
+	fn   func(context.Context, *openrtb.BidRequest) error
+}
+
+func (s stageFunc) Name() string { return s.name }
+
+func (s stageFunc) Enrich(ctx context.Context, req *openrtb.BidRequest) error { return s.fn(ctx, req) }
+
+// StageFunc creates a named stage from a function.
+func StageFunc(name string, fn func(context.Context, *openrtb.BidRequest) error) Stage {
+	return stageFunc{name: name, fn: fn}
+}
+
+// AppStoreStage enriches req.app using an openrtb.AppStoreProvider.
+func AppStoreStage(p openrtb.AppStoreProvider) Stage {
+	return StageFunc(StageAppStore, func(ctx context.Context, req *openrtb.BidRequest) error {
+		if req.App == nil {
+			return nil
+		}
+		return req.App.EnrichFromStore(ctx, p)
+	})
+}
+
+// GeoStage enriches req.device.geo using an openrtb.GeoProvider.
+func GeoStage(p openrtb.GeoProvider) Stage {
+	return StageFunc(StageGeo, func(ctx context.Context, req *openrtb.BidRequest) error {
+		if req.Device == nil {
+			return nil
+		}
+		return req.Device.EnrichGeo(ctx, p)
+	})
+}
+
+// UAStage enriches req.device using an openrtb.DeviceEnricher.
+func UAStage(e openrtb.DeviceEnricher) Stage {
+	return StageFunc(StageUA, func(ctx context.Context, req *openrtb.BidRequest) error {
+		if req.Device == nil {
+			return nil
+		}
+		return req.Device.EnrichFromUA(ctx, e)
+	})
+}
+
+// SkippedStage describes a skipped stage.
+type SkippedStage struct {
+	Stage  string        `json:"stage"`
+	Reason string        `json:"reason"`    // See Skip* constants
+	Budget time.Duration `json:"budget"`    // Budget of the stage
+	Left   time.Duration `json:"remaining"` // Time remaining when the stage was skipped
+}
+
+// FailedStage describes a failed stage.
+type FailedStage struct {
+	Stage string `json:"stage"`
+	Err   error  `json:"-"`
+}
+
+// Result summarises a pipeline run.
+type Result struct {
+	Applied []string       // Names of stages which completed successfully
+	Skipped []SkippedStage // Stages which were skipped
+	Failed  []FailedStage  // Stages which returned an error
+	Elapsed time.Duration  // Total time spent
+}
+
+type step struct {
+	stage  Stage
+	budget time.Duration
+}
+
+// Pipeline is a sequence of budgeted stages.
+type Pipeline struct {
+	reserve time.Duration
+	steps   []step
+}
+
+// NewPipeline creates a pipeline. The reserve is subtracted from the
+// request's tmax and kept for the remainder of the request lifecycle.
+func NewPipeline(reserve time.Duration) *Pipeline {
+	return &Pipeline{reserve: reserve}
+}
+
+// Add appends a stage with a time budget.
+func (p *Pipeline) Add(stage Stage, budget time.Duration) *Pipeline {
+	p.steps = append(p.steps, step{stage: stage, budget: budget})
+	return p
+}
+
+// Run runs all stages in order. The deadline is derived from start plus
+// req.tmax minus the reserve; requests without a tmax are only limited by
+// the stage budgets and ctx.
+func (p *Pipeline) Run(ctx context.Context, req *openrtb.BidRequest, start time.Time) *Result {
+	res := new(Result)
+	defer func() { res.Elapsed = time.Since(start) }()
+
+	var deadline time.Time
+	if req.TMax > 0 {
+		deadline = start.Add(time.Duration(req.TMax)*time.Millisecond - p.reserve)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+
+	for _, s := range p.steps {
+		name := s.stage.Name()
+
+		if !deadline.IsZero() {
+			left := time.Until(deadline)
+			if left <= 0 {
+				res.Skipped = append(res.Skipped, SkippedStage{Stage: name, Reason: SkipExpired, Budget: s.budget, Left: left})
+				continue
+			}
+			if s.budget > left {
+				res.Skipped = append(res.Skipped, SkippedStage{Stage: name, Reason: SkipNoBudget, Budget: s.budget, Left: left})
+				continue
+			}
+		}
+
+		if err := p.runStage(ctx, s, req); err != nil {
+			res.Failed = append(res.Failed, FailedStage{Stage: name, Err: err})
+			continue
+		}
+		res.Applied = append(res.Applied, name)
+	}
+	return res
+}
+
+func (p *Pipeline) runStage(ctx context.Context, s step, req *openrtb.BidRequest) error {
+	if s.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.budget)
+		defer cancel()
+	}
+	return s.stage.Enrich(ctx, req)
+}
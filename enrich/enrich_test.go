@@ -0,0 +1,123 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockStore struct{}
+
+func (mockStore) LookupApp(_ context.Context, bundle string) (*openrtb.AppStoreInfo, error) {
+	return &openrtb.AppStoreInfo{Name: "Example", StoreURL: "https://store.example.com/" + bundle}, nil
+}
+
+type mockGeo struct{}
+
+func (mockGeo) LookupGeo(_ context.Context, ip string) (*openrtb.Geo, error) {
+	return &openrtb.Geo{Country: "DEU", City: "Berlin"}, nil
+}
+
+type mockUA struct{}
+
+func (mockUA) ParseDevice(_ context.Context, d *openrtb.Device) (*openrtb.DeviceInfo, error) {
+	return &openrtb.DeviceInfo{Make: "Apple", OS: "iOS"}, nil
+}
+
+var _ = Describe("Pipeline", func() {
+	var req *openrtb.BidRequest
+	var calls []string
+
+	track := func(name string, err error) Stage {
+		return StageFunc(name, func(ctx context.Context, _ *openrtb.BidRequest) error {
+			calls = append(calls, name)
+			return err
+		})
+	}
+
+	BeforeEach(func() {
+		calls = nil
+		req = &openrtb.BidRequest{ID: "1", TMax: 100, App: &openrtb.App{Bundle: "com.example"}}
+	})
+
+	It("should run stages", func() {
+		failure := errors.New("geo failed")
+		res := NewPipeline(10*time.Millisecond).
+			Add(track(StageUA, nil), 10*time.Millisecond).
+			Add(track(StageGeo, failure), 10*time.Millisecond).
+			Add(AppStoreStage(mockStore{}), 10*time.Millisecond).
+			Run(context.Background(), req, time.Now())
+
+		Expect(calls).To(Equal([]string{StageUA, StageGeo}))
+		Expect(res.Applied).To(Equal([]string{StageUA, StageAppStore}))
+		Expect(res.Failed).To(Equal([]FailedStage{{Stage: StageGeo, Err: failure}}))
+		Expect(res.Skipped).To(BeEmpty())
+		Expect(req.App.Name).To(Equal("Example"))
+	})
+
+	It("should skip stages exceeding the remaining budget", func() {
+		res := NewPipeline(50*time.Millisecond).
+			Add(track(StageUA, nil), 10*time.Millisecond).
+			Add(track(StageBrandSafety, nil), 80*time.Millisecond).
+			Add(track(StageGeo, nil), 10*time.Millisecond).
+			Run(context.Background(), req, time.Now())
+
+		Expect(calls).To(Equal([]string{StageUA, StageGeo}))
+		Expect(res.Skipped).To(HaveLen(1))
+		Expect(res.Skipped[0].Stage).To(Equal(StageBrandSafety))
+		Expect(res.Skipped[0].Reason).To(Equal(SkipNoBudget))
+		Expect(res.Skipped[0].Left).To(BeNumerically("<=", 50*time.Millisecond))
+	})
+
+	It("should skip all stages after the deadline", func() {
+		res := NewPipeline(0).
+			Add(track(StageUA, nil), 0).
+			Run(context.Background(), req, time.Now().Add(-time.Second))
+
+		Expect(calls).To(BeEmpty())
+		Expect(res.Skipped[0].Reason).To(Equal(SkipExpired))
+	})
+
+	It("should run geo and UA stages", func() {
+		req.Device = &openrtb.Device{IP: "192.0.2.1", UA: "Mozilla/5.0 (iPhone)"}
+		res := NewPipeline(0).
+			Add(GeoStage(mockGeo{}), 10*time.Millisecond).
+			Add(UAStage(mockUA{}), 10*time.Millisecond).
+			Run(context.Background(), req, time.Now())
+
+		Expect(res.Applied).To(Equal([]string{StageGeo, StageUA}))
+		Expect(req.Device.Geo).To(Equal(&openrtb.Geo{Country: "DEU", City: "Berlin"}))
+		Expect(req.Device.Make).To(Equal("Apple"))
+	})
+
+	It("should not limit requests without tmax", func() {
+		req.TMax = 0
+		res := NewPipeline(time.Hour).
+			Add(track(StageUA, nil), time.Minute).
+			Run(context.Background(), req, time.Now())
+		Expect(res.Applied).To(Equal([]string{StageUA}))
+	})
+
+	It("should bind stages to their budget", func() {
+		slow := StageFunc(StageBrandSafety, func(ctx context.Context, _ *openrtb.BidRequest) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		res := NewPipeline(0).
+			Add(slow, 5*time.Millisecond).
+			Run(context.Background(), req, time.Now())
+
+		Expect(res.Failed).To(Equal([]FailedStage{{Stage: StageBrandSafety, Err: context.DeadlineExceeded}}))
+		Expect(res.Elapsed).To(BeNumerically("<", 50*time.Millisecond))
+	})
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/enrich")
+}
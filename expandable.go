@@ -0,0 +1,67 @@
+package openrtb
+
+import "strconv"
+
+// ValidAdPos returns true if pos is a known ad position, see AdPos*
+// constants.
+func ValidAdPos(pos int) bool {
+	return pos >= AdPosUnknown && pos <= AdPosFullscreen
+}
+
+// ValidExpDir returns true if dir is a known expandable direction, see
+// ExpDir* constants.
+func ValidExpDir(dir int) bool {
+	return dir >= ExpDirLeft && dir <= ExpDirResizeMinimize
+}
+
+// IsExpandableAttr returns true if attr is one of the expandable creative
+// attributes, i.e. CreativeAttrExpandableAuto, CreativeAttrExpandableClick
+// or CreativeAttrExpandableRollover.
+func IsExpandableAttr(attr int) bool {
+	return attr >= CreativeAttrExpandableAuto && attr <= CreativeAttrExpandableRollover
+}
+
+// IsExpandable returns true if the banner may expand in at least one
+// direction.
+func (b *Banner) IsExpandable() bool {
+	for _, dir := range b.ExpDir {
+		if dir != ExpDirResizeMinimize {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsExpDir returns true if the banner may expand in direction dir.
+func (b *Banner) AllowsExpDir(dir int) bool {
+	for _, allowed := range b.ExpDir {
+		if allowed == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckExpandable checks the expandable creative attributes of a bid
+// against the expandable directions (expdir) of the impression's banner.
+// Bids declaring an expandable attribute are rejected if the banner
+// declares expdir without any direction to expand in. A missing expdir
+// leaves the directions unspecified and allows expansion. Bids for other
+// markup types and impressions without a banner pass. It returns nil if the
+// bid is compliant.
+func (imp *Impression) CheckExpandable(bid *Bid) *Rejection {
+	if imp.Banner == nil || (bid.MType != 0 && bid.MType != MarkupTypeBanner) {
+		return nil
+	}
+	if len(imp.Banner.ExpDir) == 0 || imp.Banner.IsExpandable() {
+		return nil
+	}
+
+	for _, attr := range bid.Attr {
+		if IsExpandableAttr(attr) {
+			value := strconv.Itoa(attr)
+			return &Rejection{Code: LossCreativeAttributeExclusion, Field: "imp.banner.expdir", Value: value, Reason: "expandable creative attribute " + value + " not allowed"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidAdPos", func() {
+
+	It("should check positions", func() {
+		Expect(ValidAdPos(AdPosUnknown)).To(BeTrue())
+		Expect(ValidAdPos(AdPosFullscreen)).To(BeTrue())
+		Expect(ValidAdPos(-1)).To(BeFalse())
+		Expect(ValidAdPos(8)).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("ValidExpDir", func() {
+
+	It("should check directions", func() {
+		Expect(ValidExpDir(ExpDirLeft)).To(BeTrue())
+		Expect(ValidExpDir(ExpDirResizeMinimize)).To(BeTrue())
+		Expect(ValidExpDir(0)).To(BeFalse())
+		Expect(ValidExpDir(7)).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("Impression", func() {
+	var subject *Impression
+
+	BeforeEach(func() {
+		subject = &Impression{ID: "I", Banner: &Banner{W: 300, H: 250}}
+	})
+
+	It("should detect expandable banners", func() {
+		Expect(subject.Banner.IsExpandable()).To(BeFalse())
+		subject.Banner.ExpDir = []int{ExpDirResizeMinimize}
+		Expect(subject.Banner.IsExpandable()).To(BeFalse())
+		subject.Banner.ExpDir = []int{ExpDirUp, ExpDirDown}
+		Expect(subject.Banner.IsExpandable()).To(BeTrue())
+		Expect(subject.Banner.AllowsExpDir(ExpDirUp)).To(BeTrue())
+		Expect(subject.Banner.AllowsExpDir(ExpDirLeft)).To(BeFalse())
+	})
+
+	It("should check expandable attributes", func() {
+		bid := &Bid{ID: "B", ImpID: "I", Attr: []int{CreativeAttrTextOnly}}
+		Expect(subject.CheckExpandable(bid)).To(BeNil())
+
+		bid.Attr = append(bid.Attr, CreativeAttrExpandableClick)
+		Expect(subject.CheckExpandable(bid)).To(BeNil())
+
+		subject.Banner.ExpDir = []int{ExpDirResizeMinimize}
+		Expect(subject.CheckExpandable(bid)).To(Equal(&Rejection{
+			Code:   LossCreativeAttributeExclusion,
+			Field:  "imp.banner.expdir",
+			Value:  "4",
+			Reason: "expandable creative attribute 4 not allowed",
+		}))
+
+		bid.MType = MarkupTypeVideo
+		Expect(subject.CheckExpandable(bid)).To(BeNil())
+		bid.MType = MarkupTypeBanner
+
+		subject.Banner.ExpDir = []int{ExpDirFullScreen}
+		Expect(subject.CheckExpandable(bid)).To(BeNil())
+		Expect((&Impression{ID: "I"}).CheckExpandable(bid)).To(BeNil())
+	})
+
+})
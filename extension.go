@@ -1,6 +1,10 @@
 package openrtb
 
-import "errors"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
 
 // Extension is a raw encoded JSON value.
 // It implements Marshaler and Unmarshaler, defined in encoding/json package,
@@ -21,3 +25,91 @@ func (e *Extension) UnmarshalJSON(data []byte) error {
 	*e = append((*e)[0:0], data...)
 	return nil
 }
+
+// Decode decodes the extension into v. Numbers decoded into interface{}
+// values are kept as json.Number rather than float64, so large integer IDs
+// retain their precision when re-encoded. An empty extension leaves v
+// untouched.
+func (e Extension) Decode(v interface{}) error {
+	if len(e) == 0 {
+		return nil
+	}
+	return decodeNumbers(e, v)
+}
+
+// getKey decodes the value stored under key into v, see Decode.
+// It returns false if the extension is empty or the key is absent.
+func (e Extension) getKey(key string, v interface{}) (bool, error) {
+	if len(e) == 0 {
+		return false, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(e, &obj); err != nil {
+		return false, err
+	}
+
+	raw, ok := obj[key]
+	if !ok {
+		return false, nil
+	}
+	return true, decodeNumbers(raw, v)
+}
+
+// setKey returns a copy of the extension with v stored under key.
+func (e Extension) setKey(key string, v interface{}) (Extension, error) {
+	var obj map[string]json.RawMessage
+	if len(e) != 0 {
+		if err := json.Unmarshal(e, &obj); err != nil {
+			return nil, err
+		}
+	}
+	if obj == nil {
+		obj = make(map[string]json.RawMessage, 1)
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj[key] = raw
+	return json.Marshal(obj)
+}
+
+// deleteKey returns a copy of the extension without key. It returns nil if
+// no other keys remain.
+func (e Extension) deleteKey(key string) (Extension, error) {
+	if len(e) == 0 {
+		return e, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(e, &obj); err != nil {
+		return nil, err
+	}
+	if _, ok := obj[key]; !ok {
+		return e, nil
+	}
+
+	delete(obj, key)
+	if len(obj) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(obj)
+}
+
+// setOrDeleteKey returns a copy of the extension with v stored under key or,
+// if del is set, without key.
+func (e Extension) setOrDeleteKey(key string, v interface{}, del bool) (Extension, error) {
+	if del {
+		return e.deleteKey(key)
+	}
+	return e.setKey(key, v)
+}
+
+// decodeNumbers decodes data into v, retaining numbers as json.Number.
+func decodeNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
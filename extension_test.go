@@ -26,4 +26,27 @@ var _ = Describe("Extension", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(subject).To(Equal(Extension(`{"foo":"bar"}`)))
 	})
+
+	It("should decode preserving numeric precision", func() {
+		subject := Extension(`{"id":9007199254740993,"price":1.5,"nested":{"n":12345678901234567890}}`)
+
+		var v map[string]interface{}
+		Expect(subject.Decode(&v)).To(Succeed())
+		Expect(v["id"]).To(Equal(json.Number("9007199254740993")))
+		Expect(v["price"]).To(Equal(json.Number("1.5")))
+
+		data, err := json.Marshal(v)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"id":9007199254740993,"nested":{"n":12345678901234567890},"price":1.5}`))
+
+		var id interface{}
+		ok, err := subject.getKey("id", &id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(id).To(Equal(json.Number("9007199254740993")))
+
+		v = nil
+		Expect(Extension(nil).Decode(&v)).To(Succeed())
+		Expect(v).To(BeNil())
+	})
 })
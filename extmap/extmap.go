@@ -0,0 +1,253 @@
+/*
+Package extmap implements a small declarative engine for moving fields between
+standard OpenRTB locations and partner-specific extension paths.
+
+Paths are dot-separated lists of JSON keys. A key suffixed with "[]" iterates
+over the elements of an array, e.g. "imp[].tagid". Array segments must be
+shared by both sides of a rule, so "imp[].tagid" can be moved to
+"imp[].ext.partner.placement" but not to "ext.placement".
+
+Mappings can be constructed in Go or parsed from JSON:
+
+	{"rules":[
+		{"from":"user.buyeruid","to":"user.ext.partner.uid"},
+		{"from":"imp[].tagid","to":"imp[].ext.partner.placement","copy":true}
+	]}
+*/
+package extmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrInvalidRuleNoPath     = errors.New("extmap: rule path missing")
+	ErrInvalidRuleBadPath    = errors.New("extmap: rule path is malformed")
+	ErrInvalidRuleArrayMatch = errors.New("extmap: rule array segments do not match")
+	ErrInvalidRuleArrayLeaf  = errors.New("extmap: rule path must not end with an array segment")
+)
+
+// Rule moves the value found at From to To.
+type Rule struct {
+	From string `json:"from"`           // Source path
+	To   string `json:"to"`             // Target path
+	Copy bool   `json:"copy,omitempty"` // Keep the source value in place
+}
+
+// Validate validates the rule
+func (r *Rule) Validate() error {
+	if r.From == "" || r.To == "" {
+		return ErrInvalidRuleNoPath
+	}
+
+	from, to := strings.Split(r.From, "."), strings.Split(r.To, ".")
+	for _, key := range append(from, to...) {
+		if key == "" || key == "[]" {
+			return ErrInvalidRuleBadPath
+		}
+	}
+	if _, ok := arrayKey(from[len(from)-1]); ok {
+		return ErrInvalidRuleArrayLeaf
+	}
+	if _, ok := arrayKey(to[len(to)-1]); ok {
+		return ErrInvalidRuleArrayLeaf
+	}
+	if arrayPrefix(from) != arrayPrefix(to) {
+		return ErrInvalidRuleArrayMatch
+	}
+	return nil
+}
+
+// Mapping is an ordered list of rules
+type Mapping struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Parse parses and validates a JSON mapping definition.
+func Parse(data []byte) (*Mapping, error) {
+	var m Mapping
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate validates all rules
+func (m *Mapping) Validate() error {
+	for _, r := range m.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Outbound applies the rules (From -> To) to a JSON payload.
+func (m *Mapping) Outbound(data []byte) ([]byte, error) {
+	return m.transform(data, false)
+}
+
+// Inbound reverts the rules (To -> From) on a JSON payload.
+// Rules are reverted in reverse order.
+func (m *Mapping) Inbound(data []byte) ([]byte, error) {
+	return m.transform(data, true)
+}
+
+// OutboundObject applies the rules to v (e.g. a *openrtb.BidRequest) in place.
+func (m *Mapping) OutboundObject(v interface{}) error {
+	return m.transformObject(v, false)
+}
+
+// InboundObject reverts the rules on v (e.g. a *openrtb.BidResponse) in place.
+func (m *Mapping) InboundObject(v interface{}) error {
+	return m.transformObject(v, true)
+}
+
+func (m *Mapping) transformObject(v interface{}, reverse bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if data, err = m.transform(data, reverse); err != nil {
+		return err
+	}
+
+	// reset the target, so removed fields don't linger
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (m *Mapping) transform(data []byte, reverse bool) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	n := len(m.Rules)
+	for i := 0; i < n; i++ {
+		r := m.Rules[i]
+		if reverse {
+			r = m.Rules[n-1-i]
+			r.From, r.To = r.To, r.From
+		}
+		move(doc, strings.Split(r.From, "."), strings.Split(r.To, "."), r.Copy)
+	}
+	return json.Marshal(doc)
+}
+
+// move moves a value within doc, descending into shared array segments.
+func move(doc interface{}, from, to []string, keep bool) {
+	if len(from) == 0 || len(to) == 0 {
+		return
+	}
+	if key, ok := arrayKey(from[0]); ok {
+		obj, _ := doc.(map[string]interface{})
+		elems, _ := obj[key].([]interface{})
+		for _, elem := range elems {
+			move(elem, from[1:], to[1:], keep)
+		}
+		return
+	}
+
+	// skip the common object prefix unless it contains arrays
+	if len(from) > 1 && len(to) > 1 && from[0] == to[0] {
+		obj, _ := doc.(map[string]interface{})
+		if child, ok := obj[from[0]]; ok {
+			move(child, from[1:], to[1:], keep)
+		}
+		return
+	}
+
+	val, ok := get(doc, from)
+	if !ok {
+		return
+	}
+	if !keep {
+		del(doc, from)
+	}
+	set(doc, to, val)
+}
+
+func get(doc interface{}, path []string) (interface{}, bool) {
+	for _, key := range path {
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if doc, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+func set(doc interface{}, path []string, val interface{}) {
+	obj, ok := doc.(map[string]interface{})
+	if !ok || len(path) == 0 {
+		return
+	}
+
+	for _, key := range path[:len(path)-1] {
+		child, ok := obj[key].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			obj[key] = child
+		}
+		obj = child
+	}
+	obj[path[len(path)-1]] = val
+}
+
+// del removes the value at path and prunes parents that became empty.
+func del(doc interface{}, path []string) bool {
+	obj, ok := doc.(map[string]interface{})
+	if !ok || len(path) == 0 {
+		return false
+	}
+
+	key := path[0]
+	if len(path) == 1 {
+		delete(obj, key)
+	} else if del(obj[key], path[1:]) {
+		if child, ok := obj[key].(map[string]interface{}); ok && len(child) == 0 {
+			delete(obj, key)
+		}
+	} else {
+		return false
+	}
+	return true
+}
+
+func arrayKey(seg string) (string, bool) {
+	if strings.HasSuffix(seg, "[]") {
+		return strings.TrimSuffix(seg, "[]"), true
+	}
+	return seg, false
+}
+
+func arrayPrefix(path []string) string {
+	n := 0
+	for i, seg := range path {
+		if _, ok := arrayKey(seg); ok {
+			n = i + 1
+		}
+	}
+	return strings.Join(path[:n], ".")
+}
@@ -0,0 +1,87 @@
+package extmap
+
+import (
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mapping", func() {
+	var subject *Mapping
+
+	BeforeEach(func() {
+		var err error
+		subject, err = Parse([]byte(`{"rules":[
+			{"from":"user.buyeruid","to":"user.ext.partner.uid"},
+			{"from":"imp[].tagid","to":"imp[].ext.partner.placement","copy":true},
+			{"from":"tmax","to":"ext.timeout"}
+		]}`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should validate", func() {
+		Expect((&Rule{From: "a"}).Validate()).To(Equal(ErrInvalidRuleNoPath))
+		Expect((&Rule{From: "a..b", To: "c"}).Validate()).To(Equal(ErrInvalidRuleBadPath))
+		Expect((&Rule{From: "imp[].tagid", To: "ext.tagid"}).Validate()).To(Equal(ErrInvalidRuleArrayMatch))
+		Expect((&Rule{From: "imp[]", To: "imp[]"}).Validate()).To(Equal(ErrInvalidRuleArrayLeaf))
+		Expect((&Rule{From: "imp[].tagid", To: "imp[]"}).Validate()).To(Equal(ErrInvalidRuleArrayLeaf))
+		Expect((&Rule{From: "imp[].ext", To: "imp[].ext.partner"}).Validate()).To(Succeed())
+		Expect((&Rule{From: "imp[].tagid", To: "imp[].ext.tagid"}).Validate()).To(Succeed())
+
+		_, err := Parse([]byte(`{"rules":[{"from":"a"}]}`))
+		Expect(err).To(Equal(ErrInvalidRuleNoPath))
+	})
+
+	It("should not panic on empty paths", func() {
+		doc := map[string]interface{}{"a": 1}
+		Expect(func() { move(doc, nil, []string{"b"}, false) }).NotTo(Panic())
+		Expect(func() { move(doc, []string{"a"}, nil, false) }).NotTo(Panic())
+		Expect(func() { set(doc, nil, 1) }).NotTo(Panic())
+		Expect(func() { del(doc, nil) }).NotTo(Panic())
+		Expect(doc).To(Equal(map[string]interface{}{"a": 1}))
+	})
+
+	It("should map outbound payloads", func() {
+		out, err := subject.Outbound([]byte(`{"id":"1","tmax":120,"imp":[{"id":"1","tagid":"t1"},{"id":"2"}],"user":{"buyeruid":"u1","ext":{"other":true}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"id":"1","ext":{"timeout":120},"imp":[{"id":"1","tagid":"t1","ext":{"partner":{"placement":"t1"}}},{"id":"2"}],"user":{"ext":{"other":true,"partner":{"uid":"u1"}}}}`))
+	})
+
+	It("should map inbound payloads", func() {
+		out, err := subject.Inbound([]byte(`{"id":"1","ext":{"timeout":120},"imp":[{"id":"1","ext":{"partner":{"placement":"t1"}}}],"user":{"ext":{"partner":{"uid":"u1"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"id":"1","tmax":120,"imp":[{"id":"1","tagid":"t1","ext":{"partner":{"placement":"t1"}}}],"user":{"buyeruid":"u1"}}`))
+	})
+
+	It("should map objects", func() {
+		req := &openrtb.BidRequest{
+			ID:   "1",
+			TMax: 120,
+			Imp:  []openrtb.Impression{{ID: "1", TagID: "t1"}},
+			User: &openrtb.User{BuyerUID: "u1"},
+		}
+		Expect(subject.OutboundObject(req)).To(Succeed())
+		Expect(req.TMax).To(Equal(0))
+		Expect(req.User.BuyerUID).To(BeEmpty())
+		Expect(string(req.User.Ext)).To(Equal(`{"partner":{"uid":"u1"}}`))
+		Expect(string(req.Imp[0].Ext)).To(Equal(`{"partner":{"placement":"t1"}}`))
+
+		Expect(subject.InboundObject(req)).To(Succeed())
+		Expect(req.TMax).To(Equal(120))
+		Expect(req.User).To(Equal(&openrtb.User{BuyerUID: "u1"}))
+	})
+
+	It("should preserve numeric precision", func() {
+		out, err := subject.Outbound([]byte(`{"tmax":12345678901234567890}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal(`{"ext":{"timeout":12345678901234567890}}`))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/extmap")
+}
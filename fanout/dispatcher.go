@@ -0,0 +1,63 @@
+package fanout
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/client"
+)
+
+// Endpoint creates a named partner which sends requests over HTTP, using c.
+func Endpoint(name string, c *client.BidderClient) Partner {
+	return PartnerFunc(name, c.Bid)
+}
+
+// Dispatcher sends bid requests to registered bidder endpoints over HTTP.
+type Dispatcher struct {
+	fanout *FanOut
+	opts   *client.Options
+}
+
+// NewDispatcher creates a dispatcher. The reserve is subtracted from the
+// request's tmax and kept for the remainder of the request lifecycle, opts
+// are applied to all registered endpoints, e.g. client.Options.Test marks
+// all dispatched requests as test traffic. It returns an error if opts are
+// invalid, see client.NewBidderClient.
+func NewDispatcher(reserve time.Duration, opts *client.Options) (*Dispatcher, error) {
+	if _, err := client.NewBidderClient("", opts); err != nil {
+		return nil, err
+	}
+	return &Dispatcher{fanout: New(reserve), opts: opts}, nil
+}
+
+// Register registers a bidder endpoint URL under a name.
+func (d *Dispatcher) Register(name, endpoint string) *Dispatcher {
+	c, _ := client.NewBidderClient(endpoint, d.opts) // opts are validated by NewDispatcher
+	d.fanout.Add(Endpoint(name, c))
+	return d
+}
+
+// Add adds a custom partner.
+func (d *Dispatcher) Add(p Partner) *Dispatcher {
+	d.fanout.Add(p)
+	return d
+}
+
+// Dispatch sends req to all registered endpoints concurrently and returns
+// the outcomes, including per-bidder latencies and errors, once all bidders
+// have responded or the tmax deadline has passed. The deadline is measured
+// from the time of the call, as by openrtb.ContextWithTMax; use Run if the
+// request was received earlier.
+func (d *Dispatcher) Dispatch(ctx context.Context, req *openrtb.BidRequest) *Result {
+	start := time.Now()
+	ctx, cancel := openrtb.ContextWithTMax(ctx, req, d.fanout.reserve)
+	defer cancel()
+
+	return d.fanout.collect(ctx, req, start)
+}
+
+// Run is like Dispatch, but derives the deadline from start.
+func (d *Dispatcher) Run(ctx context.Context, req *openrtb.BidRequest, start time.Time) *Result {
+	return d.fanout.Run(ctx, req, start)
+}
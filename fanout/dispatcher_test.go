@@ -0,0 +1,73 @@
+package fanout
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dispatcher", func() {
+	var servers []*httptest.Server
+
+	serve := func(delay time.Duration, status int, body string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, body)
+		}))
+		servers = append(servers, server)
+		return server.URL
+	}
+
+	AfterEach(func() {
+		for _, s := range servers {
+			s.Close()
+		}
+		servers = servers[:0]
+	})
+
+	It("should dispatch to endpoints", func() {
+		req := &openrtb.BidRequest{ID: "R", TMax: 100, Imp: []openrtb.Impression{{ID: "I", Banner: &openrtb.Banner{}}}}
+		bid := `{"id":"R","seatbid":[{"bid":[{"id":"B","impid":"I","price":1}]}]}`
+
+		subject, err := NewDispatcher(20*time.Millisecond, &client.Options{Version: "2.5"})
+		Expect(err).NotTo(HaveOccurred())
+
+		res := subject.
+			Register("a", serve(0, http.StatusOK, bid)).
+			Register("b", serve(0, http.StatusNoContent, "")).
+			Register("c", serve(0, http.StatusOK, `{"id":`)).
+			Register("d", serve(time.Second, http.StatusOK, bid)).
+			Add(PartnerFunc("e", func(context.Context, *openrtb.BidRequest) (*openrtb.BidResponse, error) { return nil, nil })).
+			Dispatch(context.Background(), req)
+
+		Expect(res.Outcomes).To(HaveLen(5))
+		Expect(res.Outcomes[0].Status).To(Equal(StatusBid))
+		Expect(res.Outcomes[0].Latency).To(BeNumerically(">", 0))
+		Expect(res.Outcomes[1].Status).To(Equal(StatusNoBid))
+		Expect(res.Outcomes[2].Status).To(Equal(StatusError))
+		Expect(res.Outcomes[2].Err).To(MatchError(client.ErrMalformedResponse))
+		Expect(res.Outcomes[3].Status).To(Equal(StatusTimeout))
+		Expect(res.Outcomes[3].Partner).To(Equal("d"))
+		Expect(res.Outcomes[4].Status).To(Equal(StatusNoBid))
+		Expect(res.Elapsed).To(BeNumerically("<", 200*time.Millisecond))
+		Expect(res.Responses()).To(HaveKey("a"))
+	})
+
+	It("should reject invalid options", func() {
+		_, err := NewDispatcher(0, &client.Options{Version: "latest"})
+		Expect(err).To(Equal(openrtb.ErrInvalidVersion))
+	})
+
+})
@@ -0,0 +1,206 @@
+/*
+Package fanout sends bid requests to multiple partners concurrently and
+collects their responses within the request's tmax budget.
+
+The deadline is derived from the time the request was received plus its
+tmax, minus a reserve kept for the auction itself. Partners which have not
+responded by the deadline are cancelled and reported as timed out, while
+all responses received so far are returned.
+
+	f := fanout.New(20 * time.Millisecond).
+		Add(fanout.PartnerFunc("alpha", alpha.Bid)).
+		Add(fanout.PartnerFunc("beta", beta.Bid))
+
+	result := f.Run(ctx, req, receivedAt)
+	res, err := openrtb.AggregateResponses(req.ID, result.Responses(), policy)
+
+Bidders which are reachable over HTTP can be registered by URL with a
+Dispatcher, see package client:
+
+	d, err := fanout.NewDispatcher(20*time.Millisecond, &client.Options{Gzip: true})
+	...
+	d.Register("alpha", "https://alpha.example.com/bid").
+		Register("beta", "https://beta.example.com/bid")
+
+	result := d.Dispatch(ctx, req)
+*/
+package fanout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// Outcome statuses
+const (
+	StatusBid     = "bid"
+	StatusNoBid   = "nobid"
+	StatusError   = "error"
+	StatusTimeout = "timeout"
+)
+
+// Partner is a demand partner.
+type Partner interface {
+	// Name returns the partner name.
+	Name() string
+	// Bid sends req to the partner. A nil response indicates a no-bid.
+	// Implementations must not modify req and must return once ctx is done.
+	Bid(ctx context.Context, req *openrtb.BidRequest) (*openrtb.BidResponse, error)
+}
+
+type partnerFunc struct {
+	name string
+	fn   func(context.Context, *openrtb.BidRequest) (*openrtb.BidResponse, error)
+}
+
+func (p partnerFunc) Name() string { return p.name }
+
+func (p partnerFunc) Bid(ctx context.Context, req *openrtb.BidRequest) (*openrtb.BidResponse, error) {
+	return p.fn(ctx, req)
+}
+
+// PartnerFunc creates a named partner from a function.
+func PartnerFunc(name string, fn func(context.Context, *openrtb.BidRequest) (*openrtb.BidResponse, error)) Partner {
+	return partnerFunc{name: name, fn: fn}
+}
+
+// Outcome describes the outcome of a single partner call.
+type Outcome struct {
+	Partner  string               `json:"partner"`
+	Status   string               `json:"status"` // See Status* constants
+	Response *openrtb.BidResponse `json:"-"`
+	Err      error                `json:"-"`
+	Latency  time.Duration        `json:"latency"`
+}
+
+// Result summarises a fan-out run.
+type Result struct {
+	Outcomes []Outcome     // Outcomes, in the order partners were added
+	Elapsed  time.Duration // Total time spent
+}
+
+// Responses returns the responses of all partners which bid, by partner name.
+func (r *Result) Responses() map[string]*openrtb.BidResponse {
+	res := make(map[string]*openrtb.BidResponse, len(r.Outcomes))
+	for _, o := range r.Outcomes {
+		if o.Status == StatusBid {
+			res[o.Partner] = o.Response
+		}
+	}
+	return res
+}
+
+// Count returns the number of outcomes with the given status.
+func (r *Result) Count(status string) int {
+	n := 0
+	for _, o := range r.Outcomes {
+		if o.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// FanOut is a set of partners.
+type FanOut struct {
+	reserve  time.Duration
+	partners []Partner
+}
+
+// New creates a fan-out. The reserve is subtracted from the request's tmax
+// and kept for the remainder of the request lifecycle.
+func New(reserve time.Duration) *FanOut {
+	return &FanOut{reserve: reserve}
+}
+
+// Add adds a partner.
+func (f *FanOut) Add(p Partner) *FanOut {
+	f.partners = append(f.partners, p)
+	return f
+}
+
+// Run calls all partners concurrently and collects their outcomes until
+// each of them has answered or the budget expires. The budget is req.tmax,
+// counted from start, less the reserve; requests without a tmax are only
+// bounded by ctx. Once the budget expires, the remaining partners are
+// cancelled and reported with StatusTimeout, outcomes which had already
+// completed are kept.
+func (f *FanOut) Run(ctx context.Context, req *openrtb.BidRequest, start time.Time) *Result {
+	ctx, cancel := openrtb.ContextWithTMax(ctx, req, f.reserve+time.Since(start))
+	defer cancel()
+
+	return f.collect(ctx, req, start)
+}
+
+// collect calls all partners with ctx, which defines the deadline.
+func (f *FanOut) collect(ctx context.Context, req *openrtb.BidRequest, start time.Time) *Result {
+	res := &Result{Outcomes: make([]Outcome, len(f.partners))}
+	defer func() { res.Elapsed = time.Since(start) }()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type indexed struct {
+		index   int
+		outcome Outcome
+	}
+
+	received := make([]bool, len(f.partners))
+	done := make(chan indexed, len(f.partners))
+	for i, p := range f.partners {
+		res.Outcomes[i] = Outcome{Partner: p.Name(), Status: StatusTimeout}
+		go func(i int, p Partner) {
+			done <- indexed{index: i, outcome: call(ctx, p, req)}
+		}(i, p)
+	}
+
+	for pending := len(f.partners); pending > 0; pending-- {
+		select {
+		case r := <-done:
+			res.Outcomes[r.index] = r.outcome
+			received[r.index] = true
+		case <-ctx.Done():
+			// keep outcomes which completed at the same time
+			for drained := false; !drained; {
+				select {
+				case r := <-done:
+					res.Outcomes[r.index] = r.outcome
+					received[r.index] = true
+				default:
+					drained = true
+				}
+			}
+
+			latency := time.Since(start)
+			for i, ok := range received {
+				if !ok {
+					res.Outcomes[i].Err = ctx.Err()
+					res.Outcomes[i].Latency = latency
+				}
+			}
+			return res
+		}
+	}
+	return res
+}
+
+func call(ctx context.Context, p Partner, req *openrtb.BidRequest) Outcome {
+	start := time.Now()
+	resp, err := p.Bid(ctx, req)
+
+	o := Outcome{Partner: p.Name(), Response: resp, Err: err, Latency: time.Since(start)}
+	switch {
+	case err != nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)):
+		o.Status = StatusTimeout
+	case err != nil:
+		o.Status = StatusError
+	case resp == nil || len(resp.SeatBid) == 0:
+		o.Status = StatusNoBid
+	default:
+		o.Status = StatusBid
+	}
+	return o
+}
@@ -0,0 +1,126 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FanOut", func() {
+	var req *openrtb.BidRequest
+
+	respond := func(name string, delay time.Duration, res *openrtb.BidResponse, err error) Partner {
+		return PartnerFunc(name, func(ctx context.Context, _ *openrtb.BidRequest) (*openrtb.BidResponse, error) {
+			select {
+			case <-time.After(delay):
+				return res, err
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+	}
+
+	bid := func(id string) *openrtb.BidResponse {
+		return &openrtb.BidResponse{ID: "R", SeatBid: []openrtb.SeatBid{{Bid: []openrtb.Bid{{ID: id, ImpID: "1", Price: 1}}}}}
+	}
+
+	BeforeEach(func() {
+		req = &openrtb.BidRequest{ID: "R", TMax: 100, Imp: []openrtb.Impression{{ID: "1"}}}
+	})
+
+	It("should collect outcomes", func() {
+		res := New(0).
+			Add(respond("a", 0, bid("1"), nil)).
+			Add(respond("b", time.Millisecond, nil, nil)).
+			Add(respond("c", time.Millisecond, &openrtb.BidResponse{ID: "R"}, nil)).
+			Add(respond("d", 0, nil, errors.New("boom"))).
+			Run(context.Background(), req, time.Now())
+
+		Expect(res.Outcomes).To(HaveLen(4))
+		Expect(res.Outcomes[0].Partner).To(Equal("a"))
+		Expect(res.Outcomes[0].Status).To(Equal(StatusBid))
+		Expect(res.Outcomes[1].Status).To(Equal(StatusNoBid))
+		Expect(res.Outcomes[2].Status).To(Equal(StatusNoBid))
+		Expect(res.Outcomes[3].Status).To(Equal(StatusError))
+		Expect(res.Outcomes[3].Err).To(MatchError("boom"))
+		Expect(res.Responses()).To(Equal(map[string]*openrtb.BidResponse{"a": bid("1")}))
+		Expect(res.Count(StatusNoBid)).To(Equal(2))
+	})
+
+	It("should return partial results on timeout", func() {
+		req.TMax = 50
+		start := time.Now()
+		res := New(20*time.Millisecond).
+			Add(respond("fast", 0, bid("1"), nil)).
+			Add(respond("slow", time.Second, bid("2"), nil)).
+			Add(PartnerFunc("stuck", func(context.Context, *openrtb.BidRequest) (*openrtb.BidResponse, error) {
+				time.Sleep(300 * time.Millisecond)
+				return bid("3"), nil
+			})).
+			Run(context.Background(), req, start)
+
+		Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+		Expect(res.Elapsed).To(BeNumerically(">=", 30*time.Millisecond))
+		Expect(res.Outcomes[0].Status).To(Equal(StatusBid))
+		Expect(res.Outcomes[1].Status).To(Equal(StatusTimeout))
+		Expect(res.Outcomes[1].Response).To(BeNil())
+		Expect(res.Outcomes[2].Partner).To(Equal("stuck"))
+		Expect(res.Outcomes[2].Status).To(Equal(StatusTimeout))
+		Expect(res.Outcomes[2].Err).To(Equal(context.DeadlineExceeded))
+		Expect(res.Outcomes[2].Latency).To(BeNumerically(">=", 30*time.Millisecond))
+		Expect(res.Responses()).To(HaveKey("fast"))
+		Expect(res.Responses()).To(HaveLen(1))
+	})
+
+	It("should count the budget from start", func() {
+		req.TMax = 50
+		start := time.Now()
+		res := New(0).
+			Add(respond("slow", time.Second, bid("1"), nil)).
+			Run(context.Background(), req, start.Add(-40*time.Millisecond))
+
+		Expect(time.Since(start)).To(BeNumerically("<", 40*time.Millisecond))
+		Expect(res.Outcomes[0].Status).To(Equal(StatusTimeout))
+		Expect(res.Elapsed).To(BeNumerically(">=", 50*time.Millisecond))
+	})
+
+	It("should honour parent context", func() {
+		req.TMax = 0
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		res := New(0).Add(respond("slow", time.Second, bid("1"), nil)).Run(ctx, req, time.Now())
+		Expect(res.Outcomes[0].Status).To(Equal(StatusTimeout))
+	})
+
+	It("should cancel stragglers", func() {
+		cancelled := make(chan struct{})
+		New(0).
+			Add(respond("fast", 0, bid("1"), nil)).
+			Add(PartnerFunc("slow", func(ctx context.Context, _ *openrtb.BidRequest) (*openrtb.BidResponse, error) {
+				<-ctx.Done()
+				close(cancelled)
+				return nil, ctx.Err()
+			})).
+			Run(context.Background(), &openrtb.BidRequest{ID: "R", TMax: 20}, time.Now())
+
+		Eventually(cancelled).Should(BeClosed())
+	})
+
+	It("should handle empty partner lists", func() {
+		res := New(0).Run(context.Background(), req, time.Now())
+		Expect(res.Outcomes).To(BeEmpty())
+		Expect(res.Responses()).To(BeEmpty())
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/fanout")
+}
@@ -0,0 +1,97 @@
+// Command openrtb-fixtures writes generated bid requests and matching
+// responses as JSON files, one pair per request kind and index, e.g.
+// banner.1.breq.json and banner.1.bres.json.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/fixtures"
+)
+
+func main() {
+	version := flag.String("version", fixtures.Version26, "OpenRTB spec version")
+	seed := flag.Int64("seed", 1, "random seed")
+	count := flag.Int("n", 1, "number of requests per kind")
+	kinds := flag.String("kinds", "", "comma separated list of request kinds (default: all supported)")
+	out := flag.String("out", ".", "output directory")
+	flag.Parse()
+
+	if err := run(*version, *seed, *count, *kinds, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "openrtb-fixtures:", err)
+		os.Exit(1)
+	}
+}
+
+func run(version string, seed int64, count int, kinds, out string) error {
+	gen, err := fixtures.NewGenerator(version, seed)
+	if err != nil {
+		return err
+	}
+
+	var selected []fixtures.Kind
+	if kinds == "" {
+		for _, kind := range fixtures.Kinds {
+			if kind == fixtures.KindDOOH && version == fixtures.Version25 {
+				continue
+			}
+			selected = append(selected, kind)
+		}
+	} else {
+		for _, s := range strings.Split(kinds, ",") {
+			selected = append(selected, fixtures.Kind(strings.TrimSpace(s)))
+		}
+	}
+
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+
+	for _, kind := range selected {
+		for i := 1; i <= count; i++ {
+			req, err := gen.Request(kind)
+			if err != nil {
+				return fmt.Errorf("%s: %w", kind, err)
+			}
+			res := gen.Response(req)
+
+			base := filepath.Join(out, string(kind)+"."+strconv.Itoa(i))
+			if err := writeJSON(base+".breq.json", req); err != nil {
+				return err
+			}
+			if err := writeJSON(base+".bres.json", res); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeJSON(name string, v interface{}) error {
+	if req, ok := v.(*openrtb.BidRequest); ok {
+		if err := req.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,414 @@
+/*
+Package fixtures generates realistic, spec-compliant bid requests and
+matching responses, e.g. for load tests and adapter conformance tests.
+
+Generators are deterministic for a given seed and produce objects for a
+specific OpenRTB spec version; fields introduced in later versions, such as
+rwdd, mtype and cattax, are only populated where supported, while fields
+deprecated by the version are omitted.
+
+Fixture files can be written with the openrtb-fixtures tool:
+
+	//go:generate go run github.com/bsm/openrtb/fixtures/cmd/openrtb-fixtures -out testdata
+*/
+package fixtures
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+
+	"github.com/bsm/openrtb"
+	nreq "github.com/bsm/openrtb/native/request"
+	nres "github.com/bsm/openrtb/native/response"
+)
+
+// Supported spec versions
+const (
+	Version25 = "2.5"
+	Version26 = "2.6"
+)
+
+// Kind identifies the type of request to generate.
+type Kind string
+
+// Supported request kinds
+const (
+	KindBanner Kind = "banner"
+	KindVideo  Kind = "video"
+	KindAudio  Kind = "audio"
+	KindNative Kind = "native"
+	KindDOOH   Kind = "dooh" // banner impressions on digital out-of-home inventory
+)
+
+// Kinds lists all supported request kinds.
+var Kinds = []Kind{KindBanner, KindVideo, KindAudio, KindNative, KindDOOH}
+
+// Generator errors
+var (
+	ErrUnknownVersion = errors.New("fixtures: unknown spec version")
+	ErrUnknownKind    = errors.New("fixtures: unknown request kind")
+	ErrUnsupported    = errors.New("fixtures: request kind not supported by spec version")
+)
+
+// Generator generates bid requests and responses. It is not safe for
+// concurrent use.
+type Generator struct {
+	version string
+	rnd     *rand.Rand
+}
+
+// NewGenerator creates a generator for a spec version, seeded with seed.
+func NewGenerator(version string, seed int64) (*Generator, error) {
+	switch version {
+	case Version25, Version26:
+	default:
+		return nil, ErrUnknownVersion
+	}
+	return &Generator{version: version, rnd: rand.New(rand.NewSource(seed))}, nil
+}
+
+// Version returns the spec version.
+func (g *Generator) Version() string { return g.version }
+
+// Request generates a bid request of the given kind.
+func (g *Generator) Request(kind Kind) (*openrtb.BidRequest, error) {
+	if kind == KindDOOH && g.version == Version25 {
+		return nil, ErrUnsupported
+	}
+
+	req := &openrtb.BidRequest{
+		ID:          g.id(),
+		AuctionType: 1 + g.rnd.Intn(2),
+		TMax:        100 + 10*g.rnd.Intn(20),
+		Cur:         []string{g.pick("USD", "EUR", "GBP")},
+		Bcat:        []string{"IAB25", "IAB26"},
+		BAdv:        []string{"blocked.example.com"},
+		Source:      &openrtb.Source{TID: g.id()},
+		Regs:        &openrtb.Regulations{},
+	}
+	if g.is26() {
+		req.CatTax = openrtb.CatTaxIABContent10
+	}
+
+	n := 1 + g.rnd.Intn(3)
+	for i := 0; i < n; i++ {
+		imp, err := g.imp(kind, i+1)
+		if err != nil {
+			return nil, err
+		}
+		req.Imp = append(req.Imp, *imp)
+	}
+
+	switch kind {
+	case KindDOOH:
+		req.DOOH = g.dooh()
+	case KindAudio:
+		req.App = g.app()
+		req.Device = g.device(openrtb.DeviceTypePhone)
+		req.User = g.user()
+	default:
+		if g.rnd.Intn(2) == 0 {
+			req.App = g.app()
+			req.Device = g.device(openrtb.DeviceTypePhone)
+		} else {
+			req.Site = g.site()
+			req.Device = g.device(openrtb.DeviceTypePC)
+		}
+		req.User = g.user()
+	}
+
+	if req.Device != nil && req.Device.Geo != nil && req.Device.Geo.Country == "DEU" {
+		req.Regs.GDPR = 1
+	}
+	return req, nil
+}
+
+// Requests generates n bid requests of the given kind.
+func (g *Generator) Requests(kind Kind, n int) ([]*openrtb.BidRequest, error) {
+	reqs := make([]*openrtb.BidRequest, 0, n)
+	for i := 0; i < n; i++ {
+		req, err := g.Request(kind)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+// TestRequest generates a bid request of the given kind, marked as test
+// traffic.
+func (g *Generator) TestRequest(kind Kind) (*openrtb.BidRequest, error) {
+	req, err := g.Request(kind)
+	if err != nil {
+		return nil, err
+	}
+	req.Test = 1
+	return req, nil
+}
+
+// Response generates a response to req, with one bid per impression above
+// the impression's floor. Markup matches the media type of the impression.
+func (g *Generator) Response(req *openrtb.BidRequest) *openrtb.BidResponse {
+	res := &openrtb.BidResponse{
+		ID:    req.ID,
+		BidID: g.id(),
+	}
+	if len(req.Cur) != 0 {
+		res.Currency = req.Cur[0]
+	}
+
+	seats := []string{"seat-1", "seat-2"}
+	for i := range req.Imp {
+		imp := &req.Imp[i]
+		seat := seats[i%len(seats)]
+
+		bid := g.bid(imp)
+		if n := len(res.SeatBid); n != 0 && res.SeatBid[n-1].Seat == seat {
+			res.SeatBid[n-1].Bid = append(res.SeatBid[n-1].Bid, *bid)
+		} else {
+			res.SeatBid = append(res.SeatBid, openrtb.SeatBid{Seat: seat, Bid: []openrtb.Bid{*bid}})
+		}
+	}
+	return res
+}
+
+func (g *Generator) is26() bool { return g.version == Version26 }
+
+func (g *Generator) imp(kind Kind, n int) (*openrtb.Impression, error) {
+	imp := &openrtb.Impression{
+		ID:               strconv.Itoa(n),
+		TagID:            "tag-" + strconv.Itoa(g.rnd.Intn(1000)),
+		BidFloor:         g.price(0.1, 2),
+		BidFloorCurrency: "USD",
+		Secure:           1,
+	}
+
+	switch kind {
+	case KindBanner, KindDOOH:
+		sizes := []openrtb.Format{{W: 300, H: 250}, {W: 728, H: 90}, {W: 320, H: 50}, {W: 160, H: 600}}
+		if kind == KindDOOH {
+			sizes = []openrtb.Format{{W: 1920, H: 1080}, {W: 1080, H: 1920}}
+		}
+		f := sizes[g.rnd.Intn(len(sizes))]
+		imp.Banner = &openrtb.Banner{W: f.W, H: f.H, Format: []openrtb.Format{f}, BAttr: []int{1, 3}}
+	case KindVideo:
+		imp.Video = &openrtb.Video{
+			Mimes:       []string{"video/mp4", "video/webm"},
+			MinDuration: 5,
+			MaxDuration: 15 + 15*g.rnd.Intn(3),
+			Protocols:   []int{2, 3, 5, 6},
+			W:           640,
+			H:           360,
+			Linearity:   1,
+			Skip:        g.rnd.Intn(2),
+			BAttr:       []int{16},
+		}
+		if g.is26() {
+			imp.Video.Plcmt = openrtb.VideoPlcmtInstream
+		} else {
+			imp.Video.Placement = openrtb.VideoPlacementInStream
+		}
+		if g.is26() && g.rnd.Intn(3) == 0 {
+			imp.Rwdd = 1
+		}
+	case KindAudio:
+		imp.Audio = &openrtb.Audio{
+			Mimes:       []string{"audio/mp4", "audio/mpeg"},
+			MinDuration: 5,
+			MaxDuration: 30,
+			Protocols:   []int{9, 10},
+		}
+	case KindNative:
+		data, err := json.Marshal(g.nativeRequest())
+		if err != nil {
+			return nil, err
+		}
+		imp.Native = &openrtb.Native{Request: data, Ver: "1.2"}
+	default:
+		return nil, ErrUnknownKind
+	}
+	return imp, nil
+}
+
+func (g *Generator) nativeRequest() *nreq.Request {
+	return &nreq.Request{
+		Ver:              "1.2",
+		ContextTypeID:    nreq.ContextTypeContent,
+		ContextSubTypeID: nreq.ContextSubTypeArticle,
+		PlacementTypeID:  nreq.PlacementTypeInFeed,
+		PlacementCount:   1,
+		Assets: []nreq.Asset{
+			{ID: 1, Required: 1, Title: &nreq.Title{Length: 90}},
+			{ID: 2, Required: 1, Image: &nreq.Image{TypeID: nreq.ImageTypeMain, Width: 1200, Height: 627}},
+			{ID: 3, Data: &nreq.Data{TypeID: nreq.DataTypeSponsored, Length: 25}},
+		},
+	}
+}
+
+func (g *Generator) bid(imp *openrtb.Impression) *openrtb.Bid {
+	id := g.id()
+	bid := &openrtb.Bid{
+		ID:         id,
+		ImpID:      imp.ID,
+		Price:      round(imp.BidFloor+g.price(0.01, 3), 4),
+		AdID:       "ad-" + id[:8],
+		NURL:       "https://dsp.example.com/win?price=" + openrtb.MacroAuctionPrice,
+		AdvDomain:  []string{g.pick("brand.example.com", "shop.example.com", "cars.example.com")},
+		CampaignID: openrtb.MultiString("cmp-" + strconv.Itoa(g.rnd.Intn(100))),
+		CreativeID: "cr-" + id[:8],
+		Cat:        []string{g.pick("IAB1", "IAB2", "IAB3-1", "IAB19")},
+	}
+	if g.is26() {
+		bid.CatTax = openrtb.CatTaxIABContent10
+	}
+
+	var mtype int
+	switch {
+	case imp.Banner != nil:
+		mtype = openrtb.MarkupTypeBanner
+		bid.W, bid.H = imp.Banner.W, imp.Banner.H
+		bid.AdMarkup = `<a href="https://brand.example.com/"><img src="https://cdn.example.com/` + bid.CreativeID + `.png" width="` + strconv.Itoa(bid.W) + `" height="` + strconv.Itoa(bid.H) + `"></a>`
+	case imp.Video != nil:
+		mtype = openrtb.MarkupTypeVideo
+		bid.W, bid.H = imp.Video.W, imp.Video.H
+		bid.AdMarkup = g.vast(bid.CreativeID, "video/mp4", imp.Video.MaxDuration)
+	case imp.Audio != nil:
+		mtype = openrtb.MarkupTypeAudio
+		bid.AdMarkup = g.vast(bid.CreativeID, "audio/mp4", imp.Audio.MaxDuration)
+	case imp.Native != nil:
+		mtype = openrtb.MarkupTypeNative
+		data, _ := json.Marshal(&nres.Response{
+			Ver: "1.2",
+			Assets: []nres.Asset{
+				{ID: 1, Required: 1, Title: &nres.Title{Text: "Discover the new collection"}},
+				{ID: 2, Required: 1, Image: &nres.Image{URL: "https://cdn.example.com/" + bid.CreativeID + ".jpg", Width: 1200, Height: 627}},
+				{ID: 3, Data: &nres.Data{Value: "Brand"}},
+			},
+			Link:        nres.Link{URL: "https://brand.example.com/", ClickTrackers: []string{}},
+			ImpTrackers: []string{"https://dsp.example.com/imp?price=" + openrtb.MacroAuctionPrice},
+		})
+		bid.AdMarkup = string(data)
+	}
+	if g.is26() {
+		bid.MType = mtype
+	}
+	return bid
+}
+
+func (g *Generator) vast(crid, mime string, duration int) string {
+	if duration <= 0 {
+		duration = 15
+	}
+	dur := "00:" + strconv.Itoa(100 + duration/60)[1:] + ":" + strconv.Itoa(100 + duration%60)[1:]
+	return `<VAST version="4.0"><Ad id="` + crid + `"><InLine><AdSystem>fixtures</AdSystem><AdTitle>` + crid + `</AdTitle>` +
+		`<Impression><![CDATA[https://dsp.example.com/imp]]></Impression><Creatives><Creative><Linear><Duration>` + dur + `</Duration>` +
+		`<MediaFiles><MediaFile delivery="progressive" type="` + mime + `"><![CDATA[https://cdn.example.com/` + crid + `]]></MediaFile></MediaFiles>` +
+		`</Linear></Creative></Creatives></InLine></Ad></VAST>`
+}
+
+func (g *Generator) site() *openrtb.Site {
+	domain := g.pick("news.example.com", "sports.example.com", "recipes.example.com")
+	return &openrtb.Site{
+		Inventory: openrtb.Inventory{
+			ID:        "site-" + strconv.Itoa(g.rnd.Intn(1000)),
+			Name:      domain,
+			Domain:    domain,
+			Cat:       []string{g.pick("IAB12", "IAB17", "IAB8")},
+			Publisher: &openrtb.Publisher{ID: "pub-" + strconv.Itoa(g.rnd.Intn(100)), Name: "Example Media"},
+		},
+		Page: "https://" + domain + "/articles/" + strconv.Itoa(g.rnd.Intn(100000)),
+	}
+}
+
+func (g *Generator) app() *openrtb.App {
+	bundle := g.pick("com.example.game", "com.example.news", "com.example.music")
+	return &openrtb.App{
+		Inventory: openrtb.Inventory{
+			ID:        "app-" + strconv.Itoa(g.rnd.Intn(1000)),
+			Name:      bundle,
+			Cat:       []string{g.pick("IAB9", "IAB1", "IAB12")},
+			Publisher: &openrtb.Publisher{ID: "pub-" + strconv.Itoa(g.rnd.Intn(100)), Name: "Example Apps"},
+		},
+		Bundle:   bundle,
+		StoreURL: "https://play.google.com/store/apps/details?id=" + bundle,
+		Ver:      "1." + strconv.Itoa(g.rnd.Intn(10)),
+	}
+}
+
+func (g *Generator) dooh() *openrtb.DOOH {
+	return &openrtb.DOOH{
+		ID:           "dooh-" + strconv.Itoa(g.rnd.Intn(1000)),
+		Name:         "Screen " + strconv.Itoa(g.rnd.Intn(100)),
+		VenueType:    []string{g.pick("outdoor.billboards", "transit.airports", "retail.malls")},
+		VenueTypeTax: 2,
+		Publisher:    &openrtb.Publisher{ID: "pub-" + strconv.Itoa(g.rnd.Intn(100)), Name: "Example Screens"},
+		Domain:       "screens.example.com",
+	}
+}
+
+func (g *Generator) device(deviceType int) *openrtb.Device {
+	d := &openrtb.Device{
+		DeviceType: deviceType,
+		Geo:        g.geo(),
+		IP:         "192.0.2." + strconv.Itoa(1+g.rnd.Intn(254)),
+		Language:   g.pick("en", "de", "fr"),
+		JS:         1,
+	}
+	if deviceType == openrtb.DeviceTypePhone {
+		d.UA = "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36"
+		d.Make, d.Model, d.OS, d.OSVer = "Google", "Pixel 7", "Android", "13"
+		d.W, d.H = 1080, 2400
+		d.IFA = g.uuid()
+		d.ConnType = 2
+	} else {
+		d.UA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Safari/537.36"
+		d.OS, d.OSVer = "Windows", "10"
+		d.W, d.H = 1920, 1080
+		d.ConnType = 1
+	}
+	return d
+}
+
+func (g *Generator) geo() *openrtb.Geo {
+	switch g.rnd.Intn(3) {
+	case 0:
+		return &openrtb.Geo{Country: "USA", Region: "NY", Type: 2, Lat: 40.7128, Lon: -74.006}
+	case 1:
+		return &openrtb.Geo{Country: "GBR", Region: "ENG", Type: 2, Lat: 51.5072, Lon: -0.1276}
+	default:
+		return &openrtb.Geo{Country: "DEU", Region: "BE", Type: 2, Lat: 52.52, Lon: 13.405}
+	}
+}
+
+func (g *Generator) user() *openrtb.User {
+	return &openrtb.User{ID: g.id()}
+}
+
+func (g *Generator) pick(opts ...string) string {
+	return opts[g.rnd.Intn(len(opts))]
+}
+
+func (g *Generator) price(min, max float64) float64 {
+	return round(min+g.rnd.Float64()*(max-min), 2)
+}
+
+func (g *Generator) id() string {
+	var b [16]byte
+	g.rnd.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func (g *Generator) uuid() string {
+	s := g.id()
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+func round(f float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(f*p) / p
+}
@@ -0,0 +1,128 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	nreq "github.com/bsm/openrtb/native/request"
+	nres "github.com/bsm/openrtb/native/response"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Generator", func() {
+
+	It("should reject unknown versions", func() {
+		_, err := NewGenerator("3.0", 1)
+		Expect(err).To(Equal(ErrUnknownVersion))
+	})
+
+	It("should be deterministic", func() {
+		g1, err := NewGenerator(Version26, 42)
+		Expect(err).NotTo(HaveOccurred())
+		g2, err := NewGenerator(Version26, 42)
+		Expect(err).NotTo(HaveOccurred())
+
+		r1, err := g1.Request(KindVideo)
+		Expect(err).NotTo(HaveOccurred())
+		r2, err := g2.Request(KindVideo)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r1).To(Equal(r2))
+		Expect(g1.Response(r1)).To(Equal(g2.Response(r2)))
+	})
+
+	It("should reject unknown kinds", func() {
+		g, _ := NewGenerator(Version26, 1)
+		_, err := g.Request(Kind("ctv"))
+		Expect(err).To(Equal(ErrUnknownKind))
+
+		g, _ = NewGenerator(Version25, 1)
+		_, err = g.Request(KindDOOH)
+		Expect(err).To(Equal(ErrUnsupported))
+	})
+
+	for _, version := range []string{Version25, Version26} {
+		version := version
+
+		It("should generate valid "+version+" requests and responses", func() {
+			g, err := NewGenerator(version, 7)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, kind := range Kinds {
+				if kind == KindDOOH && version == Version25 {
+					continue
+				}
+
+				reqs, err := g.Requests(kind, 20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reqs).To(HaveLen(20))
+
+				for _, req := range reqs {
+					Expect(req.Validate()).To(Succeed(), "%s: %+v", kind, req)
+					if version == Version26 {
+						Expect(openrtb.LintDeprecated(req)).To(BeEmpty())
+					}
+
+					res := g.Response(req)
+					Expect(res.ValidateForRequest(req)).To(Succeed(), "%s: %+v", kind, res)
+
+					for _, sb := range res.SeatBid {
+						for _, bid := range sb.Bid {
+							imp := req.ImpByID(bid.ImpID)
+							Expect(bid.Price).To(BeNumerically(">=", imp.BidFloor))
+							if version == Version26 {
+								Expect(bid.MType).NotTo(BeZero())
+								Expect(bid.CatTax).To(Equal(openrtb.CatTaxIABContent10))
+							} else {
+								Expect(bid.MType).To(BeZero())
+								Expect(bid.CatTax).To(BeZero())
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+
+	It("should generate native markup", func() {
+		g, _ := NewGenerator(Version26, 3)
+		req, err := g.Request(KindNative)
+		Expect(err).NotTo(HaveOccurred())
+
+		var nr nreq.Request
+		Expect(json.Unmarshal(req.Imp[0].Native.Request, &nr)).To(Succeed())
+		Expect(nr.Assets).To(HaveLen(3))
+
+		res := g.Response(req)
+		var ns nres.Response
+		Expect(json.Unmarshal([]byte(res.SeatBid[0].Bid[0].AdMarkup), &ns)).To(Succeed())
+		Expect(ns.Assets).To(HaveLen(3))
+	})
+
+	It("should generate test requests", func() {
+		g, _ := NewGenerator(Version26, 3)
+		req, err := g.TestRequest(KindBanner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.IsTest()).To(BeTrue())
+		Expect(req.Validate()).To(Succeed())
+
+		_, err = g.TestRequest(Kind("unknown"))
+		Expect(err).To(MatchError(ErrUnknownKind))
+	})
+
+	It("should generate DOOH", func() {
+		g, _ := NewGenerator(Version26, 3)
+		req, err := g.Request(KindDOOH)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.DOOH).NotTo(BeNil())
+		Expect(req.Site).To(BeNil())
+		Expect(req.App).To(BeNil())
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/fixtures")
+}
@@ -0,0 +1,117 @@
+package openrtb
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrInvalidFlag is reported by validation for flags decoded from a value
+// other than 0, 1, a boolean or null. Lenient decoding skips the check and
+// leaves such flags unset, see DecodeOptions.Lenient.
+var ErrInvalidFlag = errors.New("openrtb: invalid flag value")
+
+// Flag is a tri-state 0/1 indicator, which distinguishes absent values from
+// explicit zeros. The zero value is FlagUnset and is omitted from JSON output.
+type Flag int8
+
+// Flag values
+const (
+	FlagFalse Flag = -1 // Encoded as 0
+	FlagUnset Flag = 0  // Absent
+	FlagTrue  Flag = 1  // Encoded as 1
+
+	flagInvalid Flag = 2 // Decoded from an invalid value, reads as unset
+)
+
+// NewFlag returns FlagTrue or FlagFalse
+func NewFlag(b bool) Flag {
+	if b {
+		return FlagTrue
+	}
+	return FlagFalse
+}
+
+// IsSet returns true if the flag was explicitly set
+func (f Flag) IsSet() bool { return f == FlagTrue || f == FlagFalse }
+
+// Valid returns false if the flag was decoded from an invalid value
+func (f Flag) Valid() bool { return f >= FlagFalse && f <= FlagTrue }
+
+// IsTrue returns true if the flag was explicitly set to 1
+func (f Flag) IsTrue() bool { return f == FlagTrue }
+
+// IsFalse returns true if the flag was explicitly set to 0
+func (f Flag) IsFalse() bool { return f == FlagFalse }
+
+// Int returns the numeric value of the flag, or def if unset
+func (f Flag) Int(def int) int {
+	switch f {
+	case FlagTrue:
+		return 1
+	case FlagFalse:
+		return 0
+	}
+	return def
+}
+
+// String returns "0", "1" or "unset"
+func (f Flag) String() string {
+	if f.IsSet() {
+		return strconv.Itoa(f.Int(0))
+	}
+	return "unset"
+}
+
+// MarshalJSON encodes the flag as 0 or 1, or null if unset.
+func (f Flag) MarshalJSON() ([]byte, error) {
+	switch f {
+	case FlagTrue:
+		return []byte("1"), nil
+	case FlagFalse:
+		return []byte("0"), nil
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes 0, 1, booleans and null. Other values do not fail
+// decoding, so that lenient decoding can complete with either JSON backend.
+// They mark the flag as invalid instead, which reads as unset and is reported
+// by validation.
+func (f *Flag) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "1", "true":
+		*f = FlagTrue
+	case "0", "false":
+		*f = FlagFalse
+	case "null":
+		*f = FlagUnset
+	default:
+		*f = flagInvalid
+	}
+	return nil
+}
+
+// invalidFlag returns the JSON name of the first flag of d which was decoded
+// from an invalid value, or an empty string.
+func (d *Device) invalidFlag() string {
+	switch {
+	case !d.DNT.Valid():
+		return "dnt"
+	case !d.LMT.Valid():
+		return "lmt"
+	case !d.JS.Valid():
+		return "js"
+	case !d.GeoFetch.Valid():
+		return "geofetch"
+	}
+	return ""
+}
+
+// resetInvalidFlags unsets the flags of d which were decoded from invalid values.
+func (d *Device) resetInvalidFlags() {
+	for _, f := range []*Flag{&d.DNT, &d.LMT, &d.JS, &d.GeoFetch} {
+		if !f.Valid() {
+			*f = FlagUnset
+		}
+	}
+}
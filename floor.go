@@ -0,0 +1,148 @@
+package openrtb
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultCurrency is assumed when no currency is specified
+const DefaultCurrency = "USD"
+
+// Floor errors
+var (
+	ErrFloorNoConverter = errors.New("openrtb: floor currency not allowed and no converter given")
+	ErrFloorNoDeal      = errors.New("openrtb: no eligible deal in private auction")
+)
+
+// CurrencyConverter converts amounts between ISO-4217 currencies.
+type CurrencyConverter interface {
+	Convert(amount float64, from, to string) (float64, error)
+}
+
+// Floor is a bid floor price with its currency
+type Floor struct {
+	Price    float64
+	Currency string
+}
+
+// Convert converts the floor into the target currency.
+// A nil converter is only accepted if no conversion is necessary.
+func (f Floor) Convert(to string, conv CurrencyConverter) (Floor, error) {
+	from, to := strings.ToUpper(f.Currency), strings.ToUpper(to)
+	if from == to || f.Price == 0 {
+		return Floor{Price: f.Price, Currency: to}, nil
+	}
+	if conv == nil {
+		return Floor{}, ErrFloorNoConverter
+	}
+
+	price, err := conv.Convert(f.Price, from, to)
+	if err != nil {
+		return Floor{}, err
+	}
+	return Floor{Price: price, Currency: to}, nil
+}
+
+// Floor resolves the effective bid floor for an impression and an optional
+// deal, with the following precedence:
+//
+//  1. deal.bidfloor, if deal is not nil and its floor is set, in
+//     deal.bidfloorcur, falling back to imp.bidfloorcur and then USD;
+//  2. imp.bidfloor, in imp.bidfloorcur, falling back to USD.
+//
+// The resolved floor is then expressed in a currency bidders are allowed to
+// bid in. If the floor currency is listed in request.cur (or is USD when cur
+// is empty) it is returned as is, otherwise it is converted to the first
+// currency of request.cur using conv.
+func (req *BidRequest) Floor(imp *Impression, deal *Deal, conv CurrencyConverter) (Floor, error) {
+	floor := Floor{Price: imp.BidFloor, Currency: firstCurrency(imp.BidFloorCurrency)}
+	if deal != nil && deal.BidFloor != 0 {
+		floor = Floor{Price: deal.BidFloor, Currency: firstCurrency(deal.BidFloorCurrency, imp.BidFloorCurrency)}
+	}
+
+	if len(req.Cur) == 0 {
+		return floor.Convert(DefaultCurrency, conv)
+	}
+	for _, cur := range req.Cur {
+		if strings.ToUpper(cur) == floor.Currency {
+			return floor, nil
+		}
+	}
+	return floor.Convert(req.Cur[0], conv)
+}
+
+// DealFloor is the floor of an impression for bids on a deal, or for open
+// market bids if Deal is nil.
+type DealFloor struct {
+	Deal  *Deal
+	Floor Floor
+}
+
+// DealFloors resolves the floors a seat may bid at on an impression: the
+// open market floor, unless the impression is a private auction, followed
+// by the floors of all deals the seat is allowed to bid on, see Floor.
+// Deals are skipped if their wseat list excludes seat or, if adomains is
+// not nil, their wadomain list excludes one of the advertiser domains.
+func (req *BidRequest) DealFloors(imp *Impression, seat string, adomains []string, conv CurrencyConverter) ([]DealFloor, error) {
+	var res []DealFloor
+	if imp.Pmp == nil || !imp.Pmp.IsPrivate() {
+		floor, err := req.Floor(imp, nil, conv)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, DealFloor{Floor: floor})
+	}
+
+	if imp.Pmp != nil {
+		for i := range imp.Pmp.Deals {
+			deal := &imp.Pmp.Deals[i]
+			if !deal.AllowsSeat(seat) || (adomains != nil && !deal.AllowsAdvDomains(adomains)) {
+				continue
+			}
+
+			floor, err := req.Floor(imp, deal, conv)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, DealFloor{Deal: deal, Floor: floor})
+		}
+	}
+	return res, nil
+}
+
+// EffectiveFloor returns the lowest of the DealFloors, i.e. the minimum
+// price at which seat may bid on the impression, together with the deal to
+// bid on. Ties are resolved in favour of the open market, then in deal
+// order. It returns ErrFloorNoDeal if the impression is a private auction
+// and seat is not eligible for any of its deals.
+func (req *BidRequest) EffectiveFloor(imp *Impression, seat string, adomains []string, conv CurrencyConverter) (DealFloor, error) {
+	floors, err := req.DealFloors(imp, seat, adomains, conv)
+	if err != nil {
+		return DealFloor{}, err
+	}
+	if len(floors) == 0 {
+		return DealFloor{}, ErrFloorNoDeal
+	}
+
+	best := floors[0]
+	for _, f := range floors[1:] {
+		cmp, err := f.Floor.Convert(best.Floor.Currency, conv)
+		if err != nil {
+			return DealFloor{}, err
+		}
+		if cmp.Price < best.Floor.Price {
+			best = f
+		}
+	}
+	return best, nil
+}
+
+// firstCurrency returns the first non-blank currency, or DefaultCurrency.
+func firstCurrency(curs ...string) string {
+	for _, cur := range curs {
+		if cur != "" {
+			return NormalizeCurrency(cur)
+		}
+	}
+	return DefaultCurrency
+}
@@ -0,0 +1,89 @@
+package openrtb
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockConverter map[string]float64
+
+func (c mockConverter) Convert(amount float64, from, to string) (float64, error) {
+	rate, ok := c[from+to]
+	if !ok {
+		return 0, errors.New("unknown rate")
+	}
+	return amount * rate, nil
+}
+
+var _ = Describe("Floor", func() {
+	var subject *BidRequest
+	var conv = mockConverter{"EURUSD": 1.25, "USDEUR": 0.8, "GBPEUR": 1.5}
+
+	BeforeEach(func() {
+		subject = &BidRequest{ID: "A", Cur: []string{"eur", "USD"}}
+	})
+
+	It("should convert", func() {
+		Expect(Floor{Price: 2, Currency: "EUR"}.Convert("usd", conv)).To(Equal(Floor{Price: 2.5, Currency: "USD"}))
+		Expect(Floor{Price: 2, Currency: "EUR"}.Convert("EUR", nil)).To(Equal(Floor{Price: 2, Currency: "EUR"}))
+		Expect(Floor{Price: 2, Currency: "eur"}.Convert("EUR", nil)).To(Equal(Floor{Price: 2, Currency: "EUR"}))
+		Expect(Floor{Price: 2, Currency: "eur"}.Convert("usd", conv)).To(Equal(Floor{Price: 2.5, Currency: "USD"}))
+		_, err := Floor{Price: 2, Currency: "EUR"}.Convert("USD", nil)
+		Expect(err).To(Equal(ErrFloorNoConverter))
+	})
+
+	It("should resolve imp floors", func() {
+		imp := &Impression{ID: "1", BidFloor: 1, BidFloorCurrency: "usd"}
+		Expect(subject.Floor(imp, nil, nil)).To(Equal(Floor{Price: 1, Currency: "USD"}))
+
+		imp.BidFloorCurrency = ""
+		Expect(subject.Floor(imp, nil, nil)).To(Equal(Floor{Price: 1, Currency: "USD"}))
+
+		imp.BidFloorCurrency = "GBP"
+		Expect(subject.Floor(imp, nil, conv)).To(Equal(Floor{Price: 1.5, Currency: "EUR"}))
+		_, err := subject.Floor(imp, nil, nil)
+		Expect(err).To(Equal(ErrFloorNoConverter))
+
+		subject.Cur = nil
+		imp.BidFloorCurrency = "EUR"
+		Expect(subject.Floor(imp, nil, conv)).To(Equal(Floor{Price: 1.25, Currency: "USD"}))
+	})
+
+	It("should prefer deal floors", func() {
+		imp := &Impression{ID: "1", BidFloor: 1, BidFloorCurrency: "GBP"}
+		Expect(subject.Floor(imp, &Deal{ID: "D", BidFloor: 3}, conv)).To(Equal(Floor{Price: 4.5, Currency: "EUR"}))
+		Expect(subject.Floor(imp, &Deal{ID: "D", BidFloor: 3, BidFloorCurrency: "USD"}, conv)).To(Equal(Floor{Price: 3, Currency: "USD"}))
+		Expect(subject.Floor(imp, &Deal{ID: "D", BidFloor: 3, BidFloorCurrency: "eur"}, conv)).To(Equal(Floor{Price: 3, Currency: "EUR"}))
+		Expect(subject.Floor(imp, &Deal{ID: "D"}, conv)).To(Equal(Floor{Price: 1.5, Currency: "EUR"}))
+	})
+
+	It("should resolve deal floors", func() {
+		imp := &Impression{ID: "1", BidFloor: 2, BidFloorCurrency: "EUR", Pmp: &Pmp{Deals: []Deal{
+			{ID: "D1", BidFloor: 1, WSeat: []string{"s1"}},
+			{ID: "D2", BidFloor: 1.5, BidFloorCurrency: "USD", WAdvDomain: []string{"example.com"}},
+			{ID: "D3", BidFloor: 3},
+		}}}
+		deals := imp.Pmp.Deals
+
+		Expect(subject.DealFloors(imp, "s2", nil, conv)).To(Equal([]DealFloor{
+			{Floor: Floor{Price: 2, Currency: "EUR"}},
+			{Deal: &deals[1], Floor: Floor{Price: 1.5, Currency: "USD"}},
+			{Deal: &deals[2], Floor: Floor{Price: 3, Currency: "EUR"}},
+		}))
+		Expect(subject.DealFloors(imp, "s2", []string{"other.com"}, conv)).To(HaveLen(2))
+
+		Expect(subject.EffectiveFloor(imp, "s2", nil, conv)).To(Equal(DealFloor{Deal: &deals[1], Floor: Floor{Price: 1.5, Currency: "USD"}}))
+		Expect(subject.EffectiveFloor(imp, "s1", []string{"other.com"}, conv)).To(Equal(DealFloor{Deal: &deals[0], Floor: Floor{Price: 1, Currency: "EUR"}}))
+		Expect(subject.EffectiveFloor(imp, "s2", []string{"other.com"}, conv)).To(Equal(DealFloor{Floor: Floor{Price: 2, Currency: "EUR"}}))
+
+		imp.Pmp.Private = 1
+		Expect(subject.EffectiveFloor(imp, "s2", []string{"other.com"}, conv)).To(Equal(DealFloor{Deal: &deals[2], Floor: Floor{Price: 3, Currency: "EUR"}}))
+
+		imp.Pmp.Deals = deals[:1]
+		_, err := subject.EffectiveFloor(imp, "s2", nil, conv)
+		Expect(err).To(Equal(ErrFloorNoDeal))
+	})
+
+})
@@ -0,0 +1,126 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrInvalidGeoLat     = errors.New("openrtb: geo latitude out of range")
+	ErrInvalidGeoLon     = errors.New("openrtb: geo longitude out of range")
+	ErrInvalidGeoType    = errors.New("openrtb: geo type is invalid")
+	ErrInvalidGeoCountry = errors.New("openrtb: geo country is not an ISO-3166-1 alpha-3 code")
+)
+
+// GeoProvider looks up the location of an IP address, e.g. via an IP-geo
+// database. Implementations should return nil, nil for unknown addresses.
+type GeoProvider interface {
+	LookupGeo(ctx context.Context, ip string) (*Geo, error)
+}
+
+// IsValidCountry returns true if code is an assigned ISO-3166-1 alpha-3
+// country code.
+func IsValidCountry(code string) bool {
+	_, ok := iso3166[strings.ToUpper(code)]
+	return ok
+}
+
+// Validate checks the latitude and longitude ranges, the location type and
+// the country code.
+func (g *Geo) Validate() error {
+	if g.Lat < -90 || g.Lat > 90 {
+		return ErrInvalidGeoLat
+	} else if g.Lon < -180 || g.Lon > 180 {
+		return ErrInvalidGeoLon
+	} else if g.Type != 0 && (g.Type < LocationTypeGPS || g.Type > LocationTypeUser) {
+		return ErrInvalidGeoType
+	} else if g.Country != "" && !IsValidCountry(g.Country) {
+		return ErrInvalidGeoCountry
+	}
+	return nil
+}
+
+// EnrichGeo populates missing geo attributes of the device from the
+// location of its IP address, falling back to the IPv6 address. Declared
+// values are never overwritten. If a location was added, the geo type is
+// set to LocationTypeIP, unless already set.
+func (d *Device) EnrichGeo(ctx context.Context, p GeoProvider) error {
+	ip := d.IP
+	if ip == "" {
+		ip = d.IPv6
+	}
+	if ip == "" {
+		return nil
+	}
+
+	info, err := p.LookupGeo(ctx, ip)
+	if err != nil || info == nil {
+		return err
+	}
+
+	if d.Geo == nil {
+		d.Geo = new(Geo)
+	}
+	geo := d.Geo
+	if geo.Lat == 0 && geo.Lon == 0 && (info.Lat != 0 || info.Lon != 0) {
+		geo.Lat, geo.Lon = info.Lat, info.Lon
+		if geo.Accuracy == 0 {
+			geo.Accuracy = info.Accuracy
+		}
+		if geo.Type == 0 {
+			geo.Type = LocationTypeIP
+		}
+	}
+	if geo.IPService == 0 {
+		geo.IPService = info.IPService
+	}
+	if geo.Country == "" {
+		geo.Country = info.Country
+	}
+	if geo.Region == "" {
+		geo.Region = info.Region
+	}
+	if geo.Metro == "" {
+		geo.Metro = info.Metro
+	}
+	if geo.City == "" {
+		geo.City = info.City
+	}
+	if geo.Zip == "" {
+		geo.Zip = info.Zip
+	}
+	if geo.UTCOffset == 0 {
+		geo.UTCOffset = info.UTCOffset
+	}
+	return nil
+}
+
+var iso3166 = map[string]struct{}{
+	"ABW": {}, "AFG": {}, "AGO": {}, "AIA": {}, "ALA": {}, "ALB": {}, "AND": {}, "ARE": {}, "ARG": {}, "ARM": {},
+	"ASM": {}, "ATA": {}, "ATF": {}, "ATG": {}, "AUS": {}, "AUT": {}, "AZE": {}, "BDI": {}, "BEL": {}, "BEN": {},
+	"BES": {}, "BFA": {}, "BGD": {}, "BGR": {}, "BHR": {}, "BHS": {}, "BIH": {}, "BLM": {}, "BLR": {}, "BLZ": {},
+	"BMU": {}, "BOL": {}, "BRA": {}, "BRB": {}, "BRN": {}, "BTN": {}, "BVT": {}, "BWA": {}, "CAF": {}, "CAN": {},
+	"CCK": {}, "CHE": {}, "CHL": {}, "CHN": {}, "CIV": {}, "CMR": {}, "COD": {}, "COG": {}, "COK": {}, "COL": {},
+	"COM": {}, "CPV": {}, "CRI": {}, "CUB": {}, "CUW": {}, "CXR": {}, "CYM": {}, "CYP": {}, "CZE": {}, "DEU": {},
+	"DJI": {}, "DMA": {}, "DNK": {}, "DOM": {}, "DZA": {}, "ECU": {}, "EGY": {}, "ERI": {}, "ESH": {}, "ESP": {},
+	"EST": {}, "ETH": {}, "FIN": {}, "FJI": {}, "FLK": {}, "FRA": {}, "FRO": {}, "FSM": {}, "GAB": {}, "GBR": {},
+	"GEO": {}, "GGY": {}, "GHA": {}, "GIB": {}, "GIN": {}, "GLP": {}, "GMB": {}, "GNB": {}, "GNQ": {}, "GRC": {},
+	"GRD": {}, "GRL": {}, "GTM": {}, "GUF": {}, "GUM": {}, "GUY": {}, "HKG": {}, "HMD": {}, "HND": {}, "HRV": {},
+	"HTI": {}, "HUN": {}, "IDN": {}, "IMN": {}, "IND": {}, "IOT": {}, "IRL": {}, "IRN": {}, "IRQ": {}, "ISL": {},
+	"ISR": {}, "ITA": {}, "JAM": {}, "JEY": {}, "JOR": {}, "JPN": {}, "KAZ": {}, "KEN": {}, "KGZ": {}, "KHM": {},
+	"KIR": {}, "KNA": {}, "KOR": {}, "KWT": {}, "LAO": {}, "LBN": {}, "LBR": {}, "LBY": {}, "LCA": {}, "LIE": {},
+	"LKA": {}, "LSO": {}, "LTU": {}, "LUX": {}, "LVA": {}, "MAC": {}, "MAF": {}, "MAR": {}, "MCO": {}, "MDA": {},
+	"MDG": {}, "MDV": {}, "MEX": {}, "MHL": {}, "MKD": {}, "MLI": {}, "MLT": {}, "MMR": {}, "MNE": {}, "MNG": {},
+	"MNP": {}, "MOZ": {}, "MRT": {}, "MSR": {}, "MTQ": {}, "MUS": {}, "MWI": {}, "MYS": {}, "MYT": {}, "NAM": {},
+	"NCL": {}, "NER": {}, "NFK": {}, "NGA": {}, "NIC": {}, "NIU": {}, "NLD": {}, "NOR": {}, "NPL": {}, "NRU": {},
+	"NZL": {}, "OMN": {}, "PAK": {}, "PAN": {}, "PCN": {}, "PER": {}, "PHL": {}, "PLW": {}, "PNG": {}, "POL": {},
+	"PRI": {}, "PRK": {}, "PRT": {}, "PRY": {}, "PSE": {}, "PYF": {}, "QAT": {}, "REU": {}, "ROU": {}, "RUS": {},
+	"RWA": {}, "SAU": {}, "SDN": {}, "SEN": {}, "SGP": {}, "SGS": {}, "SHN": {}, "SJM": {}, "SLB": {}, "SLE": {},
+	"SLV": {}, "SMR": {}, "SOM": {}, "SPM": {}, "SRB": {}, "SSD": {}, "STP": {}, "SUR": {}, "SVK": {}, "SVN": {},
+	"SWE": {}, "SWZ": {}, "SXM": {}, "SYC": {}, "SYR": {}, "TCA": {}, "TCD": {}, "TGO": {}, "THA": {}, "TJK": {},
+	"TKL": {}, "TKM": {}, "TLS": {}, "TON": {}, "TTO": {}, "TUN": {}, "TUR": {}, "TUV": {}, "TWN": {}, "TZA": {},
+	"UGA": {}, "UKR": {}, "UMI": {}, "URY": {}, "USA": {}, "UZB": {}, "VAT": {}, "VCT": {}, "VEN": {}, "VGB": {},
+	"VIR": {}, "VNM": {}, "VUT": {}, "WLF": {}, "WSM": {}, "YEM": {}, "ZAF": {}, "ZMB": {}, "ZWE": {},
+}
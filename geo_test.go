@@ -0,0 +1,75 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockGeoProvider map[string]*Geo
+
+func (m mockGeoProvider) LookupGeo(_ context.Context, ip string) (*Geo, error) {
+	if ip == "error" {
+		return nil, errors.New("lookup failed")
+	}
+	return m[ip], nil
+}
+
+var _ = Describe("Geo", func() {
+
+	It("should validate", func() {
+		Expect((&Geo{}).Validate()).To(Succeed())
+		Expect((&Geo{Lat: -90, Lon: 180, Type: LocationTypeUser, Country: "usa"}).Validate()).To(Succeed())
+		Expect((&Geo{Lat: 90.1}).Validate()).To(Equal(ErrInvalidGeoLat))
+		Expect((&Geo{Lon: -180.5}).Validate()).To(Equal(ErrInvalidGeoLon))
+		Expect((&Geo{Type: 4}).Validate()).To(Equal(ErrInvalidGeoType))
+		Expect((&Geo{Country: "US"}).Validate()).To(Equal(ErrInvalidGeoCountry))
+		Expect((&Geo{Country: "XYZ"}).Validate()).To(Equal(ErrInvalidGeoCountry))
+		Expect(ValidationCode(ErrInvalidGeoCountry)).To(Equal("geo_invalid_country"))
+	})
+
+	It("should validate countries", func() {
+		Expect(IsValidCountry("DEU")).To(BeTrue())
+		Expect(IsValidCountry("gbr")).To(BeTrue())
+		Expect(IsValidCountry("UK")).To(BeFalse())
+		Expect(IsValidCountry("")).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("Device", func() {
+	var provider = mockGeoProvider{
+		"192.0.2.1":   {Lat: 52.52, Lon: 13.405, Accuracy: 5000, Country: "DEU", City: "Berlin", IPService: 3},
+		"2001:db8::1": {Country: "FRA"},
+	}
+
+	It("should enrich geo", func() {
+		dev := &Device{IP: "192.0.2.1"}
+		Expect(dev.EnrichGeo(context.Background(), provider)).To(Succeed())
+		Expect(dev.Geo).To(Equal(&Geo{Lat: 52.52, Lon: 13.405, Accuracy: 5000, Type: LocationTypeIP, Country: "DEU", City: "Berlin", IPService: 3}))
+
+		dev = &Device{IP: "192.0.2.1", Geo: &Geo{Lat: 1, Lon: 2, Type: LocationTypeGPS, Country: "POL"}}
+		Expect(dev.EnrichGeo(context.Background(), provider)).To(Succeed())
+		Expect(dev.Geo).To(Equal(&Geo{Lat: 1, Lon: 2, Type: LocationTypeGPS, Country: "POL", City: "Berlin", IPService: 3}))
+
+		dev = &Device{IPv6: "2001:db8::1"}
+		Expect(dev.EnrichGeo(context.Background(), provider)).To(Succeed())
+		Expect(dev.Geo).To(Equal(&Geo{Country: "FRA"}))
+	})
+
+	It("should skip unknown addresses", func() {
+		dev := &Device{IP: "198.51.100.1"}
+		Expect(dev.EnrichGeo(context.Background(), provider)).To(Succeed())
+		Expect(dev.Geo).To(BeNil())
+
+		dev = &Device{}
+		Expect(dev.EnrichGeo(context.Background(), provider)).To(Succeed())
+		Expect(dev.Geo).To(BeNil())
+
+		dev = &Device{IP: "error"}
+		Expect(dev.EnrichGeo(context.Background(), provider)).To(MatchError("lookup failed"))
+	})
+
+})
@@ -0,0 +1,91 @@
+package openrtb
+
+import "strings"
+
+// ImpExtData is the imp.ext.data object, containing first party data about
+// the impression.
+type ImpExtData struct {
+	PBAdSlot string          `json:"pbadslot,omitempty"` // DEPRECATED: Prebid ad slot, replaced by imp.ext.gpid
+	AdServer *ImpExtAdServer `json:"adserver,omitempty"` // Ad server the impression is served by
+}
+
+// ImpExtAdServer is the imp.ext.data.adserver object.
+type ImpExtAdServer struct {
+	Name   string `json:"name,omitempty"`   // Name of the ad server, e.g. "gam"
+	AdSlot string `json:"adslot,omitempty"` // Ad unit code of the ad server, e.g. "/1111/homepage"
+}
+
+// ExtData decodes imp.ext.data. It returns nil if absent.
+func (imp *Impression) ExtData() (*ImpExtData, error) {
+	var data *ImpExtData
+	if _, err := imp.Ext.getKey("data", &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GPID returns the normalized Global Placement ID of the impression, from
+// imp.ext.gpid or, as a fallback, the legacy imp.ext.data.pbadslot. It
+// returns an empty string if neither is present.
+func (imp *Impression) GPID() (string, error) {
+	var gpid string
+	if _, err := imp.Ext.getKey("gpid", &gpid); err != nil {
+		return "", err
+	}
+	if gpid != "" {
+		return NormalizeGPID(gpid), nil
+	}
+
+	data, err := imp.ExtData()
+	if err != nil || data == nil {
+		return "", err
+	}
+	return NormalizeGPID(data.PBAdSlot), nil
+}
+
+// SetGPID normalizes gpid and stores it as imp.ext.gpid.
+func (imp *Impression) SetGPID(gpid string) error {
+	ext, err := imp.Ext.setKey("gpid", NormalizeGPID(gpid))
+	if err != nil {
+		return err
+	}
+	imp.Ext = ext
+	return nil
+}
+
+// ImpByGPID returns the first impression with the given Global Placement ID,
+// or nil if not found. Impressions with invalid extensions are skipped.
+func (req *BidRequest) ImpByGPID(gpid string) *Impression {
+	gpid = NormalizeGPID(gpid)
+	if gpid == "" {
+		return nil
+	}
+
+	for i := range req.Imp {
+		if v, err := req.Imp[i].GPID(); err == nil && v == gpid {
+			return &req.Imp[i]
+		}
+	}
+	return nil
+}
+
+// NormalizeGPID normalizes a Global Placement ID of the form
+// "<ad unit path>[#<div id>]" by trimming whitespace, collapsing repeated
+// slashes and removing trailing slashes from the ad unit path. The div ID is
+// left unchanged.
+func NormalizeGPID(gpid string) string {
+	gpid = strings.TrimSpace(gpid)
+
+	path, div := gpid, ""
+	if i := strings.IndexByte(gpid, '#'); i > -1 {
+		path, div = gpid[:i], gpid[i:]
+	}
+
+	for strings.Contains(path, "//") {
+		path = strings.Replace(path, "//", "/", -1)
+	}
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	return path + div
+}
@@ -0,0 +1,65 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GPID", func() {
+
+	It("should normalize", func() {
+		Expect(NormalizeGPID("")).To(Equal(""))
+		Expect(NormalizeGPID(" /1111/home ")).To(Equal("/1111/home"))
+		Expect(NormalizeGPID("/1111//home/")).To(Equal("/1111/home"))
+		Expect(NormalizeGPID("/1111/home//#div-1")).To(Equal("/1111/home#div-1"))
+		Expect(NormalizeGPID("/1111/home#div//1")).To(Equal("/1111/home#div//1"))
+		Expect(NormalizeGPID("/")).To(Equal("/"))
+	})
+
+	It("should decode imp.ext.gpid", func() {
+		imp := &Impression{ID: "1", Ext: Extension(`{"gpid":"/1111/home//#div-1","data":{"pbadslot":"/1111/other"}}`)}
+		gpid, err := imp.GPID()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gpid).To(Equal("/1111/home#div-1"))
+	})
+
+	It("should fall back on imp.ext.data.pbadslot", func() {
+		imp := &Impression{ID: "1", Ext: Extension(`{"data":{"pbadslot":"/1111/home/","adserver":{"name":"gam","adslot":"/1111/home"}}}`)}
+		gpid, err := imp.GPID()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gpid).To(Equal("/1111/home"))
+
+		data, err := imp.ExtData()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(&ImpExtData{
+			PBAdSlot: "/1111/home/",
+			AdServer: &ImpExtAdServer{Name: "gam", AdSlot: "/1111/home"},
+		}))
+
+		gpid, err = (&Impression{ID: "1"}).GPID()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gpid).To(BeEmpty())
+
+		_, err = (&Impression{ID: "1", Ext: Extension(`{"gpid":1}`)}).GPID()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should encode imp.ext.gpid", func() {
+		imp := &Impression{ID: "1", Ext: Extension(`{"other":1}`)}
+		Expect(imp.SetGPID(" /1111//home ")).To(Succeed())
+		Expect(string(imp.Ext)).To(Equal(`{"gpid":"/1111/home","other":1}`))
+	})
+
+	It("should find impressions", func() {
+		req := &BidRequest{Imp: []Impression{
+			{ID: "1", Ext: Extension(`{"gpid":"/1111/top"}`)},
+			{ID: "2", Ext: Extension(`{"data":{"pbadslot":"/1111/bottom"}}`)},
+			{ID: "3"},
+		}}
+		Expect(req.ImpByGPID("/1111/bottom/").ID).To(Equal("2"))
+		Expect(req.ImpByGPID("/1111/top").ID).To(Equal("1"))
+		Expect(req.ImpByGPID("/1111/other")).To(BeNil())
+		Expect(req.ImpByGPID("")).To(BeNil())
+	})
+
+})
@@ -0,0 +1,235 @@
+package openrtb
+
+import (
+	"errors"
+	"strings"
+)
+
+// GPP errors
+var (
+	ErrInvalidGPP           = errors.New("openrtb: GPP string is malformed")
+	ErrNoGPPSectionParser   = errors.New("openrtb: no GPP section parser registered")
+	ErrGPPSectionNotFound   = errors.New("openrtb: GPP section not found")
+	ErrGPPSectionNotApplied = errors.New("openrtb: GPP section not listed in gpp_sid")
+)
+
+// GPP section IDs
+const (
+	GPPSectionTCFEUv2 int = 2
+	GPPSectionTCFCAv1 int = 5
+	GPPSectionUSPv1   int = 6
+	GPPSectionUSNat   int = 7
+	GPPSectionUSCA    int = 8
+	GPPSectionUSVA    int = 9
+	GPPSectionUSCO    int = 10
+	GPPSectionUSUT    int = 11
+	GPPSectionUSCT    int = 12
+)
+
+// GPP header limits, guarding against headers that expand into more
+// sections than a GPP string can carry.
+const (
+	maxGPPSectionID = 1<<12 - 1
+	maxGPPSections  = 64
+)
+
+// GPPSectionParser parses the encoded value of a single GPP section.
+type GPPSectionParser interface {
+	ParseGPPSection(value string) (interface{}, error)
+}
+
+var gppSectionParsers = make(map[int]GPPSectionParser)
+
+// RegisterGPPSectionParser registers a parser for a GPP section ID.
+// It is not safe for concurrent use and should be called on init.
+// Pass nil to unregister.
+func RegisterGPPSectionParser(sectionID int, p GPPSectionParser) {
+	if p == nil {
+		delete(gppSectionParsers, sectionID)
+		return
+	}
+	gppSectionParsers[sectionID] = p
+}
+
+// GPPSection is a single, still encoded section of a GPP string
+type GPPSection struct {
+	ID    int
+	Value string
+}
+
+// ParseGPP splits a GPP string into its sections, using the header to
+// identify each of them.
+func ParseGPP(s string) ([]GPPSection, error) {
+	parts := strings.Split(s, "~")
+
+	ids, err := parseGPPHeader(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(parts)-1 {
+		return nil, ErrInvalidGPP
+	}
+
+	sections := make([]GPPSection, len(ids))
+	for i, id := range ids {
+		sections[i] = GPPSection{ID: id, Value: parts[i+1]}
+	}
+	return sections, nil
+}
+
+// GPPSection decodes the section with the given ID using the registered parser.
+// Sections that are not listed in GPPSID are not returned when GPPSID is set.
+func (r *Regulations) GPPSection(sectionID int) (interface{}, error) {
+	parser, ok := gppSectionParsers[sectionID]
+	if !ok {
+		return nil, ErrNoGPPSectionParser
+	}
+
+	if len(r.GPPSID) != 0 {
+		applies := false
+		for _, sid := range r.GPPSID {
+			if sid == sectionID {
+				applies = true
+				break
+			}
+		}
+		if !applies {
+			return nil, ErrGPPSectionNotApplied
+		}
+	}
+
+	if r.GPP == "" {
+		return nil, ErrGPPSectionNotFound
+	}
+	sections, err := ParseGPP(r.GPP)
+	if err != nil {
+		return nil, err
+	}
+	for _, sec := range sections {
+		if sec.ID == sectionID {
+			return parser.ParseGPPSection(sec.Value)
+		}
+	}
+	return nil, ErrGPPSectionNotFound
+}
+
+// parseGPPHeader decodes the section IDs from the GPP header.
+func parseGPPHeader(header string) ([]int, error) {
+	r, err := newGPPBitReader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ, err := r.readInt(6); err != nil {
+		return nil, err
+	} else if typ != 3 {
+		return nil, ErrInvalidGPP
+	}
+	if ver, err := r.readInt(6); err != nil {
+		return nil, err
+	} else if ver != 1 {
+		return nil, ErrInvalidGPP
+	}
+
+	count, err := r.readInt(12)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	last := 0
+	for i := 0; i < count; i++ {
+		isRange, err := r.readInt(1)
+		if err != nil {
+			return nil, err
+		}
+
+		start, err := r.readFibonacci()
+		if err != nil {
+			return nil, err
+		}
+		if start > maxGPPSectionID-last {
+			return nil, ErrInvalidGPP
+		}
+		start += last
+		end := start
+
+		if isRange == 1 {
+			n, err := r.readFibonacci()
+			if err != nil {
+				return nil, err
+			}
+			if n > maxGPPSectionID-end {
+				return nil, ErrInvalidGPP
+			}
+			end += n
+		}
+
+		if len(ids)+end-start+1 > maxGPPSections {
+			return nil, ErrInvalidGPP
+		}
+		for id := start; id <= end; id++ {
+			ids = append(ids, id)
+		}
+		last = end
+	}
+	return ids, nil
+}
+
+type gppBitReader struct {
+	bits []byte
+	pos  int
+}
+
+func newGPPBitReader(s string) (*gppBitReader, error) {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+	bits := make([]byte, 0, len(s)*6)
+	for i := 0; i < len(s); i++ {
+		n := strings.IndexByte(alphabet, s[i])
+		if n < 0 {
+			return nil, ErrInvalidGPP
+		}
+		for j := 5; j >= 0; j-- {
+			bits = append(bits, byte(n>>uint(j))&1)
+		}
+	}
+	return &gppBitReader{bits: bits}, nil
+}
+
+func (r *gppBitReader) readInt(n int) (int, error) {
+	if r.pos+n > len(r.bits) {
+		return 0, ErrInvalidGPP
+	}
+
+	v := 0
+	for _, b := range r.bits[r.pos : r.pos+n] {
+		v = v<<1 | int(b)
+	}
+	r.pos += n
+	return v, nil
+}
+
+// readFibonacci reads a Fibonacci encoded integer, terminated by two consecutive 1 bits.
+// Values above maxGPPSectionID are rejected.
+func (r *gppBitReader) readFibonacci() (int, error) {
+	v, a, b := 0, 1, 2
+	prev := byte(0)
+	for r.pos < len(r.bits) {
+		bit := r.bits[r.pos]
+		r.pos++
+
+		if bit == 1 && prev == 1 {
+			return v, nil
+		}
+		if a > maxGPPSectionID {
+			return 0, ErrInvalidGPP
+		}
+		if bit == 1 {
+			v += a
+		}
+		prev = bit
+		a, b = b, a+b
+	}
+	return 0, ErrInvalidGPP
+}
@@ -0,0 +1,69 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockGPPSectionParser struct{}
+
+func (mockGPPSectionParser) ParseGPPSection(v string) (interface{}, error) { return "parsed:" + v, nil }
+
+var _ = Describe("GPP", func() {
+	const tcf = "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"
+
+	AfterEach(func() {
+		RegisterGPPSectionParser(GPPSectionUSPv1, nil)
+	})
+
+	It("should parse strings", func() {
+		Expect(ParseGPP("DBABMA~" + tcf)).To(Equal([]GPPSection{
+			{ID: GPPSectionTCFEUv2, Value: tcf},
+		}))
+		Expect(ParseGPP("DBACNY~" + tcf + "~1YNN")).To(Equal([]GPPSection{
+			{ID: GPPSectionTCFEUv2, Value: tcf},
+			{ID: GPPSectionUSPv1, Value: "1YNN"},
+		}))
+	})
+
+	It("should reject bad strings", func() {
+		_, err := ParseGPP("DBACNY~" + tcf)
+		Expect(err).To(Equal(ErrInvalidGPP))
+		_, err = ParseGPP("DBA")
+		Expect(err).To(Equal(ErrInvalidGPP))
+		_, err = ParseGPP("*BABMA~" + tcf)
+		Expect(err).To(Equal(ErrInvalidGPP))
+	})
+
+	It("should reject headers with too many sections", func() {
+		_, err := ParseGPP("DBAB6qqqqqqw")
+		Expect(err).To(Equal(ErrInvalidGPP))
+		_, err = ParseGPP("DBAB6Qw")
+		Expect(err).To(Equal(ErrInvalidGPP))
+
+		subject := &Regulations{GPP: "DBAB6qqqqqqw~1YNN"}
+		RegisterGPPSectionParser(GPPSectionUSPv1, mockGPPSectionParser{})
+		_, err = subject.GPPSection(GPPSectionUSPv1)
+		Expect(err).To(Equal(ErrInvalidGPP))
+	})
+
+	It("should decode sections", func() {
+		subject := &Regulations{GPP: "DBACNY~" + tcf + "~1YNN", GPPSID: []int{GPPSectionUSPv1}}
+
+		_, err := subject.GPPSection(GPPSectionUSPv1)
+		Expect(err).To(Equal(ErrNoGPPSectionParser))
+
+		RegisterGPPSectionParser(GPPSectionUSPv1, mockGPPSectionParser{})
+		Expect(subject.GPPSection(GPPSectionUSPv1)).To(Equal("parsed:1YNN"))
+
+		subject.GPPSID = []int{GPPSectionTCFEUv2}
+		_, err = subject.GPPSection(GPPSectionUSPv1)
+		Expect(err).To(Equal(ErrGPPSectionNotApplied))
+
+		subject.GPPSID = nil
+		subject.GPP = "DBABMA~" + tcf
+		_, err = subject.GPPSection(GPPSectionUSPv1)
+		Expect(err).To(Equal(ErrGPPSectionNotFound))
+	})
+
+})
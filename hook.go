@@ -0,0 +1,50 @@
+package openrtb
+
+import "context"
+
+// RequestHook inspects or modifies a bid request, e.g. to sanitize fields,
+// scrub personal data, adjust floors or log. Returning an error aborts
+// processing of the request.
+//
+// Hooks are plain functions for the codec and HTTP helpers, which have no
+// auction state: see DecodeOptions.RequestHook, which also applies to
+// ReadBidRequest, and client.Options. Modules of package module plug into
+// the auction lifecycle instead; module.RequestHook adapts a hook to a
+// module stage.
+type RequestHook func(ctx context.Context, req *BidRequest) error
+
+// ResponseHook inspects or modifies a bid response. Returning an error
+// aborts processing of the response.
+type ResponseHook func(ctx context.Context, res *BidResponse) error
+
+// ChainRequestHooks composes hooks into a single hook which runs them in
+// order and stops at the first error. Nil hooks are skipped.
+func ChainRequestHooks(hooks ...RequestHook) RequestHook {
+	return func(ctx context.Context, req *BidRequest) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				continue
+			}
+			if err := hook(ctx, req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ChainResponseHooks composes hooks into a single hook which runs them in
+// order and stops at the first error. Nil hooks are skipped.
+func ChainResponseHooks(hooks ...ResponseHook) ResponseHook {
+	return func(ctx context.Context, res *BidResponse) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				continue
+			}
+			if err := hook(ctx, res); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChainRequestHooks", func() {
+	var ctx = context.Background()
+
+	It("should run hooks in order", func() {
+		var calls []string
+		track := func(name string, err error) RequestHook {
+			return func(_ context.Context, req *BidRequest) error {
+				calls = append(calls, name)
+				req.Test = len(calls)
+				return err
+			}
+		}
+
+		req := &BidRequest{ID: "R"}
+		Expect(ChainRequestHooks(track("a", nil), nil, track("b", nil))(ctx, req)).To(Succeed())
+		Expect(calls).To(Equal([]string{"a", "b"}))
+		Expect(req.Test).To(Equal(2))
+
+		calls = calls[:0]
+		Expect(ChainRequestHooks(track("a", errors.New("boom")), track("b", nil))(ctx, req)).To(MatchError("boom"))
+		Expect(calls).To(Equal([]string{"a"}))
+
+		Expect(ChainRequestHooks()(ctx, req)).To(Succeed())
+	})
+
+})
+
+var _ = Describe("ChainResponseHooks", func() {
+	var ctx = context.Background()
+
+	It("should run hooks in order", func() {
+		var calls []string
+		track := func(name string, err error) ResponseHook {
+			return func(_ context.Context, res *BidResponse) error {
+				calls = append(calls, name)
+				return err
+			}
+		}
+
+		res := &BidResponse{ID: "R"}
+		Expect(ChainResponseHooks(track("a", nil), track("b", nil))(ctx, res)).To(Succeed())
+		Expect(calls).To(Equal([]string{"a", "b"}))
+
+		calls = calls[:0]
+		Expect(ChainResponseHooks(track("a", nil), track("b", errors.New("boom")), track("c", nil))(ctx, res)).To(MatchError("boom"))
+		Expect(calls).To(Equal([]string{"a", "b"}))
+	})
+
+})
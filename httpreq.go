@@ -0,0 +1,31 @@
+package openrtb
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+)
+
+// ReadBidRequest decodes and validates the bid request in the body of an
+// inbound HTTP request. Gzipped bodies are decompressed and decoding stops
+// early when the HTTP request is cancelled. Devices without a structured user
+// agent are populated from the Sec-CH-UA* headers of r, if present, see
+// Device.ApplyClientHints.
+func ReadBidRequest(r *http.Request, opts *DecodeOptions) (*BidRequest, error) {
+	var o DecodeOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.header = r.Header
+
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		body = zr
+	}
+	return DecodeBidRequestContext(r.Context(), body, &o)
+}
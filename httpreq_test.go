@@ -0,0 +1,56 @@
+package openrtb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadBidRequest", func() {
+	const body = `{"id":"R","imp":[{"id":"1","banner":{}}],"device":{"ua":"Mozilla/5.0"}}`
+
+	It("should decode requests", func() {
+		r := httptest.NewRequest("POST", "/bid", strings.NewReader(body))
+		req, err := ReadBidRequest(r, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("R"))
+		Expect(req.Device.SUA).To(BeNil())
+
+		r = httptest.NewRequest("POST", "/bid", strings.NewReader(`{"imp":[]}`))
+		_, err = ReadBidRequest(r, nil)
+		Expect(err).To(MatchError(ErrInvalidReqNoID))
+	})
+
+	It("should decompress", func() {
+		buf := new(bytes.Buffer)
+		zw := gzip.NewWriter(buf)
+		_, _ = zw.Write([]byte(body))
+		Expect(zw.Close()).To(Succeed())
+
+		r := httptest.NewRequest("POST", "/bid", buf)
+		r.Header.Set("Content-Encoding", "gzip")
+		req, err := ReadBidRequest(r, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("R"))
+	})
+
+	It("should apply client hints", func() {
+		r := httptest.NewRequest("POST", "/bid", strings.NewReader(body))
+		r.Header.Set(HeaderSecCHUA, `"Chromium";v="110"`)
+		r.Header.Set(HeaderSecCHUAPlatform, `"macOS"`)
+
+		req, err := ReadBidRequest(r, &DecodeOptions{Strict: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Device.SUA).To(Equal(&UserAgent{
+			Browsers: []BrandVersion{{Brand: "Chromium", Version: []string{"110"}}},
+			Platform: &BrandVersion{Brand: "macOS"},
+			Source:   UASourceLowEntropy,
+		}))
+		Expect(req.Device.OS).To(Equal("macOS"))
+	})
+
+})
@@ -0,0 +1,112 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// IFA types, as defined by the IAB Tech Lab Guidelines for IFA on OTT platforms
+const (
+	IFATypeDPID      = "dpid"      // Generic device provided ID
+	IFATypePPID      = "ppid"      // Publisher provided ID
+	IFATypeSSPID     = "sspid"     // SSP provided ID
+	IFATypeSessionID = "sessionid" // Short-lived session ID
+	IFATypeAAID      = "aaid"      // Android advertising ID
+	IFATypeIDFA      = "idfa"      // Apple identifier for advertising
+	IFATypeRIDA      = "rida"      // Roku ID for advertising
+	IFATypeAFAI      = "afai"      // Amazon Fire advertising ID
+	IFATypeMSAI      = "msai"      // Microsoft advertising ID
+	IFATypeTIFA      = "tifa"      // Samsung Tizen ID for advertising
+	IFATypeVIDA      = "vida"      // LG webOS ID for advertising
+)
+
+// Validation errors
+var (
+	ErrInvalidDeviceIFATracking = errors.New("openrtb: device ifa present despite lmt or dnt")
+	ErrInvalidDeviceZeroIFA     = errors.New("openrtb: device ifa is all zeros without lmt")
+)
+
+// DeviceExtIFA contains the IFA attributes of device.ext.
+type DeviceExtIFA struct {
+	IFAType   string `json:"ifa_type,omitempty"`   // Source of the IFA, see IFAType* constants
+	SessionID string `json:"session_id,omitempty"` // Session ID, where no persistent IFA is available
+}
+
+// IsZeroIFA returns true if ifa consists of zeros only, e.g.
+// "00000000-0000-0000-0000-000000000000", as sent by devices with limited ad
+// tracking.
+func IsZeroIFA(ifa string) bool {
+	zero := false
+	for i := 0; i < len(ifa); i++ {
+		switch ifa[i] {
+		case '0':
+			zero = true
+		case '-':
+		default:
+			return false
+		}
+	}
+	return zero
+}
+
+// HasIFA returns true if the device has a usable IFA, i.e. one which is
+// present and not all zeros.
+func (d *Device) HasIFA() bool {
+	return d.IFA != "" && !IsZeroIFA(d.IFA)
+}
+
+// ExtIFA decodes the IFA attributes of device.ext. It returns nil if absent.
+func (d *Device) ExtIFA() (*DeviceExtIFA, error) {
+	if len(d.Ext) == 0 {
+		return nil, nil
+	}
+
+	var ext DeviceExtIFA
+	if err := json.Unmarshal(d.Ext, &ext); err != nil {
+		return nil, err
+	}
+	if ext == (DeviceExtIFA{}) {
+		return nil, nil
+	}
+	return &ext, nil
+}
+
+// SetExtIFA stores the IFA attributes in device.ext, removing empty ones.
+func (d *Device) SetExtIFA(x *DeviceExtIFA) error {
+	if x == nil {
+		x = new(DeviceExtIFA)
+	}
+
+	ext, err := d.Ext.setOrDeleteKey("ifa_type", x.IFAType, x.IFAType == "")
+	if err == nil {
+		ext, err = ext.setOrDeleteKey("session_id", x.SessionID, x.SessionID == "")
+	}
+	if err != nil {
+		return err
+	}
+	d.Ext = ext
+	return nil
+}
+
+// ValidateIFA checks that the IFA of the device is consistent with its
+// tracking flags: a usable IFA must not be sent when lmt or dnt is set and
+// an all-zeros IFA should only be sent with lmt.
+func (d *Device) ValidateIFA() error {
+	if d.HasIFA() && (d.LMT.IsTrue() || d.DNT.IsTrue()) {
+		return ErrInvalidDeviceIFATracking
+	}
+	if d.IFA != "" && IsZeroIFA(d.IFA) && !d.LMT.IsTrue() {
+		return ErrInvalidDeviceZeroIFA
+	}
+	return nil
+}
+
+// ScrubIFA blanks the IFA of the device if lmt is set. It returns true if
+// the IFA was removed.
+func (d *Device) ScrubIFA() bool {
+	if d.IFA == "" || !d.LMT.IsTrue() {
+		return false
+	}
+	d.IFA = ""
+	return true
+}
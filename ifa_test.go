@@ -0,0 +1,78 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsZeroIFA", func() {
+
+	It("should detect zero IFAs", func() {
+		Expect(IsZeroIFA("00000000-0000-0000-0000-000000000000")).To(BeTrue())
+		Expect(IsZeroIFA("00000000000000000000000000000000")).To(BeTrue())
+		Expect(IsZeroIFA("AEBE52E7-03EE-455A-B3C4-E57283966239")).To(BeFalse())
+		Expect(IsZeroIFA("----")).To(BeFalse())
+		Expect(IsZeroIFA("")).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("Device", func() {
+	var subject *Device
+
+	BeforeEach(func() {
+		subject = &Device{IFA: "AEBE52E7-03EE-455A-B3C4-E57283966239"}
+	})
+
+	It("should check for usable IFAs", func() {
+		Expect(subject.HasIFA()).To(BeTrue())
+		subject.IFA = "00000000-0000-0000-0000-000000000000"
+		Expect(subject.HasIFA()).To(BeFalse())
+		subject.IFA = ""
+		Expect(subject.HasIFA()).To(BeFalse())
+	})
+
+	It("should read and write IFA attributes", func() {
+		Expect(subject.ExtIFA()).To(BeNil())
+
+		subject.Ext = Extension(`{"x":1}`)
+		Expect(subject.SetExtIFA(&DeviceExtIFA{IFAType: IFATypeRIDA})).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"x":1,"ifa_type":"rida"}`))
+		Expect(subject.ExtIFA()).To(Equal(&DeviceExtIFA{IFAType: "rida"}))
+
+		Expect(subject.SetExtIFA(&DeviceExtIFA{SessionID: "S"})).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"x":1,"session_id":"S"}`))
+		Expect(subject.SetExtIFA(nil)).To(Succeed())
+		Expect(string(subject.Ext)).To(MatchJSON(`{"x":1}`))
+		Expect(subject.ExtIFA()).To(BeNil())
+
+		subject.Ext = Extension(`bad`)
+		_, err := subject.ExtIFA()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should validate IFA consistency", func() {
+		Expect(subject.ValidateIFA()).To(Succeed())
+		subject.LMT = FlagTrue
+		Expect(subject.ValidateIFA()).To(MatchError(ErrInvalidDeviceIFATracking))
+		subject.LMT, subject.DNT = FlagFalse, FlagTrue
+		Expect(subject.ValidateIFA()).To(MatchError(ErrInvalidDeviceIFATracking))
+
+		subject.IFA, subject.DNT = "00000000-0000-0000-0000-000000000000", FlagUnset
+		Expect(subject.ValidateIFA()).To(MatchError(ErrInvalidDeviceZeroIFA))
+		Expect(ValidationCode(subject.ValidateIFA())).To(Equal("device_zero_ifa"))
+		subject.LMT = FlagTrue
+		Expect(subject.ValidateIFA()).To(Succeed())
+	})
+
+	It("should scrub IFAs", func() {
+		Expect(subject.ScrubIFA()).To(BeFalse())
+		Expect(subject.IFA).NotTo(BeEmpty())
+
+		subject.LMT = FlagTrue
+		Expect(subject.ScrubIFA()).To(BeTrue())
+		Expect(subject.IFA).To(BeEmpty())
+		Expect(subject.ScrubIFA()).To(BeFalse())
+	})
+
+})
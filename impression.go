@@ -1,12 +1,29 @@
 package openrtb
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // Validation errors
 var (
 	ErrInvalidImpNoID        = errors.New("openrtb: impression ID missing")
-	ErrInvalidImpNoAssets    = errors.New("openrtb: impression has no assets")       // neither Banner, nor Video, nor Native
-	ErrInvalidImpMultiAssets = errors.New("openrtb: impression has multiple assets") // at least two out of Banner, Video, Native
+	ErrInvalidImpNoAssets    = errors.New("openrtb: impression has no assets")       // neither Banner, nor Video, nor Audio, nor Native
+	ErrInvalidImpMultiAssets = errors.New("openrtb: impression has multiple assets") // at least two out of Banner, Video, Audio, Native
+	ErrInvalidImpRwdd        = errors.New("openrtb: impression rwdd must be 0 or 1")
+	ErrInvalidImpFloorCur    = errors.New("openrtb: impression has invalid bidfloorcur") // in imp or any of its deals
+	ErrInvalidImpSSAI        = errors.New("openrtb: impression has invalid ssai")        // unknown value or neither video nor audio
+	ErrInvalidImpQty         = errors.New("openrtb: impression has invalid qty")         // non-positive multiplier, unknown sourcetype or missing vendor
+	ErrInvalidImpRefresh     = errors.New("openrtb: impression has invalid refresh")     // negative count or interval, unknown reftype
+	ErrInvalidImpDealAuction = errors.New("openrtb: impression has deal with invalid at")
+)
+
+// Media types
+const (
+	MediaTypeBanner = "banner"
+	MediaTypeVideo  = "video"
+	MediaTypeAudio  = "audio"
+	MediaTypeNative = "native"
 )
 
 // The "imp" object describes the ad position or impression being auctioned.  A single bid request
@@ -26,15 +43,112 @@ type Impression struct {
 	DisplayManager    string    `json:"displaymanager,omitempty"`    // Name of ad mediation partner, SDK technology, etc
 	DisplayManagerVer string    `json:"displaymanagerver,omitempty"` // Version of the above
 	Instl             int       `json:"instl,omitempty"`             // Interstitial, Default: 0 ("1": Interstitial, "0": Something else)
+	Rwdd              int       `json:"rwdd,omitempty"`              // Indicates whether the user receives a reward for viewing the creative, where 0 = no, 1 = yes
 	TagID             string    `json:"tagid,omitempty"`             // IDentifier for specific ad placement or ad tag
 	BidFloor          float64   `json:"bidfloor,omitempty"`          // Bid floor for this impression in CPM
 	BidFloorCurrency  string    `json:"bidfloorcur,omitempty"`       // Currency of bid floor
 	Secure            int       `json:"secure,omitempty"`            // Flag to indicate whether the impression requires secure HTTPS URL creative assets and markup.
 	Exp               int       `json:"exp,omitempty"`               // Advisory as to the number of seconds that may elapse between the auction and the actual impression.
 	IFrameBuster      []string  `json:"iframebuster,omitempty"`      // Array of names for supportediframe busters.
+	Qty               *Qty      `json:"qty,omitempty"`               // Impression multiplier, describing the number of impressions a single ad play represents (e.g. DOOH).
+	Dt                float64   `json:"dt,omitempty"`                // Timestamp when the item is estimated to be fulfilled (e.g. when a DOOH impression will be displayed) in Unix format (i.e., milliseconds since the epoch).
+	Metric            []Metric  `json:"metric,omitempty"`            // An array of Metric object.
+	SSAI              int       `json:"ssai,omitempty"`              // Indicates if server-side ad insertion (e.g., stitching an ad into an audio or video stream) is in use and the impact of this on asset and tracker retrieval. See SSAI* constants.
+	Refresh           *Refresh  `json:"refresh,omitempty"`           // Details about ad slots being refreshed automatically.
 	Ext               Extension `json:"ext,omitempty"`
 }
 
+// A programmatic impression is often referred to as a 'spot' in digital out-of-home and CTV, with an
+// impression being a unique member of the audience viewing it. This object describes the quantity of
+// impressions a single ad play represents.
+type Qty struct {
+	Multiplier float64   `json:"multiplier,omitempty"` // The quantity of billable events which will be deemed to have occurred if this item is purchased.
+	SourceType int       `json:"sourcetype,omitempty"` // The source of the quantity measurement.
+	Vendor     string    `json:"vendor,omitempty"`     // The top-level business domain of the measurement vendor, required when sourcetype is 1.
+	Ext        Extension `json:"ext,omitempty"`
+}
+
+// This object is associated with an impression as an array of metrics. These metrics can offer insight
+// into the impression to assist with decisioning such as average recent viewability, click-through rate,
+// etc. Each metric is identified by its type, reports the value of the metric, and optionally
+// identifies the source or vendor measuring the value.
+type Metric struct {
+	Type   string    `json:"type"`             // Type of metric being presented using exchange curated string names which should be published to bidders a priori.
+	Value  float64   `json:"value"`            // Number representing the value of the metric. Probabilities must be in the range 0.0 – 1.0.
+	Vendor string    `json:"vendor,omitempty"` // Source of the value using exchange curated string names which should be published to bidders a priori. If the exchange itself is the source versus a third party, "EXCHANGE" is recommended.
+	Ext    Extension `json:"ext,omitempty"`
+}
+
+// MetricValue returns the value of the first metric of the given type
+func (imp *Impression) MetricValue(typ string) (float64, bool) {
+	for _, m := range imp.Metric {
+		if m.Type == typ {
+			return m.Value, true
+		}
+	}
+	return 0, false
+}
+
+// Multiplier returns the impression quantity multiplier, defaulting to 1.
+func (imp *Impression) Multiplier() float64 {
+	if imp.Qty != nil && imp.Qty.Multiplier > 0 {
+		return imp.Qty.Multiplier
+	}
+	return 1
+}
+
+// FulfillmentTime returns the time the impression is estimated to be
+// fulfilled, see dt. It returns false if dt is not set.
+func (imp *Impression) FulfillmentTime() (time.Time, bool) {
+	if imp.Dt <= 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(imp.Dt)), true
+}
+
+// valid checks that the multiplier is positive, the source type is known
+// and measurement vendors are named.
+func (q *Qty) valid() bool {
+	switch {
+	case q.Multiplier <= 0:
+		return false
+	case q.SourceType < QtySourceTypeUnknown || q.SourceType > QtySourceTypeExchange:
+		return false
+	case q.SourceType == QtySourceTypeMeasurementVendor && q.Vendor == "":
+		return false
+	}
+	return true
+}
+
+// EffectivePrice returns a CPM price scaled by the impression multiplier.
+func (imp *Impression) EffectivePrice(price float64) float64 {
+	return price * imp.Multiplier()
+}
+
+// IsInterstitial returns true if the impression is interstitial or full screen
+func (imp *Impression) IsInterstitial() bool { return imp.Instl == 1 }
+
+// IsRewarded returns true if the user receives a reward for viewing the creative
+func (imp *Impression) IsRewarded() bool { return imp.Rwdd == 1 }
+
+// MediaTypes returns the media types offered by the impression, see MediaType* constants
+func (imp *Impression) MediaTypes() []string {
+	types := make([]string, 0, 4)
+	if imp.Banner != nil {
+		types = append(types, MediaTypeBanner)
+	}
+	if imp.Video != nil {
+		types = append(types, MediaTypeVideo)
+	}
+	if imp.Audio != nil {
+		types = append(types, MediaTypeAudio)
+	}
+	if imp.Native != nil {
+		types = append(types, MediaTypeNative)
+	}
+	return types
+}
+
 func (imp *Impression) assetCount() int {
 	n := 0
 	if imp.Banner != nil {
@@ -43,29 +157,72 @@ func (imp *Impression) assetCount() int {
 	if imp.Video != nil {
 		n++
 	}
+	if imp.Audio != nil {
+		n++
+	}
 	if imp.Native != nil {
 		n++
 	}
 	return n
 }
 
-// Validates the `imp` object
+// Validates the `imp` object. Errors are returned as *ValidationError.
 func (imp *Impression) Validate() error {
-	if imp.ID == "" {
-		return ErrInvalidImpNoID
+	v := new(validator)
+	imp.validate(v, "")
+	return v.err()
+}
+
+func (imp *Impression) validate(v *validator, path string) bool {
+	if imp.ID == "" && !v.add(path, ErrInvalidImpNoID) {
+		return false
 	}
 
 	if count := imp.assetCount(); count == 0 {
-		return ErrInvalidImpNoAssets
+		if !v.add(path, ErrInvalidImpNoAssets) {
+			return false
+		}
 	} else if count > 1 {
-		return ErrInvalidImpMultiAssets
+		if !v.add(path, ErrInvalidImpMultiAssets) {
+			return false
+		}
+	}
+	if imp.Rwdd != 0 && imp.Rwdd != 1 && !v.add(path, ErrInvalidImpRwdd) {
+		return false
+	}
+	if !validCurrency(imp.BidFloorCurrency) && !v.add(path, ErrInvalidImpFloorCur) {
+		return false
+	}
+	if imp.Qty != nil && !imp.Qty.valid() && !v.add(path, ErrInvalidImpQty) {
+		return false
+	}
+	if imp.Refresh != nil && !imp.Refresh.valid() && !v.add(path, ErrInvalidImpRefresh) {
+		return false
+	}
+	if imp.SSAI != 0 && (imp.SSAI < SSAIClientSide || imp.SSAI > SSAIServerSide || (imp.Video == nil && imp.Audio == nil)) && !v.add(path, ErrInvalidImpSSAI) {
+		return false
 	}
 
-	if imp.Video != nil {
-		if err := imp.Video.Validate(); err != nil {
-			return err
+	if imp.Pmp != nil {
+		for i, deal := range imp.Pmp.Deals {
+			if !validCurrency(deal.BidFloorCurrency) && !v.addAt(joinPath(path, indexPath("pmp.deals", i)+".bidfloorcur"), ErrInvalidImpFloorCur) {
+				return false
+			}
+			if !validDealAuctionType(deal.AuctionType) && !v.addAt(joinPath(path, indexPath("pmp.deals", i)+".at"), ErrInvalidImpDealAuction) {
+				return false
+			}
 		}
 	}
 
-	return nil
+	if imp.Banner != nil && !v.add(path, imp.Banner.Validate()) {
+		return false
+	}
+	if imp.Video != nil && !v.add(path, imp.Video.Validate()) {
+		return false
+	}
+	if imp.Audio != nil && !v.add(path, imp.Audio.Validate()) {
+		return false
+	}
+
+	return true
 }
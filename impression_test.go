@@ -1,6 +1,8 @@
 package openrtb
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -21,6 +23,8 @@ var _ = Describe("Impression", func() {
 				H: 250,
 			},
 			BidFloor: 0.03,
+			Rwdd:     1,
+			Metric:   []Metric{{Type: "viewability", Value: 0.85, Vendor: "EXCHANGE"}},
 			Pmp: &Pmp{
 				Private: 1,
 				Deals: []Deal{
@@ -35,10 +39,65 @@ var _ = Describe("Impression", func() {
 	})
 
 	It("should validate", func() {
-		Expect((&Impression{}).Validate()).To(Equal(ErrInvalidImpNoID))
-		Expect((&Impression{ID: "IMPID"}).Validate()).To(Equal(ErrInvalidImpNoAssets))
-		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, Video: &Video{}}).Validate()).To(Equal(ErrInvalidImpMultiAssets))
+		Expect((&Impression{}).Validate()).To(MatchError(ErrInvalidImpNoID))
+		Expect((&Impression{ID: "IMPID"}).Validate()).To(MatchError(ErrInvalidImpNoAssets))
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, Video: &Video{}}).Validate()).To(MatchError(ErrInvalidImpMultiAssets))
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, Audio: &Audio{}}).Validate()).To(MatchError(ErrInvalidImpMultiAssets))
+		Expect((&Impression{ID: "IMPID", Audio: &Audio{}}).Validate()).To(MatchError(ErrInvalidAudioNoMimes))
+		Expect((&Impression{ID: "IMPID", Audio: &Audio{Mimes: []string{"audio/mp4"}}}).Validate()).NotTo(HaveOccurred())
 		Expect((&Impression{ID: "IMPID", Banner: &Banner{}}).Validate()).NotTo(HaveOccurred())
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, Rwdd: 2}).Validate()).To(MatchError(ErrInvalidImpRwdd))
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, Instl: 1, Rwdd: 1}).Validate()).NotTo(HaveOccurred())
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, BidFloorCurrency: "US"}).Validate()).To(MatchError(ErrInvalidImpFloorCur))
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, Pmp: &Pmp{Deals: []Deal{{ID: "D", BidFloorCurrency: "EURO"}}}}).Validate()).To(MatchError(ErrInvalidImpFloorCur))
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, BidFloorCurrency: "gbp", Pmp: &Pmp{Deals: []Deal{{ID: "D", BidFloorCurrency: "EUR"}}}}).Validate()).NotTo(HaveOccurred())
+		Expect((&Impression{ID: "IMPID", Banner: &Banner{}, SSAI: SSAIServerSide}).Validate()).To(MatchError(ErrInvalidImpSSAI))
+		Expect((&Impression{ID: "IMPID", Audio: &Audio{Mimes: []string{"audio/mp4"}}, SSAI: 4}).Validate()).To(MatchError(ErrInvalidImpSSAI))
+		Expect((&Impression{ID: "IMPID", Audio: &Audio{Mimes: []string{"audio/mp4"}}, SSAI: SSAIServerSide}).Validate()).To(Succeed())
+	})
+
+	It("should have accessors", func() {
+		v, ok := subject.MetricValue("viewability")
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal(0.85))
+		_, ok = subject.MetricValue("ctr")
+		Expect(ok).To(BeFalse())
+
+		Expect(subject.IsInterstitial()).To(BeFalse())
+		Expect(subject.IsRewarded()).To(BeTrue())
+		Expect(subject.MediaTypes()).To(Equal([]string{MediaTypeBanner}))
+		Expect((&Impression{Video: &Video{}, Native: &Native{}}).MediaTypes()).To(Equal([]string{MediaTypeVideo, MediaTypeNative}))
+	})
+
+	It("should apply multipliers", func() {
+		Expect(subject.Multiplier()).To(Equal(1.0))
+		Expect(subject.EffectivePrice(2.5)).To(Equal(2.5))
+
+		subject.Qty = &Qty{Multiplier: 4, SourceType: QtySourceTypePublisher}
+		Expect(subject.Multiplier()).To(Equal(4.0))
+		Expect(subject.EffectivePrice(2.5)).To(Equal(10.0))
+	})
+
+	It("should validate quantities", func() {
+		imp := &Impression{ID: "IMPID", Banner: &Banner{}, Qty: &Qty{Multiplier: 2.5, SourceType: QtySourceTypeMeasurementVendor, Vendor: "mv.example.com"}}
+		Expect(imp.Validate()).To(Succeed())
+
+		imp.Qty.Vendor = ""
+		Expect(imp.Validate()).To(MatchError(ErrInvalidImpQty))
+		imp.Qty = &Qty{SourceType: QtySourceTypePublisher}
+		Expect(imp.Validate()).To(MatchError(ErrInvalidImpQty))
+		imp.Qty = &Qty{Multiplier: 1, SourceType: 9}
+		Expect(imp.Validate()).To(MatchError(ErrInvalidImpQty))
+	})
+
+	It("should return fulfillment times", func() {
+		_, ok := subject.FulfillmentTime()
+		Expect(ok).To(BeFalse())
+
+		subject.Dt = 1700000000123
+		t, ok := subject.FulfillmentTime()
+		Expect(ok).To(BeTrue())
+		Expect(t.UTC()).To(Equal(time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC)))
 	})
 
 })
@@ -0,0 +1,197 @@
+package openrtb
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Interner returns a canonical instance of s, so that equal strings share
+// their backing memory.
+type Interner interface {
+	Intern(s string) string
+}
+
+// DefaultInternerSize is the number of strings held by a StringInterner,
+// unless specified.
+const DefaultInternerSize = 10000
+
+// StringInterner is a concurrency-safe Interner with a bounded number of
+// entries. Once full, new strings are returned as is.
+type StringInterner struct {
+	max  int
+	mu   sync.RWMutex
+	pool map[string]string
+}
+
+// NewStringInterner creates an interner which holds up to max strings,
+// 0 = DefaultInternerSize.
+func NewStringInterner(max int) *StringInterner {
+	if max <= 0 {
+		max = DefaultInternerSize
+	}
+	return &StringInterner{max: max, pool: make(map[string]string)}
+}
+
+// Intern implements Interner.
+func (i *StringInterner) Intern(s string) string {
+	if s == "" {
+		return s
+	}
+
+	i.mu.RLock()
+	v, ok := i.pool[s]
+	i.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if v, ok := i.pool[s]; ok {
+		return v
+	}
+	if len(i.pool) >= i.max {
+		return s
+	}
+	s = strings.Clone(s)
+	i.pool[s] = s
+	return s
+}
+
+// Len returns the number of interned strings.
+func (i *StringInterner) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.pool)
+}
+
+// internedFields are the JSON names of the fields with low-cardinality
+// values which are interned during decoding. Fields with open-ended values,
+// e.g. city, make, model, carrier or advertiser domains, would only fill
+// the interner and are not included.
+var internedFields = map[string]bool{
+	"cur": true, "bidfloorcur": true, "floormincur": true,
+	"country": true, "region": true, "metro": true,
+	"mimes": true, "cat": true, "bcat": true, "sectioncat": true, "pagecat": true,
+	"seat": true, "wseat": true, "bseat": true,
+	"language": true, "langb": true, "wlang": true, "wlangb": true,
+	"os": true, "osv": true,
+}
+
+// internField is a struct field which may hold interned values.
+type internField struct {
+	index  int
+	intern bool // the field is interned, rather than traversed
+}
+
+// internPlans caches the internFields of struct types.
+var internPlans sync.Map // map[reflect.Type][]internField
+
+// internTypes caches the results of mayIntern by type.
+var internTypes sync.Map // map[reflect.Type]bool
+
+// internStrings replaces the values of the interned fields reachable from v
+// with their canonical instances. Decoded objects are traversed once, after
+// decoding; fields which cannot hold interned values are skipped.
+func internStrings(v reflect.Value, in Interner) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			internStrings(v.Elem(), in)
+		}
+	case reflect.Slice, reflect.Array:
+		if !mayInternType(v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			internStrings(v.Index(i), in)
+		}
+	case reflect.Struct:
+		for _, f := range internPlan(v.Type()) {
+			if f.intern {
+				internValue(v.Field(f.index), in)
+			} else {
+				internStrings(v.Field(f.index), in)
+			}
+		}
+	}
+}
+
+func internPlan(t reflect.Type) []internField {
+	if plan, ok := internPlans.Load(t); ok {
+		return plan.([]internField)
+	}
+
+	var plan []internField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Type == extensionType {
+			continue
+		}
+
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); internedFields[name] {
+			plan = append(plan, internField{index: i, intern: true})
+		} else if mayInternType(field.Type) {
+			plan = append(plan, internField{index: i})
+		}
+	}
+	internPlans.Store(t, plan)
+	return plan
+}
+
+// mayInternType returns the cached result of mayIntern.
+func mayInternType(t reflect.Type) bool {
+	if ok, cached := internTypes.Load(t); cached {
+		return ok.(bool)
+	}
+
+	ok := mayIntern(t, nil)
+	internTypes.Store(t, ok)
+	return ok
+}
+
+// mayIntern returns true if values of type t may contain interned fields.
+func mayIntern(t reflect.Type, seen map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return mayIntern(t.Elem(), seen)
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		if seen[t] {
+			return false
+		}
+		if seen == nil {
+			seen = make(map[reflect.Type]bool)
+		}
+		seen[t] = true
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" || field.Type == extensionType {
+				continue
+			}
+			if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); internedFields[name] {
+				return true
+			}
+			if mayIntern(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// internValue interns a string or the elements of a string slice.
+func internValue(v reflect.Value, in Interner) {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(in.Intern(v.String()))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < v.Len(); i++ {
+			internValue(v.Index(i), in)
+		}
+	}
+}
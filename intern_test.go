@@ -0,0 +1,56 @@
+package openrtb
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StringInterner", func() {
+
+	It("should intern strings", func() {
+		subject := NewStringInterner(2)
+		a := subject.Intern(string([]byte("USD")))
+		b := subject.Intern(string([]byte("USD")))
+		Expect(a).To(Equal("USD"))
+		Expect(unsafe.StringData(a)).To(Equal(unsafe.StringData(b)))
+		Expect(subject.Intern("")).To(Equal(""))
+
+		Expect(subject.Intern("EUR")).To(Equal("EUR"))
+		Expect(subject.Intern("GBP")).To(Equal("GBP"))
+		Expect(subject.Len()).To(Equal(2))
+	})
+
+	It("should be bounded by default", func() {
+		subject := NewStringInterner(0)
+		for i := 0; i <= DefaultInternerSize; i++ {
+			subject.Intern(strconv.Itoa(i))
+		}
+		Expect(subject.Len()).To(Equal(DefaultInternerSize))
+	})
+
+	It("should intern decoded values", func() {
+		interner := NewStringInterner(0)
+		opts := &DecodeOptions{Interner: interner}
+		data := []byte(`{"id":"R","imp":[{"id":"I","banner":{"mimes":["image/png"]},"bidfloorcur":"USD"}],"cur":["USD"],"device":{"make":"Apple","geo":{"country":"USA","city":"London"}}}`)
+
+		req1, err := UnmarshalBidRequestContext(context.Background(), data, opts)
+		Expect(err).NotTo(HaveOccurred())
+		req2, err := UnmarshalBidRequestContext(context.Background(), data, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(unsafe.StringData(req1.Cur[0])).To(Equal(unsafe.StringData(req2.Imp[0].BidFloorCurrency)))
+		Expect(unsafe.StringData(req1.Device.Geo.Country)).To(Equal(unsafe.StringData(req2.Device.Geo.Country)))
+		Expect(unsafe.StringData(req1.Imp[0].Banner.Mimes[0])).To(Equal(unsafe.StringData(req2.Imp[0].Banner.Mimes[0])))
+		Expect(interner.Len()).To(Equal(3))
+
+		cached, ok := internTypes.Load(reflect.TypeOf(Impression{}))
+		Expect(ok).To(BeTrue())
+		Expect(cached).To(BeTrue())
+	})
+
+})
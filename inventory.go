@@ -4,6 +4,7 @@ type Inventory struct {
 	ID            string     `json:"id,omitempty"` // ID on the exchange
 	Name          string     `json:"name,omitempty"`
 	Domain        string     `json:"domain,omitempty"`
+	CatTax        int        `json:"cattax,omitempty"`       // The taxonomy in use for cat, sectioncat and pagecat, Default: 1
 	Cat           []string   `json:"cat,omitempty"`          // Array of IAB content categories
 	SectionCat    []string   `json:"sectioncat,omitempty"`   // Array of IAB content categories for subsection
 	PageCat       []string   `json:"pagecat,omitempty"`      // Array of IAB content categories for page
@@ -42,3 +43,17 @@ type Site struct {
 	Search string `json:"search,omitempty"` // Search string that caused naviation
 	Mobile int    `json:"mobile,omitempty"` // Mobile ("1": site is mobile optimised)
 }
+
+// This object should be included if the ad supported content is a Digital Out-Of-Home screen. A bid
+// request with a DOOH object must not contain a site or app object.
+type DOOH struct {
+	ID           string     `json:"id,omitempty"`           // Exchange provided ID for a placement or logical grouping of placements
+	Name         string     `json:"name,omitempty"`         // Name of the DOOH placement
+	VenueType    []string   `json:"venuetype,omitempty"`    // The type of out-of-home venue
+	VenueTypeTax int        `json:"venuetypetax,omitempty"` // The venue taxonomy in use, Default: 1
+	Publisher    *Publisher `json:"publisher,omitempty"`    // Details about the Publisher
+	Domain       string     `json:"domain,omitempty"`       // Domain of the inventory owner (e.g., "mysite.foo.com")
+	Keywords     string     `json:"keywords,omitempty"`     // Comma separated list of keywords about the DOOH placement
+	Content      *Content   `json:"content,omitempty"`      // Details about the Content
+	Ext          Extension  `json:"ext,omitempty"`
+}
@@ -66,3 +66,25 @@ var _ = Describe("Site", func() {
 	})
 
 })
+
+var _ = Describe("DOOH", func() {
+	var subject *DOOH
+
+	BeforeEach(func() {
+		err := fixture("dooh", &subject)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse correctly", func() {
+		Expect(subject).To(Equal(&DOOH{
+			ID:           "dooh-123",
+			Name:         "Times Square Screen 4",
+			VenueType:    []string{"outdoor.billboards"},
+			VenueTypeTax: VenueTaxonomyOpenOOH10,
+			Publisher:    &Publisher{ID: "pub-1", Name: "Screens Inc"},
+			Domain:       "screens.example.com",
+			Keywords:     "billboard,nyc",
+		}))
+	})
+
+})
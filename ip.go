@@ -0,0 +1,72 @@
+package openrtb
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// parseIP parses an IPv4 or IPv6 address, unmapping IPv4-mapped IPv6
+// addresses. Zones are not accepted.
+func parseIP(s string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// HasValidIP returns true if ip contains a valid IPv4 address.
+func (d *Device) HasValidIP() bool {
+	addr, ok := parseIP(d.IP)
+	return ok && addr.Is4()
+}
+
+// HasValidIPv6 returns true if ipv6 contains a valid IPv6 address.
+func (d *Device) HasValidIPv6() bool {
+	addr, ok := parseIP(d.IPv6)
+	return ok && addr.Is6()
+}
+
+// NormalizeIPs formats ip and ipv6 canonically, e.g. "2001:DB8::0:1"
+// becomes "2001:db8::1", and moves addresses which were passed in the wrong
+// field, e.g. an IPv6 address in ip, to the right one unless it is already
+// set. Addresses which cannot be moved, e.g. two IPv4 addresses, are kept
+// where they are. IPv4-mapped IPv6 addresses are treated as IPv4. Invalid
+// addresses are left untouched. It returns false if any address is invalid.
+func (d *Device) NormalizeIPs() bool {
+	ip, ok4 := parseIP(d.IP)
+	ipv6, ok6 := parseIP(d.IPv6)
+	valid := (ok4 || d.IP == "") && (ok6 || d.IPv6 == "")
+	if ok4 {
+		d.IP = ip.String()
+	}
+	if ok6 {
+		d.IPv6 = ipv6.String()
+	}
+
+	misplaced4, misplaced6 := ok4 && ip.Is6(), ok6 && ipv6.Is4()
+	switch {
+	case misplaced4 && misplaced6:
+		d.IP, d.IPv6 = d.IPv6, d.IP
+	case misplaced4 && d.IPv6 == "":
+		d.IP, d.IPv6 = "", d.IP
+	case misplaced6 && d.IP == "":
+		d.IP, d.IPv6 = d.IPv6, ""
+	}
+	return valid
+}
+
+// Addr returns the IP address of the device, preferring the IPv4 address.
+// Addresses passed in the wrong field are accepted. It returns the zero
+// value, which is not valid, if there is no valid address.
+func (d *Device) Addr() netip.Addr {
+	var v6 netip.Addr
+	for _, s := range []string{d.IP, d.IPv6} {
+		if addr, ok := parseIP(s); ok && addr.Is4() {
+			return addr
+		} else if ok && !v6.IsValid() {
+			v6 = addr
+		}
+	}
+	return v6
+}
@@ -0,0 +1,73 @@
+package openrtb
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Device", func() {
+
+	It("should validate IPs", func() {
+		Expect((&Device{IP: "123.145.167.189"}).HasValidIP()).To(BeTrue())
+		Expect((&Device{IP: "::ffff:123.145.167.189"}).HasValidIP()).To(BeTrue())
+		Expect((&Device{IP: "2001:db8::1"}).HasValidIP()).To(BeFalse())
+		Expect((&Device{IP: "123.145.167"}).HasValidIP()).To(BeFalse())
+		Expect((&Device{}).HasValidIP()).To(BeFalse())
+
+		Expect((&Device{IPv6: "2001:db8::1"}).HasValidIPv6()).To(BeTrue())
+		Expect((&Device{IPv6: "fe80::1%eth0"}).HasValidIPv6()).To(BeFalse())
+		Expect((&Device{IPv6: "123.145.167.189"}).HasValidIPv6()).To(BeFalse())
+	})
+
+	It("should normalize IPs", func() {
+		subject := &Device{IP: " 123.145.167.189 ", IPv6: "2001:DB8:0::0:1"}
+		Expect(subject.NormalizeIPs()).To(BeTrue())
+		Expect(subject.IP).To(Equal("123.145.167.189"))
+		Expect(subject.IPv6).To(Equal("2001:db8::1"))
+
+		subject = &Device{IP: "2001:db8::1"}
+		Expect(subject.NormalizeIPs()).To(BeTrue())
+		Expect(subject.IP).To(BeEmpty())
+		Expect(subject.IPv6).To(Equal("2001:db8::1"))
+
+		subject = &Device{IP: "2001:db8::1", IPv6: "::ffff:10.0.0.1"}
+		Expect(subject.NormalizeIPs()).To(BeTrue())
+		Expect(subject.IP).To(Equal("10.0.0.1"))
+		Expect(subject.IPv6).To(Equal("2001:db8::1"))
+
+		subject = &Device{IP: "2001:db8::1", IPv6: "2001:DB8::2"}
+		Expect(subject.NormalizeIPs()).To(BeTrue())
+		Expect(subject.IP).To(Equal("2001:db8::1"))
+		Expect(subject.IPv6).To(Equal("2001:db8::2"))
+
+		subject = &Device{IP: "10.0.0.1", IPv6: "::ffff:10.0.0.2"}
+		Expect(subject.NormalizeIPs()).To(BeTrue())
+		Expect(subject.IP).To(Equal("10.0.0.1"))
+		Expect(subject.IPv6).To(Equal("10.0.0.2"))
+
+		subject = &Device{IPv6: "10.0.0.1"}
+		Expect(subject.NormalizeIPs()).To(BeTrue())
+		Expect(subject.IP).To(Equal("10.0.0.1"))
+		Expect(subject.IPv6).To(BeEmpty())
+
+		subject = &Device{IP: "bad", IPv6: "2001:db8::1"}
+		Expect(subject.NormalizeIPs()).To(BeFalse())
+		Expect(subject.IP).To(Equal("bad"))
+		Expect(subject.IPv6).To(Equal("2001:db8::1"))
+
+		subject = &Device{IP: "10.0.0.1", IPv6: "bad"}
+		Expect(subject.NormalizeIPs()).To(BeFalse())
+		Expect(subject.IP).To(Equal("10.0.0.1"))
+		Expect(subject.IPv6).To(Equal("bad"))
+	})
+
+	It("should return addresses", func() {
+		Expect((&Device{IP: "123.145.167.189", IPv6: "2001:db8::1"}).Addr()).To(Equal(netip.MustParseAddr("123.145.167.189")))
+		Expect((&Device{IPv6: "2001:db8::1"}).Addr()).To(Equal(netip.MustParseAddr("2001:db8::1")))
+		Expect((&Device{IP: "2001:db8::1", IPv6: "123.145.167.189"}).Addr()).To(Equal(netip.MustParseAddr("123.145.167.189")))
+		Expect((&Device{IP: "bad"}).Addr().IsValid()).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,71 @@
+//go:build go1.23
+
+package openrtb
+
+import "iter"
+
+// Imps iterates over all impressions of the request.
+func (req *BidRequest) Imps() iter.Seq[*Impression] {
+	return func(yield func(*Impression) bool) {
+		for i := range req.Imp {
+			if !yield(&req.Imp[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Deals iterates over all private marketplace deals of the request,
+// together with the impressions they are offered on.
+func (req *BidRequest) Deals() iter.Seq2[*Impression, *Deal] {
+	return func(yield func(*Impression, *Deal) bool) {
+		for i := range req.Imp {
+			imp := &req.Imp[i]
+			if imp.Pmp == nil {
+				continue
+			}
+			for j := range imp.Pmp.Deals {
+				if !yield(imp, &imp.Pmp.Deals[j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AllBids iterates over all bids of the response, together with the
+// seatbids they belong to.
+func (res *BidResponse) AllBids() iter.Seq2[*SeatBid, *Bid] {
+	return func(yield func(*SeatBid, *Bid) bool) {
+		for i := range res.SeatBid {
+			sb := &res.SeatBid[i]
+			for j := range sb.Bid {
+				if !yield(sb, &sb.Bid[j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// BidsBySeat iterates over the bids of the response grouped by seat, in
+// order of first appearance. Bids of seatbids sharing the same seat are
+// merged.
+func (res *BidResponse) BidsBySeat() iter.Seq2[string, []*Bid] {
+	return func(yield func(string, []*Bid) bool) {
+		var seats []string
+		bids := make(map[string][]*Bid, len(res.SeatBid))
+		for sb, bid := range res.AllBids() {
+			if _, ok := bids[sb.Seat]; !ok {
+				seats = append(seats, sb.Seat)
+			}
+			bids[sb.Seat] = append(bids[sb.Seat], bid)
+		}
+
+		for _, seat := range seats {
+			if !yield(seat, bids[seat]) {
+				return
+			}
+		}
+	}
+}
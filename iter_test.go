@@ -0,0 +1,70 @@
+//go:build go1.23
+
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Iterators", func() {
+
+	It("should iterate over impressions and deals", func() {
+		req := &BidRequest{Imp: []Impression{
+			{ID: "1", Pmp: &Pmp{Deals: []Deal{{ID: "A"}, {ID: "B"}}}},
+			{ID: "2"},
+			{ID: "3", Pmp: &Pmp{Deals: []Deal{{ID: "C"}}}},
+		}}
+
+		var ids []string
+		for imp := range req.Imps() {
+			ids = append(ids, imp.ID)
+		}
+		Expect(ids).To(Equal([]string{"1", "2", "3"}))
+
+		ids = ids[:0]
+		for imp, deal := range req.Deals() {
+			ids = append(ids, imp.ID+":"+deal.ID)
+		}
+		Expect(ids).To(Equal([]string{"1:A", "1:B", "3:C"}))
+
+		for imp := range req.Imps() {
+			imp.BidFloor = 1
+			break
+		}
+		Expect(req.Imp[0].BidFloor).To(Equal(1.0))
+		Expect(req.Imp[1].BidFloor).To(Equal(0.0))
+	})
+
+	It("should iterate over bids", func() {
+		res := &BidResponse{SeatBid: []SeatBid{
+			{Seat: "a", Bid: []Bid{{ID: "1"}, {ID: "2"}}},
+			{Seat: "b"},
+			{Seat: "c", Bid: []Bid{{ID: "3"}}},
+			{Seat: "a", Bid: []Bid{{ID: "4"}}},
+		}}
+
+		var ids []string
+		for sb, bid := range res.AllBids() {
+			ids = append(ids, sb.Seat+":"+bid.ID)
+		}
+		Expect(ids).To(Equal([]string{"a:1", "a:2", "c:3", "a:4"}))
+
+		ids = ids[:0]
+		for seat, bids := range res.BidsBySeat() {
+			for _, bid := range bids {
+				ids = append(ids, seat+":"+bid.ID)
+			}
+			if seat == "a" {
+				break
+			}
+		}
+		Expect(ids).To(Equal([]string{"a:1", "a:2", "a:4"}))
+
+		for _, bid := range res.AllBids() {
+			bid.Price = 2
+		}
+		Expect(res.SeatBid[2].Bid[0].Price).To(Equal(2.0))
+	})
+
+})
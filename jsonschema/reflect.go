@@ -0,0 +1,136 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Reflector derives schemas from Go types, using their json struct tags.
+// Named struct types are emitted as definitions and referenced via $ref.
+type Reflector struct {
+	// Types overrides the schemas of specific types, e.g. of types with
+	// custom JSON marshalling.
+	Types map[reflect.Type]*Schema
+	// Field optionally overrides the schema of a struct field, if it returns
+	// a non-nil value.
+	Field func(t reflect.Type, field reflect.StructField) *Schema
+	// Optional reports if a struct field without the omitempty option is
+	// optional nevertheless, e.g. because a default applies. By default,
+	// fields without omitempty are required.
+	Optional func(t reflect.Type, field reflect.StructField) bool
+	// Deprecated reports if a struct field is deprecated. By default, no
+	// fields are deprecated.
+	Deprecated func(t reflect.Type, field reflect.StructField) bool
+	// Skip reports if a struct field is omitted from the schema. Unlike the
+	// other callbacks, it receives the struct type which declares the
+	// field, e.g. the type of an embedded struct.
+	Skip func(t reflect.Type, field reflect.StructField) bool
+}
+
+// Reflect returns a schema document describing the type of v.
+func (r *Reflector) Reflect(v interface{}) *Schema {
+	defs := make(map[string]*Schema)
+	s := r.reflectType(reflect.TypeOf(v), defs)
+	s.Schema = Draft07
+	if len(defs) != 0 {
+		s.Definitions = defs
+	}
+	return s
+}
+
+func (r *Reflector) reflectType(t reflect.Type, defs map[string]*Schema) *Schema {
+	if s, ok := r.Types[t]; ok {
+		return s.copy()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.reflectType(t.Elem(), defs)
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}}
+		}
+		return &Schema{Type: Types{"array"}, Items: r.reflectType(t.Elem(), defs)}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.reflectStruct(t, defs)
+		}
+		name := t.Name()
+		if _, ok := defs[name]; !ok {
+			defs[name] = nil // placeholder, in case of recursion
+			defs[name] = r.reflectStruct(t, defs)
+		}
+		return &Schema{Ref: "#/definitions/" + name}
+	}
+	return &Schema{}
+}
+
+func (r *Reflector) reflectStruct(t reflect.Type, defs map[string]*Schema) *Schema {
+	s := &Schema{Type: Types{"object"}, Properties: make(map[string]*Schema)}
+	r.reflectFields(t, t, s, defs)
+	return s
+}
+
+func (r *Reflector) reflectFields(root, t reflect.Type, s *Schema, defs map[string]*Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" || (r.Skip != nil && r.Skip(t, field)) {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.IndexByte(tag, ','); i > -1 {
+			name, opts = tag[:i], tag[i:]
+		}
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.reflectFields(root, ft, s, defs)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		var prop *Schema
+		if r.Field != nil {
+			prop = r.Field(root, field)
+		}
+		if prop == nil {
+			prop = r.reflectType(field.Type, defs)
+		}
+		if r.Deprecated != nil && r.Deprecated(root, field) {
+			prop.Deprecated = true
+		}
+		s.Properties[name] = prop
+
+		if !strings.Contains(opts+",", ",omitempty,") && (r.Optional == nil || !r.Optional(root, field)) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func (s *Schema) copy() *Schema {
+	c := *s
+	return &c
+}
@@ -0,0 +1,98 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reflector", func() {
+
+	type embedded struct {
+		Name string `json:"name,omitempty"`
+	}
+
+	type node struct {
+		embedded
+		ID       int               `json:"id"`
+		Score    float64           `json:"score,omitempty"`
+		Tags     []string          `json:"tags,omitempty"`
+		Meta     map[string]string `json:"meta,omitempty"`
+		Raw      json.RawMessage   `json:"raw,omitempty"`
+		Children []*node           `json:"children,omitempty"`
+		Old      bool              `json:"old,omitempty" deprecated:"yes"`
+		Skipped  string            `json:"-"`
+		internal string
+	}
+
+	It("should reflect types", func() {
+		r := &Reflector{
+			Types: map[reflect.Type]*Schema{
+				reflect.TypeOf(json.RawMessage(nil)): {Type: Types{"object"}},
+			},
+			Deprecated: func(_ reflect.Type, f reflect.StructField) bool {
+				return f.Tag.Get("deprecated") != ""
+			},
+		}
+
+		data, err := json.Marshal(r.Reflect(&node{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"$ref": "#/definitions/node",
+			"definitions": {
+				"node": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"id": {"type": "integer"},
+						"score": {"type": "number"},
+						"tags": {"type": "array", "items": {"type": "string"}},
+						"meta": {"type": "object"},
+						"raw": {"type": "object"},
+						"children": {"type": "array", "items": {"$ref": "#/definitions/node"}},
+						"old": {"type": "boolean", "deprecated": true}
+					},
+					"required": ["id"]
+				}
+			}
+		}`))
+	})
+
+	It("should support field overrides", func() {
+		r := &Reflector{Field: func(_ reflect.Type, f reflect.StructField) *Schema {
+			if f.Name == "ID" {
+				return &Schema{Type: Types{"integer", "string"}}
+			}
+			return nil
+		}}
+		s := r.Reflect(node{})
+		Expect(s.Definitions["node"].Properties["id"].Type).To(Equal(Types{"integer", "string"}))
+		Expect(s.Definitions["node"].Properties["name"].Type).To(Equal(Types{"string"}))
+	})
+
+	It("should skip fields", func() {
+		var declaring []string
+		r := &Reflector{Skip: func(t reflect.Type, f reflect.StructField) bool {
+			declaring = append(declaring, t.Name()+"."+f.Name)
+			return f.Name == "Score" || f.Name == "Name"
+		}}
+
+		s := r.Reflect(&node{}).Definitions["node"]
+		Expect(s.Properties).NotTo(HaveKey("score"))
+		Expect(s.Properties).NotTo(HaveKey("name"))
+		Expect(s.Properties).To(HaveKey("id"))
+		Expect(declaring).To(ContainElement("embedded.Name"))
+	})
+
+	It("should support optional fields", func() {
+		r := &Reflector{Optional: func(_ reflect.Type, f reflect.StructField) bool { return f.Name == "ID" }}
+		s := r.Reflect(node{})
+		Expect(s.Definitions["node"].Required).To(BeEmpty())
+		Expect(s.Validate(map[string]interface{}{"children": []interface{}{map[string]interface{}{"id": json.Number("1")}}})).To(Succeed())
+		Expect(s.Validate(map[string]interface{}{"id": "1"})).To(MatchError("id: expected integer, got string"))
+	})
+
+})
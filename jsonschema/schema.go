@@ -0,0 +1,199 @@
+/*
+Package jsonschema implements a compact subset of JSON Schema (draft-07),
+sufficient to describe and validate OpenRTB payloads and extensions.
+
+Supported keywords are: type, properties, required, additionalProperties
+(boolean form), items, enum, minimum, maximum, minLength, maxLength, pattern,
+minItems, maxItems, anyOf, oneOf, definitions and local $ref pointers of the
+form "#/definitions/<name>". Schemas can also be derived from Go types
+using a Reflector.
+*/
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Draft07 is the $schema URI of draft-07
+const Draft07 = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document or sub-schema.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+
+	Type                 Types              `json:"type,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// Parse parses a JSON schema document and compiles its patterns.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate validates a decoded JSON value, as returned by a json.Decoder
+// with UseNumber enabled, and returns Errors if invalid.
+func (s *Schema) Validate(v interface{}) error {
+	var errs Errors
+	s.validate(s, v, "", &errs)
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateJSON decodes and validates data. Error paths are prefixed with
+// path, e.g. "imp[0].ext.bidder".
+func (s *Schema) ValidateJSON(data []byte, path string) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return Errors{{Path: path, Message: "invalid JSON: " + err.Error()}}
+	}
+
+	var errs Errors
+	s.validate(s, v, path, &errs)
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s *Schema) compile() error {
+	if s.Pattern != "" {
+		if _, err := compilePattern(s.Pattern); err != nil {
+			return err
+		}
+	}
+	for _, c := range s.children() {
+		if err := c.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) children() []*Schema {
+	var cs []*Schema
+	for _, c := range s.Properties {
+		cs = append(cs, c)
+	}
+	for _, c := range s.Definitions {
+		cs = append(cs, c)
+	}
+	if s.Items != nil {
+		cs = append(cs, s.Items)
+	}
+	cs = append(cs, s.AnyOf...)
+	cs = append(cs, s.OneOf...)
+	return cs
+}
+
+// resolve resolves local $ref pointers against root.
+func (s *Schema) resolve(root *Schema) (*Schema, error) {
+	for i := 0; s.Ref != ""; i++ {
+		if i > 32 || !strings.HasPrefix(s.Ref, "#/definitions/") {
+			return nil, errors.New("unresolvable $ref " + s.Ref)
+		}
+		def, ok := root.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+		if !ok {
+			return nil, errors.New("unresolvable $ref " + s.Ref)
+		}
+		s = def
+	}
+	return s, nil
+}
+
+var patterns sync.Map
+
+func compilePattern(p string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(p); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(p, re)
+	return re, nil
+}
+
+// Types is a list of allowed JSON types. It is encoded as a single string
+// if it contains only one type.
+type Types []string
+
+// MarshalJSON implements json.Marshaler
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = Types{s}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// Error is a single, path-qualified validation error.
+type Error struct {
+	Path    string
+	Message string
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Errors is a list of validation errors.
+type Errors []*Error
+
+// Error implements the error interface
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
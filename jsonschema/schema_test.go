@@ -0,0 +1,81 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema", func() {
+	var subject *Schema
+
+	BeforeEach(func() {
+		var err error
+		subject, err = Parse([]byte(`{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"placementId": {"type": "integer", "minimum": 1},
+				"keywords": {"type": "array", "items": {"type": "string", "minLength": 2}, "maxItems": 2},
+				"size": {"$ref": "#/definitions/size"},
+				"mode": {"enum": ["a", "b", 3]},
+				"code": {"type": ["string", "null"], "pattern": "^[A-Z]+$"}
+			},
+			"required": ["placementId"],
+			"additionalProperties": false,
+			"definitions": {
+				"size": {"type": "object", "properties": {"w": {"type": "integer"}}, "required": ["w"]}
+			}
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse", func() {
+		Expect(subject.Type).To(Equal(Types{"object"}))
+		Expect(subject.Properties["code"].Type).To(Equal(Types{"string", "null"}))
+		Expect(json.Marshal(subject.Properties["placementId"])).To(MatchJSON(`{"type":"integer","minimum":1}`))
+
+		_, err := Parse([]byte(`{"pattern":"("}`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should validate valid documents", func() {
+		Expect(subject.ValidateJSON([]byte(`{"placementId":12,"keywords":["ab"],"size":{"w":300},"mode":3,"code":null}`), "")).To(Succeed())
+		Expect(subject.ValidateJSON([]byte(`{"placementId":12,"mode":"a","code":"ABC"}`), "")).To(Succeed())
+	})
+
+	It("should report path-qualified errors", func() {
+		err := subject.ValidateJSON([]byte(`{"placementId":1.5,"keywords":["a","bc","de"],"size":{},"mode":"c","code":"abc","other":true}`), "imp[0].ext.bidder")
+		Expect(err).To(BeAssignableToTypeOf(Errors{}))
+		Expect(err.(Errors)).To(Equal(Errors{
+			{Path: "imp[0].ext.bidder.code", Message: "does not match pattern ^[A-Z]+$"},
+			{Path: "imp[0].ext.bidder.keywords", Message: "must have at most 2 items"},
+			{Path: "imp[0].ext.bidder.keywords[0]", Message: "must be at least 2 characters long"},
+			{Path: "imp[0].ext.bidder.mode", Message: "value is not one of the allowed values"},
+			{Path: "imp[0].ext.bidder.other", Message: "is not allowed"},
+			{Path: "imp[0].ext.bidder.placementId", Message: "expected integer, got number"},
+			{Path: "imp[0].ext.bidder.size.w", Message: "is required"},
+		}))
+
+		err = subject.ValidateJSON([]byte(`{}`), "bidder")
+		Expect(err).To(MatchError("bidder.placementId: is required"))
+		err = subject.ValidateJSON([]byte(`{"placementId":0}`), "")
+		Expect(err).To(MatchError("placementId: must be >= 1"))
+	})
+
+	It("should support anyOf/oneOf", func() {
+		s, err := Parse([]byte(`{"oneOf":[{"type":"string"},{"type":"integer"}],"anyOf":[{"type":"string"},{"type":"number"}]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.ValidateJSON([]byte(`"x"`), "")).To(Succeed())
+		Expect(s.ValidateJSON([]byte(`1.5`), "")).To(MatchError("value must match exactly one of the allowed schemas"))
+		Expect(s.ValidateJSON([]byte(`true`), "")).To(HaveOccurred())
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/jsonschema")
+}
@@ -0,0 +1,202 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+func (s *Schema) validate(root *Schema, v interface{}, path string, errs *Errors) {
+	s, err := s.resolve(root)
+	if err != nil {
+		errs.add(path, err.Error())
+		return
+	}
+
+	if len(s.Type) != 0 && !s.Type.matches(v) {
+		errs.add(path, "expected "+strings.Join(s.Type, " or ")+", got "+typeOf(v))
+		return
+	}
+	if len(s.Enum) != 0 && !inEnum(s.Enum, v) {
+		errs.add(path, "value is not one of the allowed values")
+	}
+
+	switch x := v.(type) {
+	case map[string]interface{}:
+		s.validateObject(root, x, path, errs)
+	case []interface{}:
+		s.validateArray(root, x, path, errs)
+	case string:
+		s.validateString(x, path, errs)
+	case json.Number, float64:
+		s.validateNumber(toFloat(x), path, errs)
+	}
+
+	if len(s.AnyOf) != 0 && countMatches(root, s.AnyOf, v) == 0 {
+		errs.add(path, "value does not match any of the allowed schemas")
+	}
+	if len(s.OneOf) != 0 && countMatches(root, s.OneOf, v) != 1 {
+		errs.add(path, "value must match exactly one of the allowed schemas")
+	}
+}
+
+func (s *Schema) validateObject(root *Schema, obj map[string]interface{}, path string, errs *Errors) {
+	for _, key := range s.Required {
+		if _, ok := obj[key]; !ok {
+			errs.add(joinPath(path, key), "is required")
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if prop, ok := s.Properties[key]; ok {
+			prop.validate(root, obj[key], joinPath(path, key), errs)
+		} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			errs.add(joinPath(path, key), "is not allowed")
+		}
+	}
+}
+
+func (s *Schema) validateArray(root *Schema, arr []interface{}, path string, errs *Errors) {
+	if s.MinItems != nil && len(arr) < *s.MinItems {
+		errs.add(path, fmt.Sprintf("must have at least %d items", *s.MinItems))
+	}
+	if s.MaxItems != nil && len(arr) > *s.MaxItems {
+		errs.add(path, fmt.Sprintf("must have at most %d items", *s.MaxItems))
+	}
+	if s.Items != nil {
+		for i, item := range arr {
+			s.Items.validate(root, item, path+"["+strconv.Itoa(i)+"]", errs)
+		}
+	}
+}
+
+func (s *Schema) validateString(str string, path string, errs *Errors) {
+	n := utf8.RuneCountInString(str)
+	if s.MinLength != nil && n < *s.MinLength {
+		errs.add(path, fmt.Sprintf("must be at least %d characters long", *s.MinLength))
+	}
+	if s.MaxLength != nil && n > *s.MaxLength {
+		errs.add(path, fmt.Sprintf("must be at most %d characters long", *s.MaxLength))
+	}
+	if s.Pattern != "" {
+		if re, err := compilePattern(s.Pattern); err != nil {
+			errs.add(path, "invalid pattern: "+err.Error())
+		} else if !re.MatchString(str) {
+			errs.add(path, "does not match pattern "+s.Pattern)
+		}
+	}
+}
+
+func (s *Schema) validateNumber(f float64, path string, errs *Errors) {
+	if s.Minimum != nil && f < *s.Minimum {
+		errs.add(path, "must be >= "+strconv.FormatFloat(*s.Minimum, 'f', -1, 64))
+	}
+	if s.Maximum != nil && f > *s.Maximum {
+		errs.add(path, "must be <= "+strconv.FormatFloat(*s.Maximum, 'f', -1, 64))
+	}
+}
+
+func (t Types) matches(v interface{}) bool {
+	actual := typeOf(v)
+	for _, typ := range t {
+		if typ == actual || (typ == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number, float64:
+		if f := toFloat(x); f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return reflect.TypeOf(v).String()
+}
+
+func toFloat(v interface{}) float64 {
+	switch x := v.(type) {
+	case json.Number:
+		f, _ := x.Float64()
+		return f
+	case float64:
+		return x
+	}
+	return 0
+}
+
+func inEnum(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		if isNumber(e) {
+			if isNumber(v) && toFloat(normNumber(e)) == toFloat(normNumber(v)) {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case json.Number, float64, int:
+		return true
+	}
+	return false
+}
+
+func normNumber(v interface{}) interface{} {
+	if n, ok := v.(int); ok {
+		return float64(n)
+	}
+	return v
+}
+
+func countMatches(root *Schema, schemas []*Schema, v interface{}) int {
+	n := 0
+	for _, s := range schemas {
+		var errs Errors
+		if s.validate(root, v, "", &errs); len(errs) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (e *Errors) add(path, msg string) {
+	*e = append(*e, &Error{Path: path, Message: msg})
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
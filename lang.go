@@ -0,0 +1,130 @@
+package openrtb
+
+import "strings"
+
+// IsValidLanguage returns true if lang is an ISO-639-1-alpha-2 language code,
+// e.g. "en". Codes are case-insensitive.
+func IsValidLanguage(lang string) bool {
+	_, ok := iso639[strings.ToLower(lang)]
+	return ok
+}
+
+// IsValidLanguageTag returns true if tag is a well-formed IETF BCP 47
+// language tag, e.g. "en", "en-US" or "zh-Hant-TW". The primary language
+// subtag must be a known ISO-639-1 code or a three-letter code, subsequent
+// subtags must consist of one to eight letters or digits.
+func IsValidLanguageTag(tag string) bool {
+	subtags := strings.Split(tag, "-")
+	if primary := subtags[0]; !(len(primary) == 2 && IsValidLanguage(primary)) && !(len(primary) == 3 && isLetters(primary)) {
+		return false
+	}
+	for _, sub := range subtags[1:] {
+		if len(sub) == 0 || len(sub) > 8 {
+			return false
+		}
+		for i := 0; i < len(sub); i++ {
+			if c := sub[i]; !isLetter(c) && (c < '0' || c > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CheckLanguage checks the language of a bid against the allow-lists of the
+// request:
+//
+//   - wlang: allows ISO-639-1 languages, matched against the language of the
+//     bid or the primary subtag of its langb;
+//   - wlangb: allows BCP 47 language ranges, i.e. "en" allows "en-US" but
+//     "en-US" does not allow "en", matched against the langb of the bid or,
+//     failing that, its language.
+//
+// Bids without a language and requests without allow-lists pass. It returns
+// nil if the bid is compliant.
+func (req *BidRequest) CheckLanguage(bid *Bid) *Rejection {
+	if len(req.WLang) != 0 {
+		lang := bid.Language
+		if lang == "" {
+			lang = primaryLanguage(bid.LangB)
+		}
+		if lang != "" && !containsFold(req.WLang, lang) {
+			return &Rejection{Code: LossCreativeLanguageExclusion, Field: "wlang", Value: lang, Reason: "language not allowed " + lang}
+		}
+	}
+
+	if len(req.WLangB) != 0 {
+		tag := bid.LangB
+		if tag == "" {
+			tag = bid.Language
+		}
+		if tag != "" && !matchesLanguageRange(req.WLangB, tag) {
+			return &Rejection{Code: LossCreativeLanguageExclusion, Field: "wlangb", Value: tag, Reason: "language not allowed " + tag}
+		}
+	}
+
+	return nil
+}
+
+// primaryLanguage returns the primary language subtag of a BCP 47 tag.
+func primaryLanguage(tag string) string {
+	if pos := strings.IndexByte(tag, '-'); pos > -1 {
+		return tag[:pos]
+	}
+	return tag
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLanguageRange implements basic filtering as per RFC 4647.
+func matchesLanguageRange(ranges []string, tag string) bool {
+	for _, r := range ranges {
+		if strings.EqualFold(r, tag) {
+			return true
+		}
+		if len(tag) > len(r) && tag[len(r)] == '-' && strings.EqualFold(tag[:len(r)], r) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isLetter(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+var iso639 = map[string]struct{}{
+	"aa": {}, "ab": {}, "ae": {}, "af": {}, "ak": {}, "am": {}, "an": {}, "ar": {}, "as": {},
+	"av": {}, "ay": {}, "az": {}, "ba": {}, "be": {}, "bg": {}, "bh": {}, "bi": {}, "bm": {},
+	"bn": {}, "bo": {}, "br": {}, "bs": {}, "ca": {}, "ce": {}, "ch": {}, "co": {}, "cr": {},
+	"cs": {}, "cu": {}, "cv": {}, "cy": {}, "da": {}, "de": {}, "dv": {}, "dz": {}, "ee": {},
+	"el": {}, "en": {}, "eo": {}, "es": {}, "et": {}, "eu": {}, "fa": {}, "ff": {}, "fi": {},
+	"fj": {}, "fo": {}, "fr": {}, "fy": {}, "ga": {}, "gd": {}, "gl": {}, "gn": {}, "gu": {},
+	"gv": {}, "ha": {}, "he": {}, "hi": {}, "ho": {}, "hr": {}, "ht": {}, "hu": {}, "hy": {},
+	"hz": {}, "ia": {}, "id": {}, "ie": {}, "ig": {}, "ii": {}, "ik": {}, "io": {}, "is": {},
+	"it": {}, "iu": {}, "ja": {}, "jv": {}, "ka": {}, "kg": {}, "ki": {}, "kj": {}, "kk": {},
+	"kl": {}, "km": {}, "kn": {}, "ko": {}, "kr": {}, "ks": {}, "ku": {}, "kv": {}, "kw": {},
+	"ky": {}, "la": {}, "lb": {}, "lg": {}, "li": {}, "ln": {}, "lo": {}, "lt": {}, "lu": {},
+	"lv": {}, "mg": {}, "mh": {}, "mi": {}, "mk": {}, "ml": {}, "mn": {}, "mr": {}, "ms": {},
+	"mt": {}, "my": {}, "na": {}, "nb": {}, "nd": {}, "ne": {}, "ng": {}, "nl": {}, "nn": {},
+	"no": {}, "nr": {}, "nv": {}, "ny": {}, "oc": {}, "oj": {}, "om": {}, "or": {}, "os": {},
+	"pa": {}, "pi": {}, "pl": {}, "ps": {}, "pt": {}, "qu": {}, "rm": {}, "rn": {}, "ro": {},
+	"ru": {}, "rw": {}, "sa": {}, "sc": {}, "sd": {}, "se": {}, "sg": {}, "si": {}, "sk": {},
+	"sl": {}, "sm": {}, "sn": {}, "so": {}, "sq": {}, "sr": {}, "ss": {}, "st": {}, "su": {},
+	"sv": {}, "sw": {}, "ta": {}, "te": {}, "tg": {}, "th": {}, "ti": {}, "tk": {}, "tl": {},
+	"tn": {}, "to": {}, "tr": {}, "ts": {}, "tt": {}, "tw": {}, "ty": {}, "ug": {}, "uk": {},
+	"ur": {}, "uz": {}, "ve": {}, "vi": {}, "vo": {}, "wa": {}, "wo": {}, "xh": {}, "yi": {},
+	"yo": {}, "za": {}, "zh": {}, "zu": {},
+}
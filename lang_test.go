@@ -0,0 +1,88 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsValidLanguage", func() {
+
+	It("should check codes", func() {
+		Expect(IsValidLanguage("en")).To(BeTrue())
+		Expect(IsValidLanguage("DE")).To(BeTrue())
+		Expect(IsValidLanguage("")).To(BeFalse())
+		Expect(IsValidLanguage("xx")).To(BeFalse())
+		Expect(IsValidLanguage("eng")).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("IsValidLanguageTag", func() {
+
+	It("should check tags", func() {
+		Expect(IsValidLanguageTag("en")).To(BeTrue())
+		Expect(IsValidLanguageTag("en-US")).To(BeTrue())
+		Expect(IsValidLanguageTag("zh-Hant-TW")).To(BeTrue())
+		Expect(IsValidLanguageTag("yue-HK")).To(BeTrue())
+		Expect(IsValidLanguageTag("es-419")).To(BeTrue())
+		Expect(IsValidLanguageTag("")).To(BeFalse())
+		Expect(IsValidLanguageTag("xx-US")).To(BeFalse())
+		Expect(IsValidLanguageTag("en-")).To(BeFalse())
+		Expect(IsValidLanguageTag("en_US")).To(BeFalse())
+		Expect(IsValidLanguageTag("en-toolongtag")).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("BidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{ID: "R", Imp: []Impression{{ID: "I", Banner: &Banner{}}}}
+	})
+
+	It("should validate language allow-lists", func() {
+		subject.WLang = []string{"en", "de"}
+		Expect(subject.Validate()).To(Succeed())
+		subject.WLang = []string{"en", "xx"}
+		Expect(subject.Validate()).To(MatchError(ErrInvalidReqWLang))
+
+		subject.WLang = nil
+		subject.WLangB = []string{"en-US", "zh-Hant"}
+		Expect(subject.Validate()).To(Succeed())
+		subject.WLangB = []string{"en_US"}
+		Expect(subject.Validate()).To(MatchError(ErrInvalidReqWLangB))
+
+		subject.WLang = []string{"en"}
+		subject.WLangB = []string{"en-US"}
+		Expect(subject.Validate()).To(MatchError(ErrInvalidReqMultiLang))
+	})
+
+	It("should check bid languages against wlang", func() {
+		Expect(subject.CheckLanguage(&Bid{Language: "fr"})).To(BeNil())
+
+		subject.WLang = []string{"en", "de"}
+		Expect(subject.CheckLanguage(&Bid{})).To(BeNil())
+		Expect(subject.CheckLanguage(&Bid{Language: "DE"})).To(BeNil())
+		Expect(subject.CheckLanguage(&Bid{LangB: "en-GB"})).To(BeNil())
+		Expect(subject.CheckLanguage(&Bid{Language: "fr"})).To(Equal(&Rejection{
+			Code:   LossCreativeLanguageExclusion,
+			Field:  "wlang",
+			Value:  "fr",
+			Reason: "language not allowed fr",
+		}))
+		Expect(subject.CheckLanguage(&Bid{LangB: "fr-CA"})).To(HaveField("Value", "fr"))
+	})
+
+	It("should check bid languages against wlangb", func() {
+		subject.WLangB = []string{"en", "pt-BR"}
+		Expect(subject.CheckLanguage(&Bid{LangB: "en"})).To(BeNil())
+		Expect(subject.CheckLanguage(&Bid{LangB: "en-US"})).To(BeNil())
+		Expect(subject.CheckLanguage(&Bid{LangB: "pt-br"})).To(BeNil())
+		Expect(subject.CheckLanguage(&Bid{Language: "en"})).To(BeNil())
+		Expect(subject.CheckLanguage(&Bid{LangB: "pt"})).To(HaveField("Field", "wlangb"))
+		Expect(subject.CheckLanguage(&Bid{LangB: "pt-PT"})).To(HaveField("Code", LossCreativeLanguageExclusion))
+		Expect(subject.CheckLanguage(&Bid{LangB: "eng"})).NotTo(BeNil())
+	})
+
+})
@@ -0,0 +1,231 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Substitution macros, which may be included in the notice URLs of a bid
+const (
+	MacroAuctionID         = "${AUCTION_ID}"         // ID of the bid request
+	MacroAuctionBidID      = "${AUCTION_BID_ID}"     // ID of the bid response (bidid)
+	MacroAuctionImpID      = "${AUCTION_IMP_ID}"     // ID of the impression just won
+	MacroAuctionSeatID     = "${AUCTION_SEAT_ID}"    // ID of the bidder seat for whom the bid was made
+	MacroAuctionAdID       = "${AUCTION_AD_ID}"      // ID of the ad markup the bidder wishes to serve
+	MacroAuctionPrice      = "${AUCTION_PRICE}"      // Clearing price using the same currency and units as the bid
+	MacroAuctionCurrency   = "${AUCTION_CURRENCY}"   // The currency used in the bid
+	MacroAuctionLoss       = "${AUCTION_LOSS}"       // Loss reason codes
+	MacroAuctionMinToWin   = "${AUCTION_MIN_TO_WIN}" // Minimum bid to win the exchange's auction
+	MacroAuctionMultiplier = "${AUCTION_MULTIPLIER}" // Total quantity of impressions won, for DOOH
+)
+
+// MacroEscaping determines how substituted values are escaped.
+type MacroEscaping int
+
+// Macro escaping modes
+const (
+	MacroEscapeNone MacroEscaping = iota // Values are inserted verbatim
+	MacroEscapeURL                       // Values are query-escaped, for URLs
+	MacroEscapeHTML                      // Values are HTML-escaped, for HTML and XML markup
+	MacroEscapeJSON                      // Values are escaped as JSON string contents, for native markup
+)
+
+// MacroValues contains the values substituted for the auction macros.
+// Unset optional strings are substituted with empty strings, numbers are
+// always rendered, e.g. a zero price as "0".
+type MacroValues struct {
+	AuctionID  string
+	BidID      string
+	ImpID      string
+	SeatID     string
+	AdID       string
+	Price      float64 // Clearing price (CPM) per impression
+	Currency   string
+	Loss       int
+	MinToWin   float64
+	Multiplier float64
+
+	// Test marks the values of test traffic, for which notices must not be
+	// fired, see BidRequest.IsTest.
+	Test bool
+}
+
+// NewMacroValues builds the macro values for bid, clearing at price.
+// The multiplier is taken from the impression the bid relates to and
+// defaults to 1.
+func NewMacroValues(req *BidRequest, res *BidResponse, seat *SeatBid, bid *Bid, price float64) *MacroValues {
+	v := &MacroValues{
+		AuctionID:  req.ID,
+		BidID:      res.BidID,
+		ImpID:      bid.ImpID,
+		SeatID:     seat.Seat,
+		AdID:       bid.AdID,
+		Price:      price,
+		Currency:   res.Currency,
+		Multiplier: 1,
+		Test:       req.IsTest(),
+	}
+	if imp := req.ImpByID(bid.ImpID); imp != nil {
+		v.Multiplier = imp.Multiplier()
+	}
+	return v
+}
+
+// ExpandMacros substitutes all known auction macros in s.
+func ExpandMacros(s string, v *MacroValues) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return CompileMacros(s).Expand(v)
+}
+
+// ExpandMacrosEscaped substitutes all known auction macros in s, escaping
+// the values according to esc.
+func ExpandMacrosEscaped(s string, v *MacroValues, esc MacroEscaping) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return CompileMacros(s).ExpandEscaped(v, esc)
+}
+
+// ExpandMarkup substitutes all known auction macros in the ad markup of the
+// bid at render time. Values are escaped as JSON string contents for native
+// markup and HTML-escaped otherwise, which is also safe for VAST.
+func (bid *Bid) ExpandMarkup(v *MacroValues) string {
+	return ExpandMacrosEscaped(bid.AdMarkup, v, bid.markupEscaping())
+}
+
+func (bid *Bid) markupEscaping() MacroEscaping {
+	if mediaType := bid.MediaType(); mediaType == MediaTypeNative || (mediaType == "" && strings.HasPrefix(strings.TrimSpace(bid.AdMarkup), "{")) {
+		return MacroEscapeJSON
+	}
+	return MacroEscapeHTML
+}
+
+// MacroTemplate is a string with pre-parsed macro positions, which can be
+// expanded repeatedly without re-scanning, e.g. for cached ad markup.
+type MacroTemplate struct {
+	src  string
+	segs []macroSegment
+}
+
+type macroSegment struct {
+	lit   string
+	macro string
+}
+
+// CompileMacros parses the positions of all known auction macros in s.
+func CompileMacros(s string) *MacroTemplate {
+	t := &MacroTemplate{src: s}
+
+	lit := 0
+	for pos := 0; pos < len(s); {
+		i := strings.Index(s[pos:], "${")
+		if i < 0 {
+			break
+		}
+		i += pos
+
+		j := strings.IndexByte(s[i:], '}')
+		if j < 0 {
+			break
+		}
+		j += i + 1
+
+		if macro := s[i:j]; isKnownMacro(macro) {
+			t.segs = append(t.segs, macroSegment{lit: s[lit:i], macro: macro})
+			lit = j
+		}
+		pos = i + 2
+	}
+	if lit < len(s) {
+		t.segs = append(t.segs, macroSegment{lit: s[lit:]})
+	}
+	return t
+}
+
+// String returns the original string.
+func (t *MacroTemplate) String() string { return t.src }
+
+// HasMacros returns true if the template contains any known macros.
+func (t *MacroTemplate) HasMacros() bool {
+	return len(t.segs) > 1 || (len(t.segs) == 1 && t.segs[0].macro != "")
+}
+
+// Expand substitutes all macros with values from v.
+func (t *MacroTemplate) Expand(v *MacroValues) string {
+	return t.ExpandEscaped(v, MacroEscapeNone)
+}
+
+// ExpandEscaped substitutes all macros with values from v, escaped
+// according to esc.
+func (t *MacroTemplate) ExpandEscaped(v *MacroValues, esc MacroEscaping) string {
+	if !t.HasMacros() {
+		return t.src
+	}
+
+	var b strings.Builder
+	b.Grow(len(t.src))
+	for _, seg := range t.segs {
+		b.WriteString(seg.lit)
+		if seg.macro != "" {
+			b.WriteString(esc.escape(v.lookup(seg.macro)))
+		}
+	}
+	return b.String()
+}
+
+func (esc MacroEscaping) escape(s string) string {
+	switch esc {
+	case MacroEscapeURL:
+		return url.QueryEscape(s)
+	case MacroEscapeHTML:
+		return html.EscapeString(s)
+	case MacroEscapeJSON:
+		data, _ := json.Marshal(s)
+		return string(data[1 : len(data)-1])
+	}
+	return s
+}
+
+func isKnownMacro(macro string) bool {
+	switch macro {
+	case MacroAuctionID, MacroAuctionBidID, MacroAuctionImpID, MacroAuctionSeatID, MacroAuctionAdID,
+		MacroAuctionPrice, MacroAuctionCurrency, MacroAuctionLoss, MacroAuctionMinToWin, MacroAuctionMultiplier:
+		return true
+	}
+	return false
+}
+
+func (v *MacroValues) lookup(macro string) string {
+	switch macro {
+	case MacroAuctionID:
+		return v.AuctionID
+	case MacroAuctionBidID:
+		return v.BidID
+	case MacroAuctionImpID:
+		return v.ImpID
+	case MacroAuctionSeatID:
+		return v.SeatID
+	case MacroAuctionAdID:
+		return v.AdID
+	case MacroAuctionPrice:
+		return formatMacroFloat(v.Price)
+	case MacroAuctionCurrency:
+		return v.Currency
+	case MacroAuctionLoss:
+		return strconv.Itoa(v.Loss)
+	case MacroAuctionMinToWin:
+		return formatMacroFloat(v.MinToWin)
+	case MacroAuctionMultiplier:
+		return formatMacroFloat(v.Multiplier)
+	}
+	return macro
+}
+
+func formatMacroFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,81 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Macros", func() {
+
+	It("should expand", func() {
+		Expect(ExpandMacros("http://ads.com/win", &MacroValues{})).To(Equal("http://ads.com/win"))
+		Expect(ExpandMacros(
+			"http://ads.com/win?id=${AUCTION_ID}&imp=${AUCTION_IMP_ID}&p=${AUCTION_PRICE}&c=${AUCTION_CURRENCY}&m=${AUCTION_MULTIPLIER}&x=${UNKNOWN}",
+			&MacroValues{AuctionID: "A", ImpID: "1", Price: 1.25, Currency: "USD"},
+		)).To(Equal("http://ads.com/win?id=A&imp=1&p=1.25&c=USD&m=0&x=${UNKNOWN}"))
+	})
+
+	It("should compile templates", func() {
+		t := CompileMacros("<img src=\"https://ads.com/i?p=${AUCTION_PRICE}&x=${UNKNOWN}&${AUCTION_ID\">${AUCTION_ID}")
+		Expect(t.HasMacros()).To(BeTrue())
+		Expect(t.Expand(&MacroValues{AuctionID: "A", Price: 0.5})).To(Equal("<img src=\"https://ads.com/i?p=0.5&x=${UNKNOWN}&${AUCTION_ID\">A"))
+		Expect(t.Expand(&MacroValues{AuctionID: "B"})).To(Equal("<img src=\"https://ads.com/i?p=0&x=${UNKNOWN}&${AUCTION_ID\">B"))
+
+		t = CompileMacros("${AUCTION_IMP_ID}${AUCTION_LOSS}")
+		Expect(t.Expand(&MacroValues{ImpID: "1", Loss: LossLostToHigherBid})).To(Equal("1102"))
+
+		t = CompileMacros("<div>${X}</div>")
+		Expect(t.HasMacros()).To(BeFalse())
+		Expect(t.String()).To(Equal("<div>${X}</div>"))
+		Expect(t.Expand(&MacroValues{})).To(Equal("<div>${X}</div>"))
+		Expect(CompileMacros("").Expand(&MacroValues{})).To(Equal(""))
+	})
+
+	It("should escape", func() {
+		v := &MacroValues{AuctionID: `a&b <"c">`, Price: 1.5}
+		Expect(ExpandMacrosEscaped("id=${AUCTION_ID}", v, MacroEscapeNone)).To(Equal(`id=a&b <"c">`))
+		Expect(ExpandMacrosEscaped("id=${AUCTION_ID}", v, MacroEscapeURL)).To(Equal(`id=a%26b+%3C%22c%22%3E`))
+		Expect(ExpandMacrosEscaped("id=${AUCTION_ID}", v, MacroEscapeHTML)).To(Equal(`id=a&amp;b &lt;&#34;c&#34;&gt;`))
+		Expect(ExpandMacrosEscaped("id=${AUCTION_ID}", v, MacroEscapeJSON)).To(Equal(`id=a\u0026b \u003c\"c\"\u003e`))
+		Expect(CompileMacros("p=${AUCTION_PRICE}").ExpandEscaped(v, MacroEscapeURL)).To(Equal("p=1.5"))
+	})
+
+	It("should expand markup", func() {
+		v := &MacroValues{AuctionID: `a"b`, Price: 1.5}
+		bid := &Bid{AdMarkup: `<img src="https://ads.com/i?id=${AUCTION_ID}&p=${AUCTION_PRICE}">`}
+		Expect(bid.ExpandMarkup(v)).To(Equal(`<img src="https://ads.com/i?id=a&#34;b&p=1.5">`))
+
+		bid = &Bid{MType: MarkupTypeNative, AdMarkup: `{"native":{"imptrackers":["https://ads.com/i?id=${AUCTION_ID}"]}}`}
+		Expect(bid.ExpandMarkup(v)).To(Equal(`{"native":{"imptrackers":["https://ads.com/i?id=a\"b"]}}`))
+		bid.MType = 0
+		Expect(bid.ExpandMarkup(v)).To(Equal(`{"native":{"imptrackers":["https://ads.com/i?id=a\"b"]}}`))
+	})
+
+	It("should build values with multipliers", func() {
+		req := &BidRequest{ID: "A", Imp: []Impression{{ID: "1", Qty: &Qty{Multiplier: 12.5}}, {ID: "2"}}}
+		res := &BidResponse{ID: "A", BidID: "B", Currency: "EUR"}
+		seat := &SeatBid{Seat: "S"}
+
+		v := NewMacroValues(req, res, seat, &Bid{ID: "X", ImpID: "1", AdID: "AD"}, 2.0)
+		Expect(v).To(Equal(&MacroValues{
+			AuctionID:  "A",
+			BidID:      "B",
+			ImpID:      "1",
+			SeatID:     "S",
+			AdID:       "AD",
+			Price:      2.0,
+			Currency:   "EUR",
+			Multiplier: 12.5,
+		}))
+		Expect(ExpandMacros("p=${AUCTION_PRICE}&m=${AUCTION_MULTIPLIER}", v)).To(Equal("p=2&m=12.5"))
+
+		v = NewMacroValues(req, res, seat, &Bid{ID: "Y", ImpID: "2"}, 2.0)
+		Expect(v.Multiplier).To(Equal(1.0))
+		Expect(v.Test).To(BeFalse())
+		Expect(ExpandMacros("p=${AUCTION_PRICE}&m=${AUCTION_MULTIPLIER}", v)).To(Equal("p=2&m=1"))
+
+		req.Test = 1
+		Expect(NewMacroValues(req, res, seat, &Bid{ID: "Y", ImpID: "2"}, 2.0).Test).To(BeTrue())
+	})
+
+})
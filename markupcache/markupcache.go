@@ -0,0 +1,259 @@
+/*
+Package markupcache stores the markup of bids under a random ID and rewrites
+the bids to reference it by URL. This is the standard pattern for video and
+CTV, where the player fetches the VAST document by ID after the auction,
+rather than receiving it inline.
+
+	cache := markupcache.New(markupcache.NewMemoryStore(0), "https://cache.example.com/vast", 5*time.Minute)
+	http.Handle("/vast", cache)
+
+	// after the auction
+	id, err := cache.CacheBid(ctx, bid, openrtb.NewMacroValues(req, res, seat, bid, price))
+
+VAST markup is replaced by a wrapper referencing the cached document, other
+markup by an iframe. Store can be implemented by any backend, e.g. Redis or
+Memcached, to share the cache across instances.
+*/
+package markupcache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/vastutil"
+)
+
+// Errors
+var (
+	ErrNotFound    = errors.New("markupcache: markup not found")
+	ErrNoMarkup    = errors.New("markupcache: bid has no markup")
+	ErrUnsupported = errors.New("markupcache: native markup cannot be cached")
+)
+
+// Store stores markup by ID.
+type Store interface {
+	// Put stores markup under id, expiring after ttl.
+	Put(ctx context.Context, id, markup string, ttl time.Duration) error
+	// Get retrieves markup by id. It returns ErrNotFound if the markup is
+	// unknown or expired.
+	Get(ctx context.Context, id string) (string, error)
+}
+
+// DefaultMemoryStoreCapacity is the capacity of a MemoryStore, unless
+// specified.
+const DefaultMemoryStoreCapacity = 100000
+
+// MemoryStore is an in-memory Store. It is safe for concurrent use.
+type MemoryStore struct {
+	capacity int
+	items    map[string]memoryItem
+	queue    []queuedItem // in insertion order
+	seq      uint64
+	mu       sync.RWMutex
+	now      func() time.Time
+}
+
+type memoryItem struct {
+	markup    string
+	expiresAt time.Time
+	seq       uint64
+}
+
+type queuedItem struct {
+	id  string
+	seq uint64
+}
+
+// NewMemoryStore creates a new in-memory store, which holds up to capacity
+// entries, 0 = DefaultMemoryStoreCapacity. Once full, the oldest entries
+// are evicted. Expired entries are removed as new ones are stored.
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = DefaultMemoryStoreCapacity
+	}
+	return &MemoryStore{capacity: capacity, items: make(map[string]memoryItem), now: time.Now}
+}
+
+// Put implements Store.
+func (s *MemoryStore) Put(_ context.Context, id, markup string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.seq++
+	s.items[id] = memoryItem{markup: markup, expiresAt: now.Add(ttl), seq: s.seq}
+	s.queue = append(s.queue, queuedItem{id: id, seq: s.seq})
+
+	// evict from the head of the queue: overwritten entries, expired ones
+	// and, while over capacity, the oldest
+	for len(s.queue) != 0 {
+		head := s.queue[0]
+		if item, ok := s.items[head.id]; ok && item.seq == head.seq {
+			if len(s.items) <= s.capacity && now.Before(item.expiresAt) {
+				break
+			}
+			delete(s.items, head.id)
+		}
+		s.queue[0] = queuedItem{}
+		s.queue = s.queue[1:]
+	}
+	return nil
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(_ context.Context, id string) (string, error) {
+	s.mu.RLock()
+	item, ok := s.items[id]
+	s.mu.RUnlock()
+
+	if !ok || !s.now().Before(item.expiresAt) {
+		return "", ErrNotFound
+	}
+	return item.markup, nil
+}
+
+// Sweep removes all expired markup and returns the number of removed
+// entries. Put only removes expired entries in insertion order, Sweep may
+// be called periodically if entries are stored with different TTLs.
+func (s *MemoryStore) Sweep() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, now := 0, s.now()
+	for id, item := range s.items {
+		if !now.Before(item.expiresAt) {
+			delete(s.items, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of stored entries, including expired ones.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Cache caches bid markup and serves it over HTTP.
+type Cache struct {
+	store   Store
+	baseURL string
+	ttl     time.Duration
+}
+
+// New creates a cache. The baseURL is the public URL of the cache handler,
+// e.g. "https://cache.example.com/vast", the ttl limits how long markup is
+// retrievable.
+func New(store Store, baseURL string, ttl time.Duration) *Cache {
+	return &Cache{store: store, baseURL: baseURL, ttl: ttl}
+}
+
+// URL returns the retrieval URL of the markup with the given id.
+func (c *Cache) URL(id string) string {
+	sep := "?"
+	if strings.Contains(c.baseURL, "?") {
+		sep = "&"
+	}
+	return c.baseURL + sep + "uuid=" + url.QueryEscape(id)
+}
+
+// Put stores markup under a new random ID and returns the ID.
+func (c *Cache) Put(ctx context.Context, markup string) (string, error) {
+	id := openrtb.NewTransactionID()
+	if err := c.store.Put(ctx, id, markup, c.ttl); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// CacheBid stores the markup of bid and rewrites it to reference the cached
+// copy: VAST markup is replaced by a wrapper, other markup by an iframe of
+// the bid's size. It returns the ID of the cached markup. Native markup is
+// not supported.
+//
+// The cached copy is served verbatim, so auction macros are expanded with
+// v before the markup is stored, see Bid.ExpandMarkup. Callers which have
+// already expanded the markup may pass a nil v.
+func (c *Cache) CacheBid(ctx context.Context, bid *openrtb.Bid, v *openrtb.MacroValues) (string, error) {
+	adm := strings.TrimSpace(bid.AdMarkup)
+	if adm == "" {
+		return "", ErrNoMarkup
+	}
+
+	isVAST := vastutil.IsVAST(adm)
+	if !isVAST && (bid.MType == openrtb.MarkupTypeNative || adm[0] == '{') {
+		return "", ErrUnsupported
+	}
+
+	markup := bid.AdMarkup
+	if v != nil {
+		markup = bid.ExpandMarkup(v)
+	}
+
+	id, err := c.Put(ctx, markup)
+	if err != nil {
+		return "", err
+	}
+
+	if isVAST {
+		bid.AdMarkup = wrapperVAST(id, c.URL(id))
+	} else {
+		bid.AdMarkup = iframe(c.URL(id), bid.W, bid.H)
+	}
+	return id, nil
+}
+
+// ServeHTTP serves cached markup by its uuid query parameter.
+func (c *Cache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("uuid")
+	if id == "" {
+		http.Error(w, "missing uuid", http.StatusBadRequest)
+		return
+	}
+
+	markup, err := c.store.Get(r.Context(), id)
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if vastutil.IsVAST(markup) {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write([]byte(markup))
+}
+
+func wrapperVAST(id, uri string) string {
+	return `<VAST version="3.0"><Ad id="` + id + `"><Wrapper>` +
+		`<AdSystem>openrtb</AdSystem>` +
+		`<VASTAdTagURI><![CDATA[` + uri + `]]></VASTAdTagURI>` +
+		`<Creatives></Creatives>` +
+		`</Wrapper></Ad></VAST>`
+}
+
+func iframe(src string, w, h int) string {
+	return `<iframe src="` + strings.Replace(src, "&", "&amp;", -1) + `" width="` + strconv.Itoa(w) + `" height="` + strconv.Itoa(h) +
+		`" frameborder="0" scrolling="no" marginwidth="0" marginheight="0"></iframe>`
+}
@@ -0,0 +1,151 @@
+package markupcache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/vastutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const inlineVAST = `<VAST version="3.0"><Ad id="1"><InLine><AdSystem>X</AdSystem><AdTitle>T</AdTitle><Creatives><Creative><Linear><Duration>00:00:15</Duration></Linear></Creative></Creatives></InLine></Ad></VAST>`
+
+var _ = Describe("MemoryStore", func() {
+	var subject *MemoryStore
+	var now time.Time
+	var ctx = context.Background()
+
+	BeforeEach(func() {
+		now = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		subject = NewMemoryStore(0)
+		subject.now = func() time.Time { return now }
+	})
+
+	It("should store and expire markup", func() {
+		Expect(subject.Put(ctx, "a", "A", time.Minute)).To(Succeed())
+		Expect(subject.Put(ctx, "b", "B", time.Hour)).To(Succeed())
+		Expect(subject.Get(ctx, "a")).To(Equal("A"))
+
+		_, err := subject.Get(ctx, "x")
+		Expect(err).To(MatchError(ErrNotFound))
+
+		now = now.Add(time.Minute)
+		_, err = subject.Get(ctx, "a")
+		Expect(err).To(MatchError(ErrNotFound))
+		Expect(subject.Len()).To(Equal(2))
+		Expect(subject.Sweep()).To(Equal(1))
+		Expect(subject.Len()).To(Equal(1))
+	})
+
+	It("should remove expired markup on put", func() {
+		Expect(subject.Put(ctx, "a", "A", time.Minute)).To(Succeed())
+		Expect(subject.Put(ctx, "b", "B", time.Minute)).To(Succeed())
+
+		now = now.Add(time.Minute)
+		Expect(subject.Put(ctx, "c", "C", time.Minute)).To(Succeed())
+		Expect(subject.Len()).To(Equal(1))
+	})
+
+	It("should evict the oldest markup when full", func() {
+		subject = NewMemoryStore(2)
+		Expect(subject.Put(ctx, "a", "A", time.Minute)).To(Succeed())
+		Expect(subject.Put(ctx, "b", "B", time.Minute)).To(Succeed())
+		Expect(subject.Put(ctx, "a", "A2", time.Minute)).To(Succeed())
+		Expect(subject.Put(ctx, "c", "C", time.Minute)).To(Succeed())
+		Expect(subject.Len()).To(Equal(2))
+
+		_, err := subject.Get(ctx, "b")
+		Expect(err).To(MatchError(ErrNotFound))
+		Expect(subject.Get(ctx, "a")).To(Equal("A2"))
+		Expect(subject.Get(ctx, "c")).To(Equal("C"))
+	})
+
+})
+
+var _ = Describe("Cache", func() {
+	var subject *Cache
+	var ctx = context.Background()
+
+	BeforeEach(func() {
+		subject = New(NewMemoryStore(0), "https://cache.example.com/vast", time.Minute)
+	})
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		subject.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	It("should build URLs", func() {
+		Expect(subject.URL("x y")).To(Equal("https://cache.example.com/vast?uuid=x+y"))
+		Expect(New(nil, "https://cache.example.com/?v=1", 0).URL("x")).To(Equal("https://cache.example.com/?v=1&uuid=x"))
+	})
+
+	It("should cache VAST bids", func() {
+		bid := &openrtb.Bid{ID: "B", ImpID: "I", AdMarkup: inlineVAST, MType: openrtb.MarkupTypeVideo}
+		id, err := subject.CacheBid(ctx, bid, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(HaveLen(36))
+
+		info, err := vastutil.Parse(bid.AdMarkup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Wrapper).To(BeTrue())
+		Expect(info.AdTagURI).To(Equal(subject.URL(id)))
+		Expect(bid.AdMarkup).NotTo(ContainSubstring("<Impression"))
+
+		depth, err := vastutil.WrapperDepth(ctx, bid.AdMarkup, 3, vastutil.FetcherFunc(func(_ context.Context, uri string) (string, error) {
+			return get(strings.TrimPrefix(uri, "https://cache.example.com")).Body.String(), nil
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(depth).To(Equal(1))
+	})
+
+	It("should cache other bids", func() {
+		bid := &openrtb.Bid{ID: "B", ImpID: "I", AdMarkup: "<div>ad</div>", W: 300, H: 250}
+		id, err := subject.CacheBid(ctx, bid, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bid.AdMarkup).To(HavePrefix(`<iframe src="https://cache.example.com/vast?uuid=` + id + `" width="300" height="250"`))
+
+		_, err = subject.CacheBid(ctx, &openrtb.Bid{AdMarkup: `{"native":{}}`}, nil)
+		Expect(err).To(MatchError(ErrUnsupported))
+		_, err = subject.CacheBid(ctx, &openrtb.Bid{}, nil)
+		Expect(err).To(MatchError(ErrNoMarkup))
+	})
+
+	It("should expand macros before caching", func() {
+		bid := &openrtb.Bid{ID: "B", ImpID: "I", AdMarkup: `<img src="https://x.example.com/?p=${AUCTION_PRICE}">`, W: 1, H: 1}
+		id, err := subject.CacheBid(ctx, bid, &openrtb.MacroValues{Price: 1.5})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get("/vast?uuid=" + id).Body.String()).To(Equal(`<img src="https://x.example.com/?p=1.5">`))
+	})
+
+	It("should serve markup", func() {
+		id, err := subject.Put(ctx, inlineVAST)
+		Expect(err).NotTo(HaveOccurred())
+
+		w := get("/vast?uuid=" + id)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(Equal("application/xml; charset=utf-8"))
+		Expect(w.Header().Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		Expect(w.Body.String()).To(Equal(inlineVAST))
+
+		Expect(get("/vast?uuid=unknown").Code).To(Equal(http.StatusNotFound))
+		Expect(get("/vast").Code).To(Equal(http.StatusBadRequest))
+
+		w = httptest.NewRecorder()
+		subject.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/vast?uuid="+id, nil))
+		Expect(w.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/markupcache")
+}
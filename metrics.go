@@ -0,0 +1,99 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+)
+
+// Metric names
+const (
+	// MetricDecodeBytes is a histogram of decoded payload sizes in bytes,
+	// labelled by type.
+	MetricDecodeBytes = "openrtb_decode_bytes"
+	// MetricDecodeErrors counts failed decodes, labelled by type and code,
+	// see DecodeErrorCode.
+	MetricDecodeErrors = "openrtb_decode_errors_total"
+	// MetricRequestImps is a histogram of the number of impressions per
+	// decoded request.
+	MetricRequestImps = "openrtb_request_imps"
+	// MetricResponseLatency is a histogram of bidder response latencies in
+	// seconds, labelled by outcome.
+	MetricResponseLatency = "openrtb_response_latency_seconds"
+)
+
+// Metric labels
+const (
+	MetricLabelType    = "type"
+	MetricLabelCode    = "code"
+	MetricLabelOutcome = "outcome"
+)
+
+// Metric label values of MetricLabelType
+const (
+	MetricTypeRequest  = "request"
+	MetricTypeResponse = "response"
+)
+
+// Metric label values of MetricLabelOutcome
+const (
+	MetricOutcomeBid     = "bid"
+	MetricOutcomeNoBid   = "nobid"
+	MetricOutcomeError   = "error"
+	MetricOutcomeTimeout = "timeout"
+)
+
+// Metrics receives instrumentation data, e.g. to export it to Prometheus.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// Count increments the counter name by delta.
+	Count(name string, labels map[string]string, delta float64)
+	// Observe records value in the histogram name.
+	Observe(name string, labels map[string]string, value float64)
+}
+
+// Decode error codes, in addition to the validation codes
+const (
+	DecodeCodeTooLarge        = "payload_too_large"
+	DecodeCodeTooManyImps     = "too_many_imps"
+	DecodeCodeTooManyBids     = "too_many_bids"
+	DecodeCodeTooManySeatBids = "too_many_seatbids"
+	DecodeCodeExtTooLarge     = "ext_too_large"
+	DecodeCodeTooDeep         = "too_deep"
+	DecodeCodeTrailing        = "trailing_data"
+	DecodeCodeCanceled        = "canceled"
+	DecodeCodeMalformed       = "malformed"
+)
+
+var decodeCodes = map[error]string{
+	ErrDecodeTooLarge:        DecodeCodeTooLarge,
+	ErrDecodeTooManyImps:     DecodeCodeTooManyImps,
+	ErrDecodeTooManyBids:     DecodeCodeTooManyBids,
+	ErrDecodeTooManySeatBids: DecodeCodeTooManySeatBids,
+	ErrDecodeExtTooLarge:     DecodeCodeExtTooLarge,
+	ErrDecodeTooDeep:         DecodeCodeTooDeep,
+	ErrDecodeTrailing:        DecodeCodeTrailing,
+}
+
+// DecodeErrorCode returns a machine-readable code of an error returned by
+// the decoders, suitable as a metric label. Validation errors return their
+// validation code, see ValidationCode, other errors which are not decode
+// errors return DecodeCodeMalformed.
+func DecodeErrorCode(err error) string {
+	if code := ValidationCode(err); code != ValidationCodeUnknown {
+		return code
+	}
+	if code, ok := decodeCodes[err]; ok {
+		return code
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return DecodeCodeCanceled
+	}
+	return DecodeCodeMalformed
+}
+
+func observeDecode(m Metrics, typ string, size int64, err error) {
+	m.Observe(MetricDecodeBytes, map[string]string{MetricLabelType: typ}, float64(size))
+	if err != nil {
+		m.Count(MetricDecodeErrors, map[string]string{MetricLabelType: typ, MetricLabelCode: DecodeErrorCode(err)}, 1)
+	}
+}
@@ -0,0 +1,87 @@
+package openrtb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics", func() {
+	var subject *mockMetrics
+	var ctx = context.Background()
+
+	BeforeEach(func() {
+		subject = new(mockMetrics)
+	})
+
+	It("should record decoded requests", func() {
+		_, err := UnmarshalBidRequestContext(ctx, []byte(`{"id":"R","imp":[{"id":"1","banner":{}},{"id":"2","banner":{}}]}`), &DecodeOptions{Metrics: subject})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subject.events).To(ConsistOf(
+			"observe openrtb_decode_bytes type=request 64",
+			"observe openrtb_request_imps 2",
+		))
+	})
+
+	It("should record decode failures", func() {
+		_, err := UnmarshalBidRequestContext(ctx, []byte(`{"imp":[]}`), &DecodeOptions{Metrics: subject})
+		Expect(err).To(HaveOccurred())
+		_, err = UnmarshalBidResponseContext(ctx, []byte(`{"id":`), &DecodeOptions{Metrics: subject})
+		Expect(err).To(HaveOccurred())
+		Expect(subject.events).To(Equal([]string{
+			"observe openrtb_decode_bytes type=request 10",
+			"count openrtb_decode_errors_total code=request_missing_id,type=request 1",
+			"observe openrtb_decode_bytes type=response 6",
+			"count openrtb_decode_errors_total code=malformed,type=response 1",
+		}))
+	})
+
+})
+
+var _ = Describe("DecodeErrorCode", func() {
+
+	It("should return codes", func() {
+		Expect(DecodeErrorCode(ErrDecodeTooLarge)).To(Equal("payload_too_large"))
+		Expect(DecodeErrorCode(ErrInvalidReqNoID)).To(Equal("request_missing_id"))
+		Expect(DecodeErrorCode(&ValidationError{Code: "imp_missing_id", Err: ErrInvalidImpNoID})).To(Equal("imp_missing_id"))
+		Expect(DecodeErrorCode(context.Canceled)).To(Equal("canceled"))
+		Expect(DecodeErrorCode(fmt.Errorf("bad"))).To(Equal("malformed"))
+	})
+
+})
+
+type mockMetrics struct {
+	events []string
+	mu     sync.Mutex
+}
+
+func (m *mockMetrics) Count(name string, labels map[string]string, delta float64) {
+	m.record("count", name, labels, delta)
+}
+
+func (m *mockMetrics) Observe(name string, labels map[string]string, value float64) {
+	m.record("observe", name, labels, value)
+}
+
+func (m *mockMetrics) record(kind, name string, labels map[string]string, value float64) {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	parts := []string{kind, name}
+	if len(pairs) != 0 {
+		parts = append(parts, strings.Join(pairs, ","))
+	}
+	parts = append(parts, fmt.Sprint(value))
+
+	m.mu.Lock()
+	m.events = append(m.events, strings.Join(parts, " "))
+	m.mu.Unlock()
+}
@@ -0,0 +1,107 @@
+package openrtb
+
+import (
+	"errors"
+	"mime"
+	"strings"
+)
+
+// Common creative MIME types
+const (
+	MimeHTML       = "text/html"
+	MimeJavaScript = "application/javascript"
+	MimeJPEG       = "image/jpeg"
+	MimePNG        = "image/png"
+	MimeGIF        = "image/gif"
+	MimeWebP       = "image/webp"
+	MimeSVG        = "image/svg+xml"
+	MimeMP4        = "video/mp4"
+	MimeWebM       = "video/webm"
+	MimeOGG        = "video/ogg"
+	Mime3GPP       = "video/3gpp"
+	MimeQuickTime  = "video/quicktime"
+	MimeHLS        = "application/x-mpegURL"
+	MimeDASH       = "application/dash+xml"
+	MimeMP3        = "audio/mpeg"
+	MimeAAC        = "audio/aac"
+	MimeAudioMP4   = "audio/mp4"
+	MimeAudioOGG   = "audio/ogg"
+	MimeWAV        = "audio/wav"
+)
+
+// Validation errors
+var (
+	ErrInvalidBannerMime = errors.New("openrtb: banner has invalid mime type")
+	ErrInvalidVideoMime  = errors.New("openrtb: video has invalid mime type")
+	ErrInvalidAudioMime  = errors.New("openrtb: audio has invalid mime type")
+)
+
+// IsValidMime returns true if s is a syntactically valid MIME type of the
+// form type/subtype, optionally followed by parameters.
+func IsValidMime(s string) bool {
+	mt, _, err := mime.ParseMediaType(s)
+	if err != nil {
+		return false
+	}
+	i := strings.IndexByte(mt, '/')
+	return i > 0 && i < len(mt)-1
+}
+
+// MimeAllowed returns true if mime is contained in allowed. Types are
+// compared case-insensitively and without parameters. An empty allowed
+// list permits all types.
+func MimeAllowed(allowed []string, mime string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	mime = baseMime(mime)
+	for _, s := range allowed {
+		if strings.EqualFold(baseMime(s), mime) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsMime returns true if a creative of the given mime type may be
+// served on the impression, e.g. to check the media files of a bid. If
+// mtype is set, only the mimes of the matching banner, video or audio
+// object apply, otherwise mime must be allowed by any of them. Objects
+// without mimes, or impressions without a matching object, impose no
+// constraints.
+func (imp *Impression) AllowsMime(mime string, mtype int) bool {
+	var lists [][]string
+	if imp.Banner != nil && (mtype == 0 || mtype == MarkupTypeBanner) {
+		lists = append(lists, imp.Banner.Mimes)
+	}
+	if imp.Video != nil && (mtype == 0 || mtype == MarkupTypeVideo) {
+		lists = append(lists, imp.Video.Mimes)
+	}
+	if imp.Audio != nil && (mtype == 0 || mtype == MarkupTypeAudio) {
+		lists = append(lists, imp.Audio.Mimes)
+	}
+
+	for _, allowed := range lists {
+		if MimeAllowed(allowed, mime) {
+			return true
+		}
+	}
+	return len(lists) == 0
+}
+
+func validMimes(mimes []string) bool {
+	for _, s := range mimes {
+		if !IsValidMime(s) {
+			return false
+		}
+	}
+	return true
+}
+
+func baseMime(s string) string {
+	if i := strings.IndexByte(s, ';'); i > -1 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
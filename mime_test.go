@@ -0,0 +1,63 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsValidMime", func() {
+
+	It("should validate", func() {
+		Expect(IsValidMime(MimeMP4)).To(BeTrue())
+		Expect(IsValidMime(MimeHLS)).To(BeTrue())
+		Expect(IsValidMime("image/jpg")).To(BeTrue())
+		Expect(IsValidMime(`video/mp4; codecs="avc1.42E01E"`)).To(BeTrue())
+		Expect(IsValidMime("mp4")).To(BeFalse())
+		Expect(IsValidMime("video/")).To(BeFalse())
+		Expect(IsValidMime("/mp4")).To(BeFalse())
+		Expect(IsValidMime("video mp4")).To(BeFalse())
+		Expect(IsValidMime("")).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("MimeAllowed", func() {
+
+	It("should match", func() {
+		Expect(MimeAllowed(nil, MimeMP4)).To(BeTrue())
+		Expect(MimeAllowed([]string{MimeMP4, MimeWebM}, "video/webm")).To(BeTrue())
+		Expect(MimeAllowed([]string{"video/MP4"}, "video/mp4; codecs=avc1")).To(BeTrue())
+		Expect(MimeAllowed([]string{MimeMP4}, MimeWebM)).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("Impression", func() {
+
+	It("should validate mimes", func() {
+		imp := &Impression{ID: "1", Banner: &Banner{Mimes: []string{MimeJPEG, "jpeg"}}}
+		Expect(imp.Validate()).To(MatchError(ErrInvalidBannerMime))
+		Expect(ValidationCode(imp.Validate())).To(Equal("banner_invalid_mime"))
+
+		imp.Banner.Mimes = []string{MimeJPEG}
+		Expect(imp.Validate()).To(Succeed())
+	})
+
+	It("should check allowed mimes", func() {
+		imp := &Impression{
+			Banner: &Banner{Mimes: []string{MimeJPEG, MimePNG}},
+			Video:  &Video{Mimes: []string{MimeMP4}},
+		}
+		Expect(imp.AllowsMime(MimePNG, 0)).To(BeTrue())
+		Expect(imp.AllowsMime(MimeMP4, 0)).To(BeTrue())
+		Expect(imp.AllowsMime(MimeMP4, MarkupTypeBanner)).To(BeFalse())
+		Expect(imp.AllowsMime(MimeMP4, MarkupTypeVideo)).To(BeTrue())
+		Expect(imp.AllowsMime(MimeWebM, 0)).To(BeFalse())
+		Expect(imp.AllowsMime(MimeMP3, MarkupTypeAudio)).To(BeTrue())
+
+		imp.Banner.Mimes = nil
+		Expect(imp.AllowsMime(MimeGIF, MarkupTypeBanner)).To(BeTrue())
+		Expect((&Impression{Native: &Native{}}).AllowsMime(MimeGIF, 0)).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,311 @@
+/*
+Package module provides a plugin architecture for first and third-party
+modules, e.g. floors, consent, fraud detection or bid shading, which hook
+into the request and auction lifecycle.
+
+Modules handle a single stage and are registered with a Registry, usually
+the package-level default from an init function:
+
+	func init() {
+		module.Register(floorsModule{})
+	}
+
+Modules of a stage run in registration order, unless they implement Ordered
+to request to run after other modules:
+
+	err := module.Run(ctx, module.StageRequest, &module.Auction{Request: req})
+*/
+package module
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// Errors
+var (
+	ErrNoName        = errors.New("module: name missing")
+	ErrDuplicateName = errors.New("module: duplicate name")
+	ErrInvalidStage  = errors.New("module: invalid stage")
+	ErrCycle         = errors.New("module: ordering cycle")
+)
+
+// Stage is a stage of the auction lifecycle.
+type Stage int
+
+// Stages, in lifecycle order
+const (
+	StageRequest        Stage = iota + 1 // Incoming request was decoded
+	StageBidderRequest                   // Request is about to be sent to a bidder
+	StageBidderResponse                  // Response was received from a bidder
+	StageAuction                         // All bidder responses were collected
+	StageResponse                        // Response is about to be sent
+)
+
+var stageNames = map[Stage]string{
+	StageRequest:        "request",
+	StageBidderRequest:  "bidder-request",
+	StageBidderResponse: "bidder-response",
+	StageAuction:        "auction",
+	StageResponse:       "response",
+}
+
+// String returns the stage name.
+func (s Stage) String() string {
+	if name, ok := stageNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Auction is the state of an auction, passed to the modules. Fields are
+// populated as the auction progresses.
+type Auction struct {
+	Start   time.Time           // Time the request was received
+	Request *openrtb.BidRequest // Incoming request
+
+	Bidder         string               // Current bidder, for bidder stages
+	BidderRequest  *openrtb.BidRequest  // Request to the current bidder
+	BidderResponse *openrtb.BidResponse // Response of the current bidder
+
+	Responses map[string]*openrtb.BidResponse // Bidder responses, by bidder
+	Response  *openrtb.BidResponse            // Outgoing response
+
+	values map[string]interface{}
+}
+
+// Set stores a value for use by later modules.
+func (a *Auction) Set(key string, v interface{}) {
+	if a.values == nil {
+		a.values = make(map[string]interface{})
+	}
+	a.values[key] = v
+}
+
+// Get returns a value stored via Set.
+func (a *Auction) Get(key string) (interface{}, bool) {
+	v, ok := a.values[key]
+	return v, ok
+}
+
+// Module is a plugin for a single stage of the auction lifecycle.
+type Module interface {
+	// Name returns the unique module name.
+	Name() string
+	// Stage returns the stage the module handles.
+	Stage() Stage
+	// Handle handles the stage. Returning an error aborts the stage.
+	Handle(ctx context.Context, a *Auction) error
+}
+
+// Ordered may be implemented by modules which must run after other modules
+// of the same stage. Unknown names are ignored.
+type Ordered interface {
+	After() []string
+}
+
+// Error is returned when a module fails.
+type Error struct {
+	Module string
+	Stage  Stage
+	Err    error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return "module: " + e.Module + " failed at " + e.Stage.String() + " stage: " + e.Err.Error()
+}
+
+// Unwrap returns the module error.
+func (e *Error) Unwrap() error { return e.Err }
+
+// Registry holds registered modules.
+type Registry struct {
+	mu      sync.RWMutex
+	modules []Module
+	names   map[string]bool
+	plans   map[Stage][]Module
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// Register registers a module.
+func (r *Registry) Register(m Module) error {
+	name := m.Name()
+	if name == "" {
+		return ErrNoName
+	}
+	if _, ok := stageNames[m.Stage()]; !ok {
+		return ErrInvalidStage
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[name] {
+		return ErrDuplicateName
+	}
+	plans, err := plan(append(r.modules, m))
+	if err != nil {
+		return err
+	}
+
+	r.modules = append(r.modules, m)
+	r.names[name] = true
+	r.plans = plans
+	return nil
+}
+
+// Modules returns the modules of a stage, in execution order.
+func (r *Registry) Modules(stage Stage) []Module {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Module(nil), r.plans[stage]...)
+}
+
+// Run runs all modules of a stage in order. It stops at the first failing
+// module and returns its error as an *Error.
+func (r *Registry) Run(ctx context.Context, stage Stage, a *Auction) error {
+	r.mu.RLock()
+	modules := r.plans[stage]
+	r.mu.RUnlock()
+
+	for _, m := range modules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := m.Handle(ctx, a); err != nil {
+			return &Error{Module: m.Name(), Stage: stage, Err: err}
+		}
+	}
+	return nil
+}
+
+// plan sorts modules by stage, respecting Ordered constraints and otherwise
+// preserving the registration order.
+func plan(modules []Module) (map[Stage][]Module, error) {
+	byStage := make(map[Stage][]Module)
+	for _, m := range modules {
+		byStage[m.Stage()] = append(byStage[m.Stage()], m)
+	}
+
+	plans := make(map[Stage][]Module, len(byStage))
+	for stage, list := range byStage {
+		index := make(map[string]int, len(list))
+		for i, m := range list {
+			index[m.Name()] = i
+		}
+
+		const (
+			unvisited = iota
+			visiting
+			visited
+		)
+		state := make([]int, len(list))
+		sorted := make([]Module, 0, len(list))
+
+		var visit func(int) error
+		visit = func(i int) error {
+			switch state[i] {
+			case visiting:
+				return ErrCycle
+			case visited:
+				return nil
+			}
+			state[i] = visiting
+			if o, ok := list[i].(Ordered); ok {
+				for _, name := range o.After() {
+					if j, ok := index[name]; ok {
+						if err := visit(j); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			state[i] = visited
+			sorted = append(sorted, list[i])
+			return nil
+		}
+		for i := range list {
+			if err := visit(i); err != nil {
+				return nil, err
+			}
+		}
+		plans[stage] = sorted
+	}
+	return plans, nil
+}
+
+// DefaultRegistry is the registry used by the package-level functions.
+var DefaultRegistry = NewRegistry()
+
+// Register registers a module with the DefaultRegistry.
+func Register(m Module) error { return DefaultRegistry.Register(m) }
+
+// Run runs the modules of a stage registered with the DefaultRegistry.
+func Run(ctx context.Context, stage Stage, a *Auction) error {
+	return DefaultRegistry.Run(ctx, stage, a)
+}
+
+// Func creates a module from a function.
+func Func(name string, stage Stage, fn func(context.Context, *Auction) error) Module {
+	return moduleFunc{name: name, stage: stage, fn: fn}
+}
+
+type moduleFunc struct {
+	name  string
+	stage Stage
+	fn    func(context.Context, *Auction) error
+}
+
+func (m moduleFunc) Name() string                                 { return m.name }
+func (m moduleFunc) Stage() Stage                                 { return m.stage }
+func (m moduleFunc) Handle(ctx context.Context, a *Auction) error { return m.fn(ctx, a) }
+
+// RequestHook adapts a request hook to a module. At StageRequest the hook
+// is applied to the incoming request, at StageBidderRequest to the request
+// to the current bidder. Registering it for any other stage fails with
+// ErrInvalidStage.
+func RequestHook(name string, stage Stage, hook openrtb.RequestHook) Module {
+	if stage != StageRequest && stage != StageBidderRequest {
+		stage = 0
+	}
+	return Func(name, stage, func(ctx context.Context, a *Auction) error {
+		req := a.Request
+		if stage == StageBidderRequest {
+			req = a.BidderRequest
+		}
+		if req == nil {
+			return nil
+		}
+		return hook(ctx, req)
+	})
+}
+
+// ResponseHook adapts a response hook to a module. At StageBidderResponse
+// the hook is applied to the response of the current bidder, at
+// StageResponse to the outgoing response. Registering it for any other
+// stage fails with ErrInvalidStage.
+func ResponseHook(name string, stage Stage, hook openrtb.ResponseHook) Module {
+	if stage != StageBidderResponse && stage != StageResponse {
+		stage = 0
+	}
+	return Func(name, stage, func(ctx context.Context, a *Auction) error {
+		res := a.Response
+		if stage == StageBidderResponse {
+			res = a.BidderResponse
+		}
+		if res == nil {
+			return nil
+		}
+		return hook(ctx, res)
+	})
+}
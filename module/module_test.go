@@ -0,0 +1,179 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type orderedModule struct {
+	Module
+	after []string
+}
+
+func (m orderedModule) After() []string { return m.after }
+
+var _ = Describe("Registry", func() {
+	var subject *Registry
+	var calls []string
+
+	track := func(name string, stage Stage, err error) Module {
+		return Func(name, stage, func(_ context.Context, a *Auction) error {
+			calls = append(calls, name)
+			return err
+		})
+	}
+	after := func(m Module, names ...string) Module {
+		return orderedModule{Module: m, after: names}
+	}
+	names := func(modules []Module) []string {
+		var res []string
+		for _, m := range modules {
+			res = append(res, m.Name())
+		}
+		return res
+	}
+
+	BeforeEach(func() {
+		calls = nil
+		subject = NewRegistry()
+	})
+
+	It("should register modules", func() {
+		Expect(subject.Register(track("floors", StageRequest, nil))).To(Succeed())
+		Expect(subject.Register(track("shading", StageAuction, nil))).To(Succeed())
+
+		Expect(subject.Register(track("floors", StageResponse, nil))).To(Equal(ErrDuplicateName))
+		Expect(subject.Register(track("", StageRequest, nil))).To(Equal(ErrNoName))
+		Expect(subject.Register(track("bad", Stage(0), nil))).To(Equal(ErrInvalidStage))
+
+		Expect(names(subject.Modules(StageRequest))).To(Equal([]string{"floors"}))
+		Expect(names(subject.Modules(StageAuction))).To(Equal([]string{"shading"}))
+		Expect(subject.Modules(StageResponse)).To(BeEmpty())
+	})
+
+	It("should order modules", func() {
+		Expect(subject.Register(after(track("fraud", StageRequest, nil), "consent", "unknown"))).To(Succeed())
+		Expect(subject.Register(track("floors", StageRequest, nil))).To(Succeed())
+		Expect(subject.Register(track("consent", StageRequest, nil))).To(Succeed())
+		Expect(subject.Register(after(track("geo", StageRequest, nil), "fraud"))).To(Succeed())
+
+		Expect(names(subject.Modules(StageRequest))).To(Equal([]string{"consent", "fraud", "floors", "geo"}))
+	})
+
+	It("should reject cycles", func() {
+		Expect(subject.Register(after(track("a", StageRequest, nil), "b"))).To(Succeed())
+		Expect(subject.Register(after(track("b", StageRequest, nil), "a"))).To(Equal(ErrCycle))
+		Expect(names(subject.Modules(StageRequest))).To(Equal([]string{"a"}))
+
+		Expect(subject.Register(after(track("b", StageAuction, nil), "a"))).To(Succeed())
+	})
+
+	It("should run stages", func() {
+		Expect(subject.Register(track("consent", StageRequest, nil))).To(Succeed())
+		Expect(subject.Register(track("floors", StageRequest, nil))).To(Succeed())
+		Expect(subject.Register(track("shading", StageAuction, nil))).To(Succeed())
+
+		Expect(subject.Run(context.Background(), StageRequest, &Auction{})).To(Succeed())
+		Expect(calls).To(Equal([]string{"consent", "floors"}))
+	})
+
+	It("should abort on errors", func() {
+		failure := errors.New("blocked")
+		Expect(subject.Register(track("fraud", StageRequest, failure))).To(Succeed())
+		Expect(subject.Register(track("floors", StageRequest, nil))).To(Succeed())
+
+		err := subject.Run(context.Background(), StageRequest, &Auction{})
+		Expect(err).To(MatchError("module: fraud failed at request stage: blocked"))
+		Expect(errors.Is(err, failure)).To(BeTrue())
+		Expect(calls).To(Equal([]string{"fraud"}))
+	})
+
+	It("should stop when cancelled", func() {
+		Expect(subject.Register(track("floors", StageRequest, nil))).To(Succeed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(subject.Run(ctx, StageRequest, &Auction{})).To(Equal(context.Canceled))
+		Expect(calls).To(BeEmpty())
+	})
+
+	It("should share state between modules", func() {
+		Expect(subject.Register(Func("floors", StageRequest, func(_ context.Context, a *Auction) error {
+			a.Request.Imp[0].BidFloor = 1.5
+			a.Set("floors.applied", true)
+			return nil
+		}))).To(Succeed())
+		Expect(subject.Register(Func("report", StageRequest, func(_ context.Context, a *Auction) error {
+			v, ok := a.Get("floors.applied")
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal(true))
+			return nil
+		}))).To(Succeed())
+
+		a := &Auction{Request: &openrtb.BidRequest{ID: "1", Imp: []openrtb.Impression{{ID: "1"}}}}
+		Expect(subject.Run(context.Background(), StageRequest, a)).To(Succeed())
+		Expect(a.Request.Imp[0].BidFloor).To(Equal(1.5))
+
+		_, ok := (&Auction{}).Get("missing")
+		Expect(ok).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("RequestHook", func() {
+
+	It("should adapt hooks", func() {
+		hook := func(_ context.Context, req *openrtb.BidRequest) error {
+			req.Test = 1
+			return nil
+		}
+
+		subject := NewRegistry()
+		Expect(subject.Register(RequestHook("a", StageRequest, hook))).To(Succeed())
+		Expect(subject.Register(RequestHook("b", StageBidderRequest, hook))).To(Succeed())
+		Expect(subject.Register(RequestHook("c", StageAuction, hook))).To(Equal(ErrInvalidStage))
+		Expect(subject.Register(ResponseHook("d", StageRequest, nil))).To(Equal(ErrInvalidStage))
+
+		a := &Auction{Request: &openrtb.BidRequest{ID: "1"}, BidderRequest: &openrtb.BidRequest{ID: "1"}}
+		Expect(subject.Run(context.Background(), StageRequest, a)).To(Succeed())
+		Expect(a.Request.Test).To(Equal(1))
+		Expect(a.BidderRequest.Test).To(Equal(0))
+		Expect(subject.Run(context.Background(), StageBidderRequest, a)).To(Succeed())
+		Expect(a.BidderRequest.Test).To(Equal(1))
+	})
+
+})
+
+var _ = Describe("ResponseHook", func() {
+
+	It("should adapt hooks", func() {
+		subject := NewRegistry()
+		Expect(subject.Register(ResponseHook("a", StageBidderResponse, func(context.Context, *openrtb.BidResponse) error {
+			return errors.New("boom")
+		}))).To(Succeed())
+
+		err := subject.Run(context.Background(), StageBidderResponse, &Auction{BidderResponse: &openrtb.BidResponse{ID: "1"}})
+		Expect(err).To(MatchError("module: a failed at bidder-response stage: boom"))
+		Expect(subject.Run(context.Background(), StageBidderResponse, &Auction{})).To(Succeed())
+	})
+
+})
+
+var _ = Describe("Stage", func() {
+
+	It("should have names", func() {
+		Expect(StageBidderResponse.String()).To(Equal("bidder-response"))
+		Expect(Stage(0).String()).To(Equal("unknown"))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/module")
+}
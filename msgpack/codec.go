@@ -0,0 +1,460 @@
+package msgpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/bsm/openrtb"
+)
+
+var (
+	extensionType = reflect.TypeOf(openrtb.Extension(nil))
+	flagType      = reflect.TypeOf(openrtb.Flag(0))
+)
+
+// maxDepth limits the nesting of decoded values.
+const maxDepth = 100
+
+type codecField struct {
+	name      string
+	index     []int
+	omitempty bool
+}
+
+type codec struct {
+	fields []codecField
+	byName map[string]*codecField
+}
+
+var codecs sync.Map // map[reflect.Type]*codec
+
+func codecFor(t reflect.Type) *codec {
+	if c, ok := codecs.Load(t); ok {
+		return c.(*codec)
+	}
+
+	c := new(codec)
+	collectFields(t, nil, &c.fields)
+	c.byName = make(map[string]*codecField, len(c.fields))
+	for i := range c.fields {
+		c.byName[c.fields[i].name] = &c.fields[i]
+	}
+
+	codecs.Store(t, c)
+	return c
+}
+
+// collectFields collects the encoded fields of t. Embedded structs are
+// flattened, exactly like encoding/json does.
+func collectFields(t reflect.Type, index []int, fields *[]codecField) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		idx := append(append([]int(nil), index...), i)
+
+		_, hasCodecTag := sf.Tag.Lookup(openrtb.CodecMsgpack)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && sf.Tag.Get(openrtb.CodecJSON) == "" && !hasCodecTag {
+			collectFields(sf.Type, idx, fields)
+			continue
+		}
+
+		name, omitempty, ok := openrtb.FieldName(sf, openrtb.CodecMsgpack)
+		if !ok {
+			continue
+		}
+		*fields = append(*fields, codecField{name: name, index: idx, omitempty: omitempty})
+	}
+}
+
+func encodeValue(b []byte, v reflect.Value) ([]byte, error) {
+	switch t := v.Type(); t {
+	case flagType:
+		switch openrtb.Flag(v.Int()) {
+		case openrtb.FlagTrue:
+			return appendInt(b, 1), nil
+		case openrtb.FlagFalse:
+			return appendInt(b, 0), nil
+		}
+		return appendNil(b), nil
+	case extensionType:
+		if v.Len() == 0 {
+			return appendNil(b), nil
+		}
+		dec := json.NewDecoder(bytes.NewReader(v.Bytes()))
+		dec.UseNumber()
+
+		var x interface{}
+		if err := dec.Decode(&x); err != nil {
+			return nil, err
+		}
+		return appendGeneric(b, x)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return appendBool(b, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendInt(b, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return appendUint(b, v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return appendFloat(b, v.Float()), nil
+	case reflect.String:
+		return appendString(b, v.String()), nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return appendNil(b), nil
+		}
+		return encodeValue(b, v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return appendNil(b), nil
+		}
+		b = appendArrayHeader(b, v.Len())
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			if b, err = encodeValue(b, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Struct:
+		return encodeStruct(b, v)
+	}
+	return nil, ErrUnsupported
+}
+
+func encodeStruct(b []byte, v reflect.Value) ([]byte, error) {
+	c := codecFor(v.Type())
+
+	n := 0
+	for _, f := range c.fields {
+		if !f.omitempty || !isEmptyValue(v.FieldByIndex(f.index)) {
+			n++
+		}
+	}
+
+	b = appendMapHeader(b, n)
+	for _, f := range c.fields {
+		fv := v.FieldByIndex(f.index)
+		if f.omitempty && isEmptyValue(fv) {
+			continue
+		}
+
+		var err error
+		b = appendString(b, f.name)
+		if b, err = encodeValue(b, fv); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// isEmptyValue reports whether v is omitted by omitempty, as per
+// encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// appendGeneric encodes a decoded JSON value.
+func appendGeneric(b []byte, x interface{}) ([]byte, error) {
+	switch x := x.(type) {
+	case nil:
+		return appendNil(b), nil
+	case bool:
+		return appendBool(b, x), nil
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return appendInt(b, n), nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendFloat(b, f), nil
+	case string:
+		return appendString(b, x), nil
+	case []interface{}:
+		b = appendArrayHeader(b, len(x))
+		var err error
+		for _, elem := range x {
+			if b, err = appendGeneric(b, elem); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for key := range x {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b = appendMapHeader(b, len(x))
+		var err error
+		for _, key := range keys {
+			b = appendString(b, key)
+			if b, err = appendGeneric(b, x[key]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, ErrUnsupported
+}
+
+func decodeValue(b []byte, v reflect.Value, depth int) (int, error) {
+	if depth == 0 {
+		return -1, ErrMalformed
+	}
+
+	tok, n := consume(b)
+	if n < 0 {
+		return n, ErrMalformed
+	}
+	if tok.kind == kindNil {
+		v.Set(reflect.Zero(v.Type()))
+		return n, nil
+	}
+
+	switch t := v.Type(); t {
+	case flagType:
+		switch {
+		case tok.kind == kindBool:
+			v.SetInt(int64(openrtb.NewFlag(tok.b)))
+		case tok.kind == kindUint && tok.u <= 1:
+			v.SetInt(int64(openrtb.NewFlag(tok.u == 1)))
+		default:
+			return -1, errors.New("invalid flag value")
+		}
+		return n, nil
+	case extensionType:
+		x, n, err := decodeGeneric(b, depth)
+		if err != nil {
+			return n, err
+		}
+		data, err := json.Marshal(x)
+		if err != nil {
+			return -1, err
+		}
+		v.SetBytes(data)
+		return n, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if tok.kind != kindBool {
+			return -1, errKind(tok.kind)
+		}
+		v.SetBool(tok.b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := tok.int64()
+		if !ok || v.OverflowInt(i) {
+			return -1, errKind(tok.kind)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if tok.kind != kindUint || v.OverflowUint(tok.u) {
+			return -1, errKind(tok.kind)
+		}
+		v.SetUint(tok.u)
+	case reflect.Float32, reflect.Float64:
+		switch tok.kind {
+		case kindFloat:
+			v.SetFloat(tok.f)
+		case kindInt:
+			v.SetFloat(float64(tok.i))
+		case kindUint:
+			v.SetFloat(float64(tok.u))
+		default:
+			return -1, errKind(tok.kind)
+		}
+	case reflect.String:
+		if tok.kind != kindString && tok.kind != kindBinary {
+			return -1, errKind(tok.kind)
+		}
+		v.SetString(string(tok.s))
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(b, v.Elem(), depth)
+	case reflect.Slice:
+		if tok.kind != kindArray {
+			return -1, errKind(tok.kind)
+		}
+		if tok.n > len(b)-n {
+			return -1, ErrMalformed
+		}
+
+		s := reflect.MakeSlice(v.Type(), tok.n, tok.n)
+		for i := 0; i < tok.n; i++ {
+			m, err := decodeValue(b[n:], s.Index(i), depth-1)
+			if err != nil {
+				return m, err
+			}
+			n += m
+		}
+		v.Set(s)
+	case reflect.Struct:
+		if tok.kind != kindMap {
+			return -1, errKind(tok.kind)
+		}
+		return decodeStruct(b, n, tok.n, v, depth)
+	default:
+		return -1, ErrUnsupported
+	}
+	return n, nil
+}
+
+func decodeStruct(b []byte, n, size int, v reflect.Value, depth int) (int, error) {
+	c := codecFor(v.Type())
+	for i := 0; i < size; i++ {
+		key, m := consume(b[n:])
+		if m < 0 || key.kind != kindString {
+			return -1, ErrMalformed
+		}
+		n += m
+
+		f, ok := c.byName[string(key.s)]
+		if !ok {
+			if m = skipValue(b[n:], depth-1); m < 0 {
+				return m, ErrMalformed
+			}
+			n += m
+			continue
+		}
+
+		m, err := decodeValue(b[n:], v.FieldByIndex(f.index), depth-1)
+		if err != nil {
+			if errors.Is(err, ErrMalformed) {
+				return m, err
+			}
+			return m, errors.New("msgpack: " + v.Type().Name() + "." + f.name + ": " + err.Error())
+		}
+		n += m
+	}
+	return n, nil
+}
+
+// skipValue returns the encoded size of the next value, or -1 if b is
+// malformed or nested deeper than depth.
+func skipValue(b []byte, depth int) int {
+	if depth == 0 {
+		return -1
+	}
+
+	tok, n := consume(b)
+	if n < 0 {
+		return n
+	}
+
+	items := tok.n
+	if tok.kind == kindMap {
+		items *= 2
+	}
+	for i := 0; i < items; i++ {
+		m := skipValue(b[n:], depth-1)
+		if m < 0 {
+			return m
+		}
+		n += m
+	}
+	return n
+}
+
+// decodeGeneric decodes the next value into its JSON equivalent.
+func decodeGeneric(b []byte, depth int) (interface{}, int, error) {
+	if depth == 0 {
+		return nil, -1, ErrMalformed
+	}
+
+	tok, n := consume(b)
+	if n < 0 {
+		return nil, n, ErrMalformed
+	}
+
+	switch tok.kind {
+	case kindNil:
+		return nil, n, nil
+	case kindBool:
+		return tok.b, n, nil
+	case kindInt:
+		return tok.i, n, nil
+	case kindUint:
+		return tok.u, n, nil
+	case kindFloat:
+		return tok.f, n, nil
+	case kindString, kindBinary:
+		return string(tok.s), n, nil
+	case kindArray:
+		if tok.n > len(b)-n {
+			return nil, -1, ErrMalformed
+		}
+		res := make([]interface{}, tok.n)
+		for i := range res {
+			x, m, err := decodeGeneric(b[n:], depth-1)
+			if err != nil {
+				return nil, m, err
+			}
+			res[i] = x
+			n += m
+		}
+		return res, n, nil
+	case kindMap:
+		if tok.n > len(b)-n {
+			return nil, -1, ErrMalformed
+		}
+		res := make(map[string]interface{}, tok.n)
+		for i := 0; i < tok.n; i++ {
+			key, m := consume(b[n:])
+			if m < 0 || key.kind != kindString {
+				return nil, -1, ErrMalformed
+			}
+			n += m
+
+			x, m, err := decodeGeneric(b[n:], depth-1)
+			if err != nil {
+				return nil, m, err
+			}
+			res[string(key.s)] = x
+			n += m
+		}
+		return res, n, nil
+	}
+	return nil, -1, ErrMalformed
+}
+
+func (t token) int64() (int64, bool) {
+	switch t.kind {
+	case kindInt:
+		return t.i, true
+	case kindUint:
+		if t.u <= 1<<63-1 {
+			return int64(t.u), true
+		}
+	}
+	return 0, false
+}
+
+func errKind(k kind) error {
+	names := [...]string{"invalid", "nil", "bool", "int", "uint", "float", "string", "binary", "array", "map"}
+	return errors.New("unexpected " + names[k])
+}
@@ -0,0 +1,81 @@
+// Package msgpack converts bid requests, bid responses and other core
+// objects to and from the MessagePack format, which is considerably more
+// compact and cheaper to decode than JSON, e.g. for archival.
+//
+// Objects are encoded as maps, keyed by their msgpack field names, which are
+// derived from the json tags, see openrtb.FieldName. Fields are omitted under
+// the same conditions as in JSON. Extension payloads are converted to native
+// MessagePack values. Unknown keys are skipped when decoding. Messages nested
+// more than 100 levels deep are rejected as malformed.
+//
+// Unlike the JSON codec, values are encoded as-is, without normalization.
+package msgpack
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/bsm/openrtb"
+)
+
+// Errors
+var (
+	ErrMalformed   = errors.New("msgpack: malformed message")
+	ErrUnsupported = errors.New("msgpack: unsupported type")
+)
+
+// MarshalBidRequest encodes a bid request.
+func MarshalBidRequest(req *openrtb.BidRequest) ([]byte, error) {
+	return Marshal(req)
+}
+
+// UnmarshalBidRequest decodes a bid request.
+func UnmarshalBidRequest(data []byte) (*openrtb.BidRequest, error) {
+	req := new(openrtb.BidRequest)
+	if err := Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// MarshalBidResponse encodes a bid response.
+func MarshalBidResponse(res *openrtb.BidResponse) ([]byte, error) {
+	return Marshal(res)
+}
+
+// UnmarshalBidResponse decodes a bid response.
+func UnmarshalBidResponse(data []byte) (*openrtb.BidResponse, error) {
+	res := new(openrtb.BidResponse)
+	if err := Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Marshal encodes v, which must be a pointer to a struct of the openrtb
+// object model, e.g. a *openrtb.Impression.
+func Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, ErrUnsupported
+	}
+	return encodeValue(nil, rv.Elem())
+}
+
+// Unmarshal decodes data into v, which must be a pointer to a struct of the
+// openrtb object model.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrUnsupported
+	}
+
+	n, err := decodeValue(data, rv.Elem(), maxDepth)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return ErrMalformed
+	}
+	return nil
+}
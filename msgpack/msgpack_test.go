@@ -0,0 +1,147 @@
+package msgpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BidRequest", func() {
+
+	It("should round-trip", func() {
+		for _, name := range []string{"breq.banner", "breq.video", "breq.native", "breq.exp"} {
+			var req openrtb.BidRequest
+			Expect(fixture(name, &req)).To(Succeed())
+
+			data, err := MarshalBidRequest(&req)
+			Expect(err).NotTo(HaveOccurred(), name)
+
+			dec, err := UnmarshalBidRequest(data)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(json.Marshal(dec)).To(MatchJSON(mustJSON(&req)), name)
+
+			again, err := MarshalBidRequest(dec)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(again).To(Equal(data), name)
+			Expect(len(data)).To(BeNumerically("<", len(mustJSON(&req))), name)
+		}
+	})
+
+	It("should encode fields", func() {
+		req := &openrtb.BidRequest{
+			ID:  "req",
+			Imp: []openrtb.Impression{{ID: "1", Banner: &openrtb.Banner{W: 300, H: 250}, BidFloor: 0.5, Ext: openrtb.Extension(`{"x":[1,-2.5,"y",null,true]}`)}},
+			Site: &openrtb.Site{Inventory: openrtb.Inventory{
+				ID: "site", PrivacyPolicy: new(int), Publisher: &openrtb.Publisher{ID: "pub"},
+			}, Page: "http://example.com"},
+			Device: &openrtb.Device{UA: "Mozilla", DNT: openrtb.FlagFalse, LMT: openrtb.FlagTrue},
+			Cur:    []string{"USD", "EUR"},
+			TMax:   -1,
+		}
+		data, err := MarshalBidRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		dec, err := UnmarshalBidRequest(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dec).To(Equal(req))
+	})
+
+	It("should follow omitempty", func() {
+		data, err := MarshalBidRequest(&openrtb.BidRequest{ID: "R"})
+		Expect(err).NotTo(HaveOccurred())
+		// {"id":"R","at":0}
+		Expect(data).To(Equal([]byte{0x82, 0xa2, 'i', 'd', 0xa1, 'R', 0xa2, 'a', 't', 0x00}))
+	})
+
+})
+
+var _ = Describe("BidResponse", func() {
+
+	It("should round-trip", func() {
+		for _, name := range []string{"bres.single", "bres.multi", "bres.pmp", "bres.vast"} {
+			var res openrtb.BidResponse
+			Expect(fixture(name, &res)).To(Succeed())
+
+			data, err := MarshalBidResponse(&res)
+			Expect(err).NotTo(HaveOccurred(), name)
+
+			dec, err := UnmarshalBidResponse(data)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(json.Marshal(dec)).To(MatchJSON(mustJSON(&res)), name)
+		}
+	})
+
+})
+
+var _ = Describe("Unmarshal", func() {
+
+	It("should skip unknown keys", func() {
+		// {"id":"R","x":{"y":[1,2]},"at":2}
+		data := []byte{0x83, 0xa2, 'i', 'd', 0xa1, 'R', 0xa1, 'x', 0x81, 0xa1, 'y', 0x92, 0x01, 0x02, 0xa2, 'a', 't', 0x02}
+		req, err := UnmarshalBidRequest(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req).To(Equal(&openrtb.BidRequest{ID: "R", AuctionType: 2}))
+	})
+
+	It("should reject deeply nested input", func() {
+		nested := func(key string, depth int) []byte {
+			data := append([]byte{0x81, byte(0xa0 + len(key))}, key...)
+			data = append(data, bytes.Repeat([]byte{0x91}, depth)...)
+			return append(data, 0x01)
+		}
+
+		_, err := UnmarshalBidRequest(nested("x", 100000))
+		Expect(err).To(MatchError(ErrMalformed))
+		_, err = UnmarshalBidRequest(nested("ext", 100000))
+		Expect(err).To(MatchError(ErrMalformed))
+
+		req, err := UnmarshalBidRequest(nested("ext", 50))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Ext).To(HaveLen(50*2 + 1))
+	})
+
+	It("should reject invalid input", func() {
+		_, err := UnmarshalBidRequest([]byte{0x81, 0xa2, 'i', 'd'})
+		Expect(err).To(MatchError(ErrMalformed))
+
+		_, err = UnmarshalBidRequest([]byte{0x81, 0xa2, 'i', 'd', 0xa1, 'R', 0x00})
+		Expect(err).To(MatchError(ErrMalformed))
+
+		_, err = UnmarshalBidRequest([]byte{0x81, 0xa3, 'i', 'm', 'p', 0xdd, 0xff, 0xff, 0xff, 0xff})
+		Expect(err).To(MatchError(ErrMalformed))
+
+		_, err = UnmarshalBidRequest([]byte{0x81, 0xa2, 'i', 'd', 0x01})
+		Expect(err).To(MatchError("msgpack: BidRequest.id: unexpected uint"))
+
+		Expect(Unmarshal(nil, openrtb.BidRequest{})).To(MatchError(ErrUnsupported))
+		_, err = Marshal("string")
+		Expect(err).To(MatchError(ErrUnsupported))
+	})
+
+})
+
+func mustJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	Expect(err).NotTo(HaveOccurred())
+	return data
+}
+
+func fixture(fname string, v interface{}) error {
+	f, err := os.Open(filepath.Join("..", "testdata", fname+".json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/msgpack")
+}
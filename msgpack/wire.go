@@ -0,0 +1,267 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Format prefixes
+const (
+	fmtNil     = 0xc0
+	fmtFalse   = 0xc2
+	fmtTrue    = 0xc3
+	fmtBin8    = 0xc4
+	fmtBin16   = 0xc5
+	fmtBin32   = 0xc6
+	fmtFloat32 = 0xca
+	fmtFloat64 = 0xcb
+	fmtUint8   = 0xcc
+	fmtUint16  = 0xcd
+	fmtUint32  = 0xce
+	fmtUint64  = 0xcf
+	fmtInt8    = 0xd0
+	fmtInt16   = 0xd1
+	fmtInt32   = 0xd2
+	fmtInt64   = 0xd3
+	fmtStr8    = 0xd9
+	fmtStr16   = 0xda
+	fmtStr32   = 0xdb
+	fmtArray16 = 0xdc
+	fmtArray32 = 0xdd
+	fmtMap16   = 0xde
+	fmtMap32   = 0xdf
+)
+
+func appendNil(b []byte) []byte { return append(b, fmtNil) }
+
+func appendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, fmtTrue)
+	}
+	return append(b, fmtFalse)
+}
+
+func appendInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return appendUint(b, uint64(n))
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, fmtInt8, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, fmtInt16), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, fmtInt32), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, fmtInt64), uint64(n))
+}
+
+func appendUint(b []byte, n uint64) []byte {
+	switch {
+	case n <= 0x7f:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, fmtUint8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, fmtUint16), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, fmtUint32), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, fmtUint64), n)
+}
+
+func appendFloat(b []byte, f float64) []byte {
+	return binary.BigEndian.AppendUint64(append(b, fmtFloat64), math.Float64bits(f))
+}
+
+func appendString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= 31:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, fmtStr8, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, fmtStr16), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, fmtStr32), uint32(n))
+	}
+	return append(b, s...)
+}
+
+func appendArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, fmtArray16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, fmtArray32), uint32(n))
+}
+
+func appendMapHeader(b []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, fmtMap16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, fmtMap32), uint32(n))
+}
+
+// kind is the type of an encoded value.
+type kind int
+
+const (
+	kindInvalid kind = iota
+	kindNil
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindString
+	kindBinary
+	kindArray
+	kindMap
+)
+
+// token is a decoded value header. Scalars are fully decoded, strings and
+// binaries reference their payload, arrays and maps carry their length.
+type token struct {
+	kind kind
+	b    bool
+	i    int64
+	u    uint64
+	f    float64
+	s    []byte
+	n    int
+}
+
+// consume decodes the next value header from b and returns the number of
+// bytes read, or -1 if b is malformed.
+func consume(b []byte) (token, int) {
+	if len(b) == 0 {
+		return token{}, -1
+	}
+
+	c := b[0]
+	switch {
+	case c <= 0x7f:
+		return token{kind: kindUint, u: uint64(c)}, 1
+	case c >= 0xe0:
+		return token{kind: kindInt, i: int64(int8(c))}, 1
+	case c&0xf0 == 0x80:
+		return token{kind: kindMap, n: int(c & 0x0f)}, 1
+	case c&0xf0 == 0x90:
+		return token{kind: kindArray, n: int(c & 0x0f)}, 1
+	case c&0xe0 == 0xa0:
+		return consumePayload(b, kindString, 1, int(c&0x1f))
+	}
+
+	switch c {
+	case fmtNil:
+		return token{kind: kindNil}, 1
+	case fmtFalse, fmtTrue:
+		return token{kind: kindBool, b: c == fmtTrue}, 1
+	case fmtBin8, fmtStr8:
+		if len(b) < 2 {
+			return token{}, -1
+		}
+		return consumePayload(b, payloadKind(c), 2, int(b[1]))
+	case fmtBin16, fmtStr16:
+		if len(b) < 3 {
+			return token{}, -1
+		}
+		return consumePayload(b, payloadKind(c), 3, int(binary.BigEndian.Uint16(b[1:])))
+	case fmtBin32, fmtStr32:
+		if len(b) < 5 {
+			return token{}, -1
+		}
+		return consumePayload(b, payloadKind(c), 5, int(binary.BigEndian.Uint32(b[1:])))
+	case fmtArray16, fmtMap16:
+		if len(b) < 3 {
+			return token{}, -1
+		}
+		return token{kind: containerKind(c), n: int(binary.BigEndian.Uint16(b[1:]))}, 3
+	case fmtArray32, fmtMap32:
+		if len(b) < 5 {
+			return token{}, -1
+		}
+		return token{kind: containerKind(c), n: int(binary.BigEndian.Uint32(b[1:]))}, 5
+	}
+
+	switch c {
+	case fmtFloat32:
+		if len(b) < 5 {
+			return token{}, -1
+		}
+		return token{kind: kindFloat, f: float64(math.Float32frombits(binary.BigEndian.Uint32(b[1:])))}, 5
+	case fmtFloat64:
+		if len(b) < 9 {
+			return token{}, -1
+		}
+		return token{kind: kindFloat, f: math.Float64frombits(binary.BigEndian.Uint64(b[1:]))}, 9
+	case fmtUint8:
+		if len(b) < 2 {
+			return token{}, -1
+		}
+		return token{kind: kindUint, u: uint64(b[1])}, 2
+	case fmtUint16:
+		if len(b) < 3 {
+			return token{}, -1
+		}
+		return token{kind: kindUint, u: uint64(binary.BigEndian.Uint16(b[1:]))}, 3
+	case fmtUint32:
+		if len(b) < 5 {
+			return token{}, -1
+		}
+		return token{kind: kindUint, u: uint64(binary.BigEndian.Uint32(b[1:]))}, 5
+	case fmtUint64:
+		if len(b) < 9 {
+			return token{}, -1
+		}
+		return token{kind: kindUint, u: binary.BigEndian.Uint64(b[1:])}, 9
+	case fmtInt8:
+		if len(b) < 2 {
+			return token{}, -1
+		}
+		return token{kind: kindInt, i: int64(int8(b[1]))}, 2
+	case fmtInt16:
+		if len(b) < 3 {
+			return token{}, -1
+		}
+		return token{kind: kindInt, i: int64(int16(binary.BigEndian.Uint16(b[1:])))}, 3
+	case fmtInt32:
+		if len(b) < 5 {
+			return token{}, -1
+		}
+		return token{kind: kindInt, i: int64(int32(binary.BigEndian.Uint32(b[1:])))}, 5
+	case fmtInt64:
+		if len(b) < 9 {
+			return token{}, -1
+		}
+		return token{kind: kindInt, i: int64(binary.BigEndian.Uint64(b[1:]))}, 9
+	}
+	return token{}, -1
+}
+
+func consumePayload(b []byte, k kind, header, n int) (token, int) {
+	if n < 0 || len(b) < header+n {
+		return token{}, -1
+	}
+	return token{kind: k, s: b[header : header+n]}, header + n
+}
+
+func payloadKind(c byte) kind {
+	if c == fmtBin8 || c == fmtBin16 || c == fmtBin32 {
+		return kindBinary
+	}
+	return kindString
+}
+
+func containerKind(c byte) kind {
+	if c == fmtArray16 || c == fmtArray32 {
+		return kindArray
+	}
+	return kindMap
+}
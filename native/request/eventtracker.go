@@ -0,0 +1,49 @@
+package request
+
+import "github.com/bsm/openrtb"
+
+type EventTypeID int
+
+const (
+	EventTypeImpression     EventTypeID = 1 // Impression
+	EventTypeViewableMRC50  EventTypeID = 2 // Visible impression using MRC definition at 50% in view for 1 second
+	EventTypeViewableMRC100 EventTypeID = 3 // 100% in view for 1 second (ie GroupM standard)
+	EventTypeViewableVideo  EventTypeID = 4 // Visible impression for video using MRC definition at 50% in view for 2 seconds
+)
+
+type EventTrackingMethodID int
+
+const (
+	EventTrackingImage EventTrackingMethodID = 1 // Image-pixel tracking - URL provided will be inserted as a 1x1 pixel at the time of the event
+	EventTrackingJS    EventTrackingMethodID = 2 // Javascript-based tracking - URL provided will be inserted as a js tag at the time of the event
+)
+
+// The event trackers object specifies the types of events the bidder can request to be tracked in the bid
+// response, and which types of tracking are available for each event type, and is included as an array
+// in the request.
+type EventTracker struct {
+	Event   EventTypeID             `json:"event"`   // Type of event available for tracking
+	Methods []EventTrackingMethodID `json:"methods"` // Array of types of tracking available for the given event
+	Ext     openrtb.Extension       `json:"ext,omitempty"`
+}
+
+// SupportsEvent returns true if the request supports tracking event via
+// method. Requests without event trackers, i.e. prior to native 1.2, only
+// support image-pixel impression tracking.
+func (r *Request) SupportsEvent(event EventTypeID, method EventTrackingMethodID) bool {
+	if len(r.EventTrackers) == 0 {
+		return event == EventTypeImpression && method == EventTrackingImage
+	}
+
+	for _, et := range r.EventTrackers {
+		if et.Event != event {
+			continue
+		}
+		for _, m := range et.Methods {
+			if m == method {
+				return true
+			}
+		}
+	}
+	return false
+}
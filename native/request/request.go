@@ -75,5 +75,6 @@ type Request struct {
 	PlacementCount   int               `json:"plcmtcnt,omitempty"`       // The number of identical placements in this Layout
 	Sequence         int               `json:"seq,omitempty"`            // 0 for the first ad, 1 for the second ad, and so on
 	Assets           []Asset           `json:"assets"`                   // An array of Asset Objects
+	EventTrackers    []EventTracker    `json:"eventtrackers,omitempty"`  // Specifies what type of event tracking is supported
 	Ext              openrtb.Extension `json:"ext,omitempty"`
 }
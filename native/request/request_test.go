@@ -28,6 +28,22 @@ var _ = Describe("Request", func() {
 			},
 		}))
 	})
+
+	It("should check event tracking support", func() {
+		req := fixture("testdata/request1.json")
+		Expect(req.SupportsEvent(EventTypeImpression, EventTrackingImage)).To(BeTrue())
+		Expect(req.SupportsEvent(EventTypeImpression, EventTrackingJS)).To(BeFalse())
+
+		Expect(json.Unmarshal([]byte(`{"eventtrackers":[{"event":1,"methods":[1,2]},{"event":2,"methods":[2]}]}`), req)).To(Succeed())
+		Expect(req.EventTrackers).To(Equal([]EventTracker{
+			{Event: EventTypeImpression, Methods: []EventTrackingMethodID{EventTrackingImage, EventTrackingJS}},
+			{Event: EventTypeViewableMRC50, Methods: []EventTrackingMethodID{EventTrackingJS}},
+		}))
+		Expect(req.SupportsEvent(EventTypeImpression, EventTrackingJS)).To(BeTrue())
+		Expect(req.SupportsEvent(EventTypeViewableMRC50, EventTrackingJS)).To(BeTrue())
+		Expect(req.SupportsEvent(EventTypeViewableMRC50, EventTrackingImage)).To(BeFalse())
+		Expect(req.SupportsEvent(EventTypeViewableVideo, EventTrackingJS)).To(BeFalse())
+	})
 })
 
 func TestSuite(t *testing.T) {
@@ -0,0 +1,89 @@
+package response
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/native/request"
+)
+
+// The event trackers response is an array of objects and specifies the types of events the bidder wishes to
+// track and the URLs/information to track them. Bidder must only respond with methods indicated as
+// available in the request.
+type EventTracker struct {
+	Event      request.EventTypeID           `json:"event"`                // Type of event to track
+	Method     request.EventTrackingMethodID `json:"method"`               // Type of tracking requested
+	URL        string                        `json:"url,omitempty"`        // The URL of the image or js
+	CustomData map[string]string             `json:"customdata,omitempty"` // To be agreed individually with the exchange, an array of key:value objects for custom tracking
+	Ext        openrtb.Extension             `json:"ext,omitempty"`
+}
+
+// Trackers returns the URLs to track event via method. The legacy
+// imptrackers are included as image-pixel impression trackers. Duplicate
+// URLs are removed.
+func (r *Response) Trackers(event request.EventTypeID, method request.EventTrackingMethodID) []string {
+	var urls []string
+	add := func(url string) {
+		if url == "" {
+			return
+		}
+		for _, u := range urls {
+			if u == url {
+				return
+			}
+		}
+		urls = append(urls, url)
+	}
+
+	if event == request.EventTypeImpression && method == request.EventTrackingImage {
+		for _, url := range r.ImpTrackers {
+			add(url)
+		}
+	}
+	for _, et := range r.EventTrackers {
+		if et.Event == event && et.Method == method {
+			add(et.URL)
+		}
+	}
+	return urls
+}
+
+// AddImpTrackers merges image-pixel impression tracking URLs into the
+// response, e.g. to add the trackers of an exchange before rendering. URLs
+// are added as event trackers if the response uses them or is native 1.2 or
+// later, and to the legacy imptrackers otherwise. URLs which are already
+// tracked are skipped.
+func (r *Response) AddImpTrackers(urls ...string) {
+	for _, url := range urls {
+		if url == "" || containsString(r.Trackers(request.EventTypeImpression, request.EventTrackingImage), url) {
+			continue
+		}
+
+		if r.usesEventTrackers() {
+			r.EventTrackers = append(r.EventTrackers, EventTracker{Event: request.EventTypeImpression, Method: request.EventTrackingImage, URL: url})
+		} else {
+			r.ImpTrackers = append(r.ImpTrackers, url)
+		}
+	}
+}
+
+func (r *Response) usesEventTrackers() bool {
+	if len(r.EventTrackers) != 0 {
+		return true
+	}
+
+	major, minor, _ := strings.Cut(r.Ver, ".")
+	maj, _ := strconv.Atoi(major)
+	min, _ := strconv.Atoi(minor)
+	return maj > 1 || (maj == 1 && min >= 2)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
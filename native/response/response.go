@@ -4,10 +4,20 @@ import "github.com/bsm/openrtb"
 
 // The native object is the top level JSON object which identifies a native response
 type Response struct {
-	Ver         string            `json:"ver,omitempty"`         // Version of the Native Markup
-	Assets      []Asset           `json:"assets"`                // An array of Asset Objects
-	Link        Link              `json:"link"`                  // Destination Link. This is default link object for the ad
-	ImpTrackers []string          `json:"imptrackers,omitempty"` // Array of impression tracking URLs, expected to return a 1x1 image or 204 response
-	JSTracker   string            `json:"jstracker,omitempty"`   // Optional JavaScript impression tracker. This is a valid HTML, Javascript is already wrapped in <script> tags. It should be executed at impression time where it can be supported
-	Ext         openrtb.Extension `json:"ext,omitempty"`
+	Ver           string            `json:"ver,omitempty"`           // Version of the Native Markup
+	Assets        []Asset           `json:"assets"`                  // An array of Asset Objects
+	Link          Link              `json:"link"`                    // Destination Link. This is default link object for the ad
+	ImpTrackers   []string          `json:"imptrackers,omitempty"`   // Array of impression tracking URLs, expected to return a 1x1 image or 204 response
+	JSTracker     string            `json:"jstracker,omitempty"`     // Optional JavaScript impression tracker. This is a valid HTML, Javascript is already wrapped in <script> tags. It should be executed at impression time where it can be supported
+	EventTrackers []EventTracker    `json:"eventtrackers,omitempty"` // Array of tracking objects to run with the ad, in response to the declared supported methods in the request
+	Ext           openrtb.Extension `json:"ext,omitempty"`
+}
+
+// FromBid decodes the native response from the markup of bid.
+func FromBid(bid *openrtb.Bid) (*Response, error) {
+	res := new(Response)
+	if err := bid.NativeResponse(res); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
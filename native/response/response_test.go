@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/native/request"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -31,6 +33,46 @@ var _ = Describe("Response", func() {
 		}))
 	})
 
+	It("should decode from bids", func() {
+		data, err := ioutil.ReadFile("testdata/response1.json")
+		Expect(err).NotTo(HaveOccurred())
+
+		res, err := FromBid(&openrtb.Bid{ID: "1", AdMarkup: string(data)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Ver).To(Equal("1.1"))
+		Expect(res.Assets).To(HaveLen(7))
+
+		_, err = FromBid(&openrtb.Bid{ID: "1", AdMarkup: "<VAST/>"})
+		Expect(err).To(Equal(openrtb.ErrInvalidBidMarkup))
+	})
+
+	It("should parse event trackers", func() {
+		var res Response
+		Expect(json.Unmarshal([]byte(`{"ver":"1.2","link":{"url":"http://i.am.a/URL"},"assets":[],"eventtrackers":[{"event":1,"method":1,"url":"http://imp.example.com"},{"event":2,"method":2,"url":"http://view.example.com/omid.js","customdata":{"k":"v"}}]}`), &res)).To(Succeed())
+		Expect(res.EventTrackers).To(Equal([]EventTracker{
+			{Event: request.EventTypeImpression, Method: request.EventTrackingImage, URL: "http://imp.example.com"},
+			{Event: request.EventTypeViewableMRC50, Method: request.EventTrackingJS, URL: "http://view.example.com/omid.js", CustomData: map[string]string{"k": "v"}},
+		}))
+		Expect(res.Trackers(request.EventTypeViewableMRC50, request.EventTrackingJS)).To(Equal([]string{"http://view.example.com/omid.js"}))
+		Expect(res.Trackers(request.EventTypeViewableMRC50, request.EventTrackingImage)).To(BeEmpty())
+	})
+
+	It("should merge impression trackers", func() {
+		res := fixture("testdata/response1.json")
+		res.AddImpTrackers("http://exchange.com/imp", "http://imptracker.com")
+		Expect(res.ImpTrackers).To(Equal([]string{"http://imptracker.com", "http://exchange.com/imp"}))
+		Expect(res.EventTrackers).To(BeEmpty())
+
+		res = fixture("testdata/response1.json")
+		res.Ver = "1.2"
+		res.AddImpTrackers("http://exchange.com/imp", "http://imptracker.com", "http://exchange.com/imp")
+		Expect(res.ImpTrackers).To(Equal([]string{"http://imptracker.com"}))
+		Expect(res.EventTrackers).To(Equal([]EventTracker{
+			{Event: request.EventTypeImpression, Method: request.EventTrackingImage, URL: "http://exchange.com/imp"},
+		}))
+		Expect(res.Trackers(request.EventTypeImpression, request.EventTrackingImage)).To(Equal([]string{"http://imptracker.com", "http://exchange.com/imp"}))
+	})
+
 })
 
 func TestSuite(t *testing.T) {
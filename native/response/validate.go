@@ -0,0 +1,142 @@
+package response
+
+import (
+	"errors"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/bsm/openrtb/native/request"
+)
+
+// Validation errors
+var (
+	ErrMissingAsset      = errors.New("native: required asset missing")
+	ErrUnknownAsset      = errors.New("native: asset was not requested")
+	ErrAssetTypeMismatch = errors.New("native: asset type does not match request")
+	ErrTitleTooLong      = errors.New("native: title exceeds requested length")
+	ErrImageSize         = errors.New("native: image size does not match request")
+	ErrDataTooLong       = errors.New("native: data value exceeds requested length")
+	ErrMissingLinkURL    = errors.New("native: link url missing")
+	ErrMissingImageURL   = errors.New("native: image url missing")
+	ErrMissingVideoVAST  = errors.New("native: video vasttag missing")
+	ErrEmptyAsset        = errors.New("native: asset has no title, img, video, data or link")
+)
+
+// AssetError annotates an error with the ID of the asset it relates to.
+type AssetError struct {
+	ID  int   // Asset ID
+	Err error // Underlying error
+}
+
+// Error implements the error interface
+func (e *AssetError) Error() string {
+	return e.Err.Error() + " (asset " + strconv.Itoa(e.ID) + ")"
+}
+
+// Unwrap returns the underlying error
+func (e *AssetError) Unwrap() error { return e.Err }
+
+// ValidateFor validates the response against its originating native
+// request: all required assets must be present, every asset must have been
+// requested with the same type, titles and data values must not exceed the
+// requested len and images must meet the requested size. Image sizes are
+// only checked when the response declares them. Errors relating to a
+// single asset are returned as *AssetError.
+func (r *Response) ValidateFor(req *request.Request) error {
+	if r.Link.URL == "" {
+		return ErrMissingLinkURL
+	}
+
+	requested := make(map[int]*request.Asset, len(req.Assets))
+	for i := range req.Assets {
+		requested[req.Assets[i].ID] = &req.Assets[i]
+	}
+
+	present := make(map[int]bool, len(r.Assets))
+	for i := range r.Assets {
+		asset := &r.Assets[i]
+		ra, ok := requested[asset.ID]
+		if !ok {
+			return &AssetError{ID: asset.ID, Err: ErrUnknownAsset}
+		}
+		if err := asset.validateFor(ra); err != nil {
+			return &AssetError{ID: asset.ID, Err: err}
+		}
+		present[asset.ID] = true
+	}
+
+	for _, ra := range req.Assets {
+		if ra.Required == 1 && !present[ra.ID] {
+			return &AssetError{ID: ra.ID, Err: ErrMissingAsset}
+		}
+	}
+	return nil
+}
+
+func (a *Asset) validateFor(ra *request.Asset) error {
+	if a.Link != nil && a.Link.URL == "" {
+		return ErrMissingLinkURL
+	}
+
+	switch {
+	case a.Title != nil:
+		if ra.Title == nil {
+			return ErrAssetTypeMismatch
+		}
+		if ra.Title.Length > 0 && utf8.RuneCountInString(a.Title.Text) > ra.Title.Length {
+			return ErrTitleTooLong
+		}
+	case a.Image != nil:
+		if ra.Image == nil {
+			return ErrAssetTypeMismatch
+		}
+		if a.Image.URL == "" {
+			return ErrMissingImageURL
+		}
+		if !a.Image.fits(ra.Image) {
+			return ErrImageSize
+		}
+	case a.Video != nil:
+		if ra.Video == nil {
+			return ErrAssetTypeMismatch
+		}
+		if a.Video.VASTTag == "" {
+			return ErrMissingVideoVAST
+		}
+	case a.Data != nil:
+		if ra.Data == nil {
+			return ErrAssetTypeMismatch
+		}
+		if ra.Data.Length > 0 && utf8.RuneCountInString(a.Data.Value) > ra.Data.Length {
+			return ErrDataTooLong
+		}
+	case a.Link == nil:
+		return ErrEmptyAsset
+	}
+	return nil
+}
+
+// fits returns true if the image meets the requested size. Requests with
+// wmin/hmin accept any image at least that large, requests with w/h only
+// require an exact match.
+func (img *Image) fits(ri *request.Image) bool {
+	if img.Width != 0 {
+		if ri.WidthMin != 0 {
+			if img.Width < ri.WidthMin {
+				return false
+			}
+		} else if ri.Width != 0 && img.Width != ri.Width {
+			return false
+		}
+	}
+	if img.Height != 0 {
+		if ri.HeightMin != 0 {
+			if img.Height < ri.HeightMin {
+				return false
+			}
+		} else if ri.Height != 0 && img.Height != ri.Height {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package response
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/bsm/openrtb/native/request"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Response", func() {
+	var subject *Response
+	var req *request.Request
+
+	BeforeEach(func() {
+		data, err := ioutil.ReadFile("../request/testdata/request1.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.Unmarshal(data, &req)).To(Succeed())
+
+		subject = &Response{
+			Link: Link{URL: "http://i.am.a/URL"},
+			Assets: []Asset{
+				{ID: 123, Title: &Title{Text: "Learn about this awesome thing"}},
+				{ID: 128, Image: &Image{URL: "http://www.myads.com/largethumb1.png", Width: 1000, Height: 800}},
+				{ID: 126, Data: &Data{Value: "My Brand"}},
+				{ID: 127, Data: &Data{Value: "Learn all about this awesome story of someone using my product."}},
+				{ID: 4, Video: &Video{VASTTag: "<VAST version=\"2.0\"></VAST>"}},
+			},
+		}
+	})
+
+	It("should validate against requests", func() {
+		Expect(subject.ValidateFor(req)).To(Succeed())
+
+		res := fixture("testdata/response1.json")
+		Expect(res.ValidateFor(req)).To(MatchError(ErrUnknownAsset))
+	})
+
+	It("should require assets and links", func() {
+		subject.Assets = subject.Assets[1:]
+		err := subject.ValidateFor(req)
+		Expect(err).To(MatchError(ErrMissingAsset))
+		Expect(err).To(MatchError("native: required asset missing (asset 123)"))
+
+		subject.Assets = subject.Assets[1:2]
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrMissingAsset))
+
+		subject.Link.URL = ""
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrMissingLinkURL))
+	})
+
+	It("should check asset types", func() {
+		subject.Assets[0] = Asset{ID: 123, Data: &Data{Value: "x"}}
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrAssetTypeMismatch))
+
+		subject.Assets[0] = Asset{ID: 123}
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrEmptyAsset))
+
+		subject.Assets[0] = Asset{ID: 123, Title: &Title{Text: "x"}, Link: &Link{}}
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrMissingLinkURL))
+	})
+
+	It("should check lengths", func() {
+		subject.Assets[2].Data.Value = "Brand name longer than 25 chars"
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrDataTooLong))
+
+		subject.Assets[2].Data.Value = "Brand"
+		subject.Assets[0].Title.Text = string(make([]byte, 141))
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrTitleTooLong))
+	})
+
+	It("should check images", func() {
+		subject.Assets[1].Image.Width = 800
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrImageSize))
+		subject.Assets[1].Image.Width = 836
+		Expect(subject.ValidateFor(req)).To(Succeed())
+
+		req.Assets[1].Image.WidthMin, req.Assets[1].Image.HeightMin = 0, 0
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrImageSize))
+		subject.Assets[1].Image.Width, subject.Assets[1].Image.Height = 0, 0
+		Expect(subject.ValidateFor(req)).To(Succeed())
+
+		subject.Assets[1].Image.URL = ""
+		Expect(subject.ValidateFor(req)).To(MatchError(ErrMissingImageURL))
+	})
+
+})
@@ -0,0 +1,72 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// NativeResponse decodes the native markup of the bid into v, typically a
+// *response.Response of the native/response package. Markup sent as an
+// escaped string and as a raw JSON object are both supported, as is the
+// {"native":{...}} wrapper of Native 1.0 responses.
+func (bid *Bid) NativeResponse(v interface{}) error {
+	adm := bytes.TrimSpace([]byte(bid.AdMarkup))
+	if len(adm) == 0 || adm[0] != '{' {
+		return ErrInvalidBidMarkup
+	}
+
+	var wrapper struct {
+		Native json.RawMessage `json:"native"`
+	}
+	if err := json.Unmarshal(adm, &wrapper); err != nil {
+		return err
+	}
+	if len(wrapper.Native) != 0 {
+		adm = wrapper.Native
+	}
+	return json.Unmarshal(adm, v)
+}
+
+// SetNativeResponse encodes v as the native markup of the bid and sets the
+// markup type accordingly.
+func (bid *Bid) SetNativeResponse(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 || data[0] != '{' {
+		return ErrInvalidBidMarkup
+	}
+
+	bid.AdMarkup = string(data)
+	bid.MType = MarkupTypeNative
+	return nil
+}
+
+type jsonBid Bid
+
+// UnmarshalJSON custom unmarshalling, accepting native markup sent as a raw
+// JSON object instead of an escaped string.
+func (bid *Bid) UnmarshalJSON(data []byte) error {
+	var h struct {
+		*jsonBid
+		AdMarkup json.RawMessage `json:"adm,omitempty"`
+	}
+	h.jsonBid = (*jsonBid)(bid)
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+
+	switch adm := bytes.TrimSpace(h.AdMarkup); {
+	case len(adm) == 0 || string(adm) == "null":
+	case adm[0] == '{':
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, adm); err != nil {
+			return err
+		}
+		bid.AdMarkup = buf.String()
+	default:
+		return json.Unmarshal(adm, &bid.AdMarkup)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package openrtb
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NativeResponse", func() {
+
+	type nativeLink struct {
+		URL string `json:"url"`
+	}
+	type nativeResponse struct {
+		Ver  string     `json:"ver,omitempty"`
+		Link nativeLink `json:"link"`
+	}
+
+	It("should decode escaped markup", func() {
+		var bid Bid
+		Expect(json.Unmarshal([]byte(`{"id":"1","impid":"1","price":1,"adm":"{\"ver\":\"1.2\",\"link\":{\"url\":\"http://example.com\"}}"}`), &bid)).To(Succeed())
+
+		var res nativeResponse
+		Expect(bid.NativeResponse(&res)).To(Succeed())
+		Expect(res).To(Equal(nativeResponse{Ver: "1.2", Link: nativeLink{URL: "http://example.com"}}))
+	})
+
+	It("should decode raw object markup", func() {
+		var bid Bid
+		Expect(json.Unmarshal([]byte(`{"id":"1","impid":"1","price":1,"adm":{"ver": "1.2", "link": {"url": "http://example.com"}}}`), &bid)).To(Succeed())
+		Expect(bid.ID).To(Equal("1"))
+		Expect(bid.AdMarkup).To(Equal(`{"ver":"1.2","link":{"url":"http://example.com"}}`))
+
+		var res nativeResponse
+		Expect(bid.NativeResponse(&res)).To(Succeed())
+		Expect(res).To(Equal(nativeResponse{Ver: "1.2", Link: nativeLink{URL: "http://example.com"}}))
+	})
+
+	It("should decode the legacy wrapper", func() {
+		bid := &Bid{ID: "1", AdMarkup: `{"native":{"ver":"1.0","link":{"url":"http://example.com"}}}`}
+
+		var res nativeResponse
+		Expect(bid.NativeResponse(&res)).To(Succeed())
+		Expect(res).To(Equal(nativeResponse{Ver: "1.0", Link: nativeLink{URL: "http://example.com"}}))
+	})
+
+	It("should reject non-native markup", func() {
+		var res nativeResponse
+		Expect((&Bid{ID: "1"}).NativeResponse(&res)).To(Equal(ErrInvalidBidMarkup))
+		Expect((&Bid{ID: "1", AdMarkup: "<VAST/>"}).NativeResponse(&res)).To(Equal(ErrInvalidBidMarkup))
+	})
+
+	It("should encode markup", func() {
+		bid := &Bid{ID: "1", ImpID: "1", Price: 1}
+		Expect(bid.SetNativeResponse(&nativeResponse{Ver: "1.2", Link: nativeLink{URL: "http://example.com"}})).To(Succeed())
+		Expect(bid.MType).To(Equal(MarkupTypeNative))
+		Expect(bid.AdMarkup).To(Equal(`{"ver":"1.2","link":{"url":"http://example.com"}}`))
+
+		data, err := json.Marshal(bid)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"id":"1","impid":"1","price":1,"adm":"{\"ver\":\"1.2\",\"link\":{\"url\":\"http://example.com\"}}","mtype":4}`))
+
+		Expect(bid.SetNativeResponse("string")).To(Equal(ErrInvalidBidMarkup))
+	})
+
+})
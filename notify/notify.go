@@ -0,0 +1,306 @@
+/*
+Package notify fires the notice URLs of bids, e.g. win notices, after the
+auction. Auction macros are substituted, URLs are optionally signed and
+failed deliveries are retried with exponential backoff.
+
+	n := notify.NewWinNotifier(&notify.Options{
+		Retries: 2,
+		Signer:  &notify.Signer{Key: secret},
+		Metrics: metrics,
+	})
+
+	v := openrtb.NewMacroValues(req, res, seat, bid, price)
+	if err := n.Notify(ctx, bid, v); err != nil {
+		...
+	}
+*/
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// Metric names
+const (
+	// MetricNotices counts fired notices, labelled by type and outcome.
+	MetricNotices = "openrtb_notices_total"
+	// MetricNoticeLatency is a histogram of notice delivery latencies in
+	// seconds, including retries, labelled by type and outcome.
+	MetricNoticeLatency = "openrtb_notice_latency_seconds"
+)
+
+// Notice types, values of the openrtb.MetricLabelType label
+const (
+	TypeWin = "win"
+)
+
+// Notice outcomes, values of the openrtb.MetricLabelOutcome label
+const (
+	OutcomeDelivered = "delivered"
+	OutcomeFailed    = "failed"
+)
+
+// Errors
+var (
+	ErrTestTraffic   = errors.New("notify: notices must not be fired for test traffic")
+	ErrNoMacroValues = errors.New("notify: macro values missing")
+)
+
+// DefaultSignatureParam is the query parameter of URL signatures, unless
+// configured otherwise.
+const DefaultSignatureParam = "sig"
+
+// StatusError is returned when a notice URL responds with an unexpected
+// HTTP status, i.e. anything other than 2xx.
+type StatusError struct {
+	Code int // HTTP status code
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return "notify: unexpected status " + strconv.Itoa(e.Code)
+}
+
+// Signer signs notice URLs with an HMAC-SHA256 query parameter, so the
+// receiver can verify that they were not tampered with.
+type Signer struct {
+	Key   []byte // Secret key
+	Param string // Query parameter, default: DefaultSignatureParam
+}
+
+// Sign appends the signature of rawURL as the last query parameter and
+// replaces an existing signature. The URL is otherwise left as is, so that
+// notices reach the exact URL supplied by the bidder.
+func (s *Signer) Sign(rawURL string) (string, error) {
+	if _, err := url.Parse(rawURL); err != nil {
+		return "", err
+	}
+
+	unsigned, _ := s.split(rawURL)
+	base, fragment := cutFragment(unsigned)
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	return base + sep + s.param() + "=" + s.sign(unsigned) + fragment, nil
+}
+
+// Verify returns true if rawURL carries a valid signature.
+func (s *Signer) Verify(rawURL string) bool {
+	if _, err := url.Parse(rawURL); err != nil {
+		return false
+	}
+
+	unsigned, sig := s.split(rawURL)
+	return sig != "" && hmac.Equal([]byte(sig), []byte(s.sign(unsigned)))
+}
+
+// split removes the signature parameter from the raw query of rawURL. It
+// returns the unsigned URL and the last signature found.
+func (s *Signer) split(rawURL string) (unsigned, sig string) {
+	base, fragment := cutFragment(rawURL)
+	path, query, ok := strings.Cut(base, "?")
+	if !ok {
+		return rawURL, ""
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		key, val, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(key); err == nil && k == s.param() {
+			sig = val
+			continue
+		}
+		kept = append(kept, pair)
+	}
+	if len(kept) != 0 {
+		path += "?" + strings.Join(kept, "&")
+	}
+	return path + fragment, sig
+}
+
+func (s *Signer) sign(msg string) string {
+	mac := hmac.New(sha256.New, s.Key)
+	_, _ = io.WriteString(mac, msg)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// cutFragment splits rawURL before the fragment, if any.
+func cutFragment(rawURL string) (string, string) {
+	if i := strings.IndexByte(rawURL, '#'); i >= 0 {
+		return rawURL[:i], rawURL[i:]
+	}
+	return rawURL, ""
+}
+
+func (s *Signer) param() string {
+	if s.Param != "" {
+		return s.Param
+	}
+	return DefaultSignatureParam
+}
+
+// Options configure notifiers. A nil value uses the zero value defaults.
+type Options struct {
+	// HTTPClient overrides http.DefaultClient.
+	HTTPClient *http.Client
+	// Timeout limits each attempt, default: 1s.
+	Timeout time.Duration
+	// Retries is the number of retries after failed attempts. Transport
+	// errors and 5xx and 429 statuses are retried, other statuses are not.
+	Retries int
+	// Backoff is the delay before the first retry, doubled for every
+	// subsequent retry, default: 100ms.
+	Backoff time.Duration
+	// Signer optionally signs notice URLs after macro substitution.
+	Signer *Signer
+	// Metrics optionally records the number and latency of notices,
+	// labelled by type and outcome.
+	Metrics openrtb.Metrics
+}
+
+// notifier fires notice URLs of a type.
+type notifier struct {
+	typ    string
+	client *http.Client
+	opts   Options
+}
+
+func newNotifier(typ string, opts *Options) notifier {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Timeout == 0 {
+		o.Timeout = time.Second
+	}
+	if o.Backoff == 0 {
+		o.Backoff = 100 * time.Millisecond
+	}
+
+	client := o.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return notifier{typ: typ, client: client, opts: o}
+}
+
+// fire substitutes the macros of rawURL and fires it.
+func (n *notifier) fire(ctx context.Context, rawURL string, v *openrtb.MacroValues) error {
+	start := time.Now()
+	err := n.deliver(ctx, openrtb.ExpandMacrosEscaped(rawURL, v, openrtb.MacroEscapeURL))
+
+	if m := n.opts.Metrics; m != nil {
+		outcome := OutcomeDelivered
+		if err != nil {
+			outcome = OutcomeFailed
+		}
+		labels := map[string]string{openrtb.MetricLabelType: n.typ, openrtb.MetricLabelOutcome: outcome}
+		m.Count(MetricNotices, labels, 1)
+		m.Observe(MetricNoticeLatency, labels, time.Since(start).Seconds())
+	}
+	return err
+}
+
+func (n *notifier) deliver(ctx context.Context, target string) error {
+	if n.opts.Signer != nil {
+		var err error
+		if target, err = n.opts.Signer.Sign(target); err != nil {
+			return err
+		}
+	}
+
+	backoff := n.opts.Backoff
+	for attempt := 0; ; attempt++ {
+		err := n.attempt(ctx, target)
+		if err == nil || attempt >= n.opts.Retries || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func (n *notifier) attempt(ctx context.Context, target string) error {
+	ctx, cancel := context.WithTimeout(ctx, n.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &StatusError{Code: res.StatusCode}
+	}
+	return nil
+}
+
+func retryable(err error) bool {
+	var serr *StatusError
+	if errors.As(err, &serr) {
+		return serr.Code >= 500 || serr.Code == http.StatusTooManyRequests
+	}
+	var uerr *url.Error
+	if errors.As(err, &uerr) {
+		return !errors.Is(err, context.Canceled)
+	}
+	return false
+}
+
+// WinNotifier fires the win notice URLs (nurl) of winning bids. It is safe
+// for concurrent use.
+type WinNotifier struct {
+	notifier
+}
+
+// NewWinNotifier creates a win notifier.
+func NewWinNotifier(opts *Options) *WinNotifier {
+	return &WinNotifier{notifier: newNotifier(TypeWin, opts)}
+}
+
+// Notify fires the nurl of bid, substituting the auction macros with v.
+// Bids without a nurl are skipped. Notices for test traffic are rejected
+// with ErrTestTraffic, see openrtb.MacroValues.Test, and notices without
+// values with ErrNoMacroValues.
+func (n *WinNotifier) Notify(ctx context.Context, bid *openrtb.Bid, v *openrtb.MacroValues) error {
+	if v == nil {
+		return ErrNoMacroValues
+	}
+	if v.Test {
+		return ErrTestTraffic
+	}
+	if bid.NURL == "" {
+		return nil
+	}
+	return n.fire(ctx, bid.NURL, v)
+}
@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Signer", func() {
+	subject := &Signer{Key: []byte("secret")}
+
+	It("should sign and verify URLs", func() {
+		signed, err := subject.Sign("https://example.com/win?price=1.5&id=B")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signed).To(MatchRegexp(`^https://example.com/win\?price=1.5&id=B&sig=[0-9a-f]{64}$`))
+		Expect(subject.Verify(signed)).To(BeTrue())
+
+		resigned, err := subject.Sign(signed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resigned).To(Equal(signed))
+
+		Expect(subject.Verify("https://example.com/win?id=B&price=1.5")).To(BeFalse())
+		Expect(subject.Verify(signed[:len(signed)-1] + "0")).To(BeFalse())
+		Expect((&Signer{Key: []byte("other")}).Verify(signed)).To(BeFalse())
+	})
+
+	It("should retain the query as supplied", func() {
+		signed, err := subject.Sign("https://example.com/win?q=a%20b&x=2&x=1&sig=old#top")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signed).To(MatchRegexp(`^https://example.com/win\?q=a%20b&x=2&x=1&sig=[0-9a-f]{64}#top$`))
+		Expect(subject.Verify(signed)).To(BeTrue())
+
+		signed, err = subject.Sign("https://example.com/win")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signed).To(MatchRegexp(`^https://example.com/win\?sig=[0-9a-f]{64}$`))
+		Expect(subject.Verify(signed)).To(BeTrue())
+	})
+
+})
+
+var _ = Describe("WinNotifier", func() {
+	var server *httptest.Server
+	var status []int
+	var received []string
+	var mu sync.Mutex
+	var ctx = context.Background()
+
+	v := &openrtb.MacroValues{AuctionID: "R", Price: 1.25, Currency: "USD"}
+
+	BeforeEach(func() {
+		status, received = nil, nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			received = append(received, r.URL.RequestURI())
+			if len(status) != 0 {
+				w.WriteHeader(status[0])
+				status = status[1:]
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should fire notices", func() {
+		bid := &openrtb.Bid{NURL: server.URL + "/win?auction=${AUCTION_ID}&price=${AUCTION_PRICE}&cur=${AUCTION_CURRENCY}"}
+		Expect(NewWinNotifier(nil).Notify(ctx, bid, v)).To(Succeed())
+		Expect(received).To(Equal([]string{"/win?auction=R&price=1.25&cur=USD"}))
+
+		Expect(NewWinNotifier(nil).Notify(ctx, &openrtb.Bid{}, v)).To(Succeed())
+		Expect(received).To(HaveLen(1))
+	})
+
+	It("should reject test traffic", func() {
+		bid := &openrtb.Bid{NURL: server.URL + "/win"}
+		Expect(NewWinNotifier(nil).Notify(ctx, bid, &openrtb.MacroValues{Test: true})).To(MatchError(ErrTestTraffic))
+		Expect(NewWinNotifier(nil).Notify(ctx, bid, nil)).To(MatchError(ErrNoMacroValues))
+		Expect(received).To(BeEmpty())
+	})
+
+	It("should sign notices", func() {
+		signer := &Signer{Key: []byte("secret"), Param: "s"}
+		bid := &openrtb.Bid{NURL: server.URL + "/win?price=${AUCTION_PRICE}"}
+		Expect(NewWinNotifier(&Options{Signer: signer}).Notify(ctx, bid, v)).To(Succeed())
+		Expect(received).To(HaveLen(1))
+		Expect(received[0]).To(MatchRegexp(`^/win\?price=1.25&s=[0-9a-f]{64}$`))
+		Expect(signer.Verify(server.URL + received[0])).To(BeTrue())
+	})
+
+	It("should retry", func() {
+		bid := &openrtb.Bid{NURL: server.URL + "/win"}
+		subject := NewWinNotifier(&Options{Retries: 2, Backoff: time.Millisecond})
+
+		status = []int{http.StatusBadGateway, http.StatusTooManyRequests}
+		Expect(subject.Notify(ctx, bid, v)).To(Succeed())
+		Expect(received).To(HaveLen(3))
+
+		status = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+		Expect(subject.Notify(ctx, bid, v)).To(MatchError(&StatusError{Code: http.StatusBadGateway}))
+		Expect(received).To(HaveLen(6))
+
+		status = []int{http.StatusNotFound}
+		Expect(subject.Notify(ctx, bid, v)).To(MatchError("notify: unexpected status 404"))
+		Expect(received).To(HaveLen(7))
+	})
+
+	It("should time out", func() {
+		var calls int32
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}))
+		defer slow.Close()
+
+		subject := NewWinNotifier(&Options{Timeout: 10 * time.Millisecond, Retries: 1, Backoff: time.Millisecond})
+		Expect(subject.Notify(ctx, &openrtb.Bid{NURL: slow.URL}, v)).To(MatchError(context.DeadlineExceeded))
+		Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+	})
+
+	It("should record metrics", func() {
+		metrics := new(mockMetrics)
+		subject := NewWinNotifier(&Options{Metrics: metrics})
+
+		Expect(subject.Notify(ctx, &openrtb.Bid{NURL: server.URL}, v)).To(Succeed())
+		status = []int{http.StatusBadRequest}
+		Expect(subject.Notify(ctx, &openrtb.Bid{NURL: server.URL}, v)).NotTo(Succeed())
+
+		Expect(metrics.names).To(Equal([]string{
+			"openrtb_notices_total outcome=delivered type=win",
+			"openrtb_notice_latency_seconds outcome=delivered type=win",
+			"openrtb_notices_total outcome=failed type=win",
+			"openrtb_notice_latency_seconds outcome=failed type=win",
+		}))
+	})
+
+})
+
+type mockMetrics struct {
+	names []string
+	mu    sync.Mutex
+}
+
+func (m *mockMetrics) Count(name string, labels map[string]string, _ float64) {
+	m.record(name, labels)
+}
+
+func (m *mockMetrics) Observe(name string, labels map[string]string, _ float64) {
+	m.record(name, labels)
+}
+
+func (m *mockMetrics) record(name string, labels map[string]string) {
+	name += " " + openrtb.MetricLabelOutcome + "=" + labels[openrtb.MetricLabelOutcome]
+	name += " " + openrtb.MetricLabelType + "=" + labels[openrtb.MetricLabelType]
+
+	m.mu.Lock()
+	m.names = append(m.names, name)
+	m.mu.Unlock()
+}
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/notify")
+}
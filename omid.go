@@ -0,0 +1,81 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Validation errors
+var (
+	ErrInvalidBidOMID = errors.New("openrtb: bid requires open measurement not supported by request")
+)
+
+// SourceExtOMID holds the Open Measurement attributes of source.ext,
+// identifying the OM SDK integration of the publisher.
+type SourceExtOMID struct {
+	PartnerName    string `json:"omidpn,omitempty"` // OM SDK partner name, e.g. "ExamplePublisher"
+	PartnerVersion string `json:"omidpv,omitempty"` // OM SDK partner version, e.g. "1.2.3"
+}
+
+// ExtOMID decodes the OM SDK attributes of source.ext. It returns nil if
+// they are absent.
+func (s *Source) ExtOMID() (*SourceExtOMID, error) {
+	if len(s.Ext) == 0 {
+		return nil, nil
+	}
+
+	var ext SourceExtOMID
+	if err := json.Unmarshal(s.Ext, &ext); err != nil {
+		return nil, err
+	}
+	if ext == (SourceExtOMID{}) {
+		return nil, nil
+	}
+	return &ext, nil
+}
+
+// SetExtOMID stores the OM SDK attributes in source.ext, removing empty
+// ones.
+func (s *Source) SetExtOMID(x *SourceExtOMID) error {
+	if x == nil {
+		x = new(SourceExtOMID)
+	}
+
+	ext, err := s.Ext.setOrDeleteKey("omidpn", x.PartnerName, x.PartnerName == "")
+	if err == nil {
+		ext, err = ext.setOrDeleteKey("omidpv", x.PartnerVersion, x.PartnerVersion == "")
+	}
+	if err != nil {
+		return err
+	}
+	s.Ext = ext
+	return nil
+}
+
+// SupportsOMID returns true if open measurement is available for the
+// impression and markup type, i.e. if the impression lists
+// APIFrameworkOMID1 and the request identifies an OM SDK integration via
+// source.ext.omidpn.
+func (req *BidRequest) SupportsOMID(imp *Impression, mtype int) bool {
+	if req.Source == nil || !hasInt(imp.SupportedAPIs(mtype), APIFrameworkOMID1) {
+		return false
+	}
+	om, err := req.Source.ExtOMID()
+	return err == nil && om != nil && om.PartnerName != ""
+}
+
+// RequiresOMID returns true if the bid requires open measurement, i.e. if
+// it declares APIFrameworkOMID1 via apis or api. OM verification scripts in
+// the markup are only taken into account once added via PopulateAPIs.
+func (bid *Bid) RequiresOMID() bool {
+	return hasInt(bid.DeclaredAPIs(), APIFrameworkOMID1)
+}
+
+// ValidateOMID checks that a bid requiring open measurement targets an
+// impression of the request which supports it, see SupportsOMID.
+func (req *BidRequest) ValidateOMID(imp *Impression, bid *Bid) error {
+	if bid.RequiresOMID() && !req.SupportsOMID(imp, bid.MType) {
+		return ErrInvalidBidOMID
+	}
+	return nil
+}
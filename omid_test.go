@@ -0,0 +1,84 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Source", func() {
+	var subject *Source
+
+	BeforeEach(func() {
+		subject = &Source{TID: "T", Ext: Extension(`{"omidpn":"ExamplePublisher","omidpv":"1.2.3","other":1}`)}
+	})
+
+	It("should decode OMID extensions", func() {
+		Expect(subject.ExtOMID()).To(Equal(&SourceExtOMID{PartnerName: "ExamplePublisher", PartnerVersion: "1.2.3"}))
+		Expect((&Source{}).ExtOMID()).To(BeNil())
+		Expect((&Source{Ext: Extension(`{"other":1}`)}).ExtOMID()).To(BeNil())
+
+		_, err := (&Source{Ext: Extension(`[]`)}).ExtOMID()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should encode OMID extensions", func() {
+		Expect(subject.SetExtOMID(&SourceExtOMID{PartnerName: "Other"})).To(Succeed())
+		Expect([]byte(subject.Ext)).To(MatchJSON(`{"omidpn":"Other","other":1}`))
+
+		Expect(subject.SetExtOMID(nil)).To(Succeed())
+		Expect([]byte(subject.Ext)).To(MatchJSON(`{"other":1}`))
+	})
+
+})
+
+var _ = Describe("BidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID:     "R",
+			Imp:    []Impression{{ID: "I", Banner: &Banner{Api: []int{APIFrameworkMRAID2, APIFrameworkOMID1}}, Video: &Video{}}},
+			Source: &Source{Ext: Extension(`{"omidpn":"ExamplePublisher"}`)},
+		}
+	})
+
+	It("should detect OMID support", func() {
+		imp := &subject.Imp[0]
+		Expect(subject.SupportsOMID(imp, 0)).To(BeTrue())
+		Expect(subject.SupportsOMID(imp, MarkupTypeBanner)).To(BeTrue())
+		Expect(subject.SupportsOMID(imp, MarkupTypeVideo)).To(BeFalse())
+
+		subject.Source.Ext = nil
+		Expect(subject.SupportsOMID(imp, MarkupTypeBanner)).To(BeFalse())
+		subject.Source = nil
+		Expect(subject.SupportsOMID(imp, MarkupTypeBanner)).To(BeFalse())
+	})
+
+	It("should validate bids requiring OMID", func() {
+		imp := &subject.Imp[0]
+		bid := &Bid{ID: "B", ImpID: "I", MType: MarkupTypeBanner, APIs: []int{APIFrameworkOMID1}}
+		Expect(bid.RequiresOMID()).To(BeTrue())
+		Expect(subject.ValidateOMID(imp, bid)).To(Succeed())
+
+		bid.MType = MarkupTypeVideo
+		Expect(subject.ValidateOMID(imp, bid)).To(Equal(ErrInvalidBidOMID))
+
+		bid.APIs = nil
+		bid.AdMarkup = `<div>ad</div>`
+		Expect(bid.RequiresOMID()).To(BeFalse())
+		Expect(subject.ValidateOMID(imp, bid)).To(Succeed())
+	})
+
+	It("should not reject bids based on markup alone", func() {
+		imp := &subject.Imp[0]
+		bid := &Bid{ID: "B", ImpID: "I", MType: MarkupTypeVideo, AdMarkup: `<a href="https://cdn.example.com/omid/click"><img src="nomidroll.png"></a>`}
+		Expect(bid.RequiresOMID()).To(BeFalse())
+		Expect(subject.ValidateOMID(imp, bid)).To(Succeed())
+
+		bid.AdMarkup = `<script src="https://cdn.example.com/omweb-v1.js"></script>`
+		Expect(subject.ValidateOMID(imp, bid)).To(Succeed())
+		bid.PopulateAPIs()
+		Expect(subject.ValidateOMID(imp, bid)).To(Equal(ErrInvalidBidOMID))
+	})
+
+})
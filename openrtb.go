@@ -9,7 +9,25 @@ const (
 )
 
 // 5.3 Creative Attributes
-// TODO
+const (
+	CreativeAttrAudioAuto int = iota + 1
+	CreativeAttrAudioUser
+	CreativeAttrExpandableAuto
+	CreativeAttrExpandableClick
+	CreativeAttrExpandableRollover
+	CreativeAttrVideoAuto
+	CreativeAttrVideoUser
+	CreativeAttrPop
+	CreativeAttrProvocative
+	CreativeAttrAnimation
+	CreativeAttrSurvey
+	CreativeAttrTextOnly
+	CreativeAttrInteractive
+	CreativeAttrDialog
+	CreativeAttrAudioToggle
+	CreativeAttrSkipButton
+	CreativeAttrFlash
+)
 
 // 5.4 Ad Position
 const (
@@ -29,6 +47,7 @@ const (
 	ExpDirUp
 	ExpDirDown
 	ExpDirFullScreen
+	ExpDirResizeMinimize
 )
 
 // 5.6 API Frameworks
@@ -38,6 +57,10 @@ const (
 	APIFrameworkMRAID1
 	APIFrameworkORMMA
 	APIFrameworkMRAID2
+	APIFrameworkMRAID3
+	APIFrameworkOMID1
+	APIFrameworkSIMID1
+	APIFrameworkSIMID11
 )
 
 // 5.7 Video Linearity
@@ -68,6 +91,23 @@ const (
 	VideoPlaybackMouseOver
 )
 
+// Placement Subtypes - Video (list 5.9 of OpenRTB 2.5; this file follows the 2.4 numbering)
+const (
+	VideoPlacementInStream int = iota + 1
+	VideoPlacementInBanner
+	VideoPlacementInArticle
+	VideoPlacementInFeed
+	VideoPlacementInterstitial
+)
+
+// Plcmt Subtypes - Video
+const (
+	VideoPlcmtInstream int = iota + 1
+	VideoPlcmtAccompanyingContent
+	VideoPlcmtInterstitial
+	VideoPlcmtNoContent
+)
+
 // 5.10 Video Start Delay
 const (
 	VideoStartDelayPreRoll         = 0
@@ -158,6 +198,113 @@ const (
 	NBRUnmatchedUser
 )
 
+// User-Agent Source
+const (
+	UASourceUnknown int = iota
+	UASourceLowEntropy
+	UASourceHighEntropy
+	UASourceParsed
+)
+
+// DOOH Multiplier Measurement Source Types
+const (
+	QtySourceTypeUnknown int = iota
+	QtySourceTypeMeasurementVendor
+	QtySourceTypePublisher
+	QtySourceTypeExchange
+)
+
+// DOOH Venue Taxonomies
+const (
+	VenueTaxonomyAdCOM int = iota + 1
+	VenueTaxonomyOpenOOH10
+)
+
+// Feed Types
+const (
+	FeedTypeMusicService int = iota + 1
+	FeedTypeBroadcast
+	FeedTypePodcast
+)
+
+// Volume Normalization Modes
+const (
+	VolumeNormalizationNone int = iota
+	VolumeNormalizationAverage
+	VolumeNormalizationPeak
+	VolumeNormalizationLoudness
+	VolumeNormalizationCustom
+)
+
+// Pod Sequence
+const (
+	PodSequenceLast  int = -1
+	PodSequenceAny   int = 0
+	PodSequenceFirst int = 1
+)
+
+// Slot Position in Pod
+const (
+	SlotInPodLast        int = -1
+	SlotInPodAny         int = 0
+	SlotInPodFirst       int = 1
+	SlotInPodFirstOrLast int = 2
+)
+
+// Category Taxonomies
+const (
+	CatTaxIABContent10 int = iota + 1
+	CatTaxIABContent20
+	CatTaxIABProduct10
+	CatTaxIABAudience11
+	CatTaxIABContent21
+	CatTaxIABContent22
+	CatTaxIABContent30
+	CatTaxIABProduct20
+)
+
+// Loss Reason Codes
+const (
+	LossBidWon                      = 0
+	LossInternalError               = 1
+	LossImpExpired                  = 2
+	LossInvalidBidResponse          = 3
+	LossInvalidDealID               = 4
+	LossInvalidAuctionID            = 5
+	LossInvalidAdvDomain            = 6
+	LossMissingMarkup               = 7
+	LossMissingCreativeID           = 8
+	LossMissingPrice                = 9
+	LossMissingCreativeApproval     = 10
+	LossBelowAuctionFloor           = 100
+	LossBelowDealFloor              = 101
+	LossLostToHigherBid             = 102
+	LossLostToPMPDeal               = 103
+	LossSeatBlocked                 = 104
+	LossCreativeFiltered            = 200
+	LossCreativePending             = 201
+	LossCreativeDisapproved         = 202
+	LossCreativeSizeNotAllowed      = 203
+	LossCreativeIncorrectFormat     = 204
+	LossCreativeAdvertiserExclusion = 205
+	LossCreativeAppExclusion        = 206
+	LossCreativeNotSecure           = 207
+	LossCreativeLanguageExclusion   = 208
+	LossCreativeCategoryExclusion   = 209
+	LossCreativeAttributeExclusion  = 210
+	LossCreativeAdTypeExclusion     = 211
+	LossCreativeAnimationTooLong    = 212
+	LossCreativeNotAllowedInDeal    = 213
+)
+
+// Creative Markup Types
+const (
+	MarkupTypeBanner int = iota + 1
+	MarkupTypeVideo
+	MarkupTypeAudio
+	MarkupTypeNative
+)
+
 /*************************************************************************
  * COMMON OBJECT STRUCTS
  *************************************************************************/
@@ -194,15 +341,15 @@ type Producer ThirdParty
 // (such as IP geo lookup), or by user registration information (for example provided to a publisher
 // through a user registration).
 type Geo struct {
-	Lat           float64   `json:"lat,omitempty"`           // Latitude from -90 to 90
-	Lon           float64   `json:"lon,omitempty"`           // Longitude from -180 to 180
-	Type          int       `json:"type,omitempty"`          // Indicate the source of the geo data
-	Accuracy      int       `json:"accuracy,omitempty"`      // Estimated location accuracy in meters; recommended when lat/lon are specified and derived from a device’s location services
-	LastFix       int       `json:"lastfix,omitempty"`       // Number of seconds since this geolocation fix was established.
-	IPService     int       `json:"ipservice,omitempty"`     // Service or provider used to determine geolocation from IP address if applicable
-	Country       string    `json:"country,omitempty"`       // Country using ISO 3166-1 Alpha 3
-	Region        string    `json:"region,omitempty"`        // Region using ISO 3166-2
-	RegionFIPS104 string    `json:"regionFIPS104,omitempty"` // Region of a country using FIPS 10-4
+	Lat           float64   `json:"lat,omitempty"`                            // Latitude from -90 to 90
+	Lon           float64   `json:"lon,omitempty"`                            // Longitude from -180 to 180
+	Type          int       `json:"type,omitempty"`                           // Indicate the source of the geo data
+	Accuracy      int       `json:"accuracy,omitempty"`                       // Estimated location accuracy in meters; recommended when lat/lon are specified and derived from a device’s location services
+	LastFix       int       `json:"lastfix,omitempty"`                        // Number of seconds since this geolocation fix was established.
+	IPService     int       `json:"ipservice,omitempty"`                      // Service or provider used to determine geolocation from IP address if applicable
+	Country       string    `json:"country,omitempty"`                        // Country using ISO 3166-1 Alpha 3
+	Region        string    `json:"region,omitempty"`                         // Region using ISO 3166-2
+	RegionFIPS104 string    `json:"regionFIPS104,omitempty" deprecated:"2.6"` // Region of a country using FIPS 10-4
 	Metro         string    `json:"metro,omitempty"`
 	City          string    `json:"city,omitempty"`
 	Zip           string    `json:"zip,omitempty"`
@@ -222,6 +369,7 @@ type User struct {
 	Gender     string    `json:"gender,omitempty"`     // Gender ("M": male, "F" female, "O" Other)
 	Keywords   string    `json:"keywords,omitempty"`   // Comma separated list of keywords, interests, or intent
 	CustomData string    `json:"customdata,omitempty"` // Optional feature to pass bidder data that was set in the exchange's cookie. The string must be in base85 cookie safe characters and be in any format. Proper JSON encoding must be used to include "escaped" quotation marks.
+	Consent    string    `json:"consent,omitempty"`    // The TCF v2 consent string, required when regs.gdpr is 1.
 	Geo        *Geo      `json:"geo,omitempty"`
 	Data       []Data    `json:"data,omitempty"`
 	Ext        Extension `json:"ext,omitempty"`
@@ -252,14 +400,30 @@ type Segment struct {
 // coppa flag signals whether or not the request falls under the United States Federal Trade Commission's
 // regulations for the United States Children's Online Privacy Protection Act ("COPPA").
 type Regulations struct {
-	Coppa int       `json:"coppa,omitempty"` // Flag indicating if this request is subject to the COPPA regulations established by the USA FTC, where 0 = no, 1 = yes.
-	Ext   Extension `json:"ext,omitempty"`
+	Coppa     int       `json:"coppa,omitempty"`      // Flag indicating if this request is subject to the COPPA regulations established by the USA FTC, where 0 = no, 1 = yes.
+	GDPR      int       `json:"gdpr,omitempty"`       // Flag indicating if this request is subject to the GDPR regulations established by the EU, where 0 = no, 1 = yes.
+	USPrivacy string    `json:"us_privacy,omitempty"` // Communicates signals regarding consumer privacy under US privacy regulation, see the IAB CCPA U.S. Privacy String.
+	GPP       string    `json:"gpp,omitempty"`        // Contains the Global Privacy Platform's consent string.
+	GPPSID    []int     `json:"gpp_sid,omitempty"`    // Array of the section(s) of the GPP string which should be applied for this transaction.
+	Ext       Extension `json:"ext,omitempty"`
 }
 
 // This object represents an allowed size (i.e., height and width combination) for a banner impression.
 // These are typically used in an array for an impression where multiple sizes are permitted.
 type Format struct {
-	W   int       `json:"w,omitempty"` // Width in device independent pixels (DIPS).
-	H   int       `json:"h,omitempty"` //Height in device independent pixels (DIPS).
-	Ext Extension `json:"ext,omitempty"`
+	W      int       `json:"w,omitempty"`      // Width in device independent pixels (DIPS).
+	H      int       `json:"h,omitempty"`      //Height in device independent pixels (DIPS).
+	WRatio int       `json:"wratio,omitempty"` // Relative width when expressing size as a ratio.
+	HRatio int       `json:"hratio,omitempty"` // Relative height when expressing size as a ratio.
+	WMin   int       `json:"wmin,omitempty"`   // The minimum width in device independent pixels (DIPS) at which the ad will be displayed when the size is expressed as a ratio.
+	Ext    Extension `json:"ext,omitempty"`
+}
+
+// Matches returns true if the given size matches the format, either exactly
+// or, for flexible formats, by ratio and minimum width.
+func (f *Format) Matches(w, h int) bool {
+	if f.WRatio > 0 && f.HRatio > 0 {
+		return w*f.HRatio == h*f.WRatio && w >= f.WMin
+	}
+	return f.W == w && f.H == h
 }
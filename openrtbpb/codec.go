@@ -0,0 +1,316 @@
+package openrtbpb
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/bsm/openrtb"
+)
+
+// Errors
+var (
+	ErrMalformed   = errors.New("openrtbpb: malformed message")
+	ErrUnsupported = errors.New("openrtbpb: unsupported type")
+)
+
+var (
+	extensionType = reflect.TypeOf(openrtb.Extension(nil))
+	flagType      = reflect.TypeOf(openrtb.Flag(0))
+)
+
+type codecField struct {
+	num   fieldNumber
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+type codec struct {
+	msg    *message
+	fields []codecField // sorted by number
+	byNum  map[fieldNumber]*codecField
+}
+
+var codecs sync.Map // map[reflect.Type]*codec
+
+func codecFor(t reflect.Type) (*codec, error) {
+	if c, ok := codecs.Load(t); ok {
+		return c.(*codec), nil
+	}
+
+	msg, ok := messages[t]
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	c := &codec{msg: msg, byNum: make(map[fieldNumber]*codecField, len(msg.fields))}
+	for name, num := range msg.fields {
+		sf, ok := t.FieldByName(name)
+		if !ok {
+			return nil, errors.New("openrtbpb: unknown field " + t.Name() + "." + name)
+		}
+		c.fields = append(c.fields, codecField{num: num, name: name, index: sf.Index, typ: sf.Type})
+	}
+	sort.Slice(c.fields, func(i, j int) bool { return c.fields[i].num < c.fields[j].num })
+	for i := range c.fields {
+		c.byNum[c.fields[i].num] = &c.fields[i]
+	}
+
+	codecs.Store(t, c)
+	return c, nil
+}
+
+func encodeMessage(b []byte, v reflect.Value) ([]byte, error) {
+	c, err := codecFor(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range c.fields {
+		if b, err = encodeField(b, f.num, v.FieldByIndex(f.index)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func encodeField(b []byte, num fieldNumber, v reflect.Value) ([]byte, error) {
+	switch t := v.Type(); {
+	case t == flagType:
+		if f := openrtb.Flag(v.Int()); f.IsSet() {
+			b = appendTag(b, num, varintType)
+			b = appendVarint(b, encodeBool(f.IsTrue()))
+		}
+		return b, nil
+	case t == extensionType:
+		if v.Len() == 0 {
+			return b, nil
+		}
+		s := string(v.Bytes())
+		if s[0] == '"' {
+			if err := json.Unmarshal(v.Bytes(), &s); err != nil {
+				return nil, err
+			}
+		}
+		b = appendTag(b, num, bytesType)
+		return appendString(b, s), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() != 0 {
+			b = appendTag(b, num, bytesType)
+			b = appendString(b, v.String())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := v.Int(); n != 0 {
+			b = appendTag(b, num, varintType)
+			b = appendVarint(b, uint64(n))
+		}
+	case reflect.Float64:
+		if f := v.Float(); f != 0 {
+			b = appendTag(b, num, fixed64Type)
+			b = appendFixed64(b, math.Float64bits(f))
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return b, nil
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			return appendSubMessage(b, num, v.Elem())
+		}
+		b = appendTag(b, num, varintType)
+		return appendVarint(b, uint64(v.Elem().Int())), nil
+	case reflect.Slice:
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			if elem.Kind() == reflect.Struct {
+				b, err = appendSubMessage(b, num, elem)
+			} else {
+				b, err = appendRepeated(b, num, elem)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, ErrUnsupported
+	}
+	return b, nil
+}
+
+func appendRepeated(b []byte, num fieldNumber, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.String:
+		b = appendTag(b, num, bytesType)
+		return appendString(b, v.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b = appendTag(b, num, varintType)
+		return appendVarint(b, uint64(v.Int())), nil
+	}
+	return nil, ErrUnsupported
+}
+
+func appendSubMessage(b []byte, num fieldNumber, v reflect.Value) ([]byte, error) {
+	sub, err := encodeMessage(nil, v)
+	if err != nil {
+		return nil, err
+	}
+	b = appendTag(b, num, bytesType)
+	return appendBytes(b, sub), nil
+}
+
+func decodeMessage(b []byte, v reflect.Value) error {
+	c, err := codecFor(v.Type())
+	if err != nil {
+		return err
+	}
+
+	for len(b) != 0 {
+		num, typ, n := readTag(b)
+		if n < 0 {
+			return ErrMalformed
+		}
+		b = b[n:]
+
+		f, ok := c.byNum[num]
+		if !ok {
+			if n = skipField(num, typ, b); n < 0 {
+				return ErrMalformed
+			}
+			b = b[n:]
+			continue
+		}
+
+		if n, err = decodeField(b, typ, v.FieldByIndex(f.index)); err != nil {
+			return errors.New("openrtbpb: " + c.msg.name + "." + f.name + ": " + err.Error())
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func decodeField(b []byte, typ wireType, v reflect.Value) (int, error) {
+	switch t := v.Type(); {
+	case t == flagType:
+		u, n := consumeVarint(b, typ)
+		if n < 0 {
+			return n, errWireType(typ)
+		}
+		v.SetInt(int64(openrtb.NewFlag(decodeBool(u))))
+		return n, nil
+	case t == extensionType:
+		s, n := consumeBytes(b, typ)
+		if n < 0 {
+			return n, errWireType(typ)
+		}
+		data, _ := json.Marshal(string(s))
+		v.SetBytes(data)
+		return n, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			sub, n := consumeBytes(b, typ)
+			if n < 0 {
+				return n, errWireType(typ)
+			}
+			return n, decodeMessage(sub, v.Elem())
+		}
+		return decodeField(b, typ, v.Elem())
+	case reflect.Slice:
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if elem.Kind() == reflect.Struct {
+			sub, n := consumeBytes(b, typ)
+			if n < 0 {
+				return n, errWireType(typ)
+			}
+			if err := decodeMessage(sub, elem); err != nil {
+				return n, err
+			}
+			v.Set(reflect.Append(v, elem))
+			return n, nil
+		}
+		if elem.Kind() != reflect.String && typ == bytesType {
+			return decodePacked(b, v)
+		}
+		n, err := decodeField(b, typ, elem)
+		if err == nil {
+			v.Set(reflect.Append(v, elem))
+		}
+		return n, err
+	case reflect.String:
+		s, n := consumeBytes(b, typ)
+		if n < 0 {
+			return n, errWireType(typ)
+		}
+		v.SetString(string(s))
+		return n, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		u, n := consumeVarint(b, typ)
+		if n < 0 {
+			return n, errWireType(typ)
+		}
+		v.SetInt(int64(u))
+		return n, nil
+	case reflect.Float64:
+		if typ != fixed64Type {
+			return -1, errWireType(typ)
+		}
+		u, n := readFixed64(b)
+		if n < 0 {
+			return n, ErrMalformed
+		}
+		v.SetFloat(math.Float64frombits(u))
+		return n, nil
+	}
+	return -1, ErrUnsupported
+}
+
+// decodePacked decodes a packed repeated varint field.
+func decodePacked(b []byte, v reflect.Value) (int, error) {
+	data, n := readBytes(b)
+	if n < 0 {
+		return n, ErrMalformed
+	}
+	for len(data) != 0 {
+		u, m := readVarint(data)
+		if m < 0 {
+			return m, ErrMalformed
+		}
+		data = data[m:]
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		elem.SetInt(int64(u))
+		v.Set(reflect.Append(v, elem))
+	}
+	return n, nil
+}
+
+func consumeVarint(b []byte, typ wireType) (uint64, int) {
+	if typ != varintType {
+		return 0, -1
+	}
+	return readVarint(b)
+}
+
+func consumeBytes(b []byte, typ wireType) ([]byte, int) {
+	if typ != bytesType {
+		return nil, -1
+	}
+	return readBytes(b)
+}
+
+func errWireType(typ wireType) error {
+	return errors.New("unexpected wire type " + strconv.Itoa(int(typ)))
+}
@@ -0,0 +1,108 @@
+package openrtbpb
+
+import (
+	"reflect"
+
+	"github.com/bsm/openrtb"
+)
+
+// message describes the protobuf encoding of a struct type.
+type message struct {
+	name   string                 // protobuf message name
+	fields map[string]fieldNumber // Go field name to field number
+}
+
+// messages maps struct types to their protobuf messages. Field numbers
+// follow the Google OpenRTB protobuf definitions, see openrtb.proto.
+var messages = map[reflect.Type]*message{
+	reflect.TypeOf(openrtb.BidRequest{}): {name: "BidRequest", fields: map[string]fieldNumber{
+		"ID": 1, "Imp": 2, "Site": 3, "App": 4, "Device": 5, "User": 6, "AuctionType": 7, "TMax": 8,
+		"WSeat": 9, "AllImps": 10, "Cur": 11, "Bcat": 12, "BAdv": 13, "Regs": 14, "Test": 15,
+		"BApp": 16, "Source": 19,
+	}},
+	reflect.TypeOf(openrtb.Source{}): {name: "BidRequest.Source", fields: map[string]fieldNumber{
+		"FD": 1, "TID": 2, "PChain": 3,
+	}},
+	reflect.TypeOf(openrtb.Impression{}): {name: "BidRequest.Imp", fields: map[string]fieldNumber{
+		"ID": 1, "Banner": 2, "Video": 3, "DisplayManager": 4, "DisplayManagerVer": 5, "Instl": 6,
+		"TagID": 7, "BidFloor": 8, "BidFloorCurrency": 9, "IFrameBuster": 10, "Pmp": 11, "Secure": 12,
+		"Native": 13, "Exp": 14, "Audio": 15, "Metric": 17,
+	}},
+	reflect.TypeOf(openrtb.Metric{}): {name: "BidRequest.Imp.Metric", fields: map[string]fieldNumber{
+		"Type": 1, "Value": 2, "Vendor": 3,
+	}},
+	reflect.TypeOf(openrtb.Banner{}): {name: "BidRequest.Imp.Banner", fields: map[string]fieldNumber{
+		"W": 1, "H": 2, "ID": 3, "Pos": 4, "BType": 5, "BAttr": 6, "Mimes": 7, "TopFrame": 8,
+		"ExpDir": 9, "Api": 10, "WMax": 11, "HMax": 12, "WMin": 13, "HMin": 14, "Format": 15,
+	}},
+	reflect.TypeOf(openrtb.Format{}): {name: "BidRequest.Imp.Banner.Format", fields: map[string]fieldNumber{
+		"W": 1, "H": 2, "WRatio": 3, "HRatio": 4, "WMin": 5,
+	}},
+	reflect.TypeOf(openrtb.Video{}): {name: "BidRequest.Imp.Video", fields: map[string]fieldNumber{
+		"Mimes": 1, "Linearity": 2, "MinDuration": 3, "MaxDuration": 4, "Protocol": 5, "W": 6, "H": 7,
+		"StartDelay": 8, "Sequence": 9, "BAttr": 10, "MaxExtended": 11, "MinBitrate": 12,
+		"MaxBitrate": 13, "BoxingAllowed": 14, "PlaybackMethod": 15, "Delivery": 16, "Pos": 17,
+		"CompanionAd": 18, "Api": 19, "CompanionType": 20, "Protocols": 21, "Skip": 23,
+		"SkipMin": 24, "SkipAfter": 25, "Placement": 26,
+	}},
+	reflect.TypeOf(openrtb.Audio{}): {name: "BidRequest.Imp.Audio", fields: map[string]fieldNumber{
+		"Mimes": 1, "MinDuration": 2, "MaxDuration": 3, "Protocols": 4, "StartDelay": 5,
+		"Sequence": 6, "BAttr": 7, "MaxExtended": 8, "MinBitrate": 9, "MaxBitrate": 10,
+		"Delivery": 11, "CompanionAd": 12, "API": 13, "CompanionType": 20, "MaxSequence": 21,
+		"Feed": 22, "Stitched": 23, "NVol": 24,
+	}},
+	reflect.TypeOf(openrtb.Native{}): {name: "BidRequest.Imp.Native", fields: map[string]fieldNumber{
+		"Request": 1, "Ver": 2, "API": 3, "BAttr": 4,
+	}},
+	reflect.TypeOf(openrtb.Pmp{}): {name: "BidRequest.Imp.Pmp", fields: map[string]fieldNumber{
+		"Private": 1, "Deals": 2,
+	}},
+	reflect.TypeOf(openrtb.Deal{}): {name: "BidRequest.Imp.Pmp.Deal", fields: map[string]fieldNumber{
+		"ID": 1, "BidFloor": 2, "BidFloorCurrency": 3, "WSeat": 4, "WAdvDomain": 5, "AuctionType": 6,
+	}},
+	reflect.TypeOf(openrtb.Site{}): {name: "BidRequest.Site", fields: map[string]fieldNumber{
+		"ID": 1, "Name": 2, "Domain": 3, "Cat": 4, "SectionCat": 5, "PageCat": 6, "Page": 7,
+		"PrivacyPolicy": 8, "Ref": 9, "Search": 10, "Publisher": 11, "Keywords": 13, "Mobile": 15,
+	}},
+	reflect.TypeOf(openrtb.App{}): {name: "BidRequest.App", fields: map[string]fieldNumber{
+		"ID": 1, "Name": 2, "Domain": 3, "Cat": 4, "SectionCat": 5, "PageCat": 6, "Ver": 7,
+		"Bundle": 8, "PrivacyPolicy": 9, "Paid": 10, "Publisher": 11, "Keywords": 13, "StoreURL": 16,
+	}},
+	reflect.TypeOf(openrtb.Publisher{}): {name: "BidRequest.Publisher", fields: map[string]fieldNumber{
+		"ID": 1, "Name": 2, "Cat": 3, "Domain": 4,
+	}},
+	reflect.TypeOf(openrtb.Device{}): {name: "BidRequest.Device", fields: map[string]fieldNumber{
+		"DNT": 1, "UA": 2, "IP": 3, "Geo": 4, "IDSHA1": 5, "IDMD5": 6, "PIDSHA1": 7, "PIDMD5": 8,
+		"IPv6": 9, "Carrier": 10, "Language": 11, "Make": 12, "Model": 13, "OS": 14, "OSVer": 15,
+		"JS": 16, "ConnType": 17, "DeviceType": 18, "FlashVer": 19, "IFA": 20, "MacSHA1": 21,
+		"MacMD5": 22, "LMT": 23, "HwVer": 24, "W": 25, "H": 26, "PPI": 27, "PxRatio": 28, "GeoFetch": 29,
+	}},
+	reflect.TypeOf(openrtb.Geo{}): {name: "BidRequest.Geo", fields: map[string]fieldNumber{
+		"Lat": 1, "Lon": 2, "Country": 3, "Region": 4, "RegionFIPS104": 5, "Metro": 6, "City": 7,
+		"Zip": 8, "Type": 9, "UTCOffset": 10, "Accuracy": 11, "LastFix": 12, "IPService": 13,
+	}},
+	reflect.TypeOf(openrtb.User{}): {name: "BidRequest.User", fields: map[string]fieldNumber{
+		"ID": 1, "BuyerUID": 2, "YOB": 3, "Gender": 4, "Keywords": 9, "CustomData": 10, "Geo": 11, "Data": 12,
+	}},
+	reflect.TypeOf(openrtb.Data{}): {name: "BidRequest.Data", fields: map[string]fieldNumber{
+		"ID": 1, "Name": 2, "Segment": 3,
+	}},
+	reflect.TypeOf(openrtb.Segment{}): {name: "BidRequest.Data.Segment", fields: map[string]fieldNumber{
+		"ID": 1, "Name": 2, "Value": 3,
+	}},
+	reflect.TypeOf(openrtb.Regulations{}): {name: "BidRequest.Regs", fields: map[string]fieldNumber{
+		"Coppa": 1,
+	}},
+
+	reflect.TypeOf(openrtb.BidResponse{}): {name: "BidResponse", fields: map[string]fieldNumber{
+		"ID": 1, "SeatBid": 2, "BidID": 3, "Currency": 4, "CustomData": 5, "NBR": 6,
+	}},
+	reflect.TypeOf(openrtb.SeatBid{}): {name: "BidResponse.SeatBid", fields: map[string]fieldNumber{
+		"Bid": 1, "Seat": 2, "Group": 3,
+	}},
+	reflect.TypeOf(openrtb.Bid{}): {name: "BidResponse.SeatBid.Bid", fields: map[string]fieldNumber{
+		"ID": 1, "ImpID": 2, "Price": 3, "AdID": 4, "NURL": 5, "AdMarkup": 6, "AdvDomain": 7,
+		"IURL": 8, "CampaignID": 9, "CreativeID": 10, "Attr": 11, "DealID": 13, "Bundle": 14,
+		"Cat": 15, "W": 16, "H": 17, "API": 18, "Protocol": 19, "QAGMediaRating": 20, "Exp": 21,
+	}},
+}
@@ -0,0 +1,42 @@
+// Package openrtbpb converts bid requests and responses to and from the
+// protobuf wire format of the Google OpenRTB definitions, as used by
+// Authorized Buyers. The corresponding schema is provided in openrtb.proto.
+//
+// The conversion covers the core OpenRTB object model. Extension objects and
+// fields which have no protobuf counterpart are not encoded, unknown protobuf
+// fields are skipped when decoding.
+package openrtbpb
+
+import (
+	"reflect"
+
+	"github.com/bsm/openrtb"
+)
+
+// MarshalBidRequest encodes a bid request in protobuf wire format.
+func MarshalBidRequest(req *openrtb.BidRequest) ([]byte, error) {
+	return encodeMessage(nil, reflect.ValueOf(req).Elem())
+}
+
+// UnmarshalBidRequest decodes a bid request from protobuf wire format.
+func UnmarshalBidRequest(data []byte) (*openrtb.BidRequest, error) {
+	req := new(openrtb.BidRequest)
+	if err := decodeMessage(data, reflect.ValueOf(req).Elem()); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// MarshalBidResponse encodes a bid response in protobuf wire format.
+func MarshalBidResponse(res *openrtb.BidResponse) ([]byte, error) {
+	return encodeMessage(nil, reflect.ValueOf(res).Elem())
+}
+
+// UnmarshalBidResponse decodes a bid response from protobuf wire format.
+func UnmarshalBidResponse(data []byte) (*openrtb.BidResponse, error) {
+	res := new(openrtb.BidResponse)
+	if err := decodeMessage(data, reflect.ValueOf(res).Elem()); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
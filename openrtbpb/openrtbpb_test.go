@@ -0,0 +1,239 @@
+package openrtbpb
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BidRequest", func() {
+
+	It("should round-trip", func() {
+		for _, name := range []string{"breq.banner", "breq.video", "breq.native", "breq.exp"} {
+			var req openrtb.BidRequest
+			Expect(fixture(name, &req)).To(Succeed())
+
+			data, err := MarshalBidRequest(&req)
+			Expect(err).NotTo(HaveOccurred(), name)
+
+			dec, err := UnmarshalBidRequest(data)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(dec.ID).To(Equal(req.ID), name)
+			Expect(dec.Imp).To(HaveLen(len(req.Imp)), name)
+			Expect(dec.Imp[0].BidFloor).To(Equal(req.Imp[0].BidFloor), name)
+
+			again, err := MarshalBidRequest(dec)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(again).To(Equal(data), name)
+		}
+	})
+
+	It("should encode core fields", func() {
+		req := &openrtb.BidRequest{
+			ID:  "req",
+			Imp: []openrtb.Impression{{ID: "1", Banner: &openrtb.Banner{W: 300, H: 250, BAttr: []int{1, 3}}, BidFloor: 0.5}},
+			Site: &openrtb.Site{Inventory: openrtb.Inventory{
+				ID: "site", PrivacyPolicy: new(int), Publisher: &openrtb.Publisher{ID: "pub"},
+			}, Page: "http://example.com"},
+			Device: &openrtb.Device{UA: "Mozilla", DNT: openrtb.FlagFalse, LMT: openrtb.FlagTrue},
+			Cur:    []string{"USD", "EUR"},
+		}
+		data, err := MarshalBidRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		dec, err := UnmarshalBidRequest(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dec).To(Equal(req))
+	})
+
+	It("should encode native requests as strings", func() {
+		req := &openrtb.BidRequest{ID: "req", Imp: []openrtb.Impression{
+			{ID: "1", Native: &openrtb.Native{Request: openrtb.Extension(`"{\"ver\":\"1.2\"}"`)}},
+		}}
+		data, err := MarshalBidRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`{"ver":"1.2"}`))
+
+		dec, err := UnmarshalBidRequest(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dec.Imp[0].Native.Request).To(Equal(openrtb.Extension(`"{\"ver\":\"1.2\"}"`)))
+
+		req.Imp[0].Native.Request = openrtb.Extension(`{"ver":"1.2"}`)
+		again, err := MarshalBidRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(Equal(data))
+	})
+
+	It("should drop extensions", func() {
+		req := &openrtb.BidRequest{ID: "req", Ext: openrtb.Extension(`{"x":1}`)}
+		data, err := MarshalBidRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		dec, err := UnmarshalBidRequest(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dec).To(Equal(&openrtb.BidRequest{ID: "req"}))
+	})
+
+	It("should accept packed and unknown fields", func() {
+		var b []byte
+		b = appendTag(b, 1, bytesType)
+		b = appendString(b, "req")
+		b = appendTag(b, 99, varintType)
+		b = appendVarint(b, 5)
+
+		var banner []byte
+		banner = appendTag(banner, 6, bytesType)
+		banner = appendBytes(banner, []byte{1, 3, 150, 1})
+
+		var imp []byte
+		imp = appendTag(imp, 1, bytesType)
+		imp = appendString(imp, "1")
+		imp = appendTag(imp, 2, bytesType)
+		imp = appendBytes(imp, banner)
+
+		b = appendTag(b, 2, bytesType)
+		b = appendBytes(b, imp)
+
+		req, err := UnmarshalBidRequest(b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("req"))
+		Expect(req.Imp).To(HaveLen(1))
+		Expect(req.Imp[0].Banner.BAttr).To(Equal([]int{1, 3, 150}))
+	})
+
+	It("should reject malformed input", func() {
+		_, err := UnmarshalBidRequest([]byte{0x0a, 0x05, 'a'})
+		Expect(err).To(HaveOccurred())
+
+		_, err = UnmarshalBidRequest([]byte{0x08, 0x01})
+		Expect(err).To(MatchError("openrtbpb: BidRequest.ID: unexpected wire type 0"))
+	})
+
+})
+
+var _ = Describe("BidResponse", func() {
+
+	It("should round-trip", func() {
+		for _, name := range []string{"bres.single", "bres.multi", "bres.pmp", "bres.vast"} {
+			var res openrtb.BidResponse
+			Expect(fixture(name, &res)).To(Succeed())
+
+			data, err := MarshalBidResponse(&res)
+			Expect(err).NotTo(HaveOccurred(), name)
+
+			dec, err := UnmarshalBidResponse(data)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(dec.ID).To(Equal(res.ID), name)
+			Expect(dec.SeatBid).To(HaveLen(len(res.SeatBid)), name)
+			Expect(dec.SeatBid[0].Bid[0].Price).To(Equal(res.SeatBid[0].Bid[0].Price), name)
+			Expect(dec.SeatBid[0].Bid[0].AdMarkup).To(Equal(res.SeatBid[0].Bid[0].AdMarkup), name)
+
+			again, err := MarshalBidResponse(dec)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(again).To(Equal(data), name)
+		}
+	})
+
+	It("should encode core fields", func() {
+		res := &openrtb.BidResponse{
+			ID:       "req",
+			Currency: "USD",
+			SeatBid: []openrtb.SeatBid{{Seat: "seat", Bid: []openrtb.Bid{
+				{ID: "1", ImpID: "1", Price: 1.25, AdvDomain: []string{"example.com"}, Attr: []int{1}, W: 300, H: 250},
+			}}},
+		}
+		data, err := MarshalBidResponse(res)
+		Expect(err).NotTo(HaveOccurred())
+
+		dec, err := UnmarshalBidResponse(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dec).To(Equal(res))
+	})
+
+})
+
+var _ = Describe("openrtb.proto", func() {
+
+	It("should match the field mapping", func() {
+		proto, err := parseProto("openrtb.proto")
+		Expect(err).NotTo(HaveOccurred())
+
+		names := make(map[string]bool, len(messages))
+		for _, msg := range messages {
+			names[msg.name] = true
+			Expect(proto).To(HaveKey(msg.name))
+
+			nums := make(map[fieldNumber]bool, len(msg.fields))
+			for _, num := range msg.fields {
+				nums[num] = true
+			}
+			Expect(proto[msg.name]).To(Equal(nums), msg.name)
+		}
+		Expect(proto).To(HaveLen(len(names)))
+	})
+
+	It("should cover all mapped structs", func() {
+		for t := range messages {
+			_, err := codecFor(t)
+			Expect(err).NotTo(HaveOccurred(), t.String())
+		}
+		_, err := codecFor(reflect.TypeOf(openrtb.Content{}))
+		Expect(err).To(MatchError(ErrUnsupported))
+	})
+
+})
+
+var (
+	protoMessage = regexp.MustCompile(`^message (\w+) \{$`)
+	protoField   = regexp.MustCompile(`^(?:optional|repeated) [\w.]+ \w+ = (\d+);$`)
+)
+
+// parseProto returns the field numbers of all messages in a proto file.
+func parseProto(fname string) (map[string]map[fieldNumber]bool, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	res := make(map[string]map[fieldNumber]bool)
+	var stack []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if m := protoMessage.FindStringSubmatch(line); m != nil {
+			stack = append(stack, m[1])
+			res[strings.Join(stack, ".")] = make(map[fieldNumber]bool)
+		} else if m := protoField.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			res[strings.Join(stack, ".")][fieldNumber(n)] = true
+		} else if line == "}" {
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return res, s.Err()
+}
+
+func fixture(fname string, v interface{}) error {
+	f, err := os.Open(filepath.Join("..", "testdata", fname+".json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/openrtbpb")
+}
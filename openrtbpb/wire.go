@@ -0,0 +1,154 @@
+package openrtbpb
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// fieldNumber is a protobuf field number.
+type fieldNumber int32
+
+// wireType is a protobuf wire type.
+type wireType int8
+
+// Wire types
+const (
+	varintType     wireType = 0
+	fixed64Type    wireType = 1
+	bytesType      wireType = 2
+	startGroupType wireType = 3
+	endGroupType   wireType = 4
+	fixed32Type    wireType = 5
+)
+
+// maxGroupDepth limits the nesting of skipped groups.
+const maxGroupDepth = 100
+
+func appendTag(b []byte, num fieldNumber, typ wireType) []byte {
+	return appendVarint(b, uint64(num)<<3|uint64(typ&7))
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func appendFixed64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendBytes(b []byte, v []byte) []byte {
+	return append(appendVarint(b, uint64(len(v))), v...)
+}
+
+func appendString(b []byte, v string) []byte {
+	return append(appendVarint(b, uint64(len(v))), v...)
+}
+
+func encodeBool(v bool) uint64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func decodeBool(v uint64) bool { return v != 0 }
+
+// readVarint reads a varint from b. It returns the value and the number of
+// bytes consumed, or a negative count if b is malformed.
+func readVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(b) && i < binary.MaxVarintLen64; i++ {
+		c := b[i]
+		if i == binary.MaxVarintLen64-1 && c > 1 {
+			return 0, -1
+		}
+		v |= uint64(c&0x7f) << (7 * uint(i))
+		if c < 0x80 {
+			return v, i + 1
+		}
+	}
+	return 0, -1
+}
+
+func readTag(b []byte) (fieldNumber, wireType, int) {
+	v, n := readVarint(b)
+	if n < 0 {
+		return 0, 0, n
+	}
+	num := v >> 3
+	if num == 0 || num > math.MaxInt32 {
+		return 0, 0, -1
+	}
+	return fieldNumber(num), wireType(v & 7), n
+}
+
+func readFixed64(b []byte) (uint64, int) {
+	if len(b) < 8 {
+		return 0, -1
+	}
+	return binary.LittleEndian.Uint64(b), 8
+}
+
+func readBytes(b []byte) ([]byte, int) {
+	v, n := readVarint(b)
+	if n < 0 {
+		return nil, n
+	}
+	if v > uint64(len(b)-n) {
+		return nil, -1
+	}
+	return b[n : n+int(v)], n + int(v)
+}
+
+// skipField returns the length of the value of a field of type typ, which
+// is not decoded.
+func skipField(num fieldNumber, typ wireType, b []byte) int {
+	return skipFieldDepth(num, typ, b, maxGroupDepth)
+}
+
+func skipFieldDepth(num fieldNumber, typ wireType, b []byte, depth int) int {
+	switch typ {
+	case varintType:
+		_, n := readVarint(b)
+		return n
+	case fixed64Type:
+		_, n := readFixed64(b)
+		return n
+	case fixed32Type:
+		if len(b) < 4 {
+			return -1
+		}
+		return 4
+	case bytesType:
+		_, n := readBytes(b)
+		return n
+	case startGroupType:
+		if depth == 0 {
+			return -1
+		}
+		for n := 0; n < len(b); {
+			gnum, gtyp, m := readTag(b[n:])
+			if m < 0 {
+				return -1
+			}
+			n += m
+			if gtyp == endGroupType {
+				if gnum != num {
+					return -1
+				}
+				return n
+			}
+			if m = skipFieldDepth(gnum, gtyp, b[n:], depth-1); m < 0 {
+				return -1
+			}
+			n += m
+		}
+	}
+	return -1
+}
@@ -0,0 +1,57 @@
+package openrtbpb
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("wire", func() {
+
+	It("should round-trip varints", func() {
+		for _, v := range []uint64{0, 1, 127, 128, 300, math.MaxUint32, math.MaxUint64} {
+			b := appendVarint(nil, v)
+			u, n := readVarint(b)
+			Expect(n).To(Equal(len(b)))
+			Expect(u).To(Equal(v))
+		}
+		Expect(appendVarint(nil, 300)).To(Equal([]byte{0xac, 0x02}))
+	})
+
+	It("should reject malformed input", func() {
+		_, n := readVarint([]byte{0x80, 0x80})
+		Expect(n).To(BeNumerically("<", 0))
+		_, n = readVarint([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02})
+		Expect(n).To(BeNumerically("<", 0))
+		_, n = readBytes([]byte{5, 'a'})
+		Expect(n).To(BeNumerically("<", 0))
+		_, n = readFixed64([]byte{1, 2, 3})
+		Expect(n).To(BeNumerically("<", 0))
+		_, _, n = readTag([]byte{0x02})
+		Expect(n).To(BeNumerically("<", 0))
+	})
+
+	It("should encode tags", func() {
+		b := appendTag(nil, 2, bytesType)
+		Expect(b).To(Equal([]byte{0x12}))
+
+		num, typ, n := readTag(b)
+		Expect(num).To(Equal(fieldNumber(2)))
+		Expect(typ).To(Equal(bytesType))
+		Expect(n).To(Equal(1))
+	})
+
+	It("should skip fields", func() {
+		Expect(skipField(1, varintType, []byte{0xac, 0x02, 9})).To(Equal(2))
+		Expect(skipField(1, fixed32Type, []byte{1, 2, 3, 4, 5})).To(Equal(4))
+		Expect(skipField(1, bytesType, appendString(nil, "abc"))).To(Equal(4))
+
+		group := appendTag(nil, 2, varintType)
+		group = appendVarint(group, 5)
+		group = appendTag(group, 1, endGroupType)
+		Expect(skipField(1, startGroupType, group)).To(Equal(len(group)))
+		Expect(skipField(3, startGroupType, group)).To(BeNumerically("<", 0))
+		Expect(skipField(1, wireType(7), nil)).To(BeNumerically("<", 0))
+	})
+})
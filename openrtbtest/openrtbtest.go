@@ -0,0 +1,183 @@
+/*
+Package openrtbtest contains testing helpers for OpenRTB objects and
+custom extension types.
+*/
+package openrtbtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TB is the subset of testing.TB used by the assertions.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// AssertRoundTrip fails the test if v does not survive a JSON round-trip,
+// see RoundTrip.
+func AssertRoundTrip(t TB, v interface{}) {
+	t.Helper()
+	if err := RoundTrip(v); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+// RoundTrip verifies that v survives a marshal, unmarshal, marshal cycle.
+// It returns an error if:
+//
+//   - the two encodings differ,
+//   - the decoded value differs from v, e.g. when populated fields are
+//     dropped by the encoding; nil and empty slices and maps are considered
+//     equal, as omitempty does not distinguish them,
+//   - omitempty is used on struct fields, where it has no effect.
+func RoundTrip(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Errorf("openrtbtest: cannot round-trip nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+
+	if err := checkOmitEmpty(rv.Type(), rv.Type().String(), make(map[reflect.Type]bool)); err != nil {
+		return err
+	}
+
+	first, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return fmt.Errorf("openrtbtest: marshal failed: %w", err)
+	}
+
+	decoded := reflect.New(rv.Type())
+	if err := json.Unmarshal(first, decoded.Interface()); err != nil {
+		return fmt.Errorf("openrtbtest: unmarshal failed: %w", err)
+	}
+
+	second, err := json.Marshal(decoded.Interface())
+	if err != nil {
+		return fmt.Errorf("openrtbtest: re-marshal failed: %w", err)
+	}
+	if !bytes.Equal(first, second) {
+		return fmt.Errorf("openrtbtest: encoding is not stable:\n\tfirst:  %s\n\tsecond: %s", first, second)
+	}
+
+	if path := diff(rv, decoded.Elem(), rv.Type().String()); path != "" {
+		return fmt.Errorf("openrtbtest: %s changed after round-trip", path)
+	}
+	return nil
+}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// diff returns the path of the first difference between a and b.
+func diff(a, b reflect.Value, path string) string {
+	if a.Type().Implements(marshalerType) || reflect.PtrTo(a.Type()).Implements(marshalerType) {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) && !sameEncoding(a, b) {
+			return path
+		}
+		return ""
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return path
+			}
+			return ""
+		}
+		return diff(a.Elem(), b.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if p := diff(a.Field(i), b.Field(i), path+"."+field.Name); p != "" {
+				return p
+			}
+		}
+		return ""
+	case reflect.Slice, reflect.Map:
+		if a.Len() != b.Len() {
+			return path
+		}
+		if a.Kind() == reflect.Map {
+			for _, key := range a.MapKeys() {
+				bv := b.MapIndex(key)
+				if !bv.IsValid() {
+					return path + "[" + fmt.Sprint(key.Interface()) + "]"
+				}
+				if p := diff(a.MapIndex(key), bv, path+"["+fmt.Sprint(key.Interface())+"]"); p != "" {
+					return p
+				}
+			}
+			return ""
+		}
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if p := diff(a.Index(i), b.Index(i), path+"["+strconv.Itoa(i)+"]"); p != "" {
+				return p
+			}
+		}
+		return ""
+	}
+
+	if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return path
+	}
+	return ""
+}
+
+func sameEncoding(a, b reflect.Value) bool {
+	ea, erra := json.Marshal(a.Interface())
+	eb, errb := json.Marshal(b.Interface())
+	return erra == nil && errb == nil && bytes.Equal(ea, eb)
+}
+
+func checkOmitEmpty(t reflect.Type, path string, seen map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct && !field.Anonymous && hasOption(tag, "omitempty") &&
+			!field.Type.Implements(marshalerType) && !reflect.PtrTo(field.Type).Implements(marshalerType) {
+			return fmt.Errorf("openrtbtest: omitempty has no effect on struct field %s.%s", path, field.Name)
+		}
+		if err := checkOmitEmpty(field.Type, field.Type.String(), seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasOption(tag, opt string) bool {
+	parts := strings.Split(tag, ",")
+	for _, p := range parts[1:] {
+		if p == opt {
+			return true
+		}
+	}
+	return false
+}
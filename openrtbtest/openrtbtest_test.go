@@ -0,0 +1,112 @@
+package openrtbtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RoundTrip", func() {
+
+	It("should accept library objects", func() {
+		for _, name := range []string{"breq.banner", "breq.video", "breq.native", "breq.exp"} {
+			var req openrtb.BidRequest
+			Expect(fixture(name, &req)).To(Succeed())
+			Expect(RoundTrip(&req)).To(Succeed(), name)
+		}
+		for _, name := range []string{"bres.single", "bres.multi", "bres.pmp", "bres.vast"} {
+			var res openrtb.BidResponse
+			Expect(fixture(name, &res)).To(Succeed())
+			Expect(RoundTrip(res)).To(Succeed(), name)
+		}
+	})
+
+	It("should reject nil", func() {
+		Expect(RoundTrip((*openrtb.Bid)(nil))).To(MatchError("openrtbtest: cannot round-trip nil *openrtb.Bid"))
+	})
+
+	It("should detect dropped fields", func() {
+		type ext struct {
+			A string `json:"a"`
+			B string `json:"-"`
+		}
+		Expect(RoundTrip(&ext{A: "x"})).To(Succeed())
+		Expect(RoundTrip(&ext{A: "x", B: "y"})).To(MatchError("openrtbtest: openrtbtest.ext.B changed after round-trip"))
+	})
+
+	It("should treat nil and empty collections alike", func() {
+		type ext struct {
+			List []string       `json:"list,omitempty"`
+			Map  map[string]int `json:"map,omitempty"`
+		}
+		Expect(RoundTrip(&ext{List: []string{}, Map: map[string]int{}})).To(Succeed())
+		Expect(RoundTrip(&ext{List: []string{"a", "b"}, Map: map[string]int{"a": 1}})).To(Succeed())
+	})
+
+	It("should detect ineffective omitempty", func() {
+		type inner struct {
+			N int `json:"n,omitempty"`
+		}
+		type ext struct {
+			Inner inner `json:"inner,omitempty"`
+		}
+		Expect(RoundTrip(&ext{})).To(MatchError("openrtbtest: omitempty has no effect on struct field openrtbtest.ext.Inner"))
+	})
+
+	It("should detect unstable encodings", func() {
+		Expect(RoundTrip(&unstable{N: 1})).To(MatchError(ContainSubstring("encoding is not stable")))
+	})
+
+	It("should fail tests", func() {
+		t := &mockTB{}
+		AssertRoundTrip(t, &openrtb.Bid{ID: "1", ImpID: "1", Price: 1.5})
+		Expect(t.failures).To(BeEmpty())
+
+		AssertRoundTrip(t, (*openrtb.Bid)(nil))
+		Expect(t.failures).To(ConsistOf("openrtbtest: cannot round-trip nil *openrtb.Bid"))
+	})
+
+})
+
+// unstable increments N on each decode.
+type unstable struct {
+	N int `json:"n"`
+}
+
+func (u *unstable) UnmarshalJSON(data []byte) error {
+	var v struct{ N int }
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	u.N = v.N + 1
+	return nil
+}
+
+type mockTB struct {
+	failures []string
+}
+
+func (*mockTB) Helper() {}
+func (t *mockTB) Fatalf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func fixture(fname string, v interface{}) error {
+	f, err := os.Open(filepath.Join("..", "testdata", fname+".json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/openrtbtest")
+}
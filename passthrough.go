@@ -0,0 +1,140 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Aggregation errors
+var (
+	ErrAggregateCurrencyMismatch = errors.New("openrtb: responses have different currencies")
+)
+
+// ExtPolicyAllowAll may be used as an allowed key to pass through all ext blocks.
+const ExtPolicyAllowAll = "*"
+
+// ExtPolicy controls which ext blocks of demand partner responses are passed
+// through to publishers. Blocks which are not explicitly allowed are stripped.
+// A nil policy passes through everything.
+type ExtPolicy struct {
+	Default  []string            // Keys allowed for all partners
+	Partners map[string][]string // Additional keys allowed per partner
+}
+
+// Allowed returns true if the ext key may be passed through for partner.
+func (p *ExtPolicy) Allowed(partner, key string) bool {
+	if p == nil {
+		return true
+	}
+	for _, keys := range [][]string{p.Default, p.Partners[partner]} {
+		for _, k := range keys {
+			if k == key || k == ExtPolicyAllowAll {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Filter returns a copy of ext with all blocks not allowed for partner
+// stripped. It returns nil if no blocks remain.
+func (p *ExtPolicy) Filter(partner string, ext Extension) (Extension, error) {
+	if p == nil || len(ext) == 0 {
+		return ext, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(ext, &obj); err != nil {
+		return nil, err
+	}
+	for key := range obj {
+		if !p.Allowed(partner, key) {
+			delete(obj, key)
+		}
+	}
+	if len(obj) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(obj)
+}
+
+// FilterResponse applies the policy to the response, seatbid and bid
+// extensions of a partner response, in place.
+func (p *ExtPolicy) FilterResponse(partner string, res *BidResponse) error {
+	if p == nil {
+		return nil
+	}
+
+	ext, err := p.Filter(partner, res.Ext)
+	if err != nil {
+		return err
+	}
+	res.Ext = ext
+
+	for i := range res.SeatBid {
+		sb := &res.SeatBid[i]
+		if sb.Ext, err = p.Filter(partner, sb.Ext); err != nil {
+			return err
+		}
+		for j := range sb.Bid {
+			bid := &sb.Bid[j]
+			if bid.Ext, err = p.Filter(partner, bid.Ext); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AggregateResponses merges demand partner responses, keyed by partner name,
+// into a single response for the publisher, enforcing the ext passthrough
+// policy. Partner responses are filtered in place. Seatbids without a seat
+// are assigned to their partner and the remaining response-level ext blocks
+// are namespaced by partner. All responses must share the same currency.
+func AggregateResponses(id string, responses map[string]*BidResponse, policy *ExtPolicy) (*BidResponse, error) {
+	partners := make([]string, 0, len(responses))
+	for partner := range responses {
+		partners = append(partners, partner)
+	}
+	sort.Strings(partners)
+
+	agg := &BidResponse{ID: id, SeatBid: []SeatBid{}}
+	for _, partner := range partners {
+		res := responses[partner]
+		if res == nil || len(res.SeatBid) == 0 {
+			continue
+		}
+
+		cur := strings.ToUpper(res.Currency)
+		if cur == "" {
+			cur = DefaultCurrency
+		}
+		if agg.Currency == "" {
+			agg.Currency = cur
+		} else if agg.Currency != cur {
+			return nil, ErrAggregateCurrencyMismatch
+		}
+
+		if err := policy.FilterResponse(partner, res); err != nil {
+			return nil, err
+		}
+
+		for _, sb := range res.SeatBid {
+			if sb.Seat == "" {
+				sb.Seat = partner
+			}
+			agg.SeatBid = append(agg.SeatBid, sb)
+		}
+
+		if len(res.Ext) != 0 {
+			ext, err := agg.Ext.setKey(partner, res.Ext)
+			if err != nil {
+				return nil, err
+			}
+			agg.Ext = ext
+		}
+	}
+	return agg, nil
+}
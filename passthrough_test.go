@@ -0,0 +1,85 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExtPolicy", func() {
+	var subject *ExtPolicy
+
+	BeforeEach(func() {
+		subject = &ExtPolicy{
+			Default: []string{"dsa"},
+			Partners: map[string][]string{
+				"alpha": {"signaling"},
+				"beta":  {ExtPolicyAllowAll},
+			},
+		}
+	})
+
+	It("should check allowed keys", func() {
+		Expect(subject.Allowed("alpha", "dsa")).To(BeTrue())
+		Expect(subject.Allowed("alpha", "signaling")).To(BeTrue())
+		Expect(subject.Allowed("alpha", "debug")).To(BeFalse())
+		Expect(subject.Allowed("beta", "debug")).To(BeTrue())
+		Expect(subject.Allowed("gamma", "signaling")).To(BeFalse())
+		Expect((*ExtPolicy)(nil).Allowed("gamma", "debug")).To(BeTrue())
+	})
+
+	It("should filter extensions", func() {
+		ext, err := subject.Filter("alpha", Extension(`{"dsa":1,"signaling":{"a":true},"debug":"x"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(ext)).To(MatchJSON(`{"dsa":1,"signaling":{"a":true}}`))
+
+		ext, err = subject.Filter("gamma", Extension(`{"debug":"x"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ext).To(BeNil())
+
+		_, err = subject.Filter("gamma", Extension(`[]`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should filter responses", func() {
+		res := &BidResponse{
+			ID:  "R",
+			Ext: Extension(`{"debug":1}`),
+			SeatBid: []SeatBid{{
+				Ext: Extension(`{"dsa":1,"debug":1}`),
+				Bid: []Bid{{ID: "A", ImpID: "1", Ext: Extension(`{"signaling":1,"debug":1}`)}},
+			}},
+		}
+		Expect(subject.FilterResponse("alpha", res)).To(Succeed())
+		Expect(res.Ext).To(BeNil())
+		Expect(string(res.SeatBid[0].Ext)).To(MatchJSON(`{"dsa":1}`))
+		Expect(string(res.SeatBid[0].Bid[0].Ext)).To(MatchJSON(`{"signaling":1}`))
+	})
+
+	It("should aggregate responses", func() {
+		res, err := AggregateResponses("R", map[string]*BidResponse{
+			"alpha": {ID: "X", Ext: Extension(`{"dsa":1,"debug":1}`), SeatBid: []SeatBid{
+				{Bid: []Bid{{ID: "A", ImpID: "1", Price: 1, Ext: Extension(`{"debug":1}`)}}},
+			}},
+			"beta": {ID: "Y", Currency: "usd", SeatBid: []SeatBid{
+				{Seat: "b1", Bid: []Bid{{ID: "B", ImpID: "1", Price: 2, Ext: Extension(`{"debug":1}`)}}},
+			}},
+			"gamma": {ID: "Z", NBR: NBRUnmatchedUser},
+			"delta": nil,
+		}, subject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.ID).To(Equal("R"))
+		Expect(res.Currency).To(Equal("USD"))
+		Expect(string(res.Ext)).To(MatchJSON(`{"alpha":{"dsa":1}}`))
+		Expect(res.SeatBid).To(Equal([]SeatBid{
+			{Seat: "alpha", Bid: []Bid{{ID: "A", ImpID: "1", Price: 1}}},
+			{Seat: "b1", Bid: []Bid{{ID: "B", ImpID: "1", Price: 2, Ext: Extension(`{"debug":1}`)}}},
+		}))
+
+		_, err = AggregateResponses("R", map[string]*BidResponse{
+			"alpha": {ID: "X", SeatBid: []SeatBid{{Bid: []Bid{{ID: "A", ImpID: "1"}}}}},
+			"beta":  {ID: "Y", Currency: "EUR", SeatBid: []SeatBid{{Bid: []Bid{{ID: "B", ImpID: "1"}}}}},
+		}, nil)
+		Expect(err).To(Equal(ErrAggregateCurrencyMismatch))
+	})
+
+})
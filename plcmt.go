@@ -0,0 +1,55 @@
+package openrtb
+
+// PlcmtFromPlacement maps a deprecated video placement type to the closest
+// plcmt subtype: in-stream to instream, interstitial, slider and floating
+// to interstitial and all other out-stream placements to no content. It
+// returns 0 for unknown values.
+func PlcmtFromPlacement(placement int) int {
+	switch placement {
+	case VideoPlacementInStream:
+		return VideoPlcmtInstream
+	case VideoPlacementInBanner, VideoPlacementInArticle, VideoPlacementInFeed:
+		return VideoPlcmtNoContent
+	case VideoPlacementInterstitial:
+		return VideoPlcmtInterstitial
+	}
+	return 0
+}
+
+// PlacementFromPlcmt maps a plcmt subtype to the closest deprecated video
+// placement type, for partners which have not migrated yet. Accompanying
+// content and interstitial map to VideoPlacementInterstitial, which also
+// covers sliders and floating players, no content maps to
+// VideoPlacementInArticle. It returns 0 for unknown values.
+func PlacementFromPlcmt(plcmt int) int {
+	switch plcmt {
+	case VideoPlcmtInstream:
+		return VideoPlacementInStream
+	case VideoPlcmtAccompanyingContent, VideoPlcmtInterstitial:
+		return VideoPlacementInterstitial
+	case VideoPlcmtNoContent:
+		return VideoPlacementInArticle
+	}
+	return 0
+}
+
+// EffectivePlcmt returns the plcmt subtype of the video or, if unset, the
+// subtype derived from the deprecated placement, see PlcmtFromPlacement.
+func (v *Video) EffectivePlcmt() int {
+	if v.Plcmt != 0 {
+		return v.Plcmt
+	}
+	return PlcmtFromPlacement(v.Placement)
+}
+
+// SyncPlacement fills whichever of placement and plcmt is unset from the
+// other, so the video can be sent to partners on either side of the
+// migration. Declared values are never overwritten.
+func (v *Video) SyncPlacement() {
+	if v.Plcmt == 0 {
+		v.Plcmt = PlcmtFromPlacement(v.Placement)
+	}
+	if v.Placement == 0 {
+		v.Placement = PlacementFromPlcmt(v.Plcmt)
+	}
+}
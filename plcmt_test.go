@@ -0,0 +1,45 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plcmt", func() {
+
+	It("should map placements", func() {
+		Expect(PlcmtFromPlacement(VideoPlacementInStream)).To(Equal(VideoPlcmtInstream))
+		Expect(PlcmtFromPlacement(VideoPlacementInBanner)).To(Equal(VideoPlcmtNoContent))
+		Expect(PlcmtFromPlacement(VideoPlacementInFeed)).To(Equal(VideoPlcmtNoContent))
+		Expect(PlcmtFromPlacement(VideoPlacementInterstitial)).To(Equal(VideoPlcmtInterstitial))
+		Expect(PlcmtFromPlacement(0)).To(Equal(0))
+		Expect(PlcmtFromPlacement(9)).To(Equal(0))
+
+		Expect(PlacementFromPlcmt(VideoPlcmtInstream)).To(Equal(VideoPlacementInStream))
+		Expect(PlacementFromPlcmt(VideoPlcmtAccompanyingContent)).To(Equal(VideoPlacementInterstitial))
+		Expect(PlacementFromPlcmt(VideoPlcmtInterstitial)).To(Equal(VideoPlacementInterstitial))
+		Expect(PlacementFromPlcmt(VideoPlcmtNoContent)).To(Equal(VideoPlacementInArticle))
+		Expect(PlacementFromPlcmt(0)).To(Equal(0))
+	})
+
+	It("should derive plcmt", func() {
+		Expect((&Video{Placement: VideoPlacementInFeed}).EffectivePlcmt()).To(Equal(VideoPlcmtNoContent))
+		Expect((&Video{Placement: VideoPlacementInFeed, Plcmt: VideoPlcmtAccompanyingContent}).EffectivePlcmt()).To(Equal(VideoPlcmtAccompanyingContent))
+		Expect((&Video{}).EffectivePlcmt()).To(Equal(0))
+	})
+
+	It("should sync placements", func() {
+		subject := &Video{Placement: VideoPlacementInStream}
+		subject.SyncPlacement()
+		Expect(subject.Plcmt).To(Equal(VideoPlcmtInstream))
+
+		subject = &Video{Plcmt: VideoPlcmtNoContent}
+		subject.SyncPlacement()
+		Expect(subject.Placement).To(Equal(VideoPlacementInArticle))
+
+		subject = &Video{Placement: VideoPlacementInFeed, Plcmt: VideoPlcmtInterstitial}
+		subject.SyncPlacement()
+		Expect(subject).To(Equal(&Video{Placement: VideoPlacementInFeed, Plcmt: VideoPlcmtInterstitial}))
+	})
+
+})
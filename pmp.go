@@ -1,7 +1,5 @@
 package openrtb
 
-import "encoding/json"
-
 // Private Marketplace Object
 type Pmp struct {
 	Private int       `json:"private_auction,omitempty"`
@@ -16,35 +14,94 @@ type Deal struct {
 	BidFloorCurrency string    `json:"bidfloorcur,omitempty"` // Currency of bid floor
 	WSeat            []string  `json:"wseat,omitempty"`       // Array of buyer seats allowed to bid on this Direct Deal.
 	WAdvDomain       []string  `json:"wadomain,omitempty"`    // Array of advertiser domains allowed to bid on this Direct Deal
-	AuctionType      int       `json:"at,omitempty"`          // Optional override of the overall auction type of the bid request, where 1 = First Price, 2 = Second Price Plus, 3 = the value passed in bidfloor is the agreed upon deal price. Additional auction types can be defined by the exchange.
+	AuctionType      int       `json:"at,omitempty"`          // Optional override of the overall auction type of the bid request, where 1 = First Price, 2 = Second Price Plus, 3 = the value passed in bidfloor is the agreed upon deal price. Additional auction types can be defined by the exchange. Default: the auction type of the request.
 	Ext              Extension `json:"ext,omitempty"`
 
-	Seats []string `json:"seats,omitempty"` // DEPRECATED: kept for backwards compatibility
-	Type  int      `json:"type,omitempty"`  // DEPRECATED: kept for backwards compatibility
+	Seats []string `json:"seats,omitempty" deprecated:"2.2,wseat"` // DEPRECATED: kept for backwards compatibility
+	Type  int      `json:"type,omitempty" deprecated:"2.2"`        // DEPRECATED: kept for backwards compatibility
 }
 
-type jsonDeal Deal
+// IsPrivate returns true if the impression is restricted to the deals.
+func (p *Pmp) IsPrivate() bool { return p.Private == 1 }
 
-// MarshalJSON custom marshalling with normalization
-func (d *Deal) MarshalJSON() ([]byte, error) {
-	d.normalize()
-	return json.Marshal((*jsonDeal)(d))
+// DealByID returns the deal with the given ID or nil if not found.
+func (p *Pmp) DealByID(id string) *Deal {
+	for i := range p.Deals {
+		if p.Deals[i].ID == id {
+			return &p.Deals[i]
+		}
+	}
+	return nil
 }
 
-// UnmarshalJSON custom unmarshalling with normalization
-func (d *Deal) UnmarshalJSON(data []byte) error {
-	var h jsonDeal
-	if err := json.Unmarshal(data, &h); err != nil {
-		return err
+// AllowsSeat returns true if seat is permitted to bid on the deal. Deals
+// without a wseat list (or deprecated seats) allow all seats.
+func (d *Deal) AllowsSeat(seat string) bool {
+	seats := d.WSeat
+	if len(seats) == 0 {
+		seats = d.Seats
+	}
+	if len(seats) == 0 {
+		return true
+	}
+	for _, s := range seats {
+		if s == seat {
+			return true
+		}
 	}
+	return false
+}
 
-	*d = (Deal)(h)
-	d.normalize()
-	return nil
+// AllowsAdvDomains returns true if all advertiser domains are permitted to
+// bid on the deal, including their sub-domains. Deals without a wadomain
+// list allow all domains.
+func (d *Deal) AllowsAdvDomains(domains []string) bool {
+	if len(d.WAdvDomain) == 0 {
+		return true
+	}
+	if len(domains) == 0 {
+		return false
+	}
+
+	for _, domain := range domains {
+		allowed := false
+		for _, w := range d.WAdvDomain {
+			if MatchDomain(domain, w) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
 }
 
-func (d *Deal) normalize() {
-	if d.AuctionType == 0 {
-		d.AuctionType = 2
+// CheckDeal checks a bid from seat against the private marketplace of the
+// impression. Bids must reference a deal of the impression if they carry a
+// deal ID or if the impression is a private auction, and seat must be
+// allowed to bid on the referenced deal. It returns nil if the bid is
+// compliant.
+func (imp *Impression) CheckDeal(bid *Bid, seat string) *Rejection {
+	var pmp Pmp
+	if imp.Pmp != nil {
+		pmp = *imp.Pmp
+	}
+
+	if bid.DealID == "" {
+		if pmp.IsPrivate() {
+			return &Rejection{Code: LossInvalidDealID, Field: "imp.pmp.private_auction", Value: "", Reason: "private auction requires a deal"}
+		}
+		return nil
 	}
+
+	deal := pmp.DealByID(bid.DealID)
+	if deal == nil {
+		return &Rejection{Code: LossInvalidDealID, Field: "imp.pmp.deals", Value: bid.DealID, Reason: "unknown deal " + bid.DealID}
+	}
+	if !deal.AllowsSeat(seat) {
+		return &Rejection{Code: LossSeatBlocked, Field: "imp.pmp.deals.wseat", Value: seat, Reason: "seat not allowed in deal " + deal.ID}
+	}
+	return nil
 }
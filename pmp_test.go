@@ -20,7 +20,7 @@ var _ = Describe("Pmp", func() {
 			Private: 1,
 			Deals: []Deal{
 				{ID: "DX-1985-010A", BidFloor: 2.5, BidFloorCurrency: "", AuctionType: 2},
-				{ID: "DX-1986-010A", BidFloor: 2.6, BidFloorCurrency: "", AuctionType: 2},
+				{ID: "DX-1986-010A", BidFloor: 2.6, BidFloorCurrency: ""},
 			},
 		}))
 	})
@@ -28,7 +28,69 @@ var _ = Describe("Pmp", func() {
 	It("should generate correctly", func() {
 		bin, err := json.Marshal(&Pmp{Deals: []Deal{{}}})
 		Expect(err).NotTo(HaveOccurred())
-		Expect(string(bin)).To(Equal(`{"deals":[{"at":2}]}`))
+		Expect(string(bin)).To(Equal(`{"deals":[{}]}`))
+	})
+
+})
+
+var _ = Describe("Impression", func() {
+	var subject *Impression
+
+	BeforeEach(func() {
+		subject = &Impression{ID: "1", Banner: &Banner{}, Pmp: &Pmp{
+			Private: 1,
+			Deals: []Deal{
+				{ID: "D1", WSeat: []string{"s1", "s2"}},
+				{ID: "D2"},
+			},
+		}}
+	})
+
+	It("should accept bids on deals", func() {
+		Expect(subject.CheckDeal(&Bid{DealID: "D1"}, "s2")).To(BeNil())
+		Expect(subject.CheckDeal(&Bid{DealID: "D2"}, "any")).To(BeNil())
+		Expect((&Impression{}).CheckDeal(&Bid{}, "any")).To(BeNil())
+
+		subject.Pmp.Private = 0
+		Expect(subject.CheckDeal(&Bid{}, "any")).To(BeNil())
+	})
+
+	It("should reject bids without deals in private auctions", func() {
+		Expect(subject.CheckDeal(&Bid{}, "s1")).To(Equal(&Rejection{
+			Code:   LossInvalidDealID,
+			Field:  "imp.pmp.private_auction",
+			Reason: "private auction requires a deal",
+		}))
+	})
+
+	It("should reject unknown deals", func() {
+		rej := subject.CheckDeal(&Bid{DealID: "D3"}, "s1")
+		Expect(rej).NotTo(BeNil())
+		Expect(rej.Code).To(Equal(LossInvalidDealID))
+		Expect(rej.Error()).To(Equal("openrtb: bid rejected: unknown deal D3 (imp.pmp.deals: D3)"))
+		Expect((&Impression{}).CheckDeal(&Bid{DealID: "D1"}, "s1")).NotTo(BeNil())
+	})
+
+	It("should reject seats not allowed in deals", func() {
+		Expect(subject.CheckDeal(&Bid{DealID: "D1"}, "s3")).To(Equal(&Rejection{
+			Code:   LossSeatBlocked,
+			Field:  "imp.pmp.deals.wseat",
+			Value:  "s3",
+			Reason: "seat not allowed in deal D1",
+		}))
+
+		subject.Pmp.Deals[0] = Deal{ID: "D1", Seats: []string{"s3"}}
+		Expect(subject.CheckDeal(&Bid{DealID: "D1"}, "s3")).To(BeNil())
+	})
+
+	It("should check advertiser domains", func() {
+		deal := &Deal{ID: "D1"}
+		Expect(deal.AllowsAdvDomains(nil)).To(BeTrue())
+
+		deal.WAdvDomain = []string{"example.com"}
+		Expect(deal.AllowsAdvDomains([]string{"shop.example.com"})).To(BeTrue())
+		Expect(deal.AllowsAdvDomains([]string{"example.com", "other.com"})).To(BeFalse())
+		Expect(deal.AllowsAdvDomains(nil)).To(BeFalse())
 	})
 
 })
@@ -0,0 +1,80 @@
+package openrtb
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Pod errors
+var (
+	ErrInvalidPodNoID           = errors.New("openrtb: pod ID missing")
+	ErrInvalidPodNoDuration     = errors.New("openrtb: pod duration missing")
+	ErrInvalidPodNoSlotDuration = errors.New("openrtb: pod slot duration missing")
+	ErrInvalidPodNoTemplate     = errors.New("openrtb: pod requires exactly one of video or audio template")
+)
+
+// PodBuilder slices an ad pod into impressions, one per slot.
+type PodBuilder struct {
+	PodID        string  // Pod ID, also used as impression ID prefix
+	PodSequence  int     // Sequence of the pod within the content stream
+	Duration     int     // Total pod duration in seconds
+	SlotDuration int     // Maximum duration of each slot in seconds
+	MinCPMPerSec float64 // Optional minimum CPM per second
+	BidFloor     float64 // Optional bid floor for each impression
+	BidFloorCur  string  // Currency of the bid floor
+	SSAI         int     // Optional server-side ad insertion mode, see SSAI* constants
+
+	// Exactly one template must be set, it is copied into each impression.
+	// Slices are shared between the copies.
+	Video *Video
+	Audio *Audio
+}
+
+// Build returns the impressions of the pod. Impressions are identified as
+// "<PodID>-<n>" and ordered by slot. If the duration is not divisible by the
+// slot duration, the last slot gets the remainder.
+func (b *PodBuilder) Build() ([]Impression, error) {
+	if b.PodID == "" {
+		return nil, ErrInvalidPodNoID
+	} else if b.Duration <= 0 {
+		return nil, ErrInvalidPodNoDuration
+	} else if b.SlotDuration <= 0 {
+		return nil, ErrInvalidPodNoSlotDuration
+	} else if (b.Video == nil) == (b.Audio == nil) {
+		return nil, ErrInvalidPodNoTemplate
+	}
+
+	n := (b.Duration + b.SlotDuration - 1) / b.SlotDuration
+	imps := make([]Impression, 0, n)
+	for i := 0; i < n; i++ {
+		dur := b.SlotDuration
+		if rem := b.Duration - i*b.SlotDuration; rem < dur {
+			dur = rem
+		}
+
+		slot := SlotInPodAny
+		if i == 0 {
+			slot = SlotInPodFirst
+		} else if i == n-1 {
+			slot = SlotInPodLast
+		}
+
+		imp := Impression{
+			ID:               b.PodID + "-" + strconv.Itoa(i+1),
+			BidFloor:         b.BidFloor,
+			BidFloorCurrency: b.BidFloorCur,
+			SSAI:             b.SSAI,
+		}
+		if b.Video != nil {
+			v := *b.Video
+			v.MaxDuration, v.PodID, v.PodSequence, v.SlotInPod, v.MinCPMPerSec = dur, b.PodID, b.PodSequence, slot, b.MinCPMPerSec
+			imp.Video = &v
+		} else {
+			a := *b.Audio
+			a.MaxDuration, a.PodID, a.PodSequence, a.SlotInPod, a.MinCPMPerSec = dur, b.PodID, b.PodSequence, slot, b.MinCPMPerSec
+			imp.Audio = &a
+		}
+		imps = append(imps, imp)
+	}
+	return imps, nil
+}
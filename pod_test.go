@@ -0,0 +1,65 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PodBuilder", func() {
+	var subject *PodBuilder
+
+	BeforeEach(func() {
+		subject = &PodBuilder{
+			PodID:        "pod1",
+			PodSequence:  PodSequenceFirst,
+			Duration:     70,
+			SlotDuration: 30,
+			MinCPMPerSec: 0.5,
+			SSAI:         SSAIServerSideAssets,
+			Video:        &Video{Mimes: []string{"video/mp4"}, MinDuration: 5},
+		}
+	})
+
+	It("should build video pods", func() {
+		imps, err := subject.Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(imps).To(HaveLen(3))
+
+		Expect(imps[0].ID).To(Equal("pod1-1"))
+		Expect(imps[0].SSAI).To(Equal(SSAIServerSideAssets))
+		Expect(imps[0].Video).To(Equal(&Video{
+			Mimes:        []string{"video/mp4"},
+			MinDuration:  5,
+			MaxDuration:  30,
+			PodID:        "pod1",
+			PodSequence:  PodSequenceFirst,
+			SlotInPod:    SlotInPodFirst,
+			MinCPMPerSec: 0.5,
+		}))
+		Expect(imps[1].Video.SlotInPod).To(Equal(SlotInPodAny))
+		Expect(imps[2].ID).To(Equal("pod1-3"))
+		Expect(imps[2].Video.MaxDuration).To(Equal(10))
+		Expect(imps[2].Video.SlotInPod).To(Equal(SlotInPodLast))
+		Expect(subject.Video.PodID).To(BeEmpty())
+	})
+
+	It("should build audio pods", func() {
+		subject.Video = nil
+		subject.Audio = &Audio{Mimes: []string{"audio/mp4"}}
+		subject.Duration = 60
+
+		imps, err := subject.Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(imps).To(HaveLen(2))
+		Expect(imps[1].Audio.MaxDuration).To(Equal(30))
+		Expect(imps[1].Audio.SlotInPod).To(Equal(SlotInPodLast))
+	})
+
+	It("should validate", func() {
+		Expect((&PodBuilder{}).Build()).Error().To(Equal(ErrInvalidPodNoID))
+		Expect((&PodBuilder{PodID: "p"}).Build()).Error().To(Equal(ErrInvalidPodNoDuration))
+		Expect((&PodBuilder{PodID: "p", Duration: 30}).Build()).Error().To(Equal(ErrInvalidPodNoSlotDuration))
+		Expect((&PodBuilder{PodID: "p", Duration: 30, SlotDuration: 15}).Build()).Error().To(Equal(ErrInvalidPodNoTemplate))
+	})
+
+})
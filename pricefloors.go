@@ -0,0 +1,257 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Price floors schema fields, see PriceFloorSchema
+const (
+	PriceFloorFieldMediaType  = "mediaType"
+	PriceFloorFieldSize       = "size"
+	PriceFloorFieldDomain     = "domain"
+	PriceFloorFieldSiteDomain = "siteDomain"
+	PriceFloorFieldPubDomain  = "pubDomain"
+	PriceFloorFieldBundle     = "bundle"
+	PriceFloorFieldCountry    = "country"
+	PriceFloorFieldDeviceType = "deviceType"
+	PriceFloorFieldGPTSlot    = "gptSlot"
+	PriceFloorFieldAdUnitCode = "adUnitCode"
+	PriceFloorFieldRefresh    = "refresh" // "true" if the ad slot was refreshed, "false" otherwise
+)
+
+// PriceFloorWildcard matches any value of a schema field.
+const PriceFloorWildcard = "*"
+
+// PriceFloors is the ext.prebid.floors object of a bid request, containing
+// the price floors models of the publisher.
+type PriceFloors struct {
+	Enabled     *bool           `json:"enabled,omitempty"`     // Floors are enforced unless set to false
+	FloorMin    float64         `json:"floormin,omitempty"`    // Minimum floor, applied to all resolved floors
+	FloorMinCur string          `json:"floormincur,omitempty"` // Currency of floormin, Default: the model currency
+	SkipRate    int             `json:"skiprate,omitempty"`    // Percentage of requests which skip floors
+	Data        *PriceFloorData `json:"data,omitempty"`        // Floors data
+	Ext         Extension       `json:"ext,omitempty"`
+}
+
+// PriceFloorData contains the floors models.
+type PriceFloorData struct {
+	Currency      string                 `json:"currency,omitempty"`            // Default currency of the model groups, Default: "USD"
+	SkipRate      int                    `json:"skiprate,omitempty"`            // Percentage of requests which skip floors, overrides PriceFloors.SkipRate
+	SchemaVersion int                    `json:"floorsschemaversion,omitempty"` // Version of the floors schema
+	ModelGroups   []PriceFloorModelGroup `json:"modelgroups,omitempty"`         // Models, one of which is selected by weight
+}
+
+// PriceFloorModelGroup is a single floors model.
+type PriceFloorModelGroup struct {
+	Currency     string             `json:"currency,omitempty"`     // Currency of the values, overrides PriceFloorData.Currency
+	SkipRate     int                `json:"skiprate,omitempty"`     // Percentage of requests which skip floors, overrides PriceFloorData.SkipRate
+	ModelWeight  int                `json:"modelweight,omitempty"`  // Relative weight used to select the model
+	ModelVersion string             `json:"modelversion,omitempty"` // Version of the model
+	Schema       PriceFloorSchema   `json:"schema"`                 // Fields the rules are keyed by
+	Values       map[string]float64 `json:"values"`                 // Floors by rule, e.g. "banner|300x250"
+	Default      float64            `json:"default,omitempty"`      // Floor if no rule matches
+}
+
+// PriceFloorSchema describes the rules of a model group.
+type PriceFloorSchema struct {
+	Fields    []string `json:"fields"`              // Schema fields, see PriceFloorField* constants
+	Delimiter string   `json:"delimiter,omitempty"` // Separator of rule fields, Default: "|"
+}
+
+// ImpExtPriceFloors is the imp.ext.prebid.floors object, recording the floor
+// applied to an impression.
+type ImpExtPriceFloors struct {
+	FloorRule      string  `json:"floorrule,omitempty"`      // The matched rule
+	FloorRuleValue float64 `json:"floorrulevalue,omitempty"` // The value of the matched rule
+	FloorValue     float64 `json:"floorvalue,omitempty"`     // The applied floor, after floormin
+	FloorMin       float64 `json:"floormin,omitempty"`       // The floormin, if any
+	FloorMinCur    string  `json:"floormincur,omitempty"`    // The currency of floormin
+}
+
+// PriceFloorMatch is a resolved price floor.
+type PriceFloorMatch struct {
+	Rule      string  // The matched rule, empty if the default was applied
+	RuleValue float64 // The value of the rule or the default
+	Floor     Floor   // The applied floor, after floormin
+}
+
+// PriceFloors decodes ext.prebid.floors. It returns nil if absent.
+func (req *BidRequest) PriceFloors() (*PriceFloors, error) {
+	var floors *PriceFloors
+	if err := getPrebidKey(req.Ext, "floors", &floors); err != nil {
+		return nil, err
+	}
+	return floors, nil
+}
+
+// PriceFloors decodes imp.ext.prebid.floors. It returns nil if absent.
+func (imp *Impression) PriceFloors() (*ImpExtPriceFloors, error) {
+	var floors *ImpExtPriceFloors
+	if err := getPrebidKey(imp.Ext, "floors", &floors); err != nil {
+		return nil, err
+	}
+	return floors, nil
+}
+
+// IsEnabled returns true unless floors are explicitly disabled.
+func (f *PriceFloors) IsEnabled() bool {
+	return f.Enabled == nil || *f.Enabled
+}
+
+// ModelGroup selects a model group by weight. The value r must be in the
+// range [0, 1), e.g. from rand.Float64. Model groups without a weight are
+// only considered if no group has a weight. It returns nil if there are no
+// model groups.
+func (d *PriceFloorData) ModelGroup(r float64) *PriceFloorModelGroup {
+	total := 0
+	for i := range d.ModelGroups {
+		total += d.ModelGroups[i].ModelWeight
+	}
+	if total == 0 {
+		if len(d.ModelGroups) == 0 {
+			return nil
+		}
+		return &d.ModelGroups[0]
+	}
+
+	n := int(r * float64(total))
+	for i := range d.ModelGroups {
+		if n < d.ModelGroups[i].ModelWeight {
+			return &d.ModelGroups[i]
+		}
+		n -= d.ModelGroups[i].ModelWeight
+	}
+	return &d.ModelGroups[len(d.ModelGroups)-1]
+}
+
+// Resolve returns the rule matching the given field values, by schema
+// field, and its value. Values are compared case-insensitively and rules
+// may contain wildcards. If multiple rules match, the one with the fewest
+// wildcards wins; among those, wildcards in later fields are preferred.
+// It returns false if no rule matches.
+func (g *PriceFloorModelGroup) Resolve(fields map[string]string) (string, float64, bool) {
+	delim := g.Schema.Delimiter
+	if delim == "" {
+		delim = "|"
+	}
+
+	best, bestScore := "", -1
+	for rule := range g.Values {
+		parts := strings.Split(rule, delim)
+		if len(parts) != len(g.Schema.Fields) {
+			continue
+		}
+
+		score := 0
+		for i, part := range parts {
+			if part == PriceFloorWildcard {
+				score |= 1 << uint(len(parts)-1-i)
+			} else if !strings.EqualFold(part, fields[g.Schema.Fields[i]]) {
+				score = -1
+				break
+			}
+		}
+		if score < 0 {
+			continue
+		}
+		if bestScore < 0 || score < bestScore || (score == bestScore && rule < best) {
+			best, bestScore = rule, score
+		}
+	}
+	if bestScore < 0 {
+		return "", 0, false
+	}
+	return best, g.Values[best], true
+}
+
+// PriceFloorFields returns the schema field values of an impression for a
+// media type, e.g. "banner", and an optional creative size.
+func (req *BidRequest) PriceFloorFields(imp *Impression, mediaType string, w, h int) map[string]string {
+	fields := map[string]string{PriceFloorFieldMediaType: mediaType}
+	if w > 0 && h > 0 {
+		fields[PriceFloorFieldSize] = strconv.Itoa(w) + "x" + strconv.Itoa(h)
+	}
+
+	var inv *Inventory
+	if req.Site != nil {
+		inv = &req.Site.Inventory
+		fields[PriceFloorFieldSiteDomain] = inv.Domain
+	} else if req.App != nil {
+		inv = &req.App.Inventory
+		fields[PriceFloorFieldBundle] = req.App.Bundle
+	}
+	if inv != nil {
+		fields[PriceFloorFieldDomain] = inv.Domain
+		if inv.Publisher != nil {
+			fields[PriceFloorFieldPubDomain] = inv.Publisher.Domain
+		}
+	}
+
+	if d := req.Device; d != nil {
+		if d.Geo != nil {
+			fields[PriceFloorFieldCountry] = d.Geo.Country
+		}
+		switch d.DeviceType {
+		case DeviceTypePC:
+			fields[PriceFloorFieldDeviceType] = "desktop"
+		case DeviceTypeMobile, DeviceTypePhone:
+			fields[PriceFloorFieldDeviceType] = "phone"
+		case DeviceTypeTablet:
+			fields[PriceFloorFieldDeviceType] = "tablet"
+		}
+	}
+
+	if data, err := imp.ExtData(); err == nil && data != nil && data.AdServer != nil {
+		fields[PriceFloorFieldGPTSlot] = data.AdServer.AdSlot
+	}
+	if gpid, err := imp.GPID(); err == nil && gpid != "" {
+		fields[PriceFloorFieldAdUnitCode] = gpid
+	} else {
+		fields[PriceFloorFieldAdUnitCode] = imp.TagID
+	}
+	fields[PriceFloorFieldRefresh] = strconv.FormatBool(imp.IsRefresh())
+	return fields
+}
+
+// ResolvePriceFloor resolves the floor of an impression for a media type
+// and an optional creative size from the model group g of the request's
+// price floors, see PriceFloorModelGroup.Resolve. The default of the model
+// group applies if no rule matches and floormin is enforced if it is given
+// in the same currency. It returns false if neither a rule nor a default
+// applies.
+func (req *BidRequest) ResolvePriceFloor(floors *PriceFloors, g *PriceFloorModelGroup, imp *Impression, mediaType string, w, h int) (PriceFloorMatch, bool) {
+	cur := g.Currency
+	if cur == "" && floors.Data != nil {
+		cur = floors.Data.Currency
+	}
+	cur = firstCurrency(cur)
+
+	m := PriceFloorMatch{Floor: Floor{Currency: cur}}
+	if rule, value, ok := g.Resolve(req.PriceFloorFields(imp, mediaType, w, h)); ok {
+		m.Rule, m.RuleValue = rule, value
+	} else if g.Default > 0 {
+		m.RuleValue = g.Default
+	} else {
+		return PriceFloorMatch{}, false
+	}
+
+	m.Floor.Price = m.RuleValue
+	if floors.FloorMin > m.Floor.Price && firstCurrency(floors.FloorMinCur, cur) == cur {
+		m.Floor.Price = floors.FloorMin
+	}
+	return m, true
+}
+
+// getPrebidKey decodes ext.prebid.<key> into v.
+func getPrebidKey(e Extension, key string, v interface{}) error {
+	var prebid map[string]json.RawMessage
+	if ok, err := e.getKey("prebid", &prebid); err != nil || !ok {
+		return err
+	}
+	if raw, ok := prebid[key]; ok {
+		return decodeNumbers(raw, v)
+	}
+	return nil
+}
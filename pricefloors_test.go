@@ -0,0 +1,122 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PriceFloors", func() {
+	var req *BidRequest
+
+	BeforeEach(func() {
+		req = &BidRequest{
+			ID: "R",
+			Imp: []Impression{
+				{ID: "1", TagID: "top", Banner: &Banner{}, Ext: Extension(`{"gpid":"/1111/home","prebid":{"floors":{"floorrule":"banner|*","floorvalue":1.2}}}`)},
+				{ID: "2", TagID: "side", Video: &Video{}},
+			},
+			Site:   &Site{Inventory: Inventory{Domain: "www.example.com", Publisher: &Publisher{Domain: "example.com"}}},
+			Device: &Device{DeviceType: DeviceTypePC, Geo: &Geo{Country: "USA"}},
+			Ext: Extension(`{"prebid":{"floors":{"floormin":0.5,"data":{"currency":"EUR","modelgroups":[
+				{"modelweight":40,"modelversion":"m1","schema":{"fields":["mediaType","size","domain"]},"values":{
+					"banner|300x250|www.example.com":1.5,
+					"banner|300x250|*":1.1,
+					"banner|*|www.example.com":1.3,
+					"*|*|*":0.2
+				}},
+				{"modelweight":60,"modelversion":"m2","schema":{"fields":["mediaType","country"],"delimiter":";"},"values":{"video;usa":4},"default":0.8}
+			]}}}}`),
+		}
+	})
+
+	It("should decode extensions", func() {
+		floors, err := req.PriceFloors()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(floors.IsEnabled()).To(BeTrue())
+		Expect(floors.FloorMin).To(Equal(0.5))
+		Expect(floors.Data.Currency).To(Equal("EUR"))
+		Expect(floors.Data.ModelGroups).To(HaveLen(2))
+		Expect(floors.Data.ModelGroups[1].Schema).To(Equal(PriceFloorSchema{Fields: []string{"mediaType", "country"}, Delimiter: ";"}))
+
+		Expect(req.Imp[0].PriceFloors()).To(Equal(&ImpExtPriceFloors{FloorRule: "banner|*", FloorValue: 1.2}))
+		Expect(req.Imp[1].PriceFloors()).To(BeNil())
+		Expect((&BidRequest{}).PriceFloors()).To(BeNil())
+	})
+
+	It("should select model groups by weight", func() {
+		floors, err := req.PriceFloors()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(floors.Data.ModelGroup(0).ModelVersion).To(Equal("m1"))
+		Expect(floors.Data.ModelGroup(0.39).ModelVersion).To(Equal("m1"))
+		Expect(floors.Data.ModelGroup(0.4).ModelVersion).To(Equal("m2"))
+		Expect(floors.Data.ModelGroup(0.99).ModelVersion).To(Equal("m2"))
+		Expect((&PriceFloorData{}).ModelGroup(0.5)).To(BeNil())
+	})
+
+	It("should derive schema fields", func() {
+		Expect(req.PriceFloorFields(&req.Imp[0], "banner", 300, 250)).To(Equal(map[string]string{
+			"mediaType":  "banner",
+			"size":       "300x250",
+			"domain":     "www.example.com",
+			"siteDomain": "www.example.com",
+			"pubDomain":  "example.com",
+			"country":    "USA",
+			"deviceType": "desktop",
+			"adUnitCode": "/1111/home",
+			"refresh":    "false",
+		}))
+		Expect(req.PriceFloorFields(&req.Imp[1], "video", 0, 0)).To(HaveKeyWithValue("adUnitCode", "side"))
+
+		req.Imp[1].Refresh = &Refresh{Count: 2}
+		Expect(req.PriceFloorFields(&req.Imp[1], "video", 0, 0)).To(HaveKeyWithValue("refresh", "true"))
+	})
+
+	It("should resolve the most specific rule", func() {
+		floors, err := req.PriceFloors()
+		Expect(err).NotTo(HaveOccurred())
+		g := &floors.Data.ModelGroups[0]
+
+		rule, _, _ := g.Resolve(map[string]string{"mediaType": "BANNER", "size": "300x250", "domain": "www.example.com"})
+		Expect(rule).To(Equal("banner|300x250|www.example.com"))
+		rule, value, ok := g.Resolve(map[string]string{"mediaType": "banner", "size": "300x250", "domain": "other.com"})
+		Expect([]interface{}{rule, value, ok}).To(Equal([]interface{}{"banner|300x250|*", 1.1, true}))
+		rule, _, _ = g.Resolve(map[string]string{"mediaType": "banner", "size": "728x90", "domain": "www.example.com"})
+		Expect(rule).To(Equal("banner|*|www.example.com"))
+		rule, _, _ = g.Resolve(map[string]string{"mediaType": "video"})
+		Expect(rule).To(Equal("*|*|*"))
+
+		delete(g.Values, "*|*|*")
+		_, _, ok = g.Resolve(map[string]string{"mediaType": "video"})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should resolve imp floors", func() {
+		floors, err := req.PriceFloors()
+		Expect(err).NotTo(HaveOccurred())
+
+		resolve := func(g int, imp int, mediaType string, w, h int) *PriceFloorMatch {
+			m, ok := req.ResolvePriceFloor(floors, &floors.Data.ModelGroups[g], &req.Imp[imp], mediaType, w, h)
+			if !ok {
+				return nil
+			}
+			return &m
+		}
+
+		Expect(resolve(0, 0, "banner", 300, 250)).To(Equal(&PriceFloorMatch{
+			Rule: "banner|300x250|www.example.com", RuleValue: 1.5, Floor: Floor{Price: 1.5, Currency: "EUR"},
+		}))
+		Expect(resolve(0, 1, "video", 0, 0)).To(Equal(&PriceFloorMatch{
+			Rule: "*|*|*", RuleValue: 0.2, Floor: Floor{Price: 0.5, Currency: "EUR"},
+		}))
+		Expect(resolve(1, 1, "video", 0, 0)).To(Equal(&PriceFloorMatch{
+			Rule: "video;usa", RuleValue: 4, Floor: Floor{Price: 4, Currency: "EUR"},
+		}))
+		Expect(resolve(1, 0, "banner", 0, 0)).To(Equal(&PriceFloorMatch{
+			RuleValue: 0.8, Floor: Floor{Price: 0.8, Currency: "EUR"},
+		}))
+
+		floors.Data.ModelGroups[1].Default = 0
+		Expect(resolve(1, 0, "banner", 0, 0)).To(BeNil())
+	})
+
+})
@@ -0,0 +1,66 @@
+package openrtb
+
+import "net"
+
+// AnonymizeIPv4 zeroes the last octet of an IPv4 address, e.g.
+// "123.145.167.189" becomes "123.145.167.0". It returns an empty string if
+// ip is not a valid IPv4 address.
+func AnonymizeIPv4(ip string) string {
+	parsed := net.ParseIP(ip).To4()
+	if parsed == nil {
+		return ""
+	}
+	return parsed.Mask(net.CIDRMask(24, 32)).String()
+}
+
+// AnonymizeIPv6 zeroes the last 80 bits of an IPv6 address, e.g.
+// "2001:db8:85a3:8d3:1319:8a2e:370:7348" becomes "2001:db8:85a3::". It
+// returns an empty string if ip is not a valid IPv6 address.
+func AnonymizeIPv6(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() != nil {
+		return ""
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
+
+// AnonymizeIP truncates the IPv4 and IPv6 addresses of the device. IPv6
+// addresses misplaced in the ip field are truncated as such.
+func (d *Device) AnonymizeIP() {
+	if d.IP != "" {
+		if ip := AnonymizeIPv4(d.IP); ip != "" {
+			d.IP = ip
+		} else {
+			d.IP = AnonymizeIPv6(d.IP)
+		}
+	}
+	if d.IPv6 != "" {
+		d.IPv6 = AnonymizeIPv6(d.IPv6)
+	}
+}
+
+// RequiresIPAnonymization returns true if the device IP of the request must
+// not be passed on in full, i.e. if the request is subject to GDPR without
+// a consent string, subject to COPPA or the device has limited ad tracking.
+func (req *BidRequest) RequiresIPAnonymization() bool {
+	if req.Regs != nil {
+		if req.Regs.Coppa == 1 {
+			return true
+		}
+		if req.IsGDPRInScope() && (req.User == nil || req.User.Consent == "") {
+			return true
+		}
+	}
+	return req.Device != nil && req.Device.LMT.IsTrue()
+}
+
+// AnonymizeIP truncates the device IP addresses of the request in place if
+// required, see RequiresIPAnonymization. It returns true if addresses were
+// truncated.
+func (req *BidRequest) AnonymizeIP() bool {
+	if req.Device == nil || !req.RequiresIPAnonymization() {
+		return false
+	}
+	req.Device.AnonymizeIP()
+	return true
+}
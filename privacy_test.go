@@ -0,0 +1,90 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AnonymizeIPv4", func() {
+
+	It("should truncate", func() {
+		Expect(AnonymizeIPv4("123.145.167.189")).To(Equal("123.145.167.0"))
+		Expect(AnonymizeIPv4("10.0.0.0")).To(Equal("10.0.0.0"))
+		Expect(AnonymizeIPv4("::ffff:123.145.167.189")).To(Equal("123.145.167.0"))
+		Expect(AnonymizeIPv4("2001:db8::1")).To(BeEmpty())
+		Expect(AnonymizeIPv4("bad")).To(BeEmpty())
+	})
+
+})
+
+var _ = Describe("AnonymizeIPv6", func() {
+
+	It("should truncate", func() {
+		Expect(AnonymizeIPv6("2001:db8:85a3:8d3:1319:8a2e:370:7348")).To(Equal("2001:db8:85a3::"))
+		Expect(AnonymizeIPv6("2001:db8::1")).To(Equal("2001:db8::"))
+		Expect(AnonymizeIPv6("123.145.167.189")).To(BeEmpty())
+		Expect(AnonymizeIPv6("bad")).To(BeEmpty())
+	})
+
+})
+
+var _ = Describe("Device", func() {
+
+	It("should anonymize IPs", func() {
+		subject := &Device{IP: "123.145.167.189", IPv6: "2001:db8::1"}
+		subject.AnonymizeIP()
+		Expect(subject.IP).To(Equal("123.145.167.0"))
+		Expect(subject.IPv6).To(Equal("2001:db8::"))
+
+		subject = &Device{IP: "2001:db8:85a3:8d3:1319:8a2e:370:7348"}
+		subject.AnonymizeIP()
+		Expect(subject.IP).To(Equal("2001:db8:85a3::"))
+
+		subject = &Device{IP: "bad"}
+		subject.AnonymizeIP()
+		Expect(subject.IP).To(BeEmpty())
+	})
+
+})
+
+var _ = Describe("BidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID:     "R",
+			Device: &Device{IP: "123.145.167.189", IPv6: "2001:db8:85a3:8d3:1319:8a2e:370:7348"},
+		}
+	})
+
+	It("should detect when IPs must be anonymized", func() {
+		Expect(subject.RequiresIPAnonymization()).To(BeFalse())
+
+		subject.Regs = &Regulations{GDPR: 1}
+		Expect(subject.RequiresIPAnonymization()).To(BeTrue())
+		subject.User = &User{Consent: "CONSENT"}
+		Expect(subject.RequiresIPAnonymization()).To(BeFalse())
+
+		subject.Regs.Coppa = 1
+		Expect(subject.RequiresIPAnonymization()).To(BeTrue())
+
+		subject.Regs = nil
+		subject.Device.LMT = FlagTrue
+		Expect(subject.RequiresIPAnonymization()).To(BeTrue())
+		subject.Device.LMT = FlagFalse
+		Expect(subject.RequiresIPAnonymization()).To(BeFalse())
+	})
+
+	It("should anonymize IPs", func() {
+		Expect(subject.AnonymizeIP()).To(BeFalse())
+		Expect(subject.Device.IP).To(Equal("123.145.167.189"))
+
+		subject.Regs = &Regulations{Coppa: 1}
+		Expect(subject.AnonymizeIP()).To(BeTrue())
+		Expect(subject.Device.IP).To(Equal("123.145.167.0"))
+		Expect(subject.Device.IPv6).To(Equal("2001:db8:85a3::"))
+
+		Expect((&BidRequest{Regs: &Regulations{Coppa: 1}}).AnonymizeIP()).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,83 @@
+package openrtb
+
+import "math"
+
+// RedactPolicy controls which personal data is masked by Redact.
+type RedactPolicy struct {
+	IP          bool     // Truncate device IPs, see AnonymizeIPv4 and AnonymizeIPv6
+	DeviceIDs   bool     // Remove device.ifa and hashed device IDs
+	UserIDs     bool     // Remove user.id, buyeruid, buyerid and customdata
+	Geo         bool     // Reduce the precision of device.geo and user.geo, removing zip
+	GeoDecimals int      // Number of decimals of lat/lon retained when Geo is set
+	UserExtKeys []string // Keys removed from user.ext, e.g. "eids"
+}
+
+// DefaultRedactPolicy masks all supported fields and reduces geo precision
+// to two decimals, i.e. roughly 1km.
+var DefaultRedactPolicy = RedactPolicy{
+	IP:          true,
+	DeviceIDs:   true,
+	UserIDs:     true,
+	Geo:         true,
+	GeoDecimals: 2,
+	UserExtKeys: []string{"eids"},
+}
+
+// Redact returns a copy of req with personal data masked as per policy,
+// suitable for logging. A nil policy applies DefaultRedactPolicy. Only the
+// affected objects are copied, req itself is not modified.
+func Redact(req *BidRequest, policy *RedactPolicy) *BidRequest {
+	if policy == nil {
+		policy = &DefaultRedactPolicy
+	}
+
+	dup := *req
+	if req.Device != nil {
+		dup.Device = policy.redactDevice(*req.Device)
+	}
+	if req.User != nil {
+		dup.User = policy.redactUser(*req.User)
+	}
+	return &dup
+}
+
+func (p *RedactPolicy) redactDevice(d Device) *Device {
+	if p.IP {
+		d.AnonymizeIP()
+	}
+	if p.DeviceIDs {
+		d.IFA = ""
+		d.IDSHA1, d.IDMD5 = "", ""
+		d.PIDSHA1, d.PIDMD5 = "", ""
+		d.MacSHA1, d.MacMD5 = "", ""
+	}
+	if p.Geo && d.Geo != nil {
+		d.Geo = p.redactGeo(*d.Geo)
+	}
+	return &d
+}
+
+func (p *RedactPolicy) redactUser(u User) *User {
+	if p.UserIDs {
+		u.ID, u.BuyerID, u.BuyerUID, u.CustomData = "", "", "", ""
+	}
+	if p.Geo && u.Geo != nil {
+		u.Geo = p.redactGeo(*u.Geo)
+	}
+	for _, key := range p.UserExtKeys {
+		ext, err := u.Ext.deleteKey(key)
+		if err != nil {
+			ext = nil // drop malformed extensions entirely
+		}
+		u.Ext = ext
+	}
+	return &u
+}
+
+func (p *RedactPolicy) redactGeo(g Geo) *Geo {
+	scale := math.Pow(10, float64(p.GeoDecimals))
+	g.Lat = math.Round(g.Lat*scale) / scale
+	g.Lon = math.Round(g.Lon*scale) / scale
+	g.Zip = ""
+	return &g
+}
@@ -0,0 +1,68 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redact", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID: "R",
+			Device: &Device{
+				UA:     "Mozilla",
+				IP:     "123.145.167.189",
+				IFA:    "AA000DFE74168477C70D291f574D344790E0BB11",
+				IDSHA1: "sha1",
+				Geo:    &Geo{Lat: 51.507351, Lon: -0.127758, Country: "GBR", Zip: "WC2N 5DU"},
+			},
+			User: &User{
+				ID:       "U",
+				BuyerUID: "B",
+				Consent:  "CONSENT",
+				Geo:      &Geo{Lat: 51.5, Lon: -0.12},
+				Ext:      Extension(`{"eids":[{"source":"x.com"}],"keep":1}`),
+			},
+		}
+	})
+
+	It("should mask personal data", func() {
+		res := Redact(subject, nil)
+		Expect(res.ID).To(Equal("R"))
+		Expect(res.Device).To(Equal(&Device{
+			UA:  "Mozilla",
+			IP:  "123.145.167.0",
+			Geo: &Geo{Lat: 51.51, Lon: -0.13, Country: "GBR"},
+		}))
+		Expect(res.User.ID).To(BeEmpty())
+		Expect(res.User.BuyerUID).To(BeEmpty())
+		Expect(res.User.Consent).To(Equal("CONSENT"))
+		Expect(res.User.Geo).To(Equal(&Geo{Lat: 51.5, Lon: -0.12}))
+		Expect(string(res.User.Ext)).To(MatchJSON(`{"keep":1}`))
+	})
+
+	It("should not modify the original", func() {
+		Redact(subject, nil)
+		Expect(subject.Device.IP).To(Equal("123.145.167.189"))
+		Expect(subject.Device.IFA).NotTo(BeEmpty())
+		Expect(subject.Device.Geo.Lat).To(Equal(51.507351))
+		Expect(subject.User.ID).To(Equal("U"))
+		Expect(string(subject.User.Ext)).To(ContainSubstring("eids"))
+	})
+
+	It("should apply custom policies", func() {
+		res := Redact(subject, &RedactPolicy{UserIDs: true, Geo: true})
+		Expect(res.Device.IP).To(Equal("123.145.167.189"))
+		Expect(res.Device.IFA).NotTo(BeEmpty())
+		Expect(res.Device.Geo.Lat).To(Equal(52.0))
+		Expect(res.User.ID).To(BeEmpty())
+		Expect(string(res.User.Ext)).To(ContainSubstring("eids"))
+
+		subject.User.Ext = Extension(`bad`)
+		res = Redact(subject, &RedactPolicy{UserExtKeys: []string{"eids"}})
+		Expect(res.User.Ext).To(BeNil())
+	})
+
+})
@@ -0,0 +1,71 @@
+package openrtb
+
+import "time"
+
+// Refresh triggers of imp.refresh.refsettings.reftype
+const (
+	RefreshTypeUnknown    = 0 // Unknown or other trigger
+	RefreshTypeUserAction = 1 // User action, e.g. a scroll or click
+	RefreshTypeEvent      = 2 // Event, e.g. a video ad finishing
+	RefreshTypeTime       = 3 // Time, refreshed after a minimum interval
+)
+
+// Refresh describes the auto-refresh behaviour of an ad slot.
+type Refresh struct {
+	RefSettings []RefSettings `json:"refsettings,omitempty"` // Descriptions of the refresh triggers of the slot
+	Count       int           `json:"count,omitempty"`       // The number of times the slot has been refreshed since the last page load, 0 = initial load
+	Ext         Extension     `json:"ext,omitempty"`
+}
+
+// RefSettings describes a single refresh trigger of an ad slot.
+type RefSettings struct {
+	RefType int       `json:"reftype,omitempty"` // The trigger of the refresh, see RefreshType* constants, Default: 0
+	MinInt  int       `json:"minint,omitempty"`  // The minimum refresh interval in seconds, applies to all refresh types
+	Ext     Extension `json:"ext,omitempty"`
+}
+
+// IsRefresh returns true if the impression is offered on an ad slot which
+// has been refreshed at least once.
+func (imp *Impression) IsRefresh() bool {
+	return imp.Refresh != nil && imp.Refresh.Count > 0
+}
+
+// MinInterval returns the shortest minimum interval of all refresh triggers.
+// It returns false if no trigger declares an interval.
+func (r *Refresh) MinInterval() (time.Duration, bool) {
+	min := 0
+	for _, s := range r.RefSettings {
+		if s.MinInt > 0 && (min == 0 || s.MinInt < min) {
+			min = s.MinInt
+		}
+	}
+	if min == 0 {
+		return 0, false
+	}
+	return time.Duration(min) * time.Second, true
+}
+
+// HasRefreshType returns true if any of the refresh triggers is of the given
+// type.
+func (r *Refresh) HasRefreshType(refType int) bool {
+	for _, s := range r.RefSettings {
+		if s.RefType == refType {
+			return true
+		}
+	}
+	return false
+}
+
+// valid checks that counts and intervals are not negative and that the
+// refresh types are known.
+func (r *Refresh) valid() bool {
+	if r.Count < 0 {
+		return false
+	}
+	for _, s := range r.RefSettings {
+		if s.MinInt < 0 || s.RefType < RefreshTypeUnknown || s.RefType > RefreshTypeTime {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Refresh", func() {
+	var subject *Refresh
+
+	BeforeEach(func() {
+		subject = &Refresh{Count: 1, RefSettings: []RefSettings{
+			{RefType: RefreshTypeUserAction},
+			{RefType: RefreshTypeTime, MinInt: 30},
+			{RefType: RefreshTypeEvent, MinInt: 15},
+		}}
+	})
+
+	It("should parse correctly", func() {
+		var imp *Impression
+		Expect(json.Unmarshal([]byte(`{"id":"1","banner":{},"refresh":{"refsettings":[{"reftype":3,"minint":30}],"count":2}}`), &imp)).To(Succeed())
+		Expect(imp.Refresh).To(Equal(&Refresh{Count: 2, RefSettings: []RefSettings{{RefType: RefreshTypeTime, MinInt: 30}}}))
+	})
+
+	It("should detect refreshed impressions", func() {
+		imp := &Impression{ID: "1"}
+		Expect(imp.IsRefresh()).To(BeFalse())
+		imp.Refresh = &Refresh{}
+		Expect(imp.IsRefresh()).To(BeFalse())
+		imp.Refresh = subject
+		Expect(imp.IsRefresh()).To(BeTrue())
+	})
+
+	It("should return the minimum interval", func() {
+		d, ok := subject.MinInterval()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(Equal(15 * time.Second))
+
+		_, ok = (&Refresh{RefSettings: []RefSettings{{RefType: RefreshTypeUserAction}}}).MinInterval()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should check refresh types", func() {
+		Expect(subject.HasRefreshType(RefreshTypeTime)).To(BeTrue())
+		Expect(subject.HasRefreshType(RefreshTypeUnknown)).To(BeFalse())
+	})
+
+	It("should validate", func() {
+		imp := &Impression{ID: "1", Banner: &Banner{}, Refresh: subject}
+		Expect(imp.Validate()).To(Succeed())
+
+		subject.RefSettings[0].RefType = 7
+		Expect(imp.Validate()).To(MatchError(ErrInvalidImpRefresh))
+		subject.RefSettings[0].RefType = RefreshTypeUnknown
+		subject.Count = -1
+		Expect(imp.Validate()).To(MatchError(ErrInvalidImpRefresh))
+	})
+})
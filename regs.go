@@ -0,0 +1,52 @@
+package openrtb
+
+import "encoding/json"
+
+type jsonRegulations Regulations
+
+// UnmarshalJSON custom unmarshalling with support for gdpr and us_privacy
+// passed in ext, as common before OpenRTB 2.6.
+func (r *Regulations) UnmarshalJSON(data []byte) error {
+	var h jsonRegulations
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+
+	*r = (Regulations)(h)
+	r.promoteExt()
+	return nil
+}
+
+func (r *Regulations) promoteExt() {
+	if len(r.Ext) == 0 || (r.GDPR != 0 && r.USPrivacy != "") {
+		return
+	}
+
+	ext, err := r.DecodeExt()
+	if err != nil {
+		return
+	}
+	if r.GDPR == 0 && ext.GDPR != nil {
+		r.GDPR = *ext.GDPR
+	}
+	if r.USPrivacy == "" {
+		r.USPrivacy = ext.USPrivacy
+	}
+}
+
+// IsGDPRInScope returns true if the request is subject to GDPR.
+func (req *BidRequest) IsGDPRInScope() bool {
+	return req.Regs != nil && req.Regs.GDPR == 1
+}
+
+// USPrivacyOptOut returns true if the user has opted out of the sale of
+// personal data, as signalled by a version 1 U.S. Privacy String, e.g.
+// "1YYN". Missing or malformed strings return false.
+func (req *BidRequest) USPrivacyOptOut() bool {
+	if req.Regs == nil {
+		return false
+	}
+
+	s := req.Regs.USPrivacy
+	return len(s) == 4 && s[0] == '1' && (s[2] == 'Y' || s[2] == 'y')
+}
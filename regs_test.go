@@ -0,0 +1,65 @@
+package openrtb
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Regulations", func() {
+
+	It("should decode gdpr and us_privacy", func() {
+		var subject *Regulations
+		Expect(json.Unmarshal([]byte(`{"coppa":1,"gdpr":1,"us_privacy":"1YNN"}`), &subject)).To(Succeed())
+		Expect(subject).To(Equal(&Regulations{Coppa: 1, GDPR: 1, USPrivacy: "1YNN"}))
+	})
+
+	It("should decode gdpr and us_privacy from ext", func() {
+		var subject *Regulations
+		Expect(json.Unmarshal([]byte(`{"ext":{"gdpr":1,"us_privacy":"1YYN"}}`), &subject)).To(Succeed())
+		Expect(subject.GDPR).To(Equal(1))
+		Expect(subject.USPrivacy).To(Equal("1YYN"))
+		Expect(string(subject.Ext)).To(MatchJSON(`{"gdpr":1,"us_privacy":"1YYN"}`))
+	})
+
+	It("should prefer fields over ext", func() {
+		var subject *Regulations
+		Expect(json.Unmarshal([]byte(`{"gdpr":1,"us_privacy":"1NNN","ext":{"gdpr":0,"us_privacy":"1YYN"}}`), &subject)).To(Succeed())
+		Expect(subject.GDPR).To(Equal(1))
+		Expect(subject.USPrivacy).To(Equal("1NNN"))
+
+		Expect(json.Unmarshal([]byte(`{"ext":"bad"}`), &subject)).To(Succeed())
+		Expect(subject.GDPR).To(Equal(0))
+	})
+
+})
+
+var _ = Describe("BidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{ID: "R"}
+	})
+
+	It("should check if GDPR is in scope", func() {
+		Expect(subject.IsGDPRInScope()).To(BeFalse())
+		subject.Regs = &Regulations{}
+		Expect(subject.IsGDPRInScope()).To(BeFalse())
+		subject.Regs.GDPR = 1
+		Expect(subject.IsGDPRInScope()).To(BeTrue())
+	})
+
+	It("should check for US privacy opt-outs", func() {
+		Expect(subject.USPrivacyOptOut()).To(BeFalse())
+		subject.Regs = &Regulations{USPrivacy: "1YNN"}
+		Expect(subject.USPrivacyOptOut()).To(BeFalse())
+		subject.Regs.USPrivacy = "1YYN"
+		Expect(subject.USPrivacyOptOut()).To(BeTrue())
+		subject.Regs.USPrivacy = "1---"
+		Expect(subject.USPrivacyOptOut()).To(BeFalse())
+		subject.Regs.USPrivacy = "2YYN"
+		Expect(subject.USPrivacyOptOut()).To(BeFalse())
+	})
+
+})
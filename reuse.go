@@ -0,0 +1,70 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// UnmarshalInto resets req and decodes data into it, reusing the backing
+// arrays of its slices and the structs they contain where their capacity
+// allows. Unlike UnmarshalBidRequestContext, it allocates no new request
+// per call and does not validate. Child objects referenced by pointer,
+// e.g. Site or Device, are released by the reset and allocated anew.
+//
+// Values of a previous decode must not be retained across calls, as their
+// memory is overwritten.
+func UnmarshalInto(data []byte, req *BidRequest) error {
+	req.Reset()
+	return json.Unmarshal(data, req)
+}
+
+// Reset clears all fields of the request, retaining the capacity of its
+// slices for reuse by UnmarshalInto. Reset slices are empty but not nil.
+func (req *BidRequest) Reset() {
+	resetValue(reflect.ValueOf(req).Elem())
+}
+
+// Reset clears all fields of the response, retaining the capacity of its
+// slices, see BidRequest.Reset.
+func (res *BidResponse) Reset() {
+	resetValue(reflect.ValueOf(res).Elem())
+}
+
+// resetValue zeroes v. Slices are truncated after their elements have been
+// reset up to their capacity, as encoding/json decodes into the existing
+// elements of a slice when it grows.
+func resetValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				resetValue(f)
+			}
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		if !isScalarKind(v.Type().Elem().Kind()) {
+			full := v.Slice(0, v.Cap())
+			for i := 0; i < full.Len(); i++ {
+				resetValue(full.Index(i))
+			}
+		}
+		v.SetLen(0)
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
+
+// isScalarKind returns true for kinds which encoding/json overwrites
+// entirely when decoding.
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
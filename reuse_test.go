@@ -0,0 +1,61 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnmarshalInto", func() {
+	var data []byte
+
+	BeforeEach(func() {
+		var err error
+		data, err = ioutil.ReadFile("testdata/breq.banner.json")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should decode into existing requests", func() {
+		subject := new(BidRequest)
+		Expect(UnmarshalInto(data, subject)).To(Succeed())
+
+		var expected *BidRequest
+		Expect(json.Unmarshal(data, &expected)).To(Succeed())
+		Expect(json.Marshal(subject)).To(MatchJSON(mustMarshal(expected)))
+	})
+
+	It("should reuse slices and clear stale values", func() {
+		subject := &BidRequest{
+			ID:  "OLD",
+			Imp: make([]Impression, 0, 4),
+			Cur: []string{"EUR", "GBP"},
+		}
+		subject.Imp = append(subject.Imp, Impression{ID: "X", Banner: &Banner{W: 300}, TagID: "T"}, Impression{ID: "Y", BidFloor: 1.5})
+		first := &subject.Imp[0]
+
+		Expect(UnmarshalInto([]byte(`{"id":"NEW","imp":[{"id":"1"},{"id":"2"},{"id":"3"}]}`), subject)).To(Succeed())
+		Expect(subject.ID).To(Equal("NEW"))
+		Expect(subject.Imp).To(Equal([]Impression{{ID: "1"}, {ID: "2"}, {ID: "3"}}))
+		Expect(&subject.Imp[0]).To(BeIdenticalTo(first))
+		Expect(subject.Cur).To(BeEmpty())
+		Expect(cap(subject.Cur)).To(Equal(2))
+	})
+
+	It("should reset responses", func() {
+		subject := &BidResponse{ID: "R", Currency: "USD", SeatBid: []SeatBid{{Seat: "S", Bid: []Bid{{ID: "B", Price: 1}}}}}
+		subject.Reset()
+		Expect(subject.ID).To(BeEmpty())
+		Expect(subject.Currency).To(BeEmpty())
+		Expect(subject.SeatBid).To(BeEmpty())
+		Expect(subject.SeatBid[:1][0]).To(Equal(SeatBid{Bid: []Bid{}}))
+	})
+
+})
+
+func mustMarshal(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	Expect(err).NotTo(HaveOccurred())
+	return data
+}
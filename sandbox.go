@@ -0,0 +1,279 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Segment taxonomies of the Topics API, see data.ext.segtax
+const (
+	SegTaxTopicsV1 = 600 // Chrome Topics API taxonomy v1
+	SegTaxTopicsV2 = 601 // Chrome Topics API taxonomy v2
+)
+
+// Auction environments, see imp.ext.ae
+const (
+	AuctionEnvironmentStandard     = 0 // Standard server-side auction only
+	AuctionEnvironmentOnDevice     = 1 // On-device interest group auction (Protected Audience)
+	AuctionEnvironmentServerSideIG = 3 // Server-side interest group auction (Bidding & Auction services)
+)
+
+// SegmentTaxonomy is the data.ext object, identifying the taxonomy of the
+// segments of a data object.
+type SegmentTaxonomy struct {
+	SegTax   int    `json:"segtax,omitempty"`   // Segment taxonomy ID, e.g. SegTaxTopicsV1
+	SegClass string `json:"segclass,omitempty"` // Version of the classifier which assigned the segments
+}
+
+// Topic is a single Topics API topic of a user, observed on a given domain.
+type Topic struct {
+	ID         int    // Topic ID within the taxonomy
+	Taxonomy   int    // Segment taxonomy ID, e.g. SegTaxTopicsV1
+	Classifier string // Classifier version, from data.ext.segclass
+	Domain     string // Domain which called the Topics API, from data.name
+}
+
+// InterestGroupIntent is an element of bidresponse.ext.igi, signalling the
+// intent of a bidder to participate in an interest group auction for an
+// impression.
+type InterestGroupIntent struct {
+	ImpID string                `json:"impid"`         // ID of the impression the intent applies to
+	IGB   []InterestGroupBuyer  `json:"igb,omitempty"` // Buyers participating in the auction
+	IGS   []InterestGroupSeller `json:"igs,omitempty"` // Seller auction configurations
+	Ext   Extension             `json:"ext,omitempty"`
+}
+
+// InterestGroupBuyer is the igb object, describing a buyer of an interest
+// group auction.
+type InterestGroupBuyer struct {
+	Origin string    `json:"origin"`           // Origin of the buyer, e.g. "https://buyer.example.com"
+	MaxBid float64   `json:"maxbid,omitempty"` // Maximum bid of the buyer, in cur
+	Cur    string    `json:"cur,omitempty"`    // Currency of maxbid, Default: "USD"
+	PBS    string    `json:"pbs,omitempty"`    // Per buyer signals, as encoded JSON
+	PS     Extension `json:"ps,omitempty"`     // Priority signals
+	Ext    Extension `json:"ext,omitempty"`
+}
+
+// InterestGroupSeller is the igs object, containing a component auction
+// configuration of a seller.
+type InterestGroupSeller struct {
+	Config Extension `json:"config"` // Auction configuration, passed to runAdAuction
+	Ext    Extension `json:"ext,omitempty"`
+}
+
+// InterestGroupSupport is the imp.ext.igs object, signalling the support of
+// interest group auctions for an impression.
+type InterestGroupSupport struct {
+	AE       int       `json:"ae,omitempty"`       // Auction environment, see AuctionEnvironment* constants
+	Biddable int       `json:"biddable,omitempty"` // 1 if the contextual auction is biddable alongside the interest group auction
+	Ext      Extension `json:"ext,omitempty"`
+}
+
+// InterestGroupBid is an element of bidresponse.ext.igbid, carrying the
+// interest group buyers of an impression for on-device auctions.
+type InterestGroupBid struct {
+	ImpID   string                  `json:"impid"`             // ID of the impression the buyers bid on
+	IGBuyer []InterestGroupBidBuyer `json:"igbuyer,omitempty"` // Interest group buyers
+	Ext     Extension               `json:"ext,omitempty"`
+}
+
+// InterestGroupBidBuyer is the igbuyer object, describing a buyer taking
+// part in an on-device auction.
+type InterestGroupBidBuyer struct {
+	IGDomain    string    `json:"igdomain"`              // Origin of the buyer, e.g. "https://buyer.example.com"
+	MaxBid      float64   `json:"maxbid,omitempty"`      // Maximum bid of the buyer, in cur
+	Cur         string    `json:"cur,omitempty"`         // Currency of maxbid, Default: "USD"
+	BuyerSignal Extension `json:"buyersignal,omitempty"` // Per buyer signals, passed to the buyer's generateBid
+	BuyerData   Extension `json:"buyerdata,omitempty"`   // Buyer data, passed to the seller's scoreAd
+	Ext         Extension `json:"ext,omitempty"`
+}
+
+// SegmentTaxonomy decodes data.ext. It returns nil if absent.
+func (d *Data) SegmentTaxonomy() (*SegmentTaxonomy, error) {
+	if len(d.Ext) == 0 {
+		return nil, nil
+	}
+
+	var tax SegmentTaxonomy
+	if err := json.Unmarshal(d.Ext, &tax); err != nil {
+		return nil, err
+	}
+	return &tax, nil
+}
+
+// Topics returns the Topics API topics from user.data entries with a Topics
+// segment taxonomy. Segments with non-numeric IDs are skipped.
+func (u *User) Topics() ([]Topic, error) {
+	var topics []Topic
+	for i := range u.Data {
+		d := &u.Data[i]
+		tax, err := d.SegmentTaxonomy()
+		if err != nil {
+			return nil, err
+		}
+		if tax == nil || !isTopicsTaxonomy(tax.SegTax) {
+			continue
+		}
+
+		for _, seg := range d.Segment {
+			id, err := strconv.Atoi(seg.ID)
+			if err != nil {
+				continue
+			}
+			topics = append(topics, Topic{ID: id, Taxonomy: tax.SegTax, Classifier: tax.SegClass, Domain: d.Name})
+		}
+	}
+	return topics, nil
+}
+
+// AddTopics appends a user.data entry with the given topics, as observed on
+// domain.
+func (u *User) AddTopics(domain string, segtax int, segclass string, ids ...int) error {
+	ext, err := json.Marshal(SegmentTaxonomy{SegTax: segtax, SegClass: segclass})
+	if err != nil {
+		return err
+	}
+
+	segments := make([]Segment, 0, len(ids))
+	for _, id := range ids {
+		segments = append(segments, Segment{ID: strconv.Itoa(id)})
+	}
+	u.Data = append(u.Data, Data{Name: domain, Segment: segments, Ext: ext})
+	return nil
+}
+
+// AuctionEnvironment decodes imp.ext.ae. It returns
+// AuctionEnvironmentStandard if absent.
+func (imp *Impression) AuctionEnvironment() (int, error) {
+	var ae int
+	if _, err := imp.Ext.getKey("ae", &ae); err != nil {
+		return 0, err
+	}
+	return ae, nil
+}
+
+// SetAuctionEnvironment stores ae as imp.ext.ae.
+func (imp *Impression) SetAuctionEnvironment(ae int) error {
+	ext, err := imp.Ext.setKey("ae", ae)
+	if err != nil {
+		return err
+	}
+	imp.Ext = ext
+	return nil
+}
+
+// InterestGroupSupport decodes imp.ext.igs. It returns nil if absent.
+func (imp *Impression) InterestGroupSupport() (*InterestGroupSupport, error) {
+	var igs *InterestGroupSupport
+	if _, err := imp.Ext.getKey("igs", &igs); err != nil {
+		return nil, err
+	}
+	return igs, nil
+}
+
+// SetInterestGroupSupport stores igs as imp.ext.igs. A nil value removes it.
+func (imp *Impression) SetInterestGroupSupport(igs *InterestGroupSupport) error {
+	ext, err := imp.Ext.setOrDeleteKey("igs", igs, igs == nil)
+	if err != nil {
+		return err
+	}
+	imp.Ext = ext
+	return nil
+}
+
+// AcceptsInterestGroups returns true if the impression supports interest
+// group auctions, as signalled by imp.ext.igs.ae or, failing that,
+// imp.ext.ae.
+func (imp *Impression) AcceptsInterestGroups() bool {
+	if igs, err := imp.InterestGroupSupport(); err == nil && igs != nil {
+		return igs.AE != AuctionEnvironmentStandard
+	}
+	ae, err := imp.AuctionEnvironment()
+	return err == nil && ae != AuctionEnvironmentStandard
+}
+
+// InterestGroupIntents decodes bidresponse.ext.igi.
+func (res *BidResponse) InterestGroupIntents() ([]InterestGroupIntent, error) {
+	var igi []InterestGroupIntent
+	if _, err := res.Ext.getKey("igi", &igi); err != nil {
+		return nil, err
+	}
+	return igi, nil
+}
+
+// SetInterestGroupIntents stores igi as bidresponse.ext.igi.
+func (res *BidResponse) SetInterestGroupIntents(igi []InterestGroupIntent) error {
+	ext, err := res.Ext.setKey("igi", igi)
+	if err != nil {
+		return err
+	}
+	res.Ext = ext
+	return nil
+}
+
+// InterestGroupBids decodes bidresponse.ext.igbid.
+func (res *BidResponse) InterestGroupBids() ([]InterestGroupBid, error) {
+	var igbid []InterestGroupBid
+	if _, err := res.Ext.getKey("igbid", &igbid); err != nil {
+		return nil, err
+	}
+	return igbid, nil
+}
+
+// SetInterestGroupBids stores igbid as bidresponse.ext.igbid. An empty
+// value removes it.
+func (res *BidResponse) SetInterestGroupBids(igbid []InterestGroupBid) error {
+	ext, err := res.Ext.setOrDeleteKey("igbid", igbid, len(igbid) == 0)
+	if err != nil {
+		return err
+	}
+	res.Ext = ext
+	return nil
+}
+
+// FilterInterestGroups removes the interest group intents and bids of the
+// response which reference impressions of req that do not accept interest
+// groups, see AcceptsInterestGroups.
+func (res *BidResponse) FilterInterestGroups(req *BidRequest) error {
+	accepts := func(impID string) bool {
+		imp := req.ImpByID(impID)
+		return imp != nil && imp.AcceptsInterestGroups()
+	}
+
+	igi, err := res.InterestGroupIntents()
+	if err != nil {
+		return err
+	}
+	if igi != nil {
+		kept := igi[:0]
+		for _, x := range igi {
+			if accepts(x.ImpID) {
+				kept = append(kept, x)
+			}
+		}
+		ext, err := res.Ext.setOrDeleteKey("igi", kept, len(kept) == 0)
+		if err != nil {
+			return err
+		}
+		res.Ext = ext
+	}
+
+	igbid, err := res.InterestGroupBids()
+	if err != nil {
+		return err
+	}
+	if igbid != nil {
+		kept := igbid[:0]
+		for _, x := range igbid {
+			if accepts(x.ImpID) {
+				kept = append(kept, x)
+			}
+		}
+		return res.SetInterestGroupBids(kept)
+	}
+	return nil
+}
+
+func isTopicsTaxonomy(segtax int) bool {
+	return segtax == SegTaxTopicsV1 || segtax == SegTaxTopicsV2
+}
@@ -0,0 +1,113 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Topics", func() {
+
+	It("should decode user.data topics", func() {
+		user := &User{Data: []Data{
+			{Name: "www.example.com", Segment: []Segment{{ID: "1"}, {ID: "186"}}, Ext: Extension(`{"segtax":600,"segclass":"4"}`)},
+			{Name: "provider", Segment: []Segment{{ID: "x"}}},
+			{Name: "other", Segment: []Segment{{ID: "7"}}, Ext: Extension(`{"segtax":4}`)},
+			{Name: "cdn.example.com", Segment: []Segment{{ID: "bad"}, {ID: "5"}}, Ext: Extension(`{"segtax":601,"segclass":"2206021246"}`)},
+		}}
+		Expect(user.Topics()).To(Equal([]Topic{
+			{ID: 1, Taxonomy: SegTaxTopicsV1, Classifier: "4", Domain: "www.example.com"},
+			{ID: 186, Taxonomy: SegTaxTopicsV1, Classifier: "4", Domain: "www.example.com"},
+			{ID: 5, Taxonomy: SegTaxTopicsV2, Classifier: "2206021246", Domain: "cdn.example.com"},
+		}))
+
+		Expect((&User{}).Topics()).To(BeEmpty())
+
+		_, err := (&User{Data: []Data{{Ext: Extension(`{"segtax":"600"}`)}}}).Topics()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should add topics", func() {
+		user := &User{}
+		Expect(user.AddTopics("www.example.com", SegTaxTopicsV2, "4", 1, 186)).To(Succeed())
+		Expect(user.Data).To(Equal([]Data{
+			{Name: "www.example.com", Segment: []Segment{{ID: "1"}, {ID: "186"}}, Ext: Extension(`{"segtax":601,"segclass":"4"}`)},
+		}))
+		Expect(user.Topics()).To(HaveLen(2))
+	})
+
+})
+
+var _ = Describe("Protected Audience", func() {
+
+	It("should decode/encode imp.ext.ae", func() {
+		imp := &Impression{ID: "1"}
+		Expect(imp.AuctionEnvironment()).To(Equal(AuctionEnvironmentStandard))
+
+		Expect(imp.SetAuctionEnvironment(AuctionEnvironmentOnDevice)).To(Succeed())
+		Expect(string(imp.Ext)).To(Equal(`{"ae":1}`))
+		Expect(imp.AuctionEnvironment()).To(Equal(AuctionEnvironmentOnDevice))
+	})
+
+	It("should decode/encode bidresponse.ext.igi", func() {
+		res := &BidResponse{ID: "1", Ext: Extension(`{"igi":[{"impid":"1","igb":[{"origin":"https://buyer.example.com","maxbid":1.5,"cur":"USD","pbs":"{\"a\":1}"}],"igs":[{"config":{"seller":"https://ssp.example.com"}}]}]}`)}
+		Expect(res.InterestGroupIntents()).To(Equal([]InterestGroupIntent{{
+			ImpID: "1",
+			IGB:   []InterestGroupBuyer{{Origin: "https://buyer.example.com", MaxBid: 1.5, Cur: "USD", PBS: `{"a":1}`}},
+			IGS:   []InterestGroupSeller{{Config: Extension(`{"seller":"https://ssp.example.com"}`)}},
+		}}))
+		Expect((&BidResponse{}).InterestGroupIntents()).To(BeNil())
+
+		res = &BidResponse{ID: "1"}
+		Expect(res.SetInterestGroupIntents([]InterestGroupIntent{{ImpID: "1", IGB: []InterestGroupBuyer{{Origin: "https://buyer.example.com"}}}})).To(Succeed())
+		Expect(string(res.Ext)).To(Equal(`{"igi":[{"impid":"1","igb":[{"origin":"https://buyer.example.com"}]}]}`))
+	})
+
+	It("should decode/encode imp.ext.igs", func() {
+		imp := &Impression{ID: "1", Ext: Extension(`{"igs":{"ae":1,"biddable":1}}`)}
+		Expect(imp.InterestGroupSupport()).To(Equal(&InterestGroupSupport{AE: AuctionEnvironmentOnDevice, Biddable: 1}))
+		Expect(imp.AcceptsInterestGroups()).To(BeTrue())
+
+		Expect(imp.SetInterestGroupSupport(&InterestGroupSupport{})).To(Succeed())
+		Expect(string(imp.Ext)).To(Equal(`{"igs":{}}`))
+		Expect(imp.AcceptsInterestGroups()).To(BeFalse())
+
+		Expect(imp.SetInterestGroupSupport(nil)).To(Succeed())
+		Expect(imp.Ext).To(BeNil())
+		Expect(imp.InterestGroupSupport()).To(BeNil())
+		Expect(imp.AcceptsInterestGroups()).To(BeFalse())
+
+		Expect(imp.SetAuctionEnvironment(AuctionEnvironmentServerSideIG)).To(Succeed())
+		Expect(imp.AcceptsInterestGroups()).To(BeTrue())
+	})
+
+	It("should decode/encode bidresponse.ext.igbid", func() {
+		res := &BidResponse{ID: "1", Ext: Extension(`{"igbid":[{"impid":"1","igbuyer":[{"igdomain":"https://buyer.example.com","maxbid":2,"buyersignal":{"a":1},"buyerdata":{"b":2}}]}]}`)}
+		Expect(res.InterestGroupBids()).To(Equal([]InterestGroupBid{{
+			ImpID: "1",
+			IGBuyer: []InterestGroupBidBuyer{{
+				IGDomain:    "https://buyer.example.com",
+				MaxBid:      2,
+				BuyerSignal: Extension(`{"a":1}`),
+				BuyerData:   Extension(`{"b":2}`),
+			}},
+		}}))
+
+		Expect(res.SetInterestGroupBids(nil)).To(Succeed())
+		Expect(res.Ext).To(BeNil())
+	})
+
+	It("should filter interest groups", func() {
+		req := &BidRequest{ID: "1", Imp: []Impression{
+			{ID: "1", Ext: Extension(`{"ae":1}`)},
+			{ID: "2"},
+		}}
+		res := &BidResponse{ID: "1", Ext: Extension(`{"other":true,"igi":[{"impid":"1"},{"impid":"2"}],"igbid":[{"impid":"2","igbuyer":[{"igdomain":"https://buyer.example.com"}]}]}`)}
+		Expect(res.FilterInterestGroups(req)).To(Succeed())
+		Expect(string(res.Ext)).To(Equal(`{"igi":[{"impid":"1"}],"other":true}`))
+
+		res = &BidResponse{ID: "1", Ext: Extension(`{"igi":[{"impid":"3"}]}`)}
+		Expect(res.FilterInterestGroups(req)).To(Succeed())
+		Expect(res.Ext).To(BeNil())
+	})
+
+})
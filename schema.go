@@ -0,0 +1,73 @@
+package openrtb
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/bsm/openrtb/jsonschema"
+)
+
+// ErrUnknownSchemaVersion is returned for spec versions which are not
+// listed in SupportedVersions.
+var ErrUnknownSchemaVersion = errors.New("openrtb: unknown schema version")
+
+// optionalFields are encoded without omitempty, but have defaults.
+var optionalFields = map[string]bool{
+	"BidRequest.AuctionType": true,
+}
+
+// schemaFields override the schemas of individual fields.
+var schemaFields = map[string]*jsonschema.Schema{
+	"Native.Request": {Type: jsonschema.Types{"string", "object"}}, // escaped JSON string, some exchanges send objects
+}
+
+var schemaTypes = map[reflect.Type]*jsonschema.Schema{
+	reflect.TypeOf(Extension(nil)):  {Type: jsonschema.Types{"object"}},
+	reflect.TypeOf(Flag(0)):         {Type: jsonschema.Types{"integer"}, Enum: []interface{}{0, 1}},
+	reflect.TypeOf(MultiString("")): {Type: jsonschema.Types{"string", "number"}},
+}
+
+// JSONSchema generates a JSON schema document for v, e.g. a *BidRequest or
+// a *BidResponse, as defined by the given spec version. Fields introduced
+// after version are omitted, see Version.Supports, and fields deprecated in
+// or before version are marked as deprecated.
+func JSONSchema(v interface{}, version Version) (*jsonschema.Schema, error) {
+	known := false
+	for _, ver := range SupportedVersions {
+		if ver == version {
+			known = true
+		}
+	}
+	if !known {
+		return nil, ErrUnknownSchemaVersion
+	}
+
+	r := &jsonschema.Reflector{
+		Types: schemaTypes,
+		Field: func(t reflect.Type, field reflect.StructField) *jsonschema.Schema {
+			if s, ok := schemaFields[t.Name()+"."+field.Name]; ok {
+				c := *s
+				return &c
+			}
+			return nil
+		},
+		Optional: func(t reflect.Type, field reflect.StructField) bool {
+			return optionalFields[t.Name()+"."+field.Name]
+		},
+		Deprecated: func(_ reflect.Type, field reflect.StructField) bool {
+			d, ok := parseDeprecation(field, "")
+			if !ok {
+				return false
+			}
+			since, err := ParseVersion(d.Since)
+			return err == nil && since.Compare(version) <= 0
+		},
+		Skip: func(t reflect.Type, field reflect.StructField) bool {
+			return !version.Supports(t.Name() + "." + field.Name)
+		},
+	}
+
+	s := r.Reflect(v)
+	s.Title = "OpenRTB " + version.String()
+	return s, nil
+}
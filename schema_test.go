@@ -0,0 +1,99 @@
+package openrtb
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONSchema", func() {
+
+	It("should reject unknown versions", func() {
+		_, err := JSONSchema(&BidRequest{}, Version{Major: 1})
+		Expect(err).To(Equal(ErrUnknownSchemaVersion))
+	})
+
+	It("should generate request schemas", func() {
+		s, err := JSONSchema(&BidRequest{}, Version26)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Title).To(Equal("OpenRTB 2.6"))
+		Expect(s.Ref).To(Equal("#/definitions/BidRequest"))
+		Expect(s.Definitions).To(HaveKey("Impression"))
+		Expect(s.Definitions).To(HaveKey("Site"))
+
+		req := s.Definitions["BidRequest"]
+		Expect(req.Required).To(Equal([]string{"id"}))
+		Expect(req.Properties["imp"].Items.Ref).To(Equal("#/definitions/Impression"))
+		Expect(req.Properties["ext"].Type).To(ConsistOf("object"))
+		Expect(req.Properties["pmp"].Deprecated).To(BeTrue())
+
+		site := s.Definitions["Site"]
+		Expect(site.Properties).To(HaveKey("page"))
+		Expect(site.Properties).To(HaveKey("domain")) // embedded inventory
+
+		Expect(s.Definitions["Device"].Properties["dnt"].Enum).To(Equal([]interface{}{0, 1}))
+		Expect(s.Definitions["Device"].Properties["didsha1"].Deprecated).To(BeTrue())
+		Expect(s.Definitions["Audio"].Required).To(Equal([]string{"mimes"}))
+	})
+
+	It("should mark deprecations by version", func() {
+		s, err := JSONSchema(&BidRequest{}, Version25)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Definitions["Device"].Properties["didsha1"].Deprecated).To(BeFalse())
+		Expect(s.Definitions["Video"].Properties["protocol"].Deprecated).To(BeTrue())
+	})
+
+	It("should omit fields introduced after the version", func() {
+		s, err := JSONSchema(&BidRequest{}, Version25)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Definitions["BidRequest"].Properties).NotTo(HaveKey("dooh"))
+		Expect(s.Definitions["BidRequest"].Properties).NotTo(HaveKey("cattax"))
+		Expect(s.Definitions["BidRequest"].Properties).To(HaveKey("source"))
+		Expect(s.Definitions["Impression"].Properties).NotTo(HaveKey("rwdd"))
+		Expect(s.Definitions["Impression"].Properties).NotTo(HaveKey("qty"))
+		Expect(s.Definitions["Impression"].Properties).NotTo(HaveKey("ssai"))
+		Expect(s.Definitions["Impression"].Properties).NotTo(HaveKey("refresh"))
+		Expect(s.Definitions["Site"].Properties).NotTo(HaveKey("cattax"))
+		Expect(s.Definitions["Content"].Properties).NotTo(HaveKey("network"))
+		Expect(s.Definitions["Content"].Properties).NotTo(HaveKey("channel"))
+
+		s, err = JSONSchema(&BidRequest{}, Version24)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Title).To(Equal("OpenRTB 2.4"))
+		Expect(s.Definitions["BidRequest"].Properties).NotTo(HaveKey("source"))
+
+		s, err = JSONSchema(&BidRequest{}, Version26)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Definitions["Impression"].Properties).To(HaveKey("refresh"))
+		Expect(s.Definitions["Site"].Properties).To(HaveKey("cattax"))
+	})
+
+	It("should validate requests", func() {
+		s, err := JSONSchema(&BidRequest{}, Version26)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, name := range []string{"breq.banner", "breq.video", "breq.native", "breq.exp"} {
+			data, err := ioutil.ReadFile("testdata/" + name + ".json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.ValidateJSON(data, "")).To(Succeed(), name)
+		}
+
+		err = s.ValidateJSON([]byte(`{"imp":[{"id":1}]}`), "")
+		Expect(err).To(MatchError("id: is required; imp[0].id: expected string, got integer"))
+	})
+
+	It("should validate responses", func() {
+		s, err := JSONSchema(&BidResponse{}, Version26)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Definitions["BidResponse"].Required).To(Equal([]string{"id", "seatbid"}))
+		Expect(s.Definitions["Bid"].Required).To(Equal([]string{"id", "impid", "price"}))
+
+		for _, name := range []string{"bres.single", "bres.multi", "bres.pmp", "bres.vast"} {
+			data, err := ioutil.ReadFile("testdata/" + name + ".json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.ValidateJSON(data, "")).To(Succeed(), name)
+		}
+	})
+
+})
@@ -0,0 +1,57 @@
+package openrtb
+
+// AllowsSeat returns true if seat is permitted to bid on the request, i.e.
+// if it is listed in wseat (if present) and not listed in bseat. Seats are
+// compared case-sensitively.
+func (req *BidRequest) AllowsSeat(seat string) bool {
+	return req.CheckSeat(seat) == nil
+}
+
+// CheckSeat checks the seat of a seatbid against the wseat and bseat lists
+// of the request. Requests with a wseat list only allow the listed seats,
+// bids without a seat are rejected in this case. It returns nil if the seat
+// is permitted or a rejection with LossSeatBlocked, which can be passed on
+// in loss notices.
+func (req *BidRequest) CheckSeat(seat string) *Rejection {
+	if len(req.WSeat) != 0 && !containsString(req.WSeat, seat) {
+		return &Rejection{Code: LossSeatBlocked, Field: "wseat", Value: seat, Reason: "seat not allowed"}
+	}
+	if seat != "" && containsString(req.BSeat, seat) {
+		return &Rejection{Code: LossSeatBlocked, Field: "bseat", Value: seat, Reason: "blocked seat " + seat}
+	}
+	return nil
+}
+
+// SeatRejection is a seatbid removed by FilterSeats.
+type SeatRejection struct {
+	SeatBid   SeatBid    // The removed seatbid
+	Rejection *Rejection // The reason, see CheckSeat
+}
+
+// FilterSeats removes the seatbids of seats which are not permitted by the
+// request, see CheckSeat, and returns them with their rejections. The bids
+// of all other seats are retained.
+func (res *BidResponse) FilterSeats(req *BidRequest) []SeatRejection {
+	var rejected []SeatRejection
+	kept := res.SeatBid[:0]
+	for _, sb := range res.SeatBid {
+		if rej := req.CheckSeat(sb.Seat); rej != nil {
+			rejected = append(rejected, SeatRejection{SeatBid: sb, Rejection: rej})
+			continue
+		}
+		kept = append(kept, sb)
+	}
+	if rejected != nil {
+		res.SeatBid = kept
+	}
+	return rejected
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{ID: "R", Imp: []Impression{{ID: "I", Banner: &Banner{}}}}
+	})
+
+	It("should allow all seats by default", func() {
+		Expect(subject.AllowsSeat("s1")).To(BeTrue())
+		Expect(subject.AllowsSeat("")).To(BeTrue())
+	})
+
+	It("should check wseat", func() {
+		subject.WSeat = []string{"s1", "s2"}
+		Expect(subject.CheckSeat("s1")).To(BeNil())
+		Expect(subject.CheckSeat("s3")).To(Equal(&Rejection{Code: LossSeatBlocked, Field: "wseat", Value: "s3", Reason: "seat not allowed"}))
+		Expect(subject.AllowsSeat("")).To(BeFalse())
+	})
+
+	It("should check bseat", func() {
+		subject.BSeat = []string{"s1"}
+		Expect(subject.CheckSeat("s1")).To(Equal(&Rejection{Code: LossSeatBlocked, Field: "bseat", Value: "s1", Reason: "blocked seat s1"}))
+		Expect(subject.CheckSeat("s2")).To(BeNil())
+		Expect(subject.AllowsSeat("")).To(BeTrue())
+	})
+
+	It("should validate response seats", func() {
+		res := &BidResponse{ID: "R", SeatBid: []SeatBid{
+			{Seat: "s1", Bid: []Bid{{ID: "B1", ImpID: "I", Price: 1}}},
+			{Seat: "s2", Bid: []Bid{{ID: "B2", ImpID: "I", Price: 1}}},
+		}}
+		Expect(res.ValidateForRequest(subject)).To(Succeed())
+
+		subject.BSeat = []string{"s2"}
+		err := res.ValidateForRequest(subject)
+		Expect(err).To(MatchError(ErrInvalidSeatBidSeat))
+		Expect(err).To(MatchError("openrtb: seatbid seat is not permitted by request (seatbid[1].seat)"))
+		Expect(ValidationCode(err)).To(Equal("seatbid_seat_blocked"))
+	})
+
+	It("should filter response seats", func() {
+		res := &BidResponse{ID: "R", SeatBid: []SeatBid{
+			{Seat: "s1", Bid: []Bid{{ID: "B1", ImpID: "I", Price: 1}}},
+			{Seat: "s2", Bid: []Bid{{ID: "B2", ImpID: "I", Price: 1}}},
+			{Seat: "s3", Bid: []Bid{{ID: "B3", ImpID: "I", Price: 1}}},
+		}}
+		Expect(res.FilterSeats(subject)).To(BeEmpty())
+		Expect(res.SeatBid).To(HaveLen(3))
+
+		subject.BSeat = []string{"s2"}
+		rejected := res.FilterSeats(subject)
+		Expect(rejected).To(HaveLen(1))
+		Expect(rejected[0].SeatBid.Seat).To(Equal("s2"))
+		Expect(rejected[0].Rejection.Code).To(Equal(LossSeatBlocked))
+		Expect(rejected[0].Rejection.Field).To(Equal("bseat"))
+		Expect(res.SeatBid).To(HaveLen(2))
+		Expect(res.SeatBid[1].Seat).To(Equal("s3"))
+		Expect(res.ValidateForRequest(subject)).To(Succeed())
+	})
+
+})
@@ -17,20 +17,56 @@ type SeatBid struct {
 
 // Validation errors
 var (
-	ErrInvalidSeatBidBid = errors.New("openrtb: seatbid is missing bids")
+	ErrInvalidSeatBidBid   = errors.New("openrtb: seatbid is missing bids")
+	ErrInvalidSeatBidSeat  = errors.New("openrtb: seatbid seat is not permitted by request") // not in wseat or in bseat
+	ErrInvalidSeatBidGroup = errors.New("openrtb: seatbid group has multiple bids for an impression")
 )
 
 // Validate required attributes
 func (sb *SeatBid) Validate() error {
-	if len(sb.Bid) == 0 {
-		return ErrInvalidSeatBidBid
+	v := new(validator)
+	sb.validate(v, "")
+	return v.err()
+}
+
+func (sb *SeatBid) validate(v *validator, path string) bool {
+	if len(sb.Bid) == 0 && !v.add(path, ErrInvalidSeatBidBid) {
+		return false
+	}
+	if sb.IsGroup() && len(sb.ImpIDs()) != len(sb.Bid) && !v.add(path, ErrInvalidSeatBidGroup) {
+		return false
 	}
 
-	for _, bid := range sb.Bid {
-		if err := bid.Validate(); err != nil {
-			return err
+	for i := range sb.Bid {
+		if !v.add(joinPath(path, indexPath("bid", i)), sb.Bid[i].Validate()) {
+			return false
 		}
 	}
 
-	return nil
+	return true
+}
+
+// IsGroup returns true if the bids of the seatbid must be won or lost as a
+// group.
+func (sb *SeatBid) IsGroup() bool { return sb.Group == 1 }
+
+// ImpIDs returns the distinct impression IDs of the bids, in order.
+func (sb *SeatBid) ImpIDs() []string {
+	ids := make([]string, 0, len(sb.Bid))
+	for i := range sb.Bid {
+		if id := sb.Bid[i].ImpID; !containsString(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// TotalPrice returns the aggregate price of all bids, in the currency of the
+// response. This is the price of a group, see IsGroup.
+func (sb *SeatBid) TotalPrice() float64 {
+	total := 0.0
+	for i := range sb.Bid {
+		total += sb.Bid[i].Price
+	}
+	return total
 }
@@ -8,10 +8,28 @@ import (
 var _ = Describe("SeatBid", func() {
 
 	It("should validate", func() {
-		Expect((&SeatBid{}).Validate()).To(Equal(ErrInvalidSeatBidBid))
+		Expect((&SeatBid{}).Validate()).To(MatchError(ErrInvalidSeatBidBid))
 		Expect((&SeatBid{Bid: []Bid{
 			{ID: "BIDID", ImpID: "IMPID"}},
 		}).Validate()).NotTo(HaveOccurred())
 	})
 
+	It("should validate groups", func() {
+		subject := &SeatBid{Group: 1, Bid: []Bid{
+			{ID: "B1", ImpID: "I1", Price: 1},
+			{ID: "B2", ImpID: "I2", Price: 2.5},
+		}}
+		Expect(subject.IsGroup()).To(BeTrue())
+		Expect(subject.ImpIDs()).To(Equal([]string{"I1", "I2"}))
+		Expect(subject.TotalPrice()).To(Equal(3.5))
+		Expect(subject.Validate()).To(Succeed())
+
+		subject.Bid[1].ImpID = "I1"
+		Expect(subject.ImpIDs()).To(Equal([]string{"I1"}))
+		Expect(subject.Validate()).To(MatchError(ErrInvalidSeatBidGroup))
+
+		subject.Group = 0
+		Expect(subject.Validate()).To(Succeed())
+	})
+
 })
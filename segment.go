@@ -0,0 +1,83 @@
+package openrtb
+
+import "encoding/json"
+
+type jsonData Data
+
+// UnmarshalJSON custom unmarshalling with support for segtax passed as a
+// top-level field, which is moved to ext where seller-defined audiences
+// expect it.
+func (d *Data) UnmarshalJSON(data []byte) error {
+	var h struct {
+		jsonData
+		SegTax *int `json:"segtax"`
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+
+	*d = (Data)(h.jsonData)
+	if h.SegTax != nil && d.SegTax() == 0 {
+		return d.SetSegTax(*h.SegTax)
+	}
+	return nil
+}
+
+// SegTax returns the taxonomy of the segments, as declared in ext.segtax,
+// see CatTax* constants. It returns 0 if no taxonomy is declared, see
+// SegmentTaxonomy for the full data.ext object.
+func (d *Data) SegTax() int {
+	tax, err := d.SegmentTaxonomy()
+	if err != nil || tax == nil {
+		return 0
+	}
+	return tax.SegTax
+}
+
+// SetSegTax declares the taxonomy of the segments in ext.segtax.
+func (d *Data) SetSegTax(segtax int) error {
+	ext, err := d.Ext.setKey("segtax", segtax)
+	if err != nil {
+		return err
+	}
+	d.Ext = ext
+	return nil
+}
+
+// SegmentsByTax returns all segments of data which use the taxonomy segtax.
+func SegmentsByTax(data []Data, segtax int) []Segment {
+	var segs []Segment
+	for i := range data {
+		if data[i].SegTax() == segtax {
+			segs = append(segs, data[i].Segment...)
+		}
+	}
+	return segs
+}
+
+// AudienceSegments returns the user segments which use the taxonomy segtax,
+// e.g. CatTaxIABAudience11 for seller-defined audiences.
+func (req *BidRequest) AudienceSegments(segtax int) []Segment {
+	if req.User == nil {
+		return nil
+	}
+	return SegmentsByTax(req.User.Data, segtax)
+}
+
+// ContentSegments returns the content segments of the site, app or DOOH
+// placement which use the taxonomy segtax, e.g. CatTaxIABContent22.
+func (req *BidRequest) ContentSegments(segtax int) []Segment {
+	var content *Content
+	switch {
+	case req.Site != nil:
+		content = req.Site.Content
+	case req.App != nil:
+		content = req.App.Content
+	case req.DOOH != nil:
+		content = req.DOOH.Content
+	}
+	if content == nil {
+		return nil
+	}
+	return SegmentsByTax(content.Data, segtax)
+}
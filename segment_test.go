@@ -0,0 +1,63 @@
+package openrtb
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Data", func() {
+
+	It("should read and write segtax", func() {
+		subject := &Data{ID: "D"}
+		Expect(subject.SegTax()).To(Equal(0))
+		Expect(subject.SetSegTax(CatTaxIABAudience11)).To(Succeed())
+		Expect(subject.SegTax()).To(Equal(4))
+		Expect(string(subject.Ext)).To(MatchJSON(`{"segtax":4}`))
+
+		subject.Ext = Extension(`bad`)
+		Expect(subject.SegTax()).To(Equal(0))
+		Expect(subject.SetSegTax(4)).NotTo(Succeed())
+	})
+
+	It("should move top-level segtax to ext", func() {
+		var subject *Data
+		Expect(json.Unmarshal([]byte(`{"id":"D","segtax":6,"segment":[{"id":"1","ext":{"x":1}}]}`), &subject)).To(Succeed())
+		Expect(subject.SegTax()).To(Equal(6))
+		Expect(subject.Segment).To(Equal([]Segment{{ID: "1", Ext: Extension(`{"x":1}`)}}))
+
+		Expect(json.Unmarshal([]byte(`{"id":"D","segtax":6,"ext":{"segtax":7}}`), &subject)).To(Succeed())
+		Expect(subject.SegTax()).To(Equal(7))
+	})
+
+})
+
+var _ = Describe("BidRequest", func() {
+	var subject *BidRequest
+
+	BeforeEach(func() {
+		subject = &BidRequest{
+			ID: "R",
+			User: &User{Data: []Data{
+				{ID: "A", Segment: []Segment{{ID: "1"}, {ID: "2"}}, Ext: Extension(`{"segtax":4}`)},
+				{ID: "B", Segment: []Segment{{ID: "3"}}},
+				{ID: "C", Segment: []Segment{{ID: "4"}}, Ext: Extension(`{"segtax":4}`)},
+			}},
+			Site: &Site{Inventory: Inventory{Content: &Content{Data: []Data{
+				{ID: "X", Segment: []Segment{{ID: "5"}}, Ext: Extension(`{"segtax":6}`)},
+			}}}},
+		}
+	})
+
+	It("should extract segments by taxonomy", func() {
+		Expect(subject.AudienceSegments(CatTaxIABAudience11)).To(Equal([]Segment{{ID: "1"}, {ID: "2"}, {ID: "4"}}))
+		Expect(subject.AudienceSegments(CatTaxIABContent22)).To(BeEmpty())
+		Expect(subject.ContentSegments(CatTaxIABContent22)).To(Equal([]Segment{{ID: "5"}}))
+		Expect(SegmentsByTax(subject.User.Data, 0)).To(Equal([]Segment{{ID: "3"}}))
+
+		Expect((&BidRequest{}).AudienceSegments(4)).To(BeNil())
+		Expect((&BidRequest{}).ContentSegments(6)).To(BeNil())
+	})
+
+})
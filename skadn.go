@@ -0,0 +1,149 @@
+package openrtb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrInvalidSKAdNNoVersion    = errors.New("openrtb: skadn version missing")
+	ErrInvalidSKAdNNoNetwork    = errors.New("openrtb: skadn network missing")
+	ErrInvalidSKAdNNoCampaign   = errors.New("openrtb: skadn campaign or source identifier missing")
+	ErrInvalidSKAdNNoITunesItem = errors.New("openrtb: skadn itunesitem missing")
+	ErrInvalidSKAdNNoSourceApp  = errors.New("openrtb: skadn sourceapp missing")
+	ErrInvalidSKAdNNoSignature  = errors.New("openrtb: skadn signature missing")
+	ErrInvalidSKAdNNoNonce      = errors.New("openrtb: skadn nonce missing")
+	ErrInvalidSKAdNNoTimestamp  = errors.New("openrtb: skadn timestamp missing")
+	ErrInvalidSKAdNNoFidelities = errors.New("openrtb: skadn fidelities missing")
+)
+
+// SKAdNetwork Fidelity Types
+const (
+	SKAdNetworkFidelityViewThrough int = iota
+	SKAdNetworkFidelityStoreKit
+)
+
+// SKAdNetworkRequest is the imp.ext.skadn object, signalling the SKAdNetwork
+// versions and network IDs supported by the publisher app.
+type SKAdNetworkRequest struct {
+	Version     string    `json:"version,omitempty"`     // DEPRECATED: Version of SKAdNetwork supported, replaced by Versions
+	Versions    []string  `json:"versions,omitempty"`    // Array of strings containing the supported SKAdNetwork versions
+	SourceApp   string    `json:"sourceapp,omitempty"`   // ID of publisher app in Apple's App Store
+	SKAdNetIDs  []string  `json:"skadnetids,omitempty"`  // A subset of SKAdNetworkItem entries in the publisher app's info.plist
+	ProductPage int       `json:"productpage,omitempty"` // Custom product page support, where 0 = not supported, 1 = supported
+	SKOverlay   int       `json:"skoverlay,omitempty"`   // Indicates whether the publisher app supports SKOverlay, where 0 = no, 1 = yes
+	Ext         Extension `json:"ext,omitempty"`
+}
+
+// SKAdNetworkResponse is the bid.ext.skadn object, containing the signed
+// SKAdNetwork parameters required to attribute an install.
+type SKAdNetworkResponse struct {
+	Version          string                `json:"version,omitempty"`          // Version of SKAdNetwork desired
+	Network          string                `json:"network,omitempty"`          // Ad network identifier used in signature
+	Campaign         string                `json:"campaign,omitempty"`         // Campaign ID compatible with Apple's spec, up to version 3.0
+	SourceIdentifier string                `json:"sourceidentifier,omitempty"` // Four-digit integer source identifier, replaces campaign from version 4.0
+	ITunesItem       string                `json:"itunesitem,omitempty"`       // ID of advertiser's app in Apple's App Store
+	ProductPageID    string                `json:"productpageid,omitempty"`    // ID of custom product page to display
+	Fidelities       []SKAdNetworkFidelity `json:"fidelities,omitempty"`       // Signed fidelity types, required from version 2.2
+	Nonce            string                `json:"nonce,omitempty"`            // Unique value for each ad response, up to version 2.1
+	SourceApp        string                `json:"sourceapp,omitempty"`        // ID of publisher's app in Apple's App Store
+	Timestamp        string                `json:"timestamp,omitempty"`        // Unix time in millis used at the time of signature, up to version 2.1
+	Signature        string                `json:"signature,omitempty"`        // SKAdNetwork signature, up to version 2.1
+	Ext              Extension             `json:"ext,omitempty"`
+}
+
+// SKAdNetworkFidelity is a signature for a single fidelity type
+type SKAdNetworkFidelity struct {
+	Fidelity  int       `json:"fidelity"`            // The fidelity type of the attribution to track
+	Signature string    `json:"signature,omitempty"` // SKAdNetwork signature
+	Nonce     string    `json:"nonce,omitempty"`     // Unique value for each ad response
+	Timestamp string    `json:"timestamp,omitempty"` // Unix time in millis used at the time of signature
+	Ext       Extension `json:"ext,omitempty"`
+}
+
+// Validate validates the signed fields required for r.Version
+func (r *SKAdNetworkResponse) Validate() error {
+	if r.Version == "" {
+		return ErrInvalidSKAdNNoVersion
+	} else if r.Network == "" {
+		return ErrInvalidSKAdNNoNetwork
+	} else if r.ITunesItem == "" {
+		return ErrInvalidSKAdNNoITunesItem
+	} else if r.SourceApp == "" {
+		return ErrInvalidSKAdNNoSourceApp
+	}
+
+	if skadnVersionBefore(r.Version, 4, 0) {
+		if r.Campaign == "" {
+			return ErrInvalidSKAdNNoCampaign
+		}
+	} else if r.SourceIdentifier == "" && r.Campaign == "" {
+		return ErrInvalidSKAdNNoCampaign
+	}
+
+	if skadnVersionBefore(r.Version, 2, 2) {
+		return validateSKAdNSignature(r.Signature, r.Nonce, r.Timestamp)
+	}
+
+	if len(r.Fidelities) == 0 {
+		return ErrInvalidSKAdNNoFidelities
+	}
+	for _, f := range r.Fidelities {
+		if err := validateSKAdNSignature(f.Signature, f.Nonce, f.Timestamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SKAdNetwork decodes imp.ext.skadn. It returns nil if absent.
+func (imp *Impression) SKAdNetwork() (*SKAdNetworkRequest, error) {
+	var skadn *SKAdNetworkRequest
+	if _, err := imp.Ext.getKey("skadn", &skadn); err != nil {
+		return nil, err
+	}
+	return skadn, nil
+}
+
+// SKAdNetwork decodes bid.ext.skadn. It returns nil if absent.
+func (bid *Bid) SKAdNetwork() (*SKAdNetworkResponse, error) {
+	var skadn *SKAdNetworkResponse
+	if _, err := bid.Ext.getKey("skadn", &skadn); err != nil {
+		return nil, err
+	}
+	return skadn, nil
+}
+
+// SetSKAdNetwork stores skadn as bid.ext.skadn.
+func (bid *Bid) SetSKAdNetwork(skadn *SKAdNetworkResponse) error {
+	ext, err := bid.Ext.setKey("skadn", skadn)
+	if err != nil {
+		return err
+	}
+	bid.Ext = ext
+	return nil
+}
+
+func validateSKAdNSignature(signature, nonce, timestamp string) error {
+	if signature == "" {
+		return ErrInvalidSKAdNNoSignature
+	} else if nonce == "" {
+		return ErrInvalidSKAdNNoNonce
+	} else if timestamp == "" {
+		return ErrInvalidSKAdNNoTimestamp
+	}
+	return nil
+}
+
+// skadnVersionBefore returns true if version v is lower than major.minor.
+func skadnVersionBefore(v string, major, minor int) bool {
+	parts := strings.SplitN(v, ".", 3)
+	vmajor, _ := strconv.Atoi(parts[0])
+	vminor := 0
+	if len(parts) > 1 {
+		vminor, _ = strconv.Atoi(parts[1])
+	}
+	return vmajor < major || (vmajor == major && vminor < minor)
+}
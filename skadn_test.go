@@ -0,0 +1,64 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SKAdNetwork", func() {
+
+	It("should decode imp.ext.skadn", func() {
+		imp := &Impression{ID: "1", Ext: Extension(`{"skadn":{"versions":["2.0","2.2"],"sourceapp":"880047117","skadnetids":["4fzdc2evr5.skadnetwork"],"productpage":1}}`)}
+		Expect(imp.SKAdNetwork()).To(Equal(&SKAdNetworkRequest{
+			Versions:    []string{"2.0", "2.2"},
+			SourceApp:   "880047117",
+			SKAdNetIDs:  []string{"4fzdc2evr5.skadnetwork"},
+			ProductPage: 1,
+		}))
+
+		Expect((&Impression{ID: "1"}).SKAdNetwork()).To(BeNil())
+	})
+
+	It("should encode bid.ext.skadn", func() {
+		bid := &Bid{ID: "1", Ext: Extension(`{"other":1}`)}
+		Expect(bid.SetSKAdNetwork(&SKAdNetworkResponse{Version: "2.2", Network: "cdkw7geqsh.skadnetwork"})).To(Succeed())
+		Expect(string(bid.Ext)).To(Equal(`{"other":1,"skadn":{"version":"2.2","network":"cdkw7geqsh.skadnetwork"}}`))
+		Expect(bid.SKAdNetwork()).To(Equal(&SKAdNetworkResponse{Version: "2.2", Network: "cdkw7geqsh.skadnetwork"}))
+	})
+
+	It("should validate", func() {
+		subject := &SKAdNetworkResponse{}
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoVersion))
+		subject.Version = "2.0"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoNetwork))
+		subject.Network = "cdkw7geqsh.skadnetwork"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoITunesItem))
+		subject.ITunesItem = "880047117"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoSourceApp))
+		subject.SourceApp = "123456789"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoCampaign))
+		subject.Campaign = "45"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoSignature))
+		subject.Signature = "MEQCIEQ"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoNonce))
+		subject.Nonce = "473b1a16-b4ef-43ad-9591-fcf3aefa82a7"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoTimestamp))
+		subject.Timestamp = "1594406341"
+		Expect(subject.Validate()).To(Succeed())
+
+		subject.Version = "2.2"
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoFidelities))
+		subject.Fidelities = []SKAdNetworkFidelity{{Fidelity: SKAdNetworkFidelityStoreKit, Signature: "MEQCIEQ"}}
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoNonce))
+		subject.Fidelities[0].Nonce = "473b1a16-b4ef-43ad-9591-fcf3aefa82a7"
+		subject.Fidelities[0].Timestamp = "1594406341"
+		Expect(subject.Validate()).To(Succeed())
+
+		subject.Version = "4.0"
+		subject.Campaign = ""
+		Expect(subject.Validate()).To(Equal(ErrInvalidSKAdNNoCampaign))
+		subject.SourceIdentifier = "3120"
+		Expect(subject.Validate()).To(Succeed())
+	})
+
+})
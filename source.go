@@ -0,0 +1,92 @@
+package openrtb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// This object describes the nature and behavior of the entity that is the source of the bid request
+// upstream from the exchange. The primary purpose of this object is to define post-auction or upstream
+// decisioning when the exchange itself does not control the final decision.
+type Source struct {
+	FD     int          `json:"fd,omitempty"`     // Entity responsible for the final impression sale decision, where 0 = exchange, 1 = upstream source
+	TID    string       `json:"tid,omitempty"`    // Transaction ID that must be common across all participants in this bid request (e.g., potentially multiple exchanges)
+	PChain string       `json:"pchain,omitempty"` // Payment ID chain string containing embedded syntax described in the TAG Payment ID Protocol v1.0
+	SChain *SupplyChain `json:"schain,omitempty"` // Supply chain object, representing all parties who are involved in the sale
+	Ext    Extension    `json:"ext,omitempty"`
+}
+
+// This object is composed of a set of nodes where each node represents a specific entity that participates
+// in the transacting of inventory. The entire chain of nodes from beginning to end represents all entities
+// who are involved in the direct flow of payment for inventory.
+type SupplyChain struct {
+	Complete int               `json:"complete"` // Flag indicating whether the chain contains all nodes involved in the transaction leading back to the owner of the site, app or other medium of the inventory, where 0 = no, 1 = yes
+	Nodes    []SupplyChainNode `json:"nodes"`    // Array of nodes, in the order the transaction passed through
+	Ver      string            `json:"ver"`      // Version of the supply chain specification in use, in the format of "major.minor"
+	Ext      Extension         `json:"ext,omitempty"`
+}
+
+// The identity of an entity participating in the supply chain.
+type SupplyChainNode struct {
+	ASI    string    `json:"asi"`              // The canonical domain name of the SSP, Exchange, Header Wrapper, etc system that bidders connect to
+	SID    string    `json:"sid"`              // The identifier associated with the seller or reseller account within the advertising system
+	RID    string    `json:"rid,omitempty"`    // The OpenRTB RequestId of the request as issued by this seller
+	Name   string    `json:"name,omitempty"`   // The name of the company (the legal entity) that is paid for inventory transacted under the given SID
+	Domain string    `json:"domain,omitempty"` // The business domain name of the entity represented by this node
+	HP     int       `json:"hp"`               // Indicates whether this node will be involved in the flow of payment for the inventory, 1 = yes
+	Ext    Extension `json:"ext,omitempty"`
+}
+
+// NewTransactionID generates a random, UUID (v4) formatted transaction ID.
+func NewTransactionID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+// TransactionID returns the source transaction ID, if any
+func (req *BidRequest) TransactionID() string {
+	if req.Source == nil {
+		return ""
+	}
+	return req.Source.TID
+}
+
+// EnsureTransactionID returns the source transaction ID, generating and
+// storing a new one if missing. Exchanges should call it once, when a
+// request enters the system.
+func (req *BidRequest) EnsureTransactionID() string {
+	if req.Source == nil {
+		req.Source = new(Source)
+	}
+	if req.Source.TID == "" {
+		req.Source.TID = NewTransactionID()
+	}
+	return req.Source.TID
+}
+
+// PropagateTransactionID copies the transaction ID of req to dst, e.g. when
+// forwarding a request to a downstream bidder. A transaction ID is generated
+// for req if missing.
+func (req *BidRequest) PropagateTransactionID(dst *BidRequest) {
+	tid := req.EnsureTransactionID()
+	if dst.Source == nil {
+		dst.Source = new(Source)
+	}
+	dst.Source.TID = tid
+}
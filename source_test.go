@@ -0,0 +1,56 @@
+package openrtb
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Source", func() {
+	var subject *Source
+
+	BeforeEach(func() {
+		err := fixture("source", &subject)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse correctly", func() {
+		Expect(subject).To(Equal(&Source{
+			FD:     1,
+			TID:    "ed3f4b00-1b3c-4a1e-9c7e-5f5c1e2b7a90",
+			PChain: "cb8e2f1a:1a2b3c4d",
+			SChain: &SupplyChain{
+				Complete: 1,
+				Ver:      "1.0",
+				Nodes: []SupplyChainNode{
+					{ASI: "exchange1.com", SID: "1234", HP: 1, RID: "bid-request-1", Name: "publisher", Domain: "publisher.com"},
+				},
+			},
+		}))
+	})
+
+	It("should generate transaction IDs", func() {
+		tid := NewTransactionID()
+		Expect(tid).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+		Expect(NewTransactionID()).NotTo(Equal(tid))
+	})
+
+	It("should ensure and propagate transaction IDs", func() {
+		req := &BidRequest{ID: "r"}
+		Expect(req.TransactionID()).To(BeEmpty())
+
+		tid := req.EnsureTransactionID()
+		Expect(tid).NotTo(BeEmpty())
+		Expect(req.TransactionID()).To(Equal(tid))
+		Expect(req.EnsureTransactionID()).To(Equal(tid))
+
+		dst := &BidRequest{ID: "d", Source: &Source{FD: 1, TID: "other"}}
+		req.PropagateTransactionID(dst)
+		Expect(dst.Source).To(Equal(&Source{FD: 1, TID: tid}))
+
+		req = &BidRequest{ID: "r", Source: subject}
+		dst = &BidRequest{ID: "d"}
+		req.PropagateTransactionID(dst)
+		Expect(dst.TransactionID()).To(Equal("ed3f4b00-1b3c-4a1e-9c7e-5f5c1e2b7a90"))
+	})
+
+})
@@ -0,0 +1,47 @@
+package openrtb
+
+// Server-side ad insertion (SSAI) modes of imp.ssai
+const (
+	SSAIUnknown          = 0 // Status unknown
+	SSAIClientSide       = 1 // All client-side, i.e. not server-side
+	SSAIServerSideAssets = 2 // Assets stitched server-side but tracking pixels fired client-side
+	SSAIServerSide       = 3 // All server-side
+)
+
+// IsServerSideStitched returns true if ad assets of the impression are
+// stitched into the content stream server-side, so players cannot execute
+// interactive creatives.
+func (imp *Impression) IsServerSideStitched() bool {
+	return imp.SSAI == SSAIServerSideAssets || imp.SSAI == SSAIServerSide
+}
+
+// FiresClientTrackers returns true unless the impression is entirely
+// server-side, i.e. tracking pixels and impression macros are fired by the
+// client device rather than by the SSAI server.
+func (imp *Impression) FiresClientTrackers() bool {
+	return imp.SSAI != SSAIServerSide
+}
+
+// ssaiAPIs drops the API frameworks of video and audio impressions which
+// are unusable with server-side ad insertion: interactive frameworks when
+// assets are stitched server-side and OM SDK measurement when nothing is
+// executed client-side.
+func (imp *Impression) ssaiAPIs(apis []int) []int {
+	if !imp.IsServerSideStitched() {
+		return apis
+	}
+
+	usable := make([]int, 0, len(apis))
+	for _, api := range apis {
+		switch api {
+		case APIFrameworkVPAID1, APIFrameworkVPAID2, APIFrameworkSIMID1, APIFrameworkSIMID11:
+			continue
+		case APIFrameworkOMID1:
+			if !imp.FiresClientTrackers() {
+				continue
+			}
+		}
+		usable = append(usable, api)
+	}
+	return usable
+}
@@ -0,0 +1,173 @@
+package openrtb
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Codecs. Field names of alternate codecs are derived from the json tags,
+// which are the single source of truth, unless overridden by an explicit
+// codec tag, e.g. `msgpack:"w"`.
+const (
+	CodecJSON    = "json"
+	CodecMsgpack = "msgpack"
+	CodecAvro    = "avro"
+	CodecParquet = "parquet"
+)
+
+// AlternateCodecs lists the codecs derived from json tags.
+var AlternateCodecs = []string{CodecMsgpack, CodecAvro, CodecParquet}
+
+// FieldName returns the encoded name of a field for the given codec and
+// whether it is omitted when empty. It returns false if the field is not
+// encoded at all.
+func FieldName(field reflect.StructField, codec string) (name string, omitempty bool, ok bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false, false
+	}
+
+	tag, found := field.Tag.Lookup(codec)
+	if !found {
+		tag = field.Tag.Get(CodecJSON)
+	}
+	if tag == "-" {
+		return "", false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, true
+}
+
+// DeriveTags returns the tag of a field, extended by derived tags for all
+// given codecs which are not already present. It is intended for code
+// generators, which need to emit explicit tags.
+func DeriveTags(field reflect.StructField, codecs ...string) reflect.StructTag {
+	tag := string(field.Tag)
+	for _, codec := range codecs {
+		if _, ok := field.Tag.Lookup(codec); ok {
+			continue
+		}
+
+		name, omitempty, ok := FieldName(field, CodecJSON)
+		if !ok || field.Anonymous {
+			continue
+		}
+		value := name
+		if omitempty {
+			value += ",omitempty"
+		}
+		if tag != "" {
+			tag += " "
+		}
+		tag += codec + ":" + strconv.Quote(value)
+	}
+	return reflect.StructTag(tag)
+}
+
+// TagIssue describes a problem with the struct tags of a field.
+type TagIssue struct {
+	Type    string // Go type name, e.g. "openrtb.Content"
+	Field   string // Go field name
+	Problem string
+}
+
+// String returns a human readable audit message
+func (i TagIssue) String() string {
+	return i.Type + "." + i.Field + ": " + i.Problem
+}
+
+// AuditTags audits the struct tags of all types reachable from v, which
+// must be a struct or a pointer to a struct. It reports exported fields
+// without json tags, unknown json options, duplicate encoded names and
+// codec tags which disagree with their json tag.
+func AuditTags(v interface{}) []TagIssue {
+	var res []TagIssue
+	auditTagsType(reflect.TypeOf(v), make(map[reflect.Type]bool), &res)
+	return res
+}
+
+func auditTagsType(t reflect.Type, seen map[reflect.Type]bool, res *[]TagIssue) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	names := make(map[string][]string)
+	auditTagsFields(t, t, names, seen, res)
+
+	dups := make([]string, 0)
+	for name, fields := range names {
+		if len(fields) > 1 {
+			dups = append(dups, name)
+		}
+	}
+	sort.Strings(dups)
+	for _, name := range dups {
+		for _, f := range names[name][1:] {
+			*res = append(*res, TagIssue{Type: t.String(), Field: f, Problem: "duplicate name " + strconv.Quote(name)})
+		}
+	}
+}
+
+func auditTagsFields(root, t reflect.Type, names map[string][]string, seen map[reflect.Type]bool, res *[]TagIssue) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag, hasJSON := field.Tag.Lookup(CodecJSON)
+		if field.Anonymous && !hasJSON {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				auditTagsFields(root, ft, names, seen, res)
+				continue
+			}
+		}
+
+		if !hasJSON {
+			*res = append(*res, TagIssue{Type: root.String(), Field: field.Name, Problem: "missing json tag"})
+		} else if tag != "-" {
+			for _, opt := range strings.Split(tag, ",")[1:] {
+				if opt != "omitempty" && opt != "string" && opt != "omitzero" {
+					*res = append(*res, TagIssue{Type: root.String(), Field: field.Name, Problem: "unknown json option " + strconv.Quote(opt)})
+				}
+			}
+		}
+
+		name, omitempty, ok := FieldName(field, CodecJSON)
+		if !ok {
+			continue
+		}
+		names[name] = append(names[name], field.Name)
+
+		for _, codec := range AlternateCodecs {
+			if _, found := field.Tag.Lookup(codec); !found {
+				continue
+			}
+			cname, comitempty, cok := FieldName(field, codec)
+			if !cok || cname != name || comitempty != omitempty {
+				*res = append(*res, TagIssue{Type: root.String(), Field: field.Name, Problem: codec + " tag disagrees with json tag"})
+			}
+		}
+
+		auditTagsType(field.Type, seen, res)
+	}
+}
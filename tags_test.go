@@ -0,0 +1,78 @@
+package openrtb
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type tagsAuditSample struct {
+	Inventory
+	Good     int    `json:"good,omitempty"`
+	Missing  int    // no tag
+	Typo     string `json:"typo,omitempy"`
+	Dup      string `json:"name"`
+	Override string `json:"over,omitempty" msgpack:"o,omitempty"`
+	Skipped  string `json:"-"`
+	hidden   string
+}
+
+var _ = Describe("Tags", func() {
+
+	It("should derive field names", func() {
+		t := reflect.TypeOf(tagsAuditSample{})
+
+		f, _ := t.FieldByName("Good")
+		name, omitempty, ok := FieldName(f, CodecMsgpack)
+		Expect(name).To(Equal("good"))
+		Expect(omitempty).To(BeTrue())
+		Expect(ok).To(BeTrue())
+
+		f, _ = t.FieldByName("Override")
+		name, _, _ = FieldName(f, CodecMsgpack)
+		Expect(name).To(Equal("o"))
+		name, _, _ = FieldName(f, CodecParquet)
+		Expect(name).To(Equal("over"))
+
+		f, _ = t.FieldByName("Missing")
+		name, _, _ = FieldName(f, CodecJSON)
+		Expect(name).To(Equal("Missing"))
+
+		f, _ = t.FieldByName("Skipped")
+		_, _, ok = FieldName(f, CodecMsgpack)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should derive tags", func() {
+		t := reflect.TypeOf(tagsAuditSample{})
+
+		f, _ := t.FieldByName("Good")
+		Expect(DeriveTags(f, AlternateCodecs...)).To(Equal(reflect.StructTag(`json:"good,omitempty" msgpack:"good,omitempty" avro:"good,omitempty" parquet:"good,omitempty"`)))
+
+		f, _ = t.FieldByName("Override")
+		Expect(DeriveTags(f, CodecMsgpack, CodecAvro)).To(Equal(reflect.StructTag(`json:"over,omitempty" msgpack:"o,omitempty" avro:"over,omitempty"`)))
+
+		f, _ = t.FieldByName("Skipped")
+		Expect(DeriveTags(f, CodecMsgpack)).To(Equal(reflect.StructTag(`json:"-"`)))
+	})
+
+	It("should audit tags", func() {
+		var res []string
+		for _, issue := range AuditTags(&tagsAuditSample{}) {
+			res = append(res, issue.String())
+		}
+		Expect(res).To(ConsistOf(
+			"openrtb.tagsAuditSample.Missing: missing json tag",
+			`openrtb.tagsAuditSample.Typo: unknown json option "omitempy"`,
+			`openrtb.tagsAuditSample.Dup: duplicate name "name"`,
+			"openrtb.tagsAuditSample.Override: msgpack tag disagrees with json tag",
+		))
+	})
+
+	It("should have a consistent object model", func() {
+		Expect(AuditTags(&BidRequest{})).To(BeEmpty())
+		Expect(AuditTags(&BidResponse{})).To(BeEmpty())
+	})
+
+})
@@ -0,0 +1,152 @@
+/*
+Package targeting converts winning bids into ad server targeting key-values,
+as expected by header bidding wrappers such as Prebid.
+
+	kv := targeting.KeyValues(winner.Bid, winner.Seat, &targeting.Options{
+		Granularity: targeting.GranularityMedium,
+		BidderKeys:  true,
+	})
+	// map[hb_bidder:alpha hb_bidder_alpha:alpha hb_pb:1.20 hb_pb_alpha:1.20 hb_size:300x250 ...]
+
+Prices are bucketed in the currency of the bid, convert them beforehand if
+the ad server line items are set up in a different currency.
+*/
+package targeting
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/bsm/openrtb"
+)
+
+// Default keys, see Options.Prefix
+const (
+	KeyPrice      = "pb"     // Price bucket
+	KeySize       = "size"   // Creative size, e.g. "300x250"
+	KeyDeal       = "deal"   // Deal ID
+	KeyBidder     = "bidder" // Seat
+	KeyCreativeID = "crid"   // Creative ID
+	KeyFormat     = "format" // Media type, e.g. "banner"
+)
+
+// DefaultPrefix is prepended to all keys, unless configured otherwise.
+const DefaultPrefix = "hb_"
+
+// Range is a price range with a fixed increment.
+type Range struct {
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Increment float64 `json:"increment"`
+}
+
+// Granularity describes how prices are bucketed. Prices below the first
+// range are bucketed as 0 and prices above the last range are capped at
+// its max.
+type Granularity struct {
+	Precision int     `json:"precision"` // Number of decimals, Default: 2
+	Ranges    []Range `json:"ranges"`    // Ranges by ascending price
+}
+
+// Standard granularities
+var (
+	GranularityLow    = Granularity{Ranges: []Range{{Max: 5, Increment: 0.5}}}
+	GranularityMedium = Granularity{Ranges: []Range{{Max: 20, Increment: 0.1}}}
+	GranularityHigh   = Granularity{Ranges: []Range{{Max: 20, Increment: 0.01}}}
+	GranularityAuto   = Granularity{Ranges: []Range{
+		{Max: 5, Increment: 0.05},
+		{Min: 5, Max: 10, Increment: 0.1},
+		{Min: 10, Max: 20, Increment: 0.5},
+	}}
+	GranularityDense = Granularity{Ranges: []Range{
+		{Max: 3, Increment: 0.01},
+		{Min: 3, Max: 8, Increment: 0.05},
+		{Min: 8, Max: 20, Increment: 0.5},
+	}}
+)
+
+// Bucket returns the price bucket of price, e.g. "1.20".
+func (g Granularity) Bucket(price float64) string {
+	precision := g.Precision
+	if precision == 0 {
+		precision = 2
+	}
+
+	bucket := 0.0
+	for _, r := range g.Ranges {
+		if price < r.Min || r.Increment <= 0 {
+			break
+		}
+		if price >= r.Max {
+			bucket = r.Max
+			continue
+		}
+		// the epsilon compensates for binary representations such as 1.2 / 0.1 = 11.999
+		bucket = r.Min + math.Floor((price-r.Min)/r.Increment+1e-9)*r.Increment
+		break
+	}
+	return strconv.FormatFloat(bucket, 'f', precision, 64)
+}
+
+// Options configure the key-values. A nil value uses the zero value
+// defaults.
+type Options struct {
+	// Granularity of the price bucket. Defaults to GranularityMedium.
+	Granularity *Granularity
+	// Prefix of the keys. Defaults to DefaultPrefix.
+	Prefix string
+	// BidderKeys additionally emits all keys suffixed by the seat, e.g.
+	// "hb_pb_alpha", so the ad server can target multiple bidders at once.
+	BidderKeys bool
+	// MaxKeyLength truncates keys, e.g. 20 for Google Ad Manager,
+	// 0 = unlimited.
+	MaxKeyLength int
+}
+
+// KeyValues returns the targeting key-values of a winning bid of a seat.
+// Keys without a value, e.g. the deal of an open market bid, are omitted.
+func KeyValues(bid *openrtb.Bid, seat string, opts *Options) map[string]string {
+	if opts == nil {
+		opts = new(Options)
+	}
+
+	granularity := GranularityMedium
+	if opts.Granularity != nil {
+		granularity = *opts.Granularity
+	}
+
+	values := map[string]string{
+		KeyPrice:      granularity.Bucket(bid.Price),
+		KeyDeal:       bid.DealID,
+		KeyBidder:     seat,
+		KeyCreativeID: bid.CreativeID,
+		KeyFormat:     bid.MediaType(),
+	}
+	if bid.W > 0 && bid.H > 0 {
+		values[KeySize] = strconv.Itoa(bid.W) + "x" + strconv.Itoa(bid.H)
+	}
+
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+
+	kv := make(map[string]string, 2*len(values))
+	for key, value := range values {
+		if value == "" {
+			continue
+		}
+		kv[opts.key(prefix+key)] = value
+		if opts.BidderKeys && seat != "" {
+			kv[opts.key(prefix+key+"_"+seat)] = value
+		}
+	}
+	return kv
+}
+
+func (o *Options) key(s string) string {
+	if o.MaxKeyLength > 0 && len(s) > o.MaxKeyLength {
+		return s[:o.MaxKeyLength]
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package targeting
+
+import (
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Granularity", func() {
+
+	It("should bucket prices", func() {
+		Expect(GranularityMedium.Bucket(1.2)).To(Equal("1.20"))
+		Expect(GranularityMedium.Bucket(1.29)).To(Equal("1.20"))
+		Expect(GranularityMedium.Bucket(25)).To(Equal("20.00"))
+		Expect(GranularityMedium.Bucket(-1)).To(Equal("0.00"))
+		Expect(GranularityLow.Bucket(2.99)).To(Equal("2.50"))
+		Expect(GranularityHigh.Bucket(0.57)).To(Equal("0.57"))
+
+		Expect(GranularityAuto.Bucket(3.87)).To(Equal("3.85"))
+		Expect(GranularityAuto.Bucket(7.77)).To(Equal("7.70"))
+		Expect(GranularityAuto.Bucket(13.3)).To(Equal("13.00"))
+		Expect(GranularityAuto.Bucket(99)).To(Equal("20.00"))
+		Expect(GranularityDense.Bucket(2.345)).To(Equal("2.34"))
+
+		custom := Granularity{Precision: 1, Ranges: []Range{{Min: 1, Max: 10, Increment: 1}}}
+		Expect(custom.Bucket(0.5)).To(Equal("0.0"))
+		Expect(custom.Bucket(4.5)).To(Equal("4.0"))
+	})
+
+})
+
+var _ = Describe("KeyValues", func() {
+	var bid *openrtb.Bid
+
+	BeforeEach(func() {
+		bid = &openrtb.Bid{ID: "B", ImpID: "I", Price: 1.234, CreativeID: "C", DealID: "D", W: 300, H: 250, MType: openrtb.MarkupTypeBanner}
+	})
+
+	It("should extract key-values", func() {
+		Expect(KeyValues(bid, "alpha", nil)).To(Equal(map[string]string{
+			"hb_pb":     "1.20",
+			"hb_size":   "300x250",
+			"hb_deal":   "D",
+			"hb_bidder": "alpha",
+			"hb_crid":   "C",
+			"hb_format": "banner",
+		}))
+
+		Expect(KeyValues(&openrtb.Bid{Price: 3.87}, "", &Options{Granularity: &GranularityAuto, Prefix: "x_"})).To(Equal(map[string]string{
+			"x_pb": "3.85",
+		}))
+	})
+
+	It("should support bidder keys", func() {
+		kv := KeyValues(bid, "alphabidder", &Options{BidderKeys: true, MaxKeyLength: 20})
+		Expect(kv).To(HaveLen(12))
+		Expect(kv).To(HaveKeyWithValue("hb_pb_alphabidder", "1.20"))
+		Expect(kv).To(HaveKeyWithValue("hb_bidder_alphabidde", "alphabidder"))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/targeting")
+}
@@ -0,0 +1,118 @@
+package taxonomy
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/bsm/openrtb"
+)
+
+// Mapping maps category IDs from one taxonomy to another, e.g. to convert
+// Content Taxonomy 3.0 categories for partners still on 1.0.
+type Mapping struct {
+	From, To int // See openrtb.CatTax* constants
+
+	ids map[string]string
+}
+
+// NewMapping creates a mapping from pairs of source to target IDs.
+func NewMapping(from, to int, ids map[string]string) *Mapping {
+	m := &Mapping{From: from, To: to, ids: make(map[string]string, len(ids))}
+	for k, v := range ids {
+		m.ids[k] = v
+	}
+	return m
+}
+
+// LoadMappingTSV loads a mapping from a tab-separated file with a header
+// line, a source ID column and a target ID column.
+func LoadMappingTSV(from, to int, r io.Reader) (*Mapping, error) {
+	ids := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for line := 0; scanner.Scan(); line++ {
+		if line == 0 || strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		cols := strings.Split(scanner.Text(), "\t")
+		if len(cols) < 2 {
+			return nil, ErrInvalidTSV
+		}
+		src, dst := strings.TrimSpace(cols[0]), strings.TrimSpace(cols[1])
+		if src == "" {
+			return nil, ErrInvalidTSV
+		}
+		if dst != "" {
+			ids[src] = dst
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &Mapping{From: from, To: to, ids: ids}, nil
+}
+
+// Map converts category IDs. IDs without a direct mapping fall back to the
+// mapping of their closest ancestor, if the source table is registered.
+// Unmappable IDs are dropped and the result is de-duplicated.
+func (m *Mapping) Map(ids []string) []string {
+	src := Get(m.From)
+
+	res := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		dst, ok := m.lookup(src, id)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[dst]; !ok {
+			seen[dst] = struct{}{}
+			res = append(res, dst)
+		}
+	}
+	return res
+}
+
+func (m *Mapping) lookup(src *Table, id string) (string, bool) {
+	for depth := 0; id != "" && depth < 16; depth++ {
+		if dst, ok := m.ids[id]; ok {
+			return dst, true
+		}
+		if src == nil {
+			break
+		}
+		cat, ok := src.Lookup(id)
+		if !ok {
+			break
+		}
+		id = cat.Parent
+	}
+	return "", false
+}
+
+type mappingKey struct{ from, to int }
+
+var mappings = map[mappingKey]*Mapping{}
+
+// RegisterMapping registers a mapping, replacing any existing one.
+// This function is not safe for concurrent use and should be called on init.
+func RegisterMapping(m *Mapping) {
+	mappings[mappingKey{from: openrtb.EffectiveCatTax(m.From), to: openrtb.EffectiveCatTax(m.To)}] = m
+}
+
+// Convert converts category IDs between taxonomies using a registered
+// mapping. Categories of the same taxonomy are returned as is.
+func Convert(ids []string, from, to int) ([]string, error) {
+	from, to = openrtb.EffectiveCatTax(from), openrtb.EffectiveCatTax(to)
+	if from == to {
+		return ids, nil
+	}
+
+	m, ok := mappings[mappingKey{from: from, to: to}]
+	if !ok {
+		return nil, ErrUnknownTaxonomy
+	}
+	return m.Map(ids), nil
+}
@@ -0,0 +1,65 @@
+package taxonomy
+
+import (
+	"os"
+	"strings"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mapping", func() {
+	var subject *Mapping
+
+	BeforeEach(func() {
+		f, err := os.Open("testdata/mapping.tsv")
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+
+		subject, err = LoadMappingTSV(openrtb.CatTaxIABContent30, openrtb.CatTaxIABContent10, f)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should load TSV", func() {
+		Expect(subject.ids).To(Equal(map[string]string{"150": "IAB1", "1": "IAB2"}))
+
+		_, err := LoadMappingTSV(1, 2, strings.NewReader("header\nbad\n"))
+		Expect(err).To(Equal(ErrInvalidTSV))
+	})
+
+	It("should map", func() {
+		Expect(subject.Map([]string{"150", "1", "150", "999", "152"})).To(Equal([]string{"IAB1", "IAB2"}))
+	})
+
+	It("should fall back to ancestors", func() {
+		f, err := os.Open("testdata/content.tsv")
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+
+		table, err := LoadTSV(openrtb.CatTaxIABContent30, f)
+		Expect(err).NotTo(HaveOccurred())
+
+		Register(table)
+		defer delete(tables, openrtb.CatTaxIABContent30)
+
+		Expect(subject.Map([]string{"152", "151", "1"})).To(Equal([]string{"IAB1", "IAB2"}))
+	})
+
+	It("should convert", func() {
+		RegisterMapping(subject)
+		defer delete(mappings, mappingKey{from: openrtb.CatTaxIABContent30, to: openrtb.CatTaxIABContent10})
+
+		Expect(Convert([]string{"150"}, openrtb.CatTaxIABContent30, 0)).To(Equal([]string{"IAB1"}))
+		Expect(Convert([]string{"IAB1"}, 0, openrtb.CatTaxIABContent10)).To(Equal([]string{"IAB1"}))
+
+		_, err := Convert([]string{"IAB1"}, openrtb.CatTaxIABContent10, openrtb.CatTaxIABContent30)
+		Expect(err).To(Equal(ErrUnknownTaxonomy))
+	})
+
+	It("should create from maps", func() {
+		m := NewMapping(openrtb.CatTaxIABContent10, openrtb.CatTaxIABContent10, map[string]string{"IAB1": "IAB2"})
+		Expect(m.Map([]string{"IAB1-1", "IAB3"})).To(Equal([]string{"IAB2"}))
+	})
+
+})
@@ -0,0 +1,207 @@
+/*
+Package taxonomy validates and maps IAB category codes, as used in cat, bcat,
+sectioncat and pagecat, according to the declared cattax.
+
+IAB Content Category Taxonomy 1.0 is built in. Tables for later taxonomies,
+e.g. Content Taxonomy 2.x and 3.x, can be loaded from the official IAB TSV
+files and registered on init:
+
+	func init() {
+		f, _ := os.Open("Content Taxonomy 3.0.tsv")
+		defer f.Close()
+
+		table, err := taxonomy.LoadTSV(openrtb.CatTaxIABContent30, f)
+		if err != nil {
+			panic(err)
+		}
+		taxonomy.Register(table)
+	}
+*/
+package taxonomy
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bsm/openrtb"
+)
+
+// Errors
+var (
+	ErrUnknownTaxonomy = errors.New("taxonomy: unknown taxonomy")
+	ErrInvalidCategory = errors.New("taxonomy: invalid category")
+	ErrInvalidTSV      = errors.New("taxonomy: invalid TSV")
+)
+
+// Category is a single taxonomy node.
+type Category struct {
+	ID     string // Unique ID, e.g. "IAB1-1" or "150"
+	Parent string // Parent ID, blank for tier-1 categories
+	Name   string // Name, may be blank
+	Tier   int    // Tier, starting at 1
+}
+
+// Table is a category table of a single taxonomy.
+type Table struct {
+	Taxonomy int // See openrtb.CatTax* constants
+
+	cats map[string]*Category
+}
+
+// NewTable creates a new table from a list of categories.
+func NewTable(taxonomy int, cats []Category) *Table {
+	t := &Table{Taxonomy: taxonomy, cats: make(map[string]*Category, len(cats))}
+	for i := range cats {
+		cat := cats[i]
+		t.cats[cat.ID] = &cat
+	}
+	return t
+}
+
+// LoadTSV loads a table from IAB's tab-separated taxonomy format. The first
+// line is a header and columns are "Unique ID", "Parent", "Name", followed
+// by one column per tier. Additional columns are ignored.
+func LoadTSV(taxonomy int, r io.Reader) (*Table, error) {
+	var cats []Category
+
+	scanner := bufio.NewScanner(r)
+	for line := 0; scanner.Scan(); line++ {
+		if line == 0 || strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		cols := strings.Split(scanner.Text(), "\t")
+		if len(cols) < 4 || strings.TrimSpace(cols[0]) == "" {
+			return nil, ErrInvalidTSV
+		}
+
+		cat := Category{
+			ID:     strings.TrimSpace(cols[0]),
+			Parent: strings.TrimSpace(cols[1]),
+			Name:   strings.TrimSpace(cols[2]),
+		}
+		for _, col := range cols[3:] {
+			if strings.TrimSpace(col) != "" {
+				cat.Tier++
+			}
+		}
+		cats = append(cats, cat)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewTable(taxonomy, cats), nil
+}
+
+// Len returns the number of categories.
+func (t *Table) Len() int { return len(t.cats) }
+
+// Lookup returns a category by ID.
+func (t *Table) Lookup(id string) (*Category, bool) {
+	cat, ok := t.cats[id]
+	return cat, ok
+}
+
+// Contains returns true if the table contains the category ID.
+func (t *Table) Contains(id string) bool {
+	_, ok := t.cats[id]
+	return ok
+}
+
+// Validate returns ErrInvalidCategory if any of the IDs is unknown.
+func (t *Table) Validate(ids []string) error {
+	for _, id := range ids {
+		if !t.Contains(id) {
+			return ErrInvalidCategory
+		}
+	}
+	return nil
+}
+
+var tables = map[int]*Table{
+	openrtb.CatTaxIABContent10: iab10,
+}
+
+// Register registers a table for its taxonomy, replacing any existing one.
+// This function is not safe for concurrent use and should be called on init.
+func Register(t *Table) {
+	tables[t.Taxonomy] = t
+}
+
+// Get returns the table registered for a taxonomy, or nil.
+// A cattax of 0 defaults to openrtb.CatTaxIABContent10.
+func Get(cattax int) *Table {
+	return tables[openrtb.EffectiveCatTax(cattax)]
+}
+
+// Validate validates category IDs against the declared cattax.
+func Validate(cattax int, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	t := Get(cattax)
+	if t == nil {
+		return ErrUnknownTaxonomy
+	}
+	return t.Validate(ids)
+}
+
+// ValidateRequest validates the blocked categories of a request.
+func ValidateRequest(req *openrtb.BidRequest) error {
+	return Validate(req.CatTax, req.Bcat)
+}
+
+// ValidateBid validates the categories of a bid.
+func ValidateBid(bid *openrtb.Bid) error {
+	return Validate(bid.CatTax, bid.Cat)
+}
+
+// iab10Tiers are the tier-1 categories of IAB Content Category Taxonomy 1.0,
+// with the number of tier-2 sub-categories each.
+var iab10Tiers = []struct {
+	Name string
+	Subs int
+}{
+	{"Arts & Entertainment", 7},
+	{"Automotive", 23},
+	{"Business", 12},
+	{"Careers", 11},
+	{"Education", 15},
+	{"Family & Parenting", 9},
+	{"Health & Fitness", 45},
+	{"Food & Drink", 18},
+	{"Hobbies & Interests", 31},
+	{"Home & Garden", 9},
+	{"Law, Gov't & Politics", 5},
+	{"News", 3},
+	{"Personal Finance", 12},
+	{"Society", 8},
+	{"Science", 10},
+	{"Pets", 7},
+	{"Sports", 44},
+	{"Style & Fashion", 6},
+	{"Technology & Computing", 36},
+	{"Travel", 27},
+	{"Real Estate", 3},
+	{"Shopping", 4},
+	{"Religion & Spirituality", 10},
+	{"Uncategorized", 0},
+	{"Non-Standard Content", 7},
+	{"Illegal Content", 4},
+}
+
+var iab10 = func() *Table {
+	var cats []Category
+	for i, tier := range iab10Tiers {
+		parent := "IAB" + strconv.Itoa(i+1)
+		cats = append(cats, Category{ID: parent, Name: tier.Name, Tier: 1})
+		for j := 1; j <= tier.Subs; j++ {
+			cats = append(cats, Category{ID: parent + "-" + strconv.Itoa(j), Parent: parent, Tier: 2})
+		}
+	}
+	return NewTable(openrtb.CatTaxIABContent10, cats)
+}()
@@ -0,0 +1,75 @@
+package taxonomy
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Table", func() {
+
+	It("should include IAB 1.0", func() {
+		t := Get(0)
+		Expect(t).NotTo(BeNil())
+		Expect(t.Taxonomy).To(Equal(openrtb.CatTaxIABContent10))
+		Expect(t.Len()).To(Equal(26 + 366))
+
+		cat, ok := t.Lookup("IAB19")
+		Expect(ok).To(BeTrue())
+		Expect(cat).To(Equal(&Category{ID: "IAB19", Name: "Technology & Computing", Tier: 1}))
+
+		cat, ok = t.Lookup("IAB19-36")
+		Expect(ok).To(BeTrue())
+		Expect(cat).To(Equal(&Category{ID: "IAB19-36", Parent: "IAB19", Tier: 2}))
+
+		Expect(t.Contains("IAB19-37")).To(BeFalse())
+		Expect(t.Contains("IAB24")).To(BeTrue())
+		Expect(t.Contains("IAB24-1")).To(BeFalse())
+		Expect(t.Contains("IAB27")).To(BeFalse())
+	})
+
+	It("should load TSV", func() {
+		f, err := os.Open("testdata/content.tsv")
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+
+		t, err := LoadTSV(openrtb.CatTaxIABContent30, f)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.Len()).To(Equal(4))
+		cat, ok := t.Lookup("152")
+		Expect(ok).To(BeTrue())
+		Expect(cat).To(Equal(&Category{ID: "152", Parent: "151", Name: "Water Parks", Tier: 3}))
+
+		_, err = LoadTSV(openrtb.CatTaxIABContent30, strings.NewReader("header\nbad line\n"))
+		Expect(err).To(Equal(ErrInvalidTSV))
+	})
+
+	It("should validate", func() {
+		Expect(Validate(0, nil)).To(Succeed())
+		Expect(Validate(0, []string{"IAB1", "IAB7-45"})).To(Succeed())
+		Expect(Validate(openrtb.CatTaxIABContent10, []string{"IAB1", "IAB7-46"})).To(Equal(ErrInvalidCategory))
+		Expect(Validate(openrtb.CatTaxIABProduct20, []string{"1"})).To(Equal(ErrUnknownTaxonomy))
+
+		Expect(ValidateRequest(&openrtb.BidRequest{Bcat: []string{"IAB25", "IAB26-4"}})).To(Succeed())
+		Expect(ValidateRequest(&openrtb.BidRequest{Bcat: []string{"150"}})).To(Equal(ErrInvalidCategory))
+		Expect(ValidateBid(&openrtb.Bid{CatTax: openrtb.CatTaxIABContent22, Cat: []string{"150"}})).To(Equal(ErrUnknownTaxonomy))
+	})
+
+	It("should register tables", func() {
+		defer delete(tables, openrtb.CatTaxIABContent22)
+
+		Register(NewTable(openrtb.CatTaxIABContent22, []Category{{ID: "150", Name: "Attractions", Tier: 1}}))
+		Expect(ValidateBid(&openrtb.Bid{CatTax: openrtb.CatTaxIABContent22, Cat: []string{"150"}})).To(Succeed())
+		Expect(ValidateBid(&openrtb.Bid{CatTax: openrtb.CatTaxIABContent22, Cat: []string{"IAB1"}})).To(Equal(ErrInvalidCategory))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/taxonomy")
+}
@@ -0,0 +1,114 @@
+/*
+Package tenant scopes configuration (validation options, scrub policies,
+floors, partner profiles, etc.) to individual publishers/tenants within a
+single process.
+
+Lookups are lock-free and allocation-free, updates copy the underlying map
+and are therefore best suited for read-heavy workloads:
+
+	reg := tenant.New(defaultConfig)
+	reg.Set("pub-1", pub1Config)
+
+	cfg := reg.ForRequest(req).(*MyConfig)
+*/
+package tenant
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/bsm/openrtb"
+)
+
+// Resolver extracts the tenant ID from a bid request.
+type Resolver func(*openrtb.BidRequest) string
+
+// PublisherID is the default resolver, using the site/app/dooh publisher ID.
+func PublisherID(req *openrtb.BidRequest) string {
+	return req.PublisherID()
+}
+
+// Registry holds configurations by tenant ID.
+type Registry struct {
+	Resolver Resolver // Defaults to PublisherID
+
+	def   interface{}
+	store atomic.Value // map[string]interface{}
+	mu    sync.Mutex
+}
+
+// New creates a registry with a default configuration, which is returned
+// for unknown tenants.
+func New(def interface{}) *Registry {
+	r := &Registry{def: def}
+	r.store.Store(map[string]interface{}{})
+	return r
+}
+
+// Default returns the default configuration.
+func (r *Registry) Default() interface{} { return r.def }
+
+// Get returns the tenant configuration, or the default.
+func (r *Registry) Get(tenantID string) interface{} {
+	if cfg, ok := r.Lookup(tenantID); ok {
+		return cfg
+	}
+	return r.def
+}
+
+// Lookup returns the tenant configuration and true if it was found.
+func (r *Registry) Lookup(tenantID string) (interface{}, bool) {
+	cfg, ok := r.load()[tenantID]
+	return cfg, ok
+}
+
+// ForRequest returns the configuration for the tenant of req.
+func (r *Registry) ForRequest(req *openrtb.BidRequest) interface{} {
+	resolve := r.Resolver
+	if resolve == nil {
+		resolve = PublisherID
+	}
+	return r.Get(resolve(req))
+}
+
+// Set stores the configuration of a tenant.
+func (r *Registry) Set(tenantID string, cfg interface{}) {
+	r.update(func(m map[string]interface{}) { m[tenantID] = cfg })
+}
+
+// Delete removes the configuration of a tenant.
+func (r *Registry) Delete(tenantID string) {
+	r.update(func(m map[string]interface{}) { delete(m, tenantID) })
+}
+
+// Replace atomically replaces all tenant configurations, e.g. on reload.
+func (r *Registry) Replace(configs map[string]interface{}) {
+	m := make(map[string]interface{}, len(configs))
+	for k, v := range configs {
+		m[k] = v
+	}
+
+	r.mu.Lock()
+	r.store.Store(m)
+	r.mu.Unlock()
+}
+
+// Len returns the number of tenants.
+func (r *Registry) Len() int { return len(r.load()) }
+
+func (r *Registry) load() map[string]interface{} {
+	return r.store.Load().(map[string]interface{})
+}
+
+func (r *Registry) update(fn func(map[string]interface{})) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cur := r.load()
+	m := make(map[string]interface{}, len(cur)+1)
+	for k, v := range cur {
+		m[k] = v
+	}
+	fn(m)
+	r.store.Store(m)
+}
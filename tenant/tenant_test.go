@@ -0,0 +1,83 @@
+package tenant
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testConfig struct{ Floor float64 }
+
+var _ = Describe("Registry", func() {
+	var subject *Registry
+	var def = &testConfig{Floor: 0.1}
+
+	BeforeEach(func() {
+		subject = New(def)
+		subject.Set("pub-1", &testConfig{Floor: 1})
+	})
+
+	It("should lookup configs", func() {
+		Expect(subject.Get("pub-1")).To(Equal(&testConfig{Floor: 1}))
+		Expect(subject.Get("pub-2")).To(BeIdenticalTo(def))
+		Expect(subject.Default()).To(BeIdenticalTo(def))
+
+		_, ok := subject.Lookup("pub-2")
+		Expect(ok).To(BeFalse())
+		Expect(subject.Len()).To(Equal(1))
+	})
+
+	It("should resolve configs by request", func() {
+		req := &openrtb.BidRequest{App: &openrtb.App{Inventory: openrtb.Inventory{Publisher: &openrtb.Publisher{ID: "pub-1"}}}}
+		Expect(subject.ForRequest(req)).To(Equal(&testConfig{Floor: 1}))
+		Expect(subject.ForRequest(&openrtb.BidRequest{})).To(BeIdenticalTo(def))
+
+		subject.Resolver = func(req *openrtb.BidRequest) string { return req.ID }
+		Expect(subject.ForRequest(&openrtb.BidRequest{ID: "pub-1"})).To(Equal(&testConfig{Floor: 1}))
+	})
+
+	It("should update", func() {
+		subject.Delete("pub-1")
+		Expect(subject.Get("pub-1")).To(BeIdenticalTo(def))
+
+		subject.Replace(map[string]interface{}{"a": 1, "b": 2})
+		Expect(subject.Len()).To(Equal(2))
+		Expect(subject.Get("b")).To(Equal(2))
+	})
+
+	It("should be thread-safe", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				subject.Set("pub-2", &testConfig{})
+			}()
+			go func() {
+				defer wg.Done()
+				_ = subject.Get("pub-1")
+			}()
+		}
+		wg.Wait()
+		Expect(subject.Len()).To(Equal(2))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/tenant")
+}
+
+func BenchmarkRegistry_Get(b *testing.B) {
+	reg := New(nil)
+	reg.Set("pub-1", &testConfig{})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = reg.Get("pub-1")
+	}
+}
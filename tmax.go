@@ -0,0 +1,45 @@
+package openrtb
+
+import (
+	"context"
+	"time"
+)
+
+// ContextWithTMax derives a context which expires once the tmax of the
+// request has elapsed, minus buffer, which is reserved for network latency.
+// The returned context never outlives ctx. Requests without a tmax are only
+// bounded by ctx.
+func ContextWithTMax(ctx context.Context, req *BidRequest, buffer time.Duration) (context.Context, context.CancelFunc) {
+	if req.TMax <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(req.TMax)*time.Millisecond-buffer)
+}
+
+// RemainingTMax returns the time left until the deadline of ctx. It returns
+// false if ctx has no deadline. Expired contexts have no time left.
+func RemainingTMax(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	if left := time.Until(deadline); left > 0 {
+		return left, true
+	}
+	return 0, true
+}
+
+// BidderTMax returns the tmax, in milliseconds, to pass on to bidders in
+// downstream requests: the time left until the deadline of ctx, minus
+// buffer. It returns 0 if ctx has no deadline and -1 if there is
+// insufficient time left to send a request at all.
+func BidderTMax(ctx context.Context, buffer time.Duration) int {
+	left, ok := RemainingTMax(ctx)
+	if !ok {
+		return 0
+	}
+	if ms := int((left - buffer) / time.Millisecond); ms > 0 {
+		return ms
+	}
+	return -1
+}
@@ -0,0 +1,53 @@
+package openrtb
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContextWithTMax", func() {
+	var ctx = context.Background()
+
+	It("should derive deadlines", func() {
+		cctx, cancel := ContextWithTMax(ctx, &BidRequest{TMax: 120}, 20*time.Millisecond)
+		defer cancel()
+
+		left, ok := RemainingTMax(cctx)
+		Expect(ok).To(BeTrue())
+		Expect(left).To(BeNumerically("~", 100*time.Millisecond, 10*time.Millisecond))
+		Expect(BidderTMax(cctx, 10*time.Millisecond)).To(BeNumerically("~", 90, 10))
+		Expect(BidderTMax(cctx, time.Second)).To(Equal(-1))
+	})
+
+	It("should not outlive parents", func() {
+		parent, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		cctx, cancel := ContextWithTMax(parent, &BidRequest{TMax: 1000}, 0)
+		defer cancel()
+
+		left, _ := RemainingTMax(cctx)
+		Expect(left).To(BeNumerically("<=", 10*time.Millisecond))
+		Eventually(cctx.Done()).Should(BeClosed())
+
+		left, ok := RemainingTMax(cctx)
+		Expect(ok).To(BeTrue())
+		Expect(left).To(BeZero())
+	})
+
+	It("should skip requests without tmax", func() {
+		cctx, cancel := ContextWithTMax(ctx, &BidRequest{}, time.Millisecond)
+		defer cancel()
+
+		_, ok := RemainingTMax(cctx)
+		Expect(ok).To(BeFalse())
+		Expect(BidderTMax(cctx, 0)).To(Equal(0))
+
+		cancel()
+		Expect(cctx.Err()).To(Equal(context.Canceled))
+	})
+
+})
@@ -0,0 +1,114 @@
+package openrtb
+
+import (
+	"context"
+	"sync"
+)
+
+// Trace stages
+const (
+	TraceStageEligibility = "eligibility"
+	TraceStageFloor       = "floor"
+	TraceStageAdjustment  = "adjustment"
+	TraceStageRanking     = "ranking"
+)
+
+// TraceEvent is a single auction decision.
+type TraceEvent struct {
+	Stage  string  `json:"stage"`            // Decision stage, see TraceStage* constants
+	ImpID  string  `json:"impid,omitempty"`  // Impression ID
+	Seat   string  `json:"seat,omitempty"`   // Bidder seat
+	BidID  string  `json:"bidid,omitempty"`  // Bid ID
+	OK     bool    `json:"ok"`               // True if the bid passed the check
+	Reason string  `json:"reason,omitempty"` // Human readable reason
+	Price  float64 `json:"price,omitempty"`  // Bid price before the decision
+	Value  float64 `json:"value,omitempty"`  // Floor, adjusted price or clearing price
+	Rank   int     `json:"rank,omitempty"`   // Position after ranking, starting at 1
+}
+
+// Trace is an opt-in, explainable log of all decisions made during a single
+// auction. All methods are safe for concurrent use and are no-ops on a nil
+// trace, so callers don't need to check whether tracing is enabled.
+type Trace struct {
+	AuctionID string       `json:"auctionid,omitempty"`
+	Events    []TraceEvent `json:"events"`
+
+	mu sync.Mutex
+}
+
+// NewTrace starts a new auction trace.
+func NewTrace(auctionID string) *Trace {
+	return &Trace{AuctionID: auctionID, Events: []TraceEvent{}}
+}
+
+// Record appends an event.
+func (t *Trace) Record(e TraceEvent) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.Events = append(t.Events, e)
+	t.mu.Unlock()
+}
+
+// Eligibility records an eligibility check of a bid.
+func (t *Trace) Eligibility(seat string, bid *Bid, ok bool, reason string) {
+	t.Record(TraceEvent{Stage: TraceStageEligibility, ImpID: bid.ImpID, Seat: seat, BidID: bid.ID, OK: ok, Reason: reason, Price: bid.Price})
+}
+
+// FloorCheck records a comparison of a bid price against a floor.
+func (t *Trace) FloorCheck(seat string, bid *Bid, floor float64, ok bool) {
+	t.Record(TraceEvent{Stage: TraceStageFloor, ImpID: bid.ImpID, Seat: seat, BidID: bid.ID, OK: ok, Price: bid.Price, Value: floor})
+}
+
+// Adjustment records a price adjustment, e.g. currency conversion or bid shading.
+func (t *Trace) Adjustment(seat string, bid *Bid, from, to float64, reason string) {
+	t.Record(TraceEvent{Stage: TraceStageAdjustment, ImpID: bid.ImpID, Seat: seat, BidID: bid.ID, OK: true, Reason: reason, Price: from, Value: to})
+}
+
+// Ranking records the final position of a bid within its impression.
+func (t *Trace) Ranking(seat string, bid *Bid, rank int, clearing float64) {
+	t.Record(TraceEvent{Stage: TraceStageRanking, ImpID: bid.ImpID, Seat: seat, BidID: bid.ID, OK: rank == 1, Price: bid.Price, Value: clearing, Rank: rank})
+}
+
+// Snapshot returns a copy of the recorded events.
+func (t *Trace) Snapshot() []TraceEvent {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceEvent(nil), t.Events...)
+}
+
+// AttachTrace stores the trace as res.ext.trace, for debugging partners.
+func (res *BidResponse) AttachTrace(t *Trace) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	ext, err := res.Ext.setKey("trace", t)
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	res.Ext = ext
+	return nil
+}
+
+type traceContextKey struct{}
+
+// ContextWithTrace enables tracing for ctx. The auctions of package auction
+// and the bidder clients of package client record their decisions in t.
+func ContextWithTrace(ctx context.Context, t *Trace) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, t)
+}
+
+// TraceFromContext returns the trace attached to ctx, or nil.
+func TraceFromContext(ctx context.Context) *Trace {
+	t, _ := ctx.Value(traceContextKey{}).(*Trace)
+	return t
+}
@@ -0,0 +1,45 @@
+package openrtb
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trace", func() {
+	var bid = &Bid{ID: "B", ImpID: "1", Price: 2}
+
+	It("should record decisions", func() {
+		subject := NewTrace("A")
+		subject.Eligibility("s1", bid, true, "")
+		subject.FloorCheck("s1", bid, 1.5, true)
+		subject.Adjustment("s1", bid, 2, 1.8, "shading")
+		subject.Ranking("s1", bid, 1, 1.6)
+
+		Expect(subject.Snapshot()).To(Equal([]TraceEvent{
+			{Stage: TraceStageEligibility, ImpID: "1", Seat: "s1", BidID: "B", OK: true, Price: 2},
+			{Stage: TraceStageFloor, ImpID: "1", Seat: "s1", BidID: "B", OK: true, Price: 2, Value: 1.5},
+			{Stage: TraceStageAdjustment, ImpID: "1", Seat: "s1", BidID: "B", OK: true, Reason: "shading", Price: 2, Value: 1.8},
+			{Stage: TraceStageRanking, ImpID: "1", Seat: "s1", BidID: "B", OK: true, Price: 2, Value: 1.6, Rank: 1},
+		}))
+	})
+
+	It("should be a no-op when disabled", func() {
+		var subject *Trace
+		subject.FloorCheck("s1", bid, 1.5, true)
+		Expect(subject.Snapshot()).To(BeNil())
+		Expect(TraceFromContext(context.Background())).To(BeNil())
+	})
+
+	It("should attach to context and responses", func() {
+		subject := NewTrace("A")
+		ctx := ContextWithTrace(context.Background(), subject)
+		TraceFromContext(ctx).Eligibility("s1", bid, false, "blocked adomain")
+
+		res := &BidResponse{ID: "A"}
+		Expect(res.AttachTrace(subject)).To(Succeed())
+		Expect(string(res.Ext)).To(MatchJSON(`{"trace":{"auctionid":"A","events":[{"stage":"eligibility","impid":"1","seat":"s1","bidid":"B","ok":false,"reason":"blocked adomain","price":2}]}}`))
+	})
+
+})
@@ -0,0 +1,55 @@
+package openrtb
+
+import "context"
+
+// DeviceInfo contains the device attributes derived from a user agent.
+type DeviceInfo struct {
+	DeviceType int    // Device type, see DeviceType* constants
+	Make       string // Device make, e.g. "Apple"
+	Model      string // Device model, e.g. "iPhone"
+	OS         string // Device OS, e.g. "iOS"
+	OSVer      string // Device OS version, e.g. "17.1"
+	HwVer      string // Hardware version, e.g. "15"
+}
+
+// DeviceEnricher derives device attributes from a user agent, e.g. by
+// wrapping a third-party user agent parser. Implementations are passed the
+// device, so they may also consider the structured user agent (sua).
+// They should return nil, nil for unknown user agents.
+type DeviceEnricher interface {
+	ParseDevice(ctx context.Context, d *Device) (*DeviceInfo, error)
+}
+
+// EnrichFromUA fills missing device type, make, model, OS, OS version and
+// hardware version using e. Declared values are never overwritten. Devices
+// without ua and sua are skipped.
+func (d *Device) EnrichFromUA(ctx context.Context, e DeviceEnricher) error {
+	if d.UA == "" && d.SUA == nil {
+		return nil
+	}
+
+	info, err := e.ParseDevice(ctx, d)
+	if err != nil || info == nil {
+		return err
+	}
+
+	if d.DeviceType == 0 {
+		d.DeviceType = info.DeviceType
+	}
+	if d.Make == "" {
+		d.Make = info.Make
+	}
+	if d.Model == "" {
+		d.Model = info.Model
+	}
+	if d.OS == "" {
+		d.OS = info.OS
+	}
+	if d.OSVer == "" {
+		d.OSVer = info.OSVer
+	}
+	if d.HwVer == "" {
+		d.HwVer = info.HwVer
+	}
+	return nil
+}
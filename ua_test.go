@@ -0,0 +1,54 @@
+package openrtb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockUAParser struct{}
+
+func (mockUAParser) ParseDevice(_ context.Context, d *Device) (*DeviceInfo, error) {
+	switch {
+	case strings.Contains(d.UA, "iPhone"):
+		return &DeviceInfo{DeviceType: DeviceTypePhone, Make: "Apple", Model: "iPhone", OS: "iOS", OSVer: "6.1"}, nil
+	case strings.Contains(d.UA, "Macintosh"):
+		return &DeviceInfo{DeviceType: DeviceTypePC, Make: "Apple", OS: "macOS"}, nil
+	case d.UA == "error":
+		return nil, errors.New("parse failed")
+	}
+	return nil, nil
+}
+
+var _ = Describe("Device", func() {
+
+	It("should enrich from UA", func() {
+		dev := &Device{UA: "Mozilla/5.0 (iPhone; CPU iPhone OS 6_1 like Mac OS X)", Model: "iPhone 5"}
+		Expect(dev.EnrichFromUA(context.Background(), mockUAParser{})).To(Succeed())
+		Expect(dev).To(Equal(&Device{
+			UA:         "Mozilla/5.0 (iPhone; CPU iPhone OS 6_1 like Mac OS X)",
+			DeviceType: DeviceTypePhone,
+			Make:       "Apple",
+			Model:      "iPhone 5",
+			OS:         "iOS",
+			OSVer:      "6.1",
+		}))
+	})
+
+	It("should skip unknown user agents", func() {
+		dev := &Device{UA: "Unknown/1.0"}
+		Expect(dev.EnrichFromUA(context.Background(), mockUAParser{})).To(Succeed())
+		Expect(dev).To(Equal(&Device{UA: "Unknown/1.0"}))
+
+		dev = &Device{}
+		Expect(dev.EnrichFromUA(context.Background(), mockUAParser{})).To(Succeed())
+		Expect(dev).To(Equal(&Device{}))
+
+		dev = &Device{UA: "error"}
+		Expect(dev.EnrichFromUA(context.Background(), mockUAParser{})).To(MatchError("parse failed"))
+	})
+
+})
@@ -0,0 +1,391 @@
+package v25
+
+import "github.com/bsm/openrtb"
+
+// The top-level bid request object contains a globally unique bid request or auction ID.  This "id"
+// attribute is required as is at least one "imp" (i.e., impression) object.  Other attributes are
+// optional since an exchange may establish default values.
+type BidRequest struct {
+	ID          string            `json:"id"` // Unique ID of the bid request
+	Imp         []Impression      `json:"imp,omitempty"`
+	Site        *Site             `json:"site,omitempty"`
+	App         *App              `json:"app,omitempty"`
+	Device      *Device           `json:"device,omitempty"`
+	User        *User             `json:"user,omitempty"`
+	Test        int               `json:"test,omitempty"`    // Indicator of test mode in which auctions are not billable, where 0 = live mode, 1 = test mode
+	AuctionType int               `json:"at"`                // Auction type, where 1 = First Price, 2 = Second Price Plus. Exchange-specific auction types can be defined using values greater than 500.
+	TMax        int               `json:"tmax,omitempty"`    // Maximum amount of time in milliseconds to submit a bid
+	WSeat       []string          `json:"wseat,omitempty"`   // Array of buyer seats allowed to bid on this auction
+	BSeat       []string          `json:"bseat,omitempty"`   // Block list of buyer seats restricted from bidding on this auction. Only one of wseat and bseat should be present.
+	AllImps     int               `json:"allimps,omitempty"` // Flag to indicate whether exchange can verify that all impressions offered represent all of the impressions available in context, Default: 0
+	Cur         []string          `json:"cur,omitempty"`     // Array of allowed currencies
+	WLang       []string          `json:"wlang,omitempty"`   // Allowed list of languages for creatives using ISO-639-1-alpha-2.
+	Bcat        []string          `json:"bcat,omitempty"`    // Blocked Advertiser Categories.
+	BAdv        []string          `json:"badv,omitempty"`    // Array of strings of blocked toplevel domains of advertisers
+	BApp        []string          `json:"bapp,omitempty"`    // Block list of applications by their platform-specific exchange-independent application identifiers. On Android, these should be bundle or package names (e.g., com.foo.mygame).  On iOS, these are numeric IDs.
+	Source      *Source           `json:"source,omitempty"`
+	Regs        *Regulations      `json:"regs,omitempty"`
+	Ext         openrtb.Extension `json:"ext,omitempty"`
+}
+
+// The "imp" object describes the ad position or impression being auctioned.  A single bid request
+// can include multiple "imp" objects, a use case for which might be an exchange that supports
+// selling all ad positions on a given page as a bundle.  Each "imp" object has a required ID so that
+// bids can reference them individually.  An exchange can also conduct private auctions by
+// restricting involvement to specific subsets of seats within bidders.
+// The presence of Banner, Video, and/or Native objects
+// subordinate to the Imp object indicates the type of impression being offered.
+type Impression struct {
+	ID                string            `json:"id"` // A unique identifier for this impression
+	Banner            *Banner           `json:"banner,omitempty"`
+	Video             *Video            `json:"video,omitempty"`
+	Audio             *Audio            `json:"audio,omitempty"`
+	Native            *Native           `json:"native,omitempty"`
+	Pmp               *Pmp              `json:"pmp,omitempty"`               // A reference to the PMP object containing any Deals eligible for the impression object.
+	DisplayManager    string            `json:"displaymanager,omitempty"`    // Name of ad mediation partner, SDK technology, etc
+	DisplayManagerVer string            `json:"displaymanagerver,omitempty"` // Version of the above
+	Instl             int               `json:"instl,omitempty"`             // Interstitial, Default: 0 ("1": Interstitial, "0": Something else)
+	TagID             string            `json:"tagid,omitempty"`             // IDentifier for specific ad placement or ad tag
+	BidFloor          float64           `json:"bidfloor,omitempty"`          // Bid floor for this impression in CPM
+	BidFloorCurrency  string            `json:"bidfloorcur,omitempty"`       // Currency of bid floor
+	Secure            int               `json:"secure,omitempty"`            // Flag to indicate whether the impression requires secure HTTPS URL creative assets and markup.
+	Exp               int               `json:"exp,omitempty"`               // Advisory as to the number of seconds that may elapse between the auction and the actual impression.
+	IFrameBuster      []string          `json:"iframebuster,omitempty"`      // Array of names for supportediframe busters.
+	Metric            []Metric          `json:"metric,omitempty"`            // An array of Metric object.
+	Ext               openrtb.Extension `json:"ext,omitempty"`
+}
+
+// A site object should be included if the ad supported content is part of a website (as opposed to
+// an application).  A bid request must not contain both a site object and an app object.
+type Site struct {
+	Inventory
+	Page   string `json:"page,omitempty"`   // URL of the page
+	Ref    string `json:"ref,omitempty"`    // Referrer URL
+	Search string `json:"search,omitempty"` // Search string that caused naviation
+	Mobile int    `json:"mobile,omitempty"` // Mobile ("1": site is mobile optimised)
+}
+
+// An "app" object should be included if the ad supported content is part of a mobile application
+// (as opposed to a mobile website).  A bid request must not contain both an "app" object and a
+// "site" object.
+type App struct {
+	Inventory
+	Bundle   string `json:"bundle,omitempty"`   // App bundle or package name
+	StoreURL string `json:"storeurl,omitempty"` // App store URL for an installed app
+	Ver      string `json:"ver,omitempty"`      // App version
+	Paid     int    `json:"paid,omitempty"`     // "1": Paid, "2": Free
+}
+
+// The "device" object provides information pertaining to the device including its hardware,
+// platform, location, and carrier. This device can refer to a mobile handset, a desktop computer,
+// set top box or other digital device.
+type Device struct {
+	UA         string            `json:"ua,omitempty"`             // User agent
+	Geo        *Geo              `json:"geo,omitempty"`            // Location of the device assumed to be the user’s current location
+	DNT        openrtb.Flag      `json:"dnt,omitempty"`            // "1": Do not track
+	LMT        openrtb.Flag      `json:"lmt,omitempty"`            // "1": Limit Ad Tracking
+	IP         string            `json:"ip,omitempty"`             // IPv4
+	IPv6       string            `json:"ipv6,omitempty"`           // IPv6
+	DeviceType int               `json:"devicetype,omitempty"`     // The general type of device.
+	Make       string            `json:"make,omitempty"`           // Device make
+	Model      string            `json:"model,omitempty"`          // Device model
+	OS         string            `json:"os,omitempty"`             // Device OS
+	OSVer      string            `json:"osv,omitempty"`            // Device OS version
+	HwVer      string            `json:"hwv,omitempty"`            // Hardware version of the device (e.g., "5S" for iPhone 5S).
+	H          int               `json:"h,omitempty"`              // Physical height of the screen in pixels.
+	W          int               `json:"w,omitempty"`              // Physical width of the screen in pixels.
+	PPI        int               `json:"ppi,omitempty"`            // Screen size as pixels per linear inch.
+	PxRatio    float64           `json:"pxratio,omitempty"`        // The ratio of physical pixels to device independent pixels.
+	JS         openrtb.Flag      `json:"js,omitempty"`             // Javascript status ("0": Disabled, "1": Enabled)
+	GeoFetch   openrtb.Flag      `json:"geofetch,omitempty"`       // Indicates if the geolocation API will be available to JavaScript code running in the banner,
+	FlashVer   string            `json:"flashver,omitempty"`       // Flash version
+	Language   string            `json:"language,omitempty"`       // Browser language
+	Carrier    string            `json:"carrier,omitempty"`        // Carrier or ISP derived from the IP address
+	ConnType   int               `json:"connectiontype,omitempty"` // Network connection type.
+	IFA        string            `json:"ifa,omitempty"`            // Native identifier for advertisers
+	IDSHA1     string            `json:"didsha1,omitempty"`        // SHA1 hashed device ID
+	IDMD5      string            `json:"didmd5,omitempty"`         // MD5 hashed device ID
+	PIDSHA1    string            `json:"dpidsha1,omitempty"`       // SHA1 hashed platform device ID
+	PIDMD5     string            `json:"dpidmd5,omitempty"`        // MD5 hashed platform device ID
+	MacSHA1    string            `json:"macsha1,omitempty"`        // SHA1 hashed device ID; IMEI when available, else MEID or ESN
+	MacMD5     string            `json:"macmd5,omitempty"`         // MD5 hashed device ID; IMEI when available, else MEID or ESN
+	Ext        openrtb.Extension `json:"ext,omitempty"`
+}
+
+// This object contains information known or derived about the human user of the device (i.e., the
+// audience for advertising). The user id is an exchange artifact and may be subject to rotation or other
+// privacy policies. However, this user ID must be stable long enough to serve reasonably as the basis for
+// frequency capping and retargeting.
+type User struct {
+	ID         string            `json:"id,omitempty"`         // Unique consumer ID of this user on the exchange
+	BuyerID    string            `json:"buyerid,omitempty"`    // Buyer-specific ID for the user as mapped by the exchange for the buyer. At least one of buyeruid/buyerid or id is recommended. Valid for OpenRTB 2.3.
+	BuyerUID   string            `json:"buyeruid,omitempty"`   // Buyer-specific ID for the user as mapped by the exchange for the buyer. Same as BuyerID but valid for OpenRTB 2.2.
+	YOB        int               `json:"yob,omitempty"`        // Year of birth as a 4-digit integer.
+	Gender     string            `json:"gender,omitempty"`     // Gender ("M": male, "F" female, "O" Other)
+	Keywords   string            `json:"keywords,omitempty"`   // Comma separated list of keywords, interests, or intent
+	CustomData string            `json:"customdata,omitempty"` // Optional feature to pass bidder data that was set in the exchange's cookie. The string must be in base85 cookie safe characters and be in any format. Proper JSON encoding must be used to include "escaped" quotation marks.
+	Geo        *Geo              `json:"geo,omitempty"`
+	Data       []Data            `json:"data,omitempty"`
+	Ext        openrtb.Extension `json:"ext,omitempty"`
+}
+
+// This object describes the nature and behavior of the entity that is the source of the bid request
+// upstream from the exchange. The primary purpose of this object is to define post-auction or upstream
+// decisioning when the exchange itself does not control the final decision.
+type Source struct {
+	FD     int               `json:"fd,omitempty"`     // Entity responsible for the final impression sale decision, where 0 = exchange, 1 = upstream source
+	TID    string            `json:"tid,omitempty"`    // Transaction ID that must be common across all participants in this bid request (e.g., potentially multiple exchanges)
+	PChain string            `json:"pchain,omitempty"` // Payment ID chain string containing embedded syntax described in the TAG Payment ID Protocol v1.0
+	Ext    openrtb.Extension `json:"ext,omitempty"`
+}
+
+// This object contains any legal, governmental, or industry regulations that apply to the request. The
+// coppa flag signals whether or not the request falls under the United States Federal Trade Commission's
+// regulations for the United States Children's Online Privacy Protection Act ("COPPA").
+type Regulations struct {
+	Coppa int               `json:"coppa,omitempty"` // Flag indicating if this request is subject to the COPPA regulations established by the USA FTC, where 0 = no, 1 = yes.
+	Ext   openrtb.Extension `json:"ext,omitempty"`
+}
+
+// Private Marketplace Object
+type Pmp struct {
+	Private int               `json:"private_auction,omitempty"`
+	Deals   []Deal            `json:"deals,omitempty"`
+	Ext     openrtb.Extension `json:"ext,omitempty"`
+}
+
+// The "banner" object must be included directly in the impression object if the impression offered
+// for auction is display or rich media, or it may be optionally embedded in the video object to
+// describe the companion banners available for the linear or non-linear video ad.  The banner
+// object may include a unique identifier; this can be useful if these IDs can be leveraged in the
+// VAST response to dictate placement of the companion creatives when multiple companion ad
+// opportunities of the same size are available on a page.
+type Banner struct {
+	W        int               `json:"w,omitempty"`        // Width
+	H        int               `json:"h,omitempty"`        // Height
+	Format   []Format          `json:"format,omitempty"`   //Array of format objects representing the banner sizes permitted.
+	WMax     int               `json:"wmax,omitempty"`     // Width maximum DEPRECATED
+	HMax     int               `json:"hmax,omitempty"`     // Height maximum DEPRECATED
+	WMin     int               `json:"wmin,omitempty"`     // Width minimum DEPRECATED
+	HMin     int               `json:"hmin,omitempty"`     // Height minimum DEPRECATED
+	ID       string            `json:"id,omitempty"`       // A unique identifier
+	BType    []int             `json:"btype,omitempty"`    // Blocked creative types
+	BAttr    []int             `json:"battr,omitempty"`    // Blocked creative attributes
+	Pos      int               `json:"pos,omitempty"`      // Ad Position
+	Mimes    []string          `json:"mimes,omitempty"`    // Whitelist of content MIME types supported
+	TopFrame int               `json:"topframe,omitempty"` // Default: 0 ("1": Delivered in top frame, "0": Elsewhere)
+	ExpDir   []int             `json:"expdir,omitempty"`   // Specify properties for an expandable ad
+	Api      []int             `json:"api,omitempty"`      // List of supported API frameworks
+	Ext      openrtb.Extension `json:"ext,omitempty"`
+}
+
+// The "video" object must be included directly in the impression object if the impression offered
+// for auction is an in-stream video ad opportunity.
+type Video struct {
+	Mimes          []string          `json:"mimes,omitempty"`          // Content MIME types supported.
+	MinDuration    int               `json:"minduration,omitempty"`    // Minimum video ad duration in seconds
+	MaxDuration    int               `json:"maxduration,omitempty"`    // Maximum video ad duration in seconds
+	Protocols      []int             `json:"protocols,omitempty"`      // Video bid response protocols
+	Protocol       int               `json:"protocol,omitempty"`       // Video bid response protocols DEPRECATED
+	W              int               `json:"w,omitempty"`              // Width of the player in pixels
+	H              int               `json:"h,omitempty"`              // Height of the player in pixels
+	StartDelay     int               `json:"startdelay,omitempty"`     // Indicates the start delay in seconds
+	Placement      int               `json:"placement,omitempty"`      // Video placement type for the impression
+	Linearity      int               `json:"linearity,omitempty"`      // Indicates whether the ad impression is linear or non-linear
+	Skip           int               `json:"skip,omitempty"`           // Indicates if the player will allow the video to be skipped, where 0 = no, 1 = yes.
+	SkipMin        int               `json:"skipmin,omitempty"`        // Videos of total duration greater than this number of seconds can be skippable
+	SkipAfter      int               `json:"skipafter,omitempty"`      // Number of seconds a video must play before skipping is enabled
+	Sequence       int               `json:"sequence,omitempty"`       // Default: 1
+	BAttr          []int             `json:"battr,omitempty"`          // Blocked creative attributes
+	MaxExtended    int               `json:"maxextended,omitempty"`    // Maximum extended video ad duration
+	MinBitrate     int               `json:"minbitrate,omitempty"`     // Minimum bit rate in Kbps
+	MaxBitrate     int               `json:"maxbitrate,omitempty"`     // Maximum bit rate in Kbps
+	BoxingAllowed  *int              `json:"boxingallowed,omitempty"`  // If exchange publisher has rules preventing letter boxing
+	PlaybackMethod []int             `json:"playbackmethod,omitempty"` // List of allowed playback methods
+	Delivery       []int             `json:"delivery,omitempty"`       // List of supported delivery methods
+	Pos            int               `json:"pos,omitempty"`            // Ad Position
+	CompanionAd    []Banner          `json:"companionad,omitempty"`
+	Api            []int             `json:"api,omitempty"` // List of supported API frameworks
+	CompanionType  []int             `json:"companiontype,omitempty"`
+	Ext            openrtb.Extension `json:"ext,omitempty"`
+}
+
+// The "audio" object must be included directly in the impression object
+type Audio struct {
+	Mimes         []string          `json:"mimes"`                 // Content MIME types supported.
+	MinDuration   int               `json:"minduration,omitempty"` // Minimum video ad duration in seconds
+	MaxDuration   int               `json:"maxduration,omitempty"` // Maximum video ad duration in seconds
+	Protocols     []int             `json:"protocols,omitempty"`   // Video bid response protocols
+	StartDelay    int               `json:"startdelay,omitempty"`  // Indicates the start delay in seconds
+	Sequence      int               `json:"sequence,omitempty"`    // Default: 1
+	BAttr         []int             `json:"battr,omitempty"`       // Blocked creative attributes
+	MaxExtended   int               `json:"maxextended,omitempty"` // Maximum extended video ad duration
+	MinBitrate    int               `json:"minbitrate,omitempty"`  // Minimum bit rate in Kbps
+	MaxBitrate    int               `json:"maxbitrate,omitempty"`  // Maximum bit rate in Kbps
+	Delivery      []int             `json:"delivery,omitempty"`    // List of supported delivery methods
+	CompanionAd   []Banner          `json:"companionad,omitempty"`
+	API           []int             `json:"api,omitempty"`
+	CompanionType []int             `json:"companiontype,omitempty"`
+	MaxSequence   int               `json:"maxseq,omitempty"`   // The maximumnumber of ads that canbe played in an ad pod.
+	Feed          int               `json:"feed,omitempty"`     // Type of audio feed.
+	Stitched      int               `json:"stitched,omitempty"` // Indicates if the ad is stitched with audio content or delivered independently
+	NVol          int               `json:"nvol,omitempty"`     // Volume normalization mode.
+	Ext           openrtb.Extension `json:"ext,omitempty"`
+}
+
+// This object represents a native type impression. Native ad units are intended to blend seamlessly into
+// the surrounding content (e.g., a sponsored Twitter or Facebook post). As such, the response must be
+// well-structured to afford the publisher fine-grained control over rendering.
+// The presence of a Native as a subordinate of the Imp object indicates that this impression is offered as
+// a native type impression. At the publisher’s discretion, that same impression may also be offered as
+// banner and/or video by also including as Imp subordinates the Banner and/or Video objects,
+// respectively. However, any given bid for the impression must conform to one of the offered types.
+type Native struct {
+	Request openrtb.Extension `json:"request"`         // Request payload complying with the Native Ad Specification.
+	Ver     string            `json:"ver,omitempty"`   // Version of the Native Ad Specification to which request complies; highly recommended for efficient parsing.
+	API     []int             `json:"api,omitempty"`   // List of supported API frameworks for this impression.
+	BAttr   []int             `json:"battr,omitempty"` // Blocked creative attributes
+	Ext     openrtb.Extension `json:"ext,omitempty"`
+}
+
+// This object is associated with an impression as an array of metrics. These metrics can offer insight
+// into the impression to assist with decisioning such as average recent viewability, click-through rate,
+// etc. Each metric is identified by its type, reports the value of the metric, and optionally
+// identifies the source or vendor measuring the value.
+type Metric struct {
+	Type   string            `json:"type"`             // Type of metric being presented using exchange curated string names which should be published to bidders a priori.
+	Value  float64           `json:"value"`            // Number representing the value of the metric. Probabilities must be in the range 0.0 – 1.0.
+	Vendor string            `json:"vendor,omitempty"` // Source of the value using exchange curated string names which should be published to bidders a priori. If the exchange itself is the source versus a third party, "EXCHANGE" is recommended.
+	Ext    openrtb.Extension `json:"ext,omitempty"`
+}
+
+type Inventory struct {
+	ID            string            `json:"id,omitempty"` // ID on the exchange
+	Name          string            `json:"name,omitempty"`
+	Domain        string            `json:"domain,omitempty"`
+	Cat           []string          `json:"cat,omitempty"`          // Array of IAB content categories
+	SectionCat    []string          `json:"sectioncat,omitempty"`   // Array of IAB content categories for subsection
+	PageCat       []string          `json:"pagecat,omitempty"`      // Array of IAB content categories for page
+	PrivacyPolicy *int              `json:"pivacypolicy,omitempty"` // Default: 1 ("1": has a privacy policy)
+	Publisher     *Publisher        `json:"publisher,omitempty"`    // Details about the Publisher
+	Content       *Content          `json:"content,omitempty"`      // Details about the Content
+	Keywords      string            `json:"keywords,omitempty"`     // Comma separated list of keywords about the site.
+	Ext           openrtb.Extension `json:"ext,omitempty"`
+}
+
+// Note that the Geo Object may appear in one or both the Device Object and the User Object.
+// This is intentional, since the information may be derived from either a device-oriented source
+// (such as IP geo lookup), or by user registration information (for example provided to a publisher
+// through a user registration).
+type Geo struct {
+	Lat           float64           `json:"lat,omitempty"`           // Latitude from -90 to 90
+	Lon           float64           `json:"lon,omitempty"`           // Longitude from -180 to 180
+	Type          int               `json:"type,omitempty"`          // Indicate the source of the geo data
+	Accuracy      int               `json:"accuracy,omitempty"`      // Estimated location accuracy in meters; recommended when lat/lon are specified and derived from a device’s location services
+	LastFix       int               `json:"lastfix,omitempty"`       // Number of seconds since this geolocation fix was established.
+	IPService     int               `json:"ipservice,omitempty"`     // Service or provider used to determine geolocation from IP address if applicable
+	Country       string            `json:"country,omitempty"`       // Country using ISO 3166-1 Alpha 3
+	Region        string            `json:"region,omitempty"`        // Region using ISO 3166-2
+	RegionFIPS104 string            `json:"regionFIPS104,omitempty"` // Region of a country using FIPS 10-4
+	Metro         string            `json:"metro,omitempty"`
+	City          string            `json:"city,omitempty"`
+	Zip           string            `json:"zip,omitempty"`
+	UTCOffset     int               `json:"utcoffset,omitempty"` // Local time as the number +/- of minutes from UTC
+	Ext           openrtb.Extension `json:"ext,omitempty"`
+}
+
+// The data and segment objects together allow additional data about the user to be specified. This data
+// may be from multiple sources whether from the exchange itself or third party providers as specified by
+// the id field. A bid request can mix data objects from multiple providers. The specific data providers in
+// use should be published by the exchange a priori to its bidders.
+type Data struct {
+	ID      string            `json:"id,omitempty"`
+	Name    string            `json:"name,omitempty"`
+	Segment []Segment         `json:"segment,omitempty"`
+	Ext     openrtb.Extension `json:"ext,omitempty"`
+}
+
+// PMP Deal
+type Deal struct {
+	ID               string            `json:"id,omitempty"` // Unique deal ID
+	BidFloor         float64           `json:"bidfloor,omitempty"`
+	BidFloorCurrency string            `json:"bidfloorcur,omitempty"` // Currency of bid floor
+	WSeat            []string          `json:"wseat,omitempty"`       // Array of buyer seats allowed to bid on this Direct Deal.
+	WAdvDomain       []string          `json:"wadomain,omitempty"`    // Array of advertiser domains allowed to bid on this Direct Deal
+	AuctionType      int               `json:"at,omitempty"`          // Optional override of the overall auction type of the bid request, where 1 = First Price, 2 = Second Price Plus, 3 = the value passed in bidfloor is the agreed upon deal price. Additional auction types can be defined by the exchange. Default: the auction type of the request.
+	Ext              openrtb.Extension `json:"ext,omitempty"`
+}
+
+// This object represents an allowed size (i.e., height and width combination) for a banner impression.
+// These are typically used in an array for an impression where multiple sizes are permitted.
+type Format struct {
+	W      int               `json:"w,omitempty"`      // Width in device independent pixels (DIPS).
+	H      int               `json:"h,omitempty"`      //Height in device independent pixels (DIPS).
+	WRatio int               `json:"wratio,omitempty"` // Relative width when expressing size as a ratio.
+	HRatio int               `json:"hratio,omitempty"` // Relative height when expressing size as a ratio.
+	WMin   int               `json:"wmin,omitempty"`   // The minimum width in device independent pixels (DIPS) at which the ad will be displayed when the size is expressed as a ratio.
+	Ext    openrtb.Extension `json:"ext,omitempty"`
+}
+
+// The publisher object itself and all of its parameters are optional, so default values are not
+// provided. If an optional parameter is not specified, it should be considered unknown.
+type Publisher ThirdParty
+
+// This object describes the content in which the impression will appear, which may be syndicated or nonsyndicated
+// content. This object may be useful when syndicated content contains impressions and does
+// not necessarily match the publisher's general content. The exchange might or might not have
+// knowledge of the page where the content is running, as a result of the syndication method. For
+// example might be a video impression embedded in an iframe on an unknown web property or device.
+type Content struct {
+	ID                 string            `json:"id,omitempty"`                 // ID uniquely identifying the content.
+	Episode            int               `json:"episode,omitempty"`            // Episode number (typically applies to video content).
+	Title              string            `json:"title,omitempty"`              // Content title.
+	Series             string            `json:"series,omitempty"`             // Content series.
+	Season             string            `json:"season,omitempty"`             // Content season.
+	Artist             string            `json:"artist,omitempty"`             // Artist credited with the content.
+	Genre              string            `json:"genre,omitempty"`              // Genre that best describes the content
+	Album              string            `json:"album,omitempty"`              // Album to which the content belongs; typically for audio.
+	ISRC               string            `json:"isrc,omitempty"`               // International Standard Recording Code conforming to ISO - 3901.
+	Producer           *Producer         `json:"producer,omitempty"`           // The producer.
+	URL                string            `json:"url,omitempty"`                // URL of the content, for buy-side contextualization or review.
+	Cat                []string          `json:"cat,omitempty"`                // Array of IAB content categories that describe the content.
+	ProdQuality        int               `json:"prodq,omitempty"`              // Production quality per IAB's classification.
+	VideoQuality       int               `json:"videoquality,omitempty"`       // Video quality per IAB's classification.
+	Context            int               `json:"context,omitempty"`            // Type of content (game, video, text, etc.).
+	ContentRating      string            `json:"contentrating,omitempty"`      // Content rating (e.g., MPAA).
+	UserRating         string            `json:"userrating,omitempty"`         // User rating of the content (e.g., number of stars, likes, etc.).
+	QAGMediaRating     int               `json:"qagmediarating,omitempty"`     // Media rating per QAG guidelines.
+	Keywords           string            `json:"keywords,omitempty"`           // Comma separated list of keywords describing the content.
+	LiveStream         int               `json:"livestream,omitempty"`         // 0 = not live, 1 = content is live (e.g., stream, live blog).
+	SourceRelationship int               `json:"sourcerelationship,omitempty"` // 0 = indirect, 1 = direct.
+	Len                int               `json:"len,omitempty"`                // Length of content in seconds; appropriate for video or audio.
+	Language           string            `json:"language,omitempty"`           // Content language using ISO-639-1-alpha-2.
+	Embeddable         int               `json:"embeddable,omitempty"`         // Indicator of whether or not the content is embeddable (e.g., an embeddable video player), where 0 = no, 1 = yes.
+	Data               []Data            `json:"data,omitempty"`               // Additional content data.
+	Ext                openrtb.Extension `json:"ext,omitempty"`
+}
+
+// Segment objects are essentially key-value pairs that convey specific units of data about the user. The
+// parent Data object is a collection of such values from a given data provider. The specific segment
+// names and value options must be published by the exchange a priori to its bidders.
+type Segment struct {
+	ID    string            `json:"id,omitempty"`
+	Name  string            `json:"name,omitempty"`
+	Value string            `json:"value,omitempty"`
+	Ext   openrtb.Extension `json:"ext,omitempty"`
+}
+
+// Abstract third-party
+type ThirdParty struct {
+	ID     string            `json:"id,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	Cat    []string          `json:"cat,omitempty"` // Array of IAB content categories
+	Domain string            `json:"domain,omitempty"`
+	Ext    openrtb.Extension `json:"ext,omitempty"`
+}
+
+// The producer is useful when content where the ad is shown is syndicated, and may appear on a
+// completely different publisher. The producer object itself and all of its parameters are optional,
+// so default values are not provided. If an optional parameter is not specified, it should be
+// considered unknown.
+type Producer ThirdParty
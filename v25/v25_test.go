@@ -0,0 +1,70 @@
+package v25
+
+import (
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BidRequest", func() {
+	var subject *openrtb.BidRequest
+
+	BeforeEach(func() {
+		subject = &openrtb.BidRequest{
+			ID:     "R",
+			Imp:    []openrtb.Impression{{ID: "I", Rwdd: 1, Video: &openrtb.Video{Plcmt: openrtb.VideoPlcmtInstream}}},
+			WLangB: []string{"en-GB"},
+			Regs:   &openrtb.Regulations{GDPR: 1},
+		}
+	})
+
+	It("should convert from the latest version", func() {
+		req, err := FromBidRequest(subject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("R"))
+		Expect(req.Imp[0].Video.Placement).To(Equal(1))
+		Expect([]byte(req.Regs.Ext)).To(MatchJSON(`{"gdpr":1}`))
+	})
+
+	It("should convert to the latest version", func() {
+		req, err := FromBidRequest(subject)
+		Expect(err).NotTo(HaveOccurred())
+
+		latest, err := ToBidRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(latest.ID).To(Equal("R"))
+		Expect(latest.Imp[0].Rwdd).To(BeZero())
+		Expect(latest.Imp[0].Video.Plcmt).To(BeZero())
+		Expect(latest.Imp[0].Video.Placement).To(Equal(openrtb.VideoPlacementInStream))
+		Expect(latest.WLangB).To(BeEmpty())
+		Expect(latest.Regs.GDPR).To(Equal(1))
+	})
+})
+
+var _ = Describe("BidResponse", func() {
+
+	It("should convert", func() {
+		subject := &openrtb.BidResponse{
+			ID:      "R",
+			SeatBid: []openrtb.SeatBid{{Seat: "S", Bid: []openrtb.Bid{{ID: "B", ImpID: "I", Price: 1.5, MType: openrtb.MarkupTypeBanner}}}},
+		}
+
+		res, err := FromBidResponse(subject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid[0].Bid[0].Price).To(Equal(1.5))
+
+		latest, err := ToBidResponse(res)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(latest.SeatBid[0].Seat).To(Equal("S"))
+		Expect(latest.SeatBid[0].Bid[0].ID).To(Equal("B"))
+		Expect(latest.SeatBid[0].Bid[0].MType).To(BeZero())
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/v25")
+}
@@ -0,0 +1,66 @@
+package v26
+
+import "github.com/bsm/openrtb"
+
+// ID and at least one "seatbid” object is required, which contains a bid on at least one impression.
+// Other attributes are optional since an exchange may establish default values.
+// No-Bids on all impressions should be indicated as a HTTP 204 response.
+// For no-bids on specific impressions, the bidder should omit these from the bid response.
+type BidResponse struct {
+	ID         string            `json:"id"`                   // Reflection of the bid request ID for logging purposes
+	SeatBid    []SeatBid         `json:"seatbid"`              // Array of seatbid objects
+	BidID      string            `json:"bidid,omitempty"`      // Optional response tracking ID for bidders
+	Currency   string            `json:"cur,omitempty"`        // Bid currency
+	CustomData string            `json:"customdata,omitempty"` // Encoded user features
+	NBR        int               `json:"nbr,omitempty"`        // Reason for not bidding, where 0 = unknown error, 1 = technical error, 2 = invalid request, 3 = known web spider, 4 = suspected Non-Human Traffic, 5 = cloud, data center, or proxy IP, 6 = unsupported device, 7 = blocked publisher or site, 8 = unmatched user
+	Ext        openrtb.Extension `json:"ext,omitempty"`        // Custom specifications in JSon
+}
+
+// At least one of Bid is required.
+// A bid response can contain multiple "seatbid” objects, each on behalf of a different bidder seat.
+// SeatBid object can contain multiple bids each pertaining to a different impression on behalf of a seat.
+// Each "bid” object must include the impression ID to which it pertains as well as the bid price.
+// Group attribute can be used to specify if a seat is willing to accept any impressions that it can win (default) or if it is
+// only interested in winning any if it can win them all (i.e., all or nothing).
+type SeatBid struct {
+	Bid   []Bid             `json:"bid"`             // Array of bid objects; each realtes to an imp, if exchange supported can have many bid objects.
+	Seat  string            `json:"seat,omitempty"`  // ID of the bidder seat optional string ID of the bidder seat on whose behalf this bid is made.
+	Group int               `json:"group,omitempty"` // '1' means impression must be won-lost as a group; default is '0'.
+	Ext   openrtb.Extension `json:"ext,omitempty"`
+}
+
+// ID, ImpID and Price are required; all other optional.
+// If the bidder wins the impression, the exchange calls notice URL (nurl)
+// a) to inform the bidder of the win;
+// b) to convey certain information using substitution macros.
+// Adomain can be used to check advertiser block list compliance.
+// Cid can be used to block ads that were previously identified as inappropriate.
+// Substitution macros may allow a bidder to use a static notice URL for all of its bids.
+type Bid struct {
+	ID             string              `json:"id"`
+	ImpID          string              `json:"impid"`                    // Required string ID of the impression object to which this bid applies.
+	Price          float64             `json:"price"`                    // Bid price in CPM. Suggests using integer math for accounting to avoid rounding errors.
+	AdID           string              `json:"adid,omitempty"`           // References the ad to be served if the bid wins.
+	NURL           string              `json:"nurl,omitempty"`           // Win notice URL.
+	AdMarkup       string              `json:"adm,omitempty"`            // Actual ad markup. XHTML if a response to a banner object, or VAST XML if a response to a video object.
+	AdvDomain      []string            `json:"adomain,omitempty"`        // Advertiser’s primary or top-level domain for advertiser checking; or multiple if imp rotating.
+	Bundle         string              `json:"bundle,omitempty"`         // A platform-specific application identifier intended to be unique to the app and independent of the exchange.
+	IURL           string              `json:"iurl,omitempty"`           // Sample image URL.
+	CampaignID     openrtb.MultiString `json:"cid,omitempty"`            // Campaign ID that appears with the Ad markup.
+	CreativeID     string              `json:"crid,omitempty"`           // Creative ID for reporting content issues or defects. This could also be used as a reference to a creative ID that is posted with an exchange.
+	CatTax         int                 `json:"cattax,omitempty"`         // The taxonomy in use for cat, Default: 1
+	Cat            []string            `json:"cat,omitempty"`            // IAB content categories of the creative. Refer to List 5.1
+	Attr           []int               `json:"attr,omitempty"`           // Array of creative attributes.
+	API            int                 `json:"api,omitempty"`            // API required by the markup if applicable
+	APIs           []int               `json:"apis,omitempty"`           // List of APIs required by the markup if applicable
+	Protocol       int                 `json:"protocol,omitempty"`       // Video response protocol of the markup if applicable
+	QAGMediaRating int                 `json:"qagmediarating,omitempty"` // Creative media rating per IQG guidelines.
+	Language       string              `json:"language,omitempty"`       // Language of the creative using ISO-639-1-alpha-2.
+	LangB          string              `json:"langb,omitempty"`          // Language of the creative using IETF BCP 47.
+	DealID         string              `json:"dealid,omitempty"`         // DealID extension of private marketplace deals
+	H              int                 `json:"h,omitempty"`              // Height of the ad in pixels.
+	W              int                 `json:"w,omitempty"`              // Width of the ad in pixels.
+	Exp            int                 `json:"exp,omitempty"`            // Advisory as to the number of seconds the bidder is willing to wait between the auction and the actual impression.
+	MType          int                 `json:"mtype,omitempty"`          // Type of the creative markup so that it can properly be associated with the right sub-object of the BidRequest.Imp.
+	Ext            openrtb.Extension   `json:"ext,omitempty"`
+}
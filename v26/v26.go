@@ -0,0 +1,54 @@
+/*
+Package v26 pins the OpenRTB 2.6 wire format. Its types declare the fields
+of the 2.6 spec only and do not change when package openrtb adopts later
+spec versions, so consumers can exchange objects with partners which speak
+2.6 without build breakages on every spec bump.
+
+Objects are converted to and from those of package openrtb, using the
+converters of package convert, which all versions share. Fields introduced
+by later versions are dropped.
+
+	req, err := v26.FromBidRequest(latest)
+	...
+	latest, err := v26.ToBidRequest(req)
+
+Objects of different versions are converted via package openrtb.
+*/
+package v26
+
+import (
+	"github.com/bsm/openrtb"
+	"github.com/bsm/openrtb/convert"
+)
+
+var options = &convert.Options{Version: openrtb.Version26}
+
+// FromBidRequest converts req into a 2.6 bid request.
+func FromBidRequest(req *openrtb.BidRequest) (*BidRequest, error) {
+	dst := new(BidRequest)
+	if err := convert.FromBidRequest(req, dst, options); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// ToBidRequest converts req into a bid request of package openrtb. The
+// result is not validated.
+func ToBidRequest(req *BidRequest) (*openrtb.BidRequest, error) {
+	return convert.ToBidRequest(req, options)
+}
+
+// FromBidResponse converts res into a 2.6 bid response.
+func FromBidResponse(res *openrtb.BidResponse) (*BidResponse, error) {
+	dst := new(BidResponse)
+	if err := convert.FromBidResponse(res, dst, options); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// ToBidResponse converts res into a bid response of package openrtb. The
+// result is not validated.
+func ToBidResponse(res *BidResponse) (*openrtb.BidResponse, error) {
+	return convert.ToBidResponse(res, options)
+}
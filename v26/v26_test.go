@@ -0,0 +1,61 @@
+package v26
+
+import (
+	"testing"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BidRequest", func() {
+	var subject *openrtb.BidRequest
+
+	BeforeEach(func() {
+		subject = &openrtb.BidRequest{
+			ID:     "R",
+			Imp:    []openrtb.Impression{{ID: "I", Rwdd: 1, Video: &openrtb.Video{Plcmt: openrtb.VideoPlcmtInstream}}},
+			WLangB: []string{"en-GB"},
+			Regs:   &openrtb.Regulations{GDPR: 1},
+			Ext:    openrtb.Extension(`{"custom":true}`),
+		}
+	})
+
+	It("should convert from the latest version", func() {
+		req, err := FromBidRequest(subject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.ID).To(Equal("R"))
+		Expect(req.Imp[0].Rwdd).To(Equal(1))
+		Expect(req.Imp[0].Video.Plcmt).To(Equal(openrtb.VideoPlcmtInstream))
+		Expect(req.WLangB).To(Equal([]string{"en-GB"}))
+		Expect(req.Regs.GDPR).To(Equal(1))
+		Expect([]byte(req.Ext)).To(MatchJSON(`{"custom":true}`))
+	})
+
+	It("should convert to the latest version", func() {
+		req, err := FromBidRequest(subject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ToBidRequest(req)).To(Equal(subject))
+	})
+})
+
+var _ = Describe("BidResponse", func() {
+
+	It("should convert", func() {
+		subject := &openrtb.BidResponse{
+			ID:      "R",
+			SeatBid: []openrtb.SeatBid{{Seat: "S", Bid: []openrtb.Bid{{ID: "B", ImpID: "I", Price: 1.5, MType: openrtb.MarkupTypeBanner}}}},
+		}
+
+		res, err := FromBidResponse(subject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.SeatBid[0].Bid[0].MType).To(Equal(openrtb.MarkupTypeBanner))
+		Expect(ToBidResponse(res)).To(Equal(subject))
+	})
+
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/v26")
+}
@@ -0,0 +1,218 @@
+package openrtb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ValidationError is returned by the validation of bid requests and
+// responses. It annotates a validation error with the JSON path of the
+// invalid object or field and a machine-readable code. The underlying
+// error is one of the ErrInvalid* errors and can be tested via errors.Is.
+type ValidationError struct {
+	Path string // JSON path, e.g. "seatbid[0].bid[2].impid"
+	Code string // Machine-readable code, e.g. "bid_missing_impid"
+	Err  error  // Underlying error
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + " (" + e.Path + ")"
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// ValidationErrors lists all validation errors of an object, as returned by
+// the ValidateAll methods.
+type ValidationErrors []*ValidationError
+
+// Error implements error.
+func (e ValidationErrors) Error() string {
+	var b strings.Builder
+	for i, err := range e {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the individual errors.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ValidationCodeUnknown is the code of validation errors without a known
+// code.
+const ValidationCodeUnknown = "invalid"
+
+type validationInfo struct {
+	code  string // machine-readable code
+	field string // path of the field, relative to the validated object
+}
+
+// validationInfos maps errors returned by Validate methods to their codes
+// and field paths.
+var validationInfos = map[error]validationInfo{
+	ErrInvalidReqNoID:      {"request_missing_id", "id"},
+	ErrInvalidReqNoImps:    {"request_missing_imp", "imp"},
+	ErrInvalidReqMultiInv:  {"request_multiple_inventory", ""},
+	ErrInvalidReqCur:       {"request_invalid_cur", "cur"},
+	ErrInvalidReqWLang:     {"request_invalid_wlang", "wlang"},
+	ErrInvalidReqWLangB:    {"request_invalid_wlangb", "wlangb"},
+	ErrInvalidReqMultiLang: {"request_multiple_wlang", ""},
+	ErrInvalidReqAuction:   {"request_invalid_at", "at"},
+
+	ErrInvalidImpNoID:        {"imp_missing_id", "id"},
+	ErrInvalidImpNoAssets:    {"imp_missing_assets", ""},
+	ErrInvalidImpMultiAssets: {"imp_multiple_assets", ""},
+	ErrInvalidImpRwdd:        {"imp_invalid_rwdd", "rwdd"},
+	ErrInvalidImpFloorCur:    {"imp_invalid_bidfloorcur", "bidfloorcur"},
+	ErrInvalidImpSSAI:        {"imp_invalid_ssai", "ssai"},
+	ErrInvalidImpQty:         {"imp_invalid_qty", "qty"},
+	ErrInvalidImpRefresh:     {"imp_invalid_refresh", "refresh"},
+	ErrInvalidImpDealAuction: {"imp_invalid_deal_at", "at"},
+
+	ErrInvalidBannerMime: {"banner_invalid_mime", "banner.mimes"},
+
+	ErrInvalidVideoNoMimes:       {"video_missing_mimes", "video.mimes"},
+	ErrInvalidVideoMime:          {"video_invalid_mime", "video.mimes"},
+	ErrInvalidVideoNoLinearity:   {"video_missing_linearity", "video.linearity"},
+	ErrInvalidVideoNoMinDuration: {"video_missing_minduration", "video.minduration"},
+	ErrInvalidVideoNoMaxDuration: {"video_missing_maxduration", "video.maxduration"},
+	ErrInvalidVideoNoProtocols:   {"video_missing_protocols", "video.protocols"},
+	ErrInvalidVideoDuration:      {"video_invalid_duration", "video.minduration"},
+	ErrInvalidVideoRqdDurs:       {"video_invalid_rqddurs", "video.rqddurs"},
+	ErrInvalidVideoSkip:          {"video_invalid_skip", "video.skip"},
+	ErrInvalidVideoSkipParams:    {"video_invalid_skip_params", "video.skip"},
+	ErrInvalidVideoSkipAfter:     {"video_invalid_skipafter", "video.skipafter"},
+	ErrInvalidVideoStartDelay:    {"video_invalid_startdelay", "video.startdelay"},
+
+	ErrInvalidAudioNoMimes:     {"audio_missing_mimes", "audio.mimes"},
+	ErrInvalidAudioMime:        {"audio_invalid_mime", "audio.mimes"},
+	ErrInvalidAudioDuration:    {"audio_invalid_duration", "audio.minduration"},
+	ErrInvalidAudioRqdDurs:     {"audio_invalid_rqddurs", "audio.rqddurs"},
+	ErrInvalidAudioPodDuration: {"audio_invalid_poddur", "audio.poddur"},
+
+	ErrInvalidGeoLat:     {"geo_invalid_lat", "lat"},
+	ErrInvalidGeoLon:     {"geo_invalid_lon", "lon"},
+	ErrInvalidGeoType:    {"geo_invalid_type", "type"},
+	ErrInvalidGeoCountry: {"geo_invalid_country", "country"},
+
+	ErrInvalidRespNoID:       {"response_missing_id", "id"},
+	ErrInvalidRespNoSeatBids: {"response_missing_seatbid", "seatbid"},
+	ErrInvalidRespCur:        {"response_invalid_cur", "cur"},
+	ErrInvalidSeatBidBid:     {"seatbid_missing_bid", "bid"},
+	ErrInvalidSeatBidSeat:    {"seatbid_seat_blocked", "seat"},
+	ErrInvalidSeatBidGroup:   {"seatbid_group_duplicate_imp", "bid"},
+
+	ErrInvalidBidNoID:    {"bid_missing_id", "id"},
+	ErrInvalidBidNoImpID: {"bid_missing_impid", "impid"},
+	ErrInvalidBidImpID:   {"bid_unknown_impid", "impid"},
+	ErrInvalidBidMType:   {"bid_invalid_mtype", "mtype"},
+	ErrInvalidBidMarkup:  {"bid_invalid_markup", "adm"},
+	ErrInvalidBidAPI:     {"bid_unsupported_api", "apis"},
+	ErrInvalidBidOMID:    {"bid_unsupported_omid", "apis"},
+	ErrInvalidBidPrice:   {"bid_invalid_price", "price"},
+	ErrInvalidBidFloor:   {"bid_below_floor", "price"},
+	ErrInvalidBidNoAdm:   {"bid_missing_adm", "adm"},
+	ErrInvalidBidNoSize:  {"bid_missing_size", "w"},
+	ErrInvalidBidDealID:  {"bid_invalid_dealid", "dealid"},
+
+	ErrInvalidBidAdvDomain: {"bid_invalid_adomain", "adomain"},
+
+	ErrInvalidBidBundle:         {"bid_invalid_bundle", "bundle"},
+	ErrInvalidBidBundlePlatform: {"bid_bundle_platform_mismatch", "bundle"},
+	ErrInvalidAppBundle:         {"app_invalid_bundle", "bundle"},
+	ErrInvalidAppStoreURL:       {"app_invalid_storeurl", "storeurl"},
+	ErrAppStoreURLMismatch:      {"app_storeurl_mismatch", "storeurl"},
+
+	ErrInvalidDeviceIFATracking: {"device_ifa_tracking_mismatch", "ifa"},
+	ErrInvalidDeviceZeroIFA:     {"device_zero_ifa", "ifa"},
+}
+
+// ValidationCode returns the machine-readable code of a validation error.
+func ValidationCode(err error) string {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr.Code
+	}
+	if info, ok := validationInfos[err]; ok {
+		return info.code
+	}
+	return ValidationCodeUnknown
+}
+
+// validator collects the validation errors of an object. Unless all is
+// set, validation stops at the first error.
+type validator struct {
+	all  bool
+	errs ValidationErrors
+}
+
+// add records err, returned for the object at path. Errors which are already
+// annotated are prefixed with path, others are annotated with their code and
+// the relative path of the field. It returns false if validation should stop.
+func (v *validator) add(path string, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return v.addAt(joinPath(path, verr.Path), verr.Err)
+	}
+
+	field := ""
+	if info, ok := validationInfos[err]; ok {
+		field = info.field
+	}
+	return v.addAt(joinPath(path, field), err)
+}
+
+// addAt records err for the exact path of the invalid field. It returns
+// false if validation should stop.
+func (v *validator) addAt(path string, err error) bool {
+	code := ValidationCodeUnknown
+	if info, ok := validationInfos[err]; ok {
+		code = info.code
+	}
+	v.errs = append(v.errs, &ValidationError{Path: path, Code: code, Err: err})
+	return v.all
+}
+
+// err returns the first error or, if all is set, all errors.
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	} else if !v.all {
+		return v.errs[0]
+	}
+	return v.errs
+}
+
+// indexPath returns the path of a slice element, e.g. "imp[2]".
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+func joinPath(parent, child string) string {
+	if parent == "" {
+		return child
+	} else if child == "" {
+		return parent
+	} else if child[0] == '[' {
+		return parent + child
+	}
+	return parent + "." + child
+}
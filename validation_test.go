@@ -0,0 +1,133 @@
+package openrtb
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidationError", func() {
+
+	validationErr := func(err error) *ValidationError {
+		var verr *ValidationError
+		Expect(errors.As(err, &verr)).To(BeTrue(), "expected a *ValidationError, got %#v", err)
+		return verr
+	}
+
+	It("should annotate request errors", func() {
+		err := (&BidRequest{}).Validate()
+		Expect(validationErr(err)).To(Equal(&ValidationError{Path: "id", Code: "request_missing_id", Err: ErrInvalidReqNoID}))
+		Expect(err).To(MatchError("openrtb: request ID missing (id)"))
+
+		err = (&BidRequest{ID: "A", Imp: []Impression{{ID: "1"}}, Site: &Site{}, App: &App{}}).Validate()
+		Expect(validationErr(err)).To(Equal(&ValidationError{Code: "request_multiple_inventory", Err: ErrInvalidReqMultiInv}))
+		Expect(err).To(MatchError("openrtb: request has multiple inventory sources"))
+
+		err = (&BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}, Cur: []string{"USD", "XYZ"}}).Validate()
+		Expect(validationErr(err).Path).To(Equal("cur[1]"))
+	})
+
+	It("should annotate impression errors", func() {
+		req := &BidRequest{ID: "A", Imp: []Impression{
+			{ID: "1", Banner: &Banner{}},
+			{ID: "2", Video: &Video{Mimes: []string{"video/mp4"}}},
+		}}
+		err := req.Validate()
+		Expect(err).To(MatchError(ErrInvalidVideoNoLinearity))
+		Expect(validationErr(err)).To(Equal(&ValidationError{Path: "imp[1].video.linearity", Code: "video_missing_linearity", Err: ErrInvalidVideoNoLinearity}))
+
+		req.Imp[1] = Impression{ID: "2", Banner: &Banner{}, Pmp: &Pmp{Deals: []Deal{{ID: "D1"}, {ID: "D2", BidFloorCurrency: "EURO"}}}}
+		Expect(validationErr(req.Validate()).Path).To(Equal("imp[1].pmp.deals[1].bidfloorcur"))
+
+		req.Imp[1] = Impression{ID: "2"}
+		Expect(validationErr(req.Validate())).To(Equal(&ValidationError{Path: "imp[1]", Code: "imp_missing_assets", Err: ErrInvalidImpNoAssets}))
+	})
+
+	It("should annotate response errors", func() {
+		res := &BidResponse{ID: "A", SeatBid: []SeatBid{
+			{Bid: []Bid{{ID: "1", ImpID: "1"}}},
+			{Bid: []Bid{{ID: "2", ImpID: "1"}, {ID: "3", ImpID: "1"}, {ID: "4"}}},
+		}}
+		err := res.Validate()
+		Expect(err).To(MatchError(ErrInvalidBidNoImpID))
+		Expect(err).To(MatchError("openrtb: bid is missing impression ID (seatbid[1].bid[2].impid)"))
+		Expect(validationErr(err).Code).To(Equal("bid_missing_impid"))
+
+		res.SeatBid[1] = SeatBid{}
+		Expect(validationErr(res.Validate()).Path).To(Equal("seatbid[1].bid"))
+
+		res.SeatBid[1] = SeatBid{Bid: []Bid{{ID: "2", ImpID: "X"}}}
+		req := &BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}}
+		Expect(validationErr(res.ValidateForRequest(req))).To(Equal(&ValidationError{Path: "seatbid[1].bid[0].impid", Code: "bid_unknown_impid", Err: ErrInvalidBidImpID}))
+	})
+
+	It("should provide codes", func() {
+		Expect(ValidationCode((&BidRequest{}).Validate())).To(Equal("request_missing_id"))
+		Expect(ValidationCode(ErrInvalidSeatBidBid)).To(Equal("seatbid_missing_bid"))
+		Expect(ValidationCode(errors.New("other"))).To(Equal(ValidationCodeUnknown))
+	})
+
+	It("should collect all errors", func() {
+		req := &BidRequest{Imp: []Impression{
+			{ID: "1"},
+			{Banner: &Banner{}, BidFloorCurrency: "US"},
+		}, Cur: []string{"XYZ"}}
+		Expect(req.Validate()).To(MatchError(ErrInvalidReqNoID))
+
+		err := req.ValidateAll()
+		Expect(err).To(MatchError(ErrInvalidReqNoID))
+		Expect(err).To(MatchError(ErrInvalidImpFloorCur))
+
+		var errs ValidationErrors
+		Expect(errors.As(err, &errs)).To(BeTrue())
+		Expect(errs).To(HaveLen(5))
+		Expect(errs[0].Path).To(Equal("id"))
+		Expect(errs[1].Path).To(Equal("cur[0]"))
+		Expect(errs[2]).To(Equal(&ValidationError{Path: "imp[0]", Code: "imp_missing_assets", Err: ErrInvalidImpNoAssets}))
+		Expect(errs[3].Path).To(Equal("imp[1].id"))
+		Expect(errs[4].Path).To(Equal("imp[1].bidfloorcur"))
+		Expect(err.Error()).To(HavePrefix("openrtb: request ID missing (id); "))
+
+		valid := &BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}}
+		Expect(valid.ValidateAll()).To(Succeed())
+	})
+
+	It("should collect all response errors", func() {
+		res := &BidResponse{ID: "A", SeatBid: []SeatBid{
+			{},
+			{Bid: []Bid{{ImpID: "1"}, {ID: "3"}}},
+		}}
+		var errs ValidationErrors
+		Expect(errors.As(res.ValidateAll(), &errs)).To(BeTrue())
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0].Path).To(Equal("seatbid[0].bid"))
+		Expect(errs[1].Path).To(Equal("seatbid[1].bid[0].id"))
+		Expect(errs[2].Path).To(Equal("seatbid[1].bid[1].impid"))
+
+		res.SeatBid = []SeatBid{{Bid: []Bid{{ID: "1", ImpID: "X"}, {ID: "2", ImpID: "Y"}}}}
+		req := &BidRequest{ID: "A", Imp: []Impression{{ID: "1", Banner: &Banner{}}}}
+		Expect(res.ValidateForRequest(req)).To(MatchError(ErrInvalidBidImpID))
+		Expect(errors.As(res.ValidateAllForRequest(req), &errs)).To(BeTrue())
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[1].Path).To(Equal("seatbid[0].bid[1].impid"))
+	})
+
+	It("should cross-check responses with structural errors", func() {
+		res := &BidResponse{SeatBid: []SeatBid{
+			{Seat: "blocked", Bid: []Bid{{ID: "1", ImpID: "1", Price: 1}}},
+			{Bid: []Bid{{ImpID: "2"}, {ID: "3", ImpID: "X", Price: 1}, {ID: "4"}}},
+		}}
+		req := &BidRequest{ID: "A", BSeat: []string{"blocked"}, Imp: []Impression{{ID: "1", Banner: &Banner{}}, {ID: "2", Banner: &Banner{}}}}
+
+		var errs ValidationErrors
+		Expect(errors.As(res.ValidateAllForRequest(req), &errs)).To(BeTrue())
+		paths := make([]string, 0, len(errs))
+		for _, e := range errs {
+			paths = append(paths, e.Path)
+		}
+		Expect(paths).To(Equal([]string{"id", "seatbid[1].bid[0].id", "seatbid[1].bid[2].impid", "seatbid[0].seat", "seatbid[1].bid[1].impid"}))
+		Expect(res.ValidateForRequest(req)).To(MatchError(ErrInvalidRespNoID))
+	})
+
+})
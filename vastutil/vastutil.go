@@ -0,0 +1,368 @@
+/*
+Package vastutil inspects VAST markup of video and audio bids, to allow
+basic sanity checks without a full VAST implementation.
+
+	info, err := vastutil.Parse(bid.AdMarkup)
+	if err != nil {
+		return err
+	}
+	if err := info.ValidateForImp(imp); err != nil {
+		return err
+	}
+*/
+package vastutil
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bsm/openrtb"
+)
+
+// Errors
+var (
+	ErrNotVAST           = errors.New("vastutil: markup is not VAST")
+	ErrNoAds             = errors.New("vastutil: no ads")
+	ErrInvalidDuration   = errors.New("vastutil: invalid duration")
+	ErrDurationTooShort  = errors.New("vastutil: duration below minimum")
+	ErrDurationTooLong   = errors.New("vastutil: duration exceeds maximum")
+	ErrMimeNotAllowed    = errors.New("vastutil: no media file with an allowed mime type")
+	ErrWrapperDepth      = errors.New("vastutil: maximum wrapper depth exceeded")
+	ErrWrapperNoAdTagURI = errors.New("vastutil: wrapper is missing an ad tag URI")
+	ErrCompanionNoSlot   = errors.New("vastutil: companion does not match any companion slot")
+	ErrCompanionType     = errors.New("vastutil: companion type is not allowed")
+)
+
+// Info summarises a VAST document.
+type Info struct {
+	Version    string        // VAST version, e.g. "4.0"
+	Ads        int           // Number of ads
+	Wrapper    bool          // True if the first ad is a wrapper
+	AdTagURI   string        // Ad tag URI of the wrapper
+	Duration   time.Duration // Duration of the first linear creative, if any
+	MediaFiles []MediaFile   // Media files of the first ad
+	Companions []Companion   // Companion ads of the first ad
+}
+
+// MediaFile describes a media file of a linear creative.
+type MediaFile struct {
+	URL      string
+	Type     string // Mime type, e.g. "video/mp4"
+	Delivery string // "progressive" or "streaming"
+	Width    int
+	Height   int
+	Bitrate  int // In Kbps
+}
+
+// Companion describes a companion ad.
+type Companion struct {
+	ID       string
+	AdSlotID string // ID of the companion slot, i.e. the banner id
+	Width    int
+	Height   int
+	Type     int // Resource type, see openrtb.VASTCompanion* constants, 0 if unknown
+}
+
+// Mimes returns the distinct mime types of the media files.
+func (i *Info) Mimes() []string {
+	var mimes []string
+	seen := make(map[string]bool, len(i.MediaFiles))
+	for _, mf := range i.MediaFiles {
+		if mf.Type != "" && !seen[mf.Type] {
+			seen[mf.Type] = true
+			mimes = append(mimes, mf.Type)
+		}
+	}
+	return mimes
+}
+
+// ValidateForImp checks the duration and media files against the video or
+// audio object of the impression. Wrappers are not checked, as their
+// creatives are only known once resolved.
+func (i *Info) ValidateForImp(imp *openrtb.Impression) error {
+	if i.Wrapper {
+		return nil
+	}
+
+	var mimes []string
+	var minDur, maxDur int
+	if imp.Video != nil {
+		mimes, minDur, maxDur = imp.Video.Mimes, imp.Video.MinDuration, imp.Video.MaxDuration
+	} else if imp.Audio != nil {
+		mimes, minDur, maxDur = imp.Audio.Mimes, imp.Audio.MinDuration, imp.Audio.MaxDuration
+	} else {
+		return nil
+	}
+
+	if i.Duration != 0 {
+		if minDur > 0 && i.Duration < time.Duration(minDur)*time.Second {
+			return ErrDurationTooShort
+		} else if maxDur > 0 && i.Duration > time.Duration(maxDur)*time.Second {
+			return ErrDurationTooLong
+		}
+	}
+
+	if len(mimes) != 0 && len(i.MediaFiles) != 0 {
+		allowed := false
+		for _, mf := range i.MediaFiles {
+			if openrtb.MimeAllowed(mimes, mf.Type) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ErrMimeNotAllowed
+		}
+	}
+	return i.validateCompanions(imp)
+}
+
+// CompanionSlots returns the companion slots of the impression the
+// companions are served in, by index. Unmatched companions are nil, see
+// openrtb.MatchCompanion.
+func (i *Info) CompanionSlots(imp *openrtb.Impression) []*openrtb.Banner {
+	var slots []openrtb.Banner
+	if imp.Video != nil {
+		slots = imp.Video.CompanionAd
+	} else if imp.Audio != nil {
+		slots = imp.Audio.CompanionAd
+	}
+
+	res := make([]*openrtb.Banner, len(i.Companions))
+	for n, c := range i.Companions {
+		res[n] = openrtb.MatchCompanion(slots, c.AdSlotID, c.Width, c.Height)
+	}
+	return res
+}
+
+// validateCompanions checks that all companions match a companion slot and
+// use an allowed type. Impressions without companion slots are not checked.
+func (i *Info) validateCompanions(imp *openrtb.Impression) error {
+	var types []int
+	if imp.Video != nil {
+		if len(imp.Video.CompanionAd) == 0 {
+			return nil
+		}
+		types = imp.Video.CompanionType
+	} else if imp.Audio != nil {
+		if len(imp.Audio.CompanionAd) == 0 {
+			return nil
+		}
+		types = imp.Audio.CompanionType
+	}
+
+	for n, slot := range i.CompanionSlots(imp) {
+		if slot == nil {
+			return ErrCompanionNoSlot
+		}
+		if c := i.Companions[n]; c.Type != 0 && !openrtb.AcceptsCompanionType(types, c.Type) {
+			return ErrCompanionType
+		}
+	}
+	return nil
+}
+
+// IsVAST returns true if adm is a VAST document. URL-encoded markup is
+// accepted.
+func IsVAST(adm string) bool {
+	dec := xml.NewDecoder(strings.NewReader(unescape(adm)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			return t.Name.Local == "VAST"
+		case xml.CharData:
+			if len(strings.TrimSpace(string(t))) != 0 {
+				return false
+			}
+		}
+	}
+}
+
+// Parse parses a VAST document. URL-encoded markup is accepted.
+func Parse(adm string) (*Info, error) {
+	if !IsVAST(adm) {
+		return nil, ErrNotVAST
+	}
+
+	var doc vastDoc
+	if err := xml.Unmarshal([]byte(unescape(adm)), &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Ads) == 0 {
+		return &Info{Version: doc.Version}, nil
+	}
+
+	info := &Info{Version: doc.Version, Ads: len(doc.Ads)}
+	ad := doc.Ads[0]
+
+	var creatives []vastCreative
+	if ad.Wrapper != nil {
+		info.Wrapper = true
+		info.AdTagURI = strings.TrimSpace(ad.Wrapper.AdTagURI)
+		creatives = ad.Wrapper.Creatives
+	} else if ad.InLine != nil {
+		creatives = ad.InLine.Creatives
+	}
+
+	for _, c := range creatives {
+		for _, comp := range c.Companions {
+			info.Companions = append(info.Companions, comp.companion())
+		}
+		if c.Linear == nil {
+			continue
+		}
+		if info.Duration == 0 && c.Linear.Duration != "" {
+			dur, err := ParseDuration(c.Linear.Duration)
+			if err != nil {
+				return nil, err
+			}
+			info.Duration = dur
+		}
+		for _, mf := range c.Linear.MediaFiles {
+			info.MediaFiles = append(info.MediaFiles, MediaFile{
+				URL:      strings.TrimSpace(mf.URL),
+				Type:     mf.Type,
+				Delivery: mf.Delivery,
+				Width:    mf.Width,
+				Height:   mf.Height,
+				Bitrate:  mf.Bitrate,
+			})
+		}
+	}
+	return info, nil
+}
+
+// Fetcher retrieves the VAST markup of a wrapper ad tag URI.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri string) (string, error)
+}
+
+// FetcherFunc is a function implementing Fetcher.
+type FetcherFunc func(ctx context.Context, uri string) (string, error)
+
+// Fetch implements Fetcher.
+func (f FetcherFunc) Fetch(ctx context.Context, uri string) (string, error) { return f(ctx, uri) }
+
+// WrapperDepth follows the wrapper chain of adm and returns the number of
+// wrappers until an inline ad is reached. It returns ErrWrapperDepth if the
+// chain is longer than max wrappers.
+func WrapperDepth(ctx context.Context, adm string, max int, f Fetcher) (int, error) {
+	for depth := 0; ; depth++ {
+		info, err := Parse(adm)
+		if err != nil {
+			return depth, err
+		}
+		if info.Ads == 0 {
+			return depth, ErrNoAds
+		}
+		if !info.Wrapper {
+			return depth, nil
+		}
+		if depth >= max {
+			return depth + 1, ErrWrapperDepth
+		}
+		if info.AdTagURI == "" {
+			return depth + 1, ErrWrapperNoAdTagURI
+		}
+
+		if adm, err = f.Fetch(ctx, info.AdTagURI); err != nil {
+			return depth + 1, err
+		}
+	}
+}
+
+// ParseDuration parses a VAST duration of the form "HH:MM:SS" or
+// "HH:MM:SS.mmm".
+func ParseDuration(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 3 {
+		return 0, ErrInvalidDuration
+	}
+
+	h, err := strconv.Atoi(parts[0])
+	if err != nil || h < 0 {
+		return 0, ErrInvalidDuration
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil || m < 0 || m > 59 {
+		return 0, ErrInvalidDuration
+	}
+	sec, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil || sec < 0 || sec >= 60 {
+		return 0, ErrInvalidDuration
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second)), nil
+}
+
+func unescape(adm string) string {
+	adm = strings.TrimSpace(adm)
+	if strings.HasPrefix(adm, "%3C") || strings.HasPrefix(adm, "%3c") {
+		if s, err := url.PathUnescape(adm); err == nil {
+			return s
+		}
+	}
+	return adm
+}
+
+type vastDoc struct {
+	XMLName xml.Name `xml:"VAST"`
+	Version string   `xml:"version,attr"`
+	Ads     []struct {
+		InLine *struct {
+			Creatives []vastCreative `xml:"Creatives>Creative"`
+		} `xml:"InLine"`
+		Wrapper *struct {
+			AdTagURI  string         `xml:"VASTAdTagURI"`
+			Creatives []vastCreative `xml:"Creatives>Creative"`
+		} `xml:"Wrapper"`
+	} `xml:"Ad"`
+}
+
+type vastCreative struct {
+	Linear *struct {
+		Duration   string          `xml:"Duration"`
+		MediaFiles []vastMediaFile `xml:"MediaFiles>MediaFile"`
+	} `xml:"Linear"`
+	Companions []vastCompanion `xml:"CompanionAds>Companion"`
+}
+
+type vastCompanion struct {
+	ID             string    `xml:"id,attr"`
+	AdSlotID       string    `xml:"adSlotID,attr"`
+	Width          int       `xml:"width,attr"`
+	Height         int       `xml:"height,attr"`
+	StaticResource *struct{} `xml:"StaticResource"`
+	HTMLResource   *struct{} `xml:"HTMLResource"`
+	IFrameResource *struct{} `xml:"IFrameResource"`
+}
+
+func (c *vastCompanion) companion() Companion {
+	comp := Companion{ID: c.ID, AdSlotID: c.AdSlotID, Width: c.Width, Height: c.Height}
+	switch {
+	case c.StaticResource != nil:
+		comp.Type = openrtb.VASTCompanionStatic
+	case c.HTMLResource != nil:
+		comp.Type = openrtb.VASTCompanionHTML
+	case c.IFrameResource != nil:
+		comp.Type = openrtb.VASTCompanionIFrame
+	}
+	return comp
+}
+
+type vastMediaFile struct {
+	URL      string `xml:",chardata"`
+	Type     string `xml:"type,attr"`
+	Delivery string `xml:"delivery,attr"`
+	Width    int    `xml:"width,attr"`
+	Height   int    `xml:"height,attr"`
+	Bitrate  int    `xml:"bitrate,attr"`
+}
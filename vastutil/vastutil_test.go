@@ -0,0 +1,223 @@
+package vastutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bsm/openrtb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const inline = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- sample -->
+<VAST version="4.0"><Ad id="1"><InLine><AdSystem>test</AdSystem><Creatives>
+<Creative><CompanionAds/></Creative>
+<Creative><Linear><Duration>00:00:15.500</Duration><MediaFiles>
+<MediaFile delivery="progressive" type="video/mp4" width="640" height="360" bitrate="800"><![CDATA[ https://cdn.example.com/a.mp4 ]]></MediaFile>
+<MediaFile delivery="streaming" type="application/x-mpegURL"><![CDATA[https://cdn.example.com/a.m3u8]]></MediaFile>
+<MediaFile delivery="progressive" type="video/mp4" width="1280" height="720"><![CDATA[https://cdn.example.com/b.mp4]]></MediaFile>
+</MediaFiles></Linear></Creative></Creatives></InLine></Ad></VAST>`
+
+func wrapper(uri string) string {
+	return `<VAST version="3.0"><Ad><Wrapper><AdSystem>test</AdSystem><VASTAdTagURI><![CDATA[` + uri + `]]></VASTAdTagURI></Wrapper></Ad></VAST>`
+}
+
+var _ = Describe("IsVAST", func() {
+
+	It("should detect VAST", func() {
+		Expect(IsVAST(inline)).To(BeTrue())
+		Expect(IsVAST(`  <VAST version="2.0"/>`)).To(BeTrue())
+		Expect(IsVAST(`%3CVAST%20version%3D%222.0%22%2F%3E`)).To(BeTrue())
+
+		Expect(IsVAST(``)).To(BeFalse())
+		Expect(IsVAST(`<div>VAST</div>`)).To(BeFalse())
+		Expect(IsVAST(`text <VAST/>`)).To(BeFalse())
+		Expect(IsVAST(`{"native":{}}`)).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("Parse", func() {
+
+	It("should parse inline ads", func() {
+		info, err := Parse(inline)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Version).To(Equal("4.0"))
+		Expect(info.Ads).To(Equal(1))
+		Expect(info.Wrapper).To(BeFalse())
+		Expect(info.Duration).To(Equal(15500 * time.Millisecond))
+		Expect(info.MediaFiles).To(HaveLen(3))
+		Expect(info.MediaFiles[0]).To(Equal(MediaFile{
+			URL: "https://cdn.example.com/a.mp4", Type: "video/mp4", Delivery: "progressive", Width: 640, Height: 360, Bitrate: 800,
+		}))
+		Expect(info.Mimes()).To(Equal([]string{"video/mp4", "application/x-mpegURL"}))
+	})
+
+	It("should parse wrappers", func() {
+		info, err := Parse(wrapper("https://ads.example.com/vast"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Version).To(Equal("3.0"))
+		Expect(info.Wrapper).To(BeTrue())
+		Expect(info.AdTagURI).To(Equal("https://ads.example.com/vast"))
+	})
+
+	It("should parse fixtures", func() {
+		var res openrtb.BidResponse
+		Expect(fixture("bres.vast", &res)).To(Succeed())
+
+		info, err := Parse(res.SeatBid[0].Bid[0].AdMarkup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Version).To(Equal("2.0"))
+		Expect(info.Duration).To(Equal(30 * time.Second))
+		Expect(info.Mimes()).To(Equal([]string{"video/mp4"}))
+	})
+
+	It("should reject bad markup", func() {
+		_, err := Parse(`<html/>`)
+		Expect(err).To(Equal(ErrNotVAST))
+
+		_, err = Parse(`<VAST><Ad><InLine><Creatives><Creative><Linear><Duration>15</Duration></Linear></Creative></Creatives></InLine></Ad></VAST>`)
+		Expect(err).To(Equal(ErrInvalidDuration))
+
+		_, err = Parse(`<VAST><Ad>`)
+		Expect(err).To(HaveOccurred())
+	})
+
+})
+
+var _ = Describe("Info", func() {
+	var subject *Info
+
+	BeforeEach(func() {
+		var err error
+		subject, err = Parse(inline)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should validate for impressions", func() {
+		imp := &openrtb.Impression{ID: "1", Video: &openrtb.Video{Mimes: []string{"video/MP4"}, MinDuration: 5, MaxDuration: 30}}
+		Expect(subject.ValidateForImp(imp)).To(Succeed())
+
+		imp = &openrtb.Impression{ID: "1", Video: &openrtb.Video{Mimes: []string{"video/mp4"}, MinDuration: 20, MaxDuration: 30}}
+		Expect(subject.ValidateForImp(imp)).To(Equal(ErrDurationTooShort))
+
+		imp = &openrtb.Impression{ID: "1", Video: &openrtb.Video{Mimes: []string{"video/mp4"}, MaxDuration: 15}}
+		Expect(subject.ValidateForImp(imp)).To(Equal(ErrDurationTooLong))
+
+		imp = &openrtb.Impression{ID: "1", Video: &openrtb.Video{Mimes: []string{"video/webm"}}}
+		Expect(subject.ValidateForImp(imp)).To(Equal(ErrMimeNotAllowed))
+
+		imp = &openrtb.Impression{ID: "1", Audio: &openrtb.Audio{Mimes: []string{"audio/mp4"}}}
+		Expect(subject.ValidateForImp(imp)).To(Equal(ErrMimeNotAllowed))
+
+		Expect(subject.ValidateForImp(&openrtb.Impression{ID: "1"})).To(Succeed())
+	})
+
+	It("should match companions", func() {
+		var err error
+		subject, err = Parse(`<VAST version="3.0"><Ad><InLine><Creatives><Creative><CompanionAds>
+<Companion id="c1" width="300" height="250"><StaticResource creativeType="image/png"><![CDATA[https://cdn.example.com/c1.png]]></StaticResource></Companion>
+<Companion id="c2" width="728" height="90" adSlotID="top"><HTMLResource><![CDATA[<div/>]]></HTMLResource></Companion>
+</CompanionAds></Creative></Creatives></InLine></Ad></VAST>`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subject.Companions).To(Equal([]Companion{
+			{ID: "c1", Width: 300, Height: 250, Type: openrtb.VASTCompanionStatic},
+			{ID: "c2", AdSlotID: "top", Width: 728, Height: 90, Type: openrtb.VASTCompanionHTML},
+		}))
+
+		imp := &openrtb.Impression{ID: "1", Video: &openrtb.Video{CompanionAd: []openrtb.Banner{
+			{ID: "side", W: 300, H: 250},
+			{ID: "top", W: 728, H: 90},
+		}}}
+		Expect(subject.CompanionSlots(imp)).To(Equal([]*openrtb.Banner{&imp.Video.CompanionAd[0], &imp.Video.CompanionAd[1]}))
+		Expect(subject.ValidateForImp(imp)).To(Succeed())
+
+		imp.Video.CompanionType = []int{openrtb.VASTCompanionStatic}
+		Expect(subject.ValidateForImp(imp)).To(Equal(ErrCompanionType))
+
+		imp.Video.CompanionType = nil
+		imp.Video.CompanionAd[1].ID = "bottom"
+		Expect(subject.CompanionSlots(imp)[1]).To(BeNil())
+		Expect(subject.ValidateForImp(imp)).To(Equal(ErrCompanionNoSlot))
+
+		imp.Video.CompanionAd = nil
+		Expect(subject.ValidateForImp(imp)).To(Succeed())
+	})
+
+})
+
+var _ = Describe("WrapperDepth", func() {
+	ctx := context.Background()
+
+	var fetcher = FetcherFunc(func(_ context.Context, uri string) (string, error) {
+		switch uri {
+		case "inline":
+			return inline, nil
+		case "loop":
+			return wrapper("loop"), nil
+		case "empty":
+			return `<VAST version="3.0"/>`, nil
+		}
+		return "", errors.New("not found")
+	})
+
+	It("should follow wrappers", func() {
+		Expect(WrapperDepth(ctx, inline, 3, fetcher)).To(Equal(0))
+		Expect(WrapperDepth(ctx, wrapper("inline"), 3, fetcher)).To(Equal(1))
+	})
+
+	It("should enforce the maximum depth", func() {
+		depth, err := WrapperDepth(ctx, wrapper("loop"), 3, fetcher)
+		Expect(err).To(Equal(ErrWrapperDepth))
+		Expect(depth).To(Equal(4))
+
+		_, err = WrapperDepth(ctx, wrapper("inline"), 0, fetcher)
+		Expect(err).To(Equal(ErrWrapperDepth))
+	})
+
+	It("should report errors", func() {
+		_, err := WrapperDepth(ctx, wrapper(""), 3, fetcher)
+		Expect(err).To(Equal(ErrWrapperNoAdTagURI))
+
+		_, err = WrapperDepth(ctx, wrapper("missing"), 3, fetcher)
+		Expect(err).To(MatchError("not found"))
+
+		_, err = WrapperDepth(ctx, wrapper("empty"), 3, fetcher)
+		Expect(err).To(Equal(ErrNoAds))
+	})
+
+})
+
+var _ = Describe("ParseDuration", func() {
+
+	It("should parse", func() {
+		Expect(ParseDuration("00:00:30")).To(Equal(30 * time.Second))
+		Expect(ParseDuration("01:02:03.250")).To(Equal(time.Hour + 2*time.Minute + 3250*time.Millisecond))
+
+		for _, s := range []string{"", "30", "00:30", "00:60:00", "00:00:60", "aa:00:00", "-1:00:00"} {
+			_, err := ParseDuration(s)
+			Expect(err).To(Equal(ErrInvalidDuration), s)
+		}
+	})
+
+})
+
+func fixture(fname string, v interface{}) error {
+	f, err := os.Open(filepath.Join("..", "testdata", fname+".json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "openrtb/vastutil")
+}
@@ -0,0 +1,272 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// HeaderVersion is the HTTP header which carries the OpenRTB version of a
+// request or response.
+const HeaderVersion = "X-Openrtb-Version"
+
+// ErrInvalidVersion is returned when a version cannot be parsed.
+var ErrInvalidVersion = errors.New("openrtb: invalid version")
+
+// Version is an OpenRTB spec version. It is used throughout the package, for
+// negotiation, downgrading, see EncodeOptions, and schema generation, see
+// JSONSchema.
+type Version struct {
+	Major, Minor int
+}
+
+// Supported versions
+var (
+	Version24 = Version{Major: 2, Minor: 4}
+	Version25 = Version{Major: 2, Minor: 5}
+	Version26 = Version{Major: 2, Minor: 6}
+)
+
+// SupportedVersions lists the supported spec versions, in ascending order.
+// Fields introduced after the oldest one are registered in fieldVersions.
+var SupportedVersions = []Version{Version24, Version25, Version26}
+
+// LatestVersion is the latest supported spec version.
+var LatestVersion = Version26
+
+// ParseVersion parses a version, e.g. "2.6". Patch levels, e.g. "2.5.1",
+// are ignored.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ".", 3)
+	if len(parts) < 2 {
+		return Version{}, ErrInvalidVersion
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 0 {
+		return Version{}, ErrInvalidVersion
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return Version{}, ErrInvalidVersion
+	}
+	return Version{Major: major, Minor: minor}, nil
+}
+
+// String returns the version as used in the x-openrtb-version header.
+func (v Version) String() string {
+	return strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor)
+}
+
+// IsZero returns true for the zero version.
+func (v Version) IsZero() bool { return v == Version{} }
+
+// Compare returns -1 if v is older than o, 1 if v is newer than o and 0 if
+// both are equal.
+func (v Version) Compare(o Version) int {
+	switch {
+	case v.Major != o.Major:
+		if v.Major < o.Major {
+			return -1
+		}
+		return 1
+	case v.Minor != o.Minor:
+		if v.Minor < o.Minor {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// Supports returns true if the field, given as "<Type>.<Field>", e.g.
+// "BidRequest.DOOH", is part of the spec version.
+func (v Version) Supports(field string) bool {
+	fv, ok := fieldVersions[field]
+	return !ok || v.Compare(fv.since) >= 0
+}
+
+// NegotiateVersion parses the x-openrtb-version header value of a partner
+// and returns the newest supported version which the partner understands.
+// It returns false if the header is invalid or older than all supported
+// versions.
+func NegotiateVersion(header string) (Version, bool) {
+	requested, err := ParseVersion(header)
+	if err != nil {
+		return Version{}, false
+	}
+
+	for i := len(SupportedVersions) - 1; i >= 0; i-- {
+		if v := SupportedVersions[i]; v.Compare(requested) <= 0 {
+			return v, true
+		}
+	}
+	return Version{}, false
+}
+
+type fieldVersion struct {
+	since Version
+	ext   bool                                                               // previously passed in ext, moved back when downgrading
+	remap func(obj map[string]interface{}, val interface{}, version Version) // maps the value onto older fields when downgrading
+}
+
+// fieldVersions contains the fields introduced after the oldest supported
+// version, by "<Type>.<Field>".
+var fieldVersions = map[string]fieldVersion{
+	"BidRequest.BSeat":      {since: Version25},
+	"BidRequest.Source":     {since: Version25},
+	"Impression.Metric":     {since: Version25},
+	"Impression.Exp":        {since: Version25},
+	"Format.WRatio":         {since: Version25},
+	"Format.HRatio":         {since: Version25},
+	"Format.WMin":           {since: Version25},
+	"Video.Placement":       {since: Version25},
+	"Device.GeoFetch":       {since: Version25},
+	"Bid.Exp":               {since: Version25},
+	"BidRequest.DOOH":       {since: Version26},
+	"BidRequest.WLangB":     {since: Version26},
+	"BidRequest.CatTax":     {since: Version26},
+	"Impression.Rwdd":       {since: Version26},
+	"Impression.Qty":        {since: Version26},
+	"Impression.SSAI":       {since: Version26},
+	"Impression.Dt":         {since: Version26},
+	"Impression.Refresh":    {since: Version26},
+	"Inventory.CatTax":      {since: Version26},
+	"Content.CatTax":        {since: Version26},
+	"Content.LangB":         {since: Version26},
+	"Content.KwArray":       {since: Version26},
+	"Content.Network":       {since: Version26},
+	"Content.Channel":       {since: Version26},
+	"Device.SUA":            {since: Version26},
+	"User.Consent":          {since: Version26, ext: true},
+	"Regulations.GDPR":      {since: Version26, ext: true},
+	"Regulations.USPrivacy": {since: Version26, ext: true},
+	"Regulations.GPP":       {since: Version26},
+	"Regulations.GPPSID":    {since: Version26},
+	"Source.SChain":         {since: Version26, ext: true},
+	"Video.RqdDurs":         {since: Version26},
+	"Video.Plcmt":           {since: Version26, remap: remapPlcmt},
+	"Video.MaxSequence":     {since: Version26},
+	"Video.PodDuration":     {since: Version26},
+	"Video.PodID":           {since: Version26},
+	"Video.PodSequence":     {since: Version26},
+	"Video.SlotInPod":       {since: Version26},
+	"Video.MinCPMPerSec":    {since: Version26},
+	"Audio.RqdDurs":         {since: Version26},
+	"Audio.PodDuration":     {since: Version26},
+	"Audio.PodID":           {since: Version26},
+	"Audio.PodSequence":     {since: Version26},
+	"Audio.SlotInPod":       {since: Version26},
+	"Audio.MinCPMPerSec":    {since: Version26},
+	"Bid.CatTax":            {since: Version26},
+	"Bid.APIs":              {since: Version26},
+	"Bid.Language":          {since: Version26},
+	"Bid.LangB":             {since: Version26},
+	"Bid.MType":             {since: Version26},
+}
+
+// downgrade removes fields from the JSON encoding data of a value of type t,
+// which were introduced after version. Fields which were previously passed
+// in ext are moved there, unless ext already contains them.
+func downgrade(data []byte, t reflect.Type, version Version) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	downgradeValue(v, t, version)
+	return json.Marshal(v)
+}
+
+func downgradeValue(v interface{}, t reflect.Type, version Version) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if vs, ok := v.([]interface{}); ok {
+			for _, elem := range vs {
+				downgradeValue(elem, t.Elem(), version)
+			}
+		}
+	case reflect.Struct:
+		if obj, ok := v.(map[string]interface{}); ok {
+			downgradeObject(obj, t, version)
+		}
+	}
+}
+
+func downgradeObject(obj map[string]interface{}, t reflect.Type, version Version) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := deprecationFieldPath(field, "")
+		if !ok {
+			continue
+		}
+		if name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				downgradeObject(obj, ft, version)
+			}
+			continue
+		}
+
+		val, ok := obj[name]
+		if !ok {
+			continue
+		}
+		downgradeValue(val, field.Type, version)
+
+		if fv, ok := fieldVersions[t.Name()+"."+field.Name]; ok && version.Compare(fv.since) < 0 {
+			delete(obj, name)
+			if fv.ext {
+				moveToExt(obj, name, val)
+			}
+			if fv.remap != nil {
+				fv.remap(obj, val, version)
+			}
+		}
+	}
+}
+
+func moveToExt(obj map[string]interface{}, name string, val interface{}) {
+	ext, ok := obj["ext"].(map[string]interface{})
+	if !ok {
+		if _, exists := obj["ext"]; exists {
+			return
+		}
+		ext = make(map[string]interface{})
+		obj["ext"] = ext
+	}
+	if _, exists := ext[name]; !exists {
+		ext[name] = val
+	}
+}
+
+// remapPlcmt derives video.placement from video.plcmt, unless placement is
+// already present or version predates it.
+func remapPlcmt(obj map[string]interface{}, val interface{}, version Version) {
+	if _, exists := obj["placement"]; exists || version.Compare(Version25) < 0 {
+		return
+	}
+	num, ok := val.(json.Number)
+	if !ok {
+		return
+	}
+	plcmt, err := num.Int64()
+	if err != nil {
+		return
+	}
+	if placement := PlacementFromPlcmt(int(plcmt)); placement != 0 {
+		obj["placement"] = placement
+	}
+}
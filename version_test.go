@@ -0,0 +1,166 @@
+package openrtb
+
+import (
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Version", func() {
+
+	It("should parse", func() {
+		Expect(ParseVersion("2.6")).To(Equal(Version26))
+		Expect(ParseVersion(" 2.5.1 ")).To(Equal(Version25))
+		Expect(ParseVersion("3.0")).To(Equal(Version{Major: 3}))
+
+		for _, s := range []string{"", "2", "2.x", "-1.2", "v2.5"} {
+			_, err := ParseVersion(s)
+			Expect(err).To(MatchError(ErrInvalidVersion), "for %q", s)
+		}
+	})
+
+	It("should format", func() {
+		Expect(Version25.String()).To(Equal("2.5"))
+		Expect(Version{Major: 3, Minor: 10}.String()).To(Equal("3.10"))
+	})
+
+	It("should compare", func() {
+		Expect(Version25.Compare(Version26)).To(Equal(-1))
+		Expect(Version26.Compare(Version25)).To(Equal(1))
+		Expect(Version26.Compare(Version26)).To(Equal(0))
+		Expect(Version{Major: 3}.Compare(Version26)).To(Equal(1))
+		Expect(Version{}.IsZero()).To(BeTrue())
+		Expect(Version25.IsZero()).To(BeFalse())
+	})
+
+	It("should check supported fields", func() {
+		Expect(Version26.Supports("BidRequest.DOOH")).To(BeTrue())
+		Expect(Version25.Supports("BidRequest.DOOH")).To(BeFalse())
+		Expect(Version25.Supports("BidRequest.ID")).To(BeTrue())
+	})
+
+})
+
+var _ = Describe("NegotiateVersion", func() {
+
+	It("should register existing fields", func() {
+		types := make(map[string]reflect.Type)
+		var walk func(reflect.Type)
+		walk = func(t reflect.Type) {
+			for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Struct || types[t.Name()] != nil {
+				return
+			}
+			types[t.Name()] = t
+			for i := 0; i < t.NumField(); i++ {
+				walk(t.Field(i).Type)
+			}
+		}
+		walk(reflect.TypeOf(BidRequest{}))
+		walk(reflect.TypeOf(BidResponse{}))
+
+		for name, fv := range fieldVersions {
+			typeName, fieldName, _ := strings.Cut(name, ".")
+			Expect(types).To(HaveKey(typeName), name)
+			_, ok := types[typeName].FieldByName(fieldName)
+			Expect(ok).To(BeTrue(), name)
+			Expect(fv.since.Compare(SupportedVersions[0])).To(Equal(1), name)
+		}
+	})
+
+	It("should generate schemas for all supported versions", func() {
+		for _, v := range SupportedVersions {
+			_, err := JSONSchema(&BidRequest{}, v)
+			Expect(err).NotTo(HaveOccurred(), v.String())
+		}
+	})
+
+	It("should pick the newest common version", func() {
+		for header, exp := range map[string]Version{"2.6": Version26, "2.5": Version25, "2.6.1": Version26, "2.4": Version24, "3.0": Version26} {
+			v, ok := NegotiateVersion(header)
+			Expect(ok).To(BeTrue(), "for %q", header)
+			Expect(v).To(Equal(exp), "for %q", header)
+		}
+
+		_, ok := NegotiateVersion("2.3")
+		Expect(ok).To(BeFalse())
+		_, ok = NegotiateVersion("bad")
+		Expect(ok).To(BeFalse())
+	})
+
+})
+
+var _ = Describe("EncodeOptions", func() {
+
+	It("should downgrade requests", func() {
+		req := &BidRequest{
+			ID:     "R",
+			Imp:    []Impression{{ID: "I", Rwdd: 1, Video: &Video{Mimes: []string{MimeMP4}, PodID: "1"}}},
+			Site:   &Site{Inventory: Inventory{ID: "S", CatTax: CatTaxIABContent30}},
+			User:   &User{Consent: "CONSENT", Ext: Extension(`{"eids":[]}`)},
+			Regs:   &Regulations{GDPR: 1, USPrivacy: "1YNN", Ext: Extension(`{"gdpr":0}`)},
+			CatTax: CatTaxIABContent30,
+		}
+
+		data, err := MarshalBidRequest(req, &EncodeOptions{Version: Version25})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"id": "R",
+			"imp": [{"id": "I", "video": {"mimes": ["video/mp4"], "linearity": 1, "sequence": 1}}],
+			"site": {"id": "S"},
+			"user": {"ext": {"eids": [], "consent": "CONSENT"}},
+			"at": 0,
+			"regs": {"ext": {"gdpr": 0, "us_privacy": "1YNN"}}
+		}`))
+
+		data, err = MarshalBidRequest(req, &EncodeOptions{Version: Version26, Canonical: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"cattax":7`))
+		Expect(string(data)).To(ContainSubstring(`"rwdd":1`))
+	})
+
+	It("should remap fields when downgrading", func() {
+		req := &BidRequest{
+			ID:     "R",
+			Imp:    []Impression{{ID: "I", Exp: 30, Video: &Video{Mimes: []string{MimeMP4}, Plcmt: VideoPlcmtInstream}}},
+			Source: &Source{TID: "T"},
+			BSeat:  []string{"s1"},
+		}
+
+		data, err := MarshalBidRequest(req, &EncodeOptions{Version: Version25})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"id": "R",
+			"imp": [{"id": "I", "exp": 30, "video": {"mimes": ["video/mp4"], "linearity": 1, "sequence": 1, "placement": 1}}],
+			"at": 0,
+			"bseat": ["s1"],
+			"source": {"tid": "T"}
+		}`))
+
+		data, err = MarshalBidRequest(req, &EncodeOptions{Version: Version24})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"id": "R",
+			"imp": [{"id": "I", "video": {"mimes": ["video/mp4"], "linearity": 1, "sequence": 1}}],
+			"at": 0
+		}`))
+
+		req.Imp[0].Video.Placement = VideoPlacementInBanner
+		data, err = MarshalBidRequest(req, &EncodeOptions{Version: Version25})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"placement":2`))
+	})
+
+	It("should downgrade responses", func() {
+		res := &BidResponse{ID: "R", SeatBid: []SeatBid{{Bid: []Bid{{ID: "B", ImpID: "I", Price: 1.5, MType: MarkupTypeBanner, APIs: []int{5}}}}}}
+
+		data, err := MarshalBidResponse(res, &EncodeOptions{Version: Version25})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"id":"R","seatbid":[{"bid":[{"id":"B","impid":"I","price":1.5}]}]}`))
+	})
+
+})
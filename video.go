@@ -12,52 +12,89 @@ var (
 	ErrInvalidVideoNoMinDuration = errors.New("openrtb: video min-duration missing")
 	ErrInvalidVideoNoMaxDuration = errors.New("openrtb: video max-duration missing")
 	ErrInvalidVideoNoProtocols   = errors.New("openrtb: video protocols missing")
+	ErrInvalidVideoDuration      = errors.New("openrtb: video min-duration exceeds max-duration")
+	ErrInvalidVideoRqdDurs       = errors.New("openrtb: video rqddurs cannot be combined with min/max-duration")
+	ErrInvalidVideoSkip          = errors.New("openrtb: video skip must be 0 or 1")
+	ErrInvalidVideoSkipParams    = errors.New("openrtb: video skipmin/skipafter require skip")
+	ErrInvalidVideoSkipAfter     = errors.New("openrtb: video skipafter exceeds max-duration")
+	ErrInvalidVideoStartDelay    = errors.New("openrtb: video startdelay is invalid")
 )
 
 // The "video" object must be included directly in the impression object if the impression offered
 // for auction is an in-stream video ad opportunity.
 type Video struct {
-	Mimes          []string  `json:"mimes,omitempty"`          // Content MIME types supported.
-	MinDuration    int       `json:"minduration,omitempty"`    // Minimum video ad duration in seconds
-	MaxDuration    int       `json:"maxduration,omitempty"`    // Maximum video ad duration in seconds
-	Protocols      []int     `json:"protocols,omitempty"`      // Video bid response protocols
-	Protocol       int       `json:"protocol,omitempty"`       // Video bid response protocols DEPRECATED
-	W              int       `json:"w,omitempty"`              // Width of the player in pixels
-	H              int       `json:"h,omitempty"`              // Height of the player in pixels
-	StartDelay     int       `json:"startdelay,omitempty"`     // Indicates the start delay in seconds
-	Linearity      int       `json:"linearity,omitempty"`      // Indicates whether the ad impression is linear or non-linear
-	Skip           int       `json:"skip,omitempty"`           // Indicates if the player will allow the video to be skipped, where 0 = no, 1 = yes.
-	SkipMin        int       `json:"skipmin,omitempty"`        // Videos of total duration greater than this number of seconds can be skippable
-	SkipAfter      int       `json:"skipafter,omitempty"`      // Number of seconds a video must play before skipping is enabled
-	Sequence       int       `json:"sequence,omitempty"`       // Default: 1
-	BAttr          []int     `json:"battr,omitempty"`          // Blocked creative attributes
-	MaxExtended    int       `json:"maxextended,omitempty"`    // Maximum extended video ad duration
-	MinBitrate     int       `json:"minbitrate,omitempty"`     // Minimum bit rate in Kbps
-	MaxBitrate     int       `json:"maxbitrate,omitempty"`     // Maximum bit rate in Kbps
-	BoxingAllowed  *int      `json:"boxingallowed,omitempty"`  // If exchange publisher has rules preventing letter boxing
-	PlaybackMethod []int     `json:"playbackmethod,omitempty"` // List of allowed playback methods
-	Delivery       []int     `json:"delivery,omitempty"`       // List of supported delivery methods
-	Pos            int       `json:"pos,omitempty"`            // Ad Position
+	Mimes          []string  `json:"mimes,omitempty"`                               // Content MIME types supported.
+	MinDuration    int       `json:"minduration,omitempty"`                         // Minimum video ad duration in seconds
+	MaxDuration    int       `json:"maxduration,omitempty"`                         // Maximum video ad duration in seconds
+	RqdDurs        []int     `json:"rqddurs,omitempty"`                             // Precise acceptable durations for video creatives in seconds, mutually exclusive with min/max-duration
+	Protocols      []int     `json:"protocols,omitempty"`                           // Video bid response protocols
+	Protocol       int       `json:"protocol,omitempty" deprecated:"2.3,protocols"` // Video bid response protocols DEPRECATED
+	W              int       `json:"w,omitempty"`                                   // Width of the player in pixels
+	H              int       `json:"h,omitempty"`                                   // Height of the player in pixels
+	StartDelay     int       `json:"startdelay,omitempty"`                          // Indicates the start delay in seconds
+	Placement      int       `json:"placement,omitempty" deprecated:"2.6,plcmt"`    // Video placement type for the impression
+	Plcmt          int       `json:"plcmt,omitempty"`                               // Video placement subtype, as per the updated IAB definitions
+	Linearity      int       `json:"linearity,omitempty"`                           // Indicates whether the ad impression is linear or non-linear
+	Skip           int       `json:"skip,omitempty"`                                // Indicates if the player will allow the video to be skipped, where 0 = no, 1 = yes.
+	SkipMin        int       `json:"skipmin,omitempty"`                             // Videos of total duration greater than this number of seconds can be skippable
+	SkipAfter      int       `json:"skipafter,omitempty"`                           // Number of seconds a video must play before skipping is enabled
+	Sequence       int       `json:"sequence,omitempty"`                            // Default: 1
+	BAttr          []int     `json:"battr,omitempty"`                               // Blocked creative attributes
+	MaxExtended    int       `json:"maxextended,omitempty"`                         // Maximum extended video ad duration
+	MinBitrate     int       `json:"minbitrate,omitempty"`                          // Minimum bit rate in Kbps
+	MaxBitrate     int       `json:"maxbitrate,omitempty"`                          // Maximum bit rate in Kbps
+	BoxingAllowed  *int      `json:"boxingallowed,omitempty"`                       // If exchange publisher has rules preventing letter boxing
+	PlaybackMethod []int     `json:"playbackmethod,omitempty"`                      // List of allowed playback methods
+	Delivery       []int     `json:"delivery,omitempty"`                            // List of supported delivery methods
+	Pos            int       `json:"pos,omitempty"`                                 // Ad Position
 	CompanionAd    []Banner  `json:"companionad,omitempty"`
 	Api            []int     `json:"api,omitempty"` // List of supported API frameworks
 	CompanionType  []int     `json:"companiontype,omitempty"`
+	MaxSequence    int       `json:"maxseq,omitempty"`       // The maximum number of ads that can be played in an ad pod.
+	PodDuration    int       `json:"poddur,omitempty"`       // Total amount of time in seconds that advertisers may fill for a dynamic video ad pod.
+	PodID          string    `json:"podid,omitempty"`        // Unique identifier indicating that an impression opportunity belongs to a video ad pod.
+	PodSequence    int       `json:"podseq,omitempty"`       // The sequence (position) of the video ad pod within a content stream.
+	SlotInPod      int       `json:"slotinpod,omitempty"`    // Guidance on the position of the individual ad impression opportunity within the pod.
+	MinCPMPerSec   float64   `json:"mincpmpersec,omitempty"` // Minimum CPM per second, a price floor for dynamic pods.
 	Ext            Extension `json:"ext,omitempty"`
 }
 
 type jsonVideo Video
 
-// Validates the object
+// Validates the object. Skip parameters are only accepted with skip=1,
+// which earlier releases did not enforce, see README.
 func (v *Video) Validate() error {
 	if len(v.Mimes) == 0 {
 		return ErrInvalidVideoNoMimes
+	} else if !validMimes(v.Mimes) {
+		return ErrInvalidVideoMime
 	} else if v.Linearity == 0 {
 		return ErrInvalidVideoNoLinearity
-	} else if v.MinDuration == 0 {
+	} else if len(v.RqdDurs) != 0 && (v.MinDuration != 0 || v.MaxDuration != 0) {
+		return ErrInvalidVideoRqdDurs
+	} else if len(v.RqdDurs) == 0 && v.MinDuration == 0 {
 		return ErrInvalidVideoNoMinDuration
-	} else if v.MaxDuration == 0 {
+	} else if len(v.RqdDurs) == 0 && v.MaxDuration == 0 {
 		return ErrInvalidVideoNoMaxDuration
+	} else if v.MinDuration > v.MaxDuration {
+		return ErrInvalidVideoDuration
 	} else if v.Protocol == 0 && len(v.Protocols) == 0 {
 		return ErrInvalidVideoNoProtocols
+	} else if v.StartDelay < VideoStartDelayGenericPostRoll {
+		return ErrInvalidVideoStartDelay
+	}
+	return v.validateSkip()
+}
+
+// validateSkip checks that skipmin and skipafter are only used with skip
+// and that videos of the maximum duration can actually be skipped.
+func (v *Video) validateSkip() error {
+	if v.Skip != 0 && v.Skip != 1 {
+		return ErrInvalidVideoSkip
+	} else if v.Skip == 0 && (v.SkipMin != 0 || v.SkipAfter != 0) {
+		return ErrInvalidVideoSkipParams
+	} else if v.MaxDuration != 0 && v.SkipAfter >= v.MaxDuration {
+		return ErrInvalidVideoSkipAfter
 	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package openrtb
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -47,6 +49,7 @@ var _ = Describe("Video", func() {
 
 	It("should validate", func() {
 		Expect((&Video{}).Validate()).To(Equal(ErrInvalidVideoNoMimes))
+		Expect((&Video{Mimes: []string{"video/mp4", "mp4"}}).Validate()).To(Equal(ErrInvalidVideoMime))
 		Expect((&Video{
 			Mimes: []string{"video/mp4"},
 		}).Validate()).To(Equal(ErrInvalidVideoNoLinearity))
@@ -74,4 +77,41 @@ var _ = Describe("Video", func() {
 		}).Validate()).NotTo(HaveOccurred())
 	})
 
+	It("should validate cross-field constraints", func() {
+		Expect(subject.Validate()).To(Succeed())
+
+		subject.MinDuration = 60
+		Expect(subject.Validate()).To(Equal(ErrInvalidVideoDuration))
+		subject.RqdDurs = []int{15, 30}
+		Expect(subject.Validate()).To(Equal(ErrInvalidVideoRqdDurs))
+		subject.MinDuration, subject.MaxDuration = 0, 0
+		Expect(subject.Validate()).To(Succeed())
+		subject.RqdDurs, subject.MinDuration, subject.MaxDuration = nil, 5, 30
+
+		subject.StartDelay = -3
+		Expect(subject.Validate()).To(Equal(ErrInvalidVideoStartDelay))
+		subject.StartDelay = VideoStartDelayGenericPostRoll
+		Expect(subject.Validate()).To(Succeed())
+
+		subject.Skip = 2
+		Expect(subject.Validate()).To(Equal(ErrInvalidVideoSkip))
+		subject.Skip, subject.SkipAfter = 0, 5
+		Expect(subject.Validate()).To(Equal(ErrInvalidVideoSkipParams))
+		subject.Skip, subject.SkipAfter = 1, 30
+		Expect(subject.Validate()).To(Equal(ErrInvalidVideoSkipAfter))
+		subject.SkipAfter, subject.SkipMin = 5, 15
+		Expect(subject.Validate()).To(Succeed())
+	})
+
+	It("should surface errors through the request validator", func() {
+		subject.Skip, subject.SkipAfter = 1, 30
+		req := &BidRequest{ID: "R", Imp: []Impression{{ID: "1", Video: subject}}}
+		err := req.Validate()
+		Expect(err).To(MatchError(ErrInvalidVideoSkipAfter))
+
+		var verr *ValidationError
+		Expect(errors.As(err, &verr)).To(BeTrue())
+		Expect(verr).To(Equal(&ValidationError{Path: "imp[0].video.skipafter", Code: "video_invalid_skipafter", Err: ErrInvalidVideoSkipAfter}))
+	})
+
 })